@@ -0,0 +1,211 @@
+// Package api espone alcune operazioni dell'applicazione come endpoint JSON su HTTP, per
+// l'automazione da script e pipeline CI. Il server ascolta solo su loopback e richiede un token.
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxRequestBytes è la dimensione massima del corpo di una richiesta.
+	MaxRequestBytes = 1 << 20
+	// MinTokenLength è la lunghezza minima del token di accesso.
+	MinTokenLength = 16
+	// pathPrefix precede il nome dell'endpoint nell'URL: POST /api/<nome>.
+	pathPrefix = "/api/"
+	// readHeaderTimeout limita l'attesa delle intestazioni, contro le connessioni lasciate aperte.
+	readHeaderTimeout = 10 * time.Second
+)
+
+// Endpoint esegue un'operazione a partire dal corpo JSON della richiesta e restituisce il valore da
+// codificare nella risposta. Un errore di tipo *RequestError è attribuito alla richiesta (400),
+// gli altri all'operazione (500).
+type Endpoint func(body json.RawMessage) (interface{}, error)
+
+// RequestError segnala parametri non validi nella richiesta.
+type RequestError struct {
+	Err error
+}
+
+func (e *RequestError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// Bind crea un Endpoint che decodifica il corpo della richiesta in P, rifiutando i campi sconosciuti,
+// e lo passa a fn. Un corpo vuoto equivale a un oggetto vuoto.
+func Bind[P any](fn func(params P) (interface{}, error)) Endpoint {
+	return func(body json.RawMessage) (interface{}, error) {
+		var params P
+		if len(bytes.TrimSpace(body)) > 0 {
+			decoder := json.NewDecoder(bytes.NewReader(body))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&params); err != nil {
+				return nil, &RequestError{Err: fmt.Errorf("invalid request body: %w", err)}
+			}
+		}
+		return fn(params)
+	}
+}
+
+// errorResponse è il corpo delle risposte di errore.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server è il server HTTP degli endpoint. Le richieste sono servite in parallelo: gli endpoint
+// devono poter essere chiamati da più goroutine.
+type Server struct {
+	token     []byte
+	endpoints map[string]Endpoint
+
+	mu       sync.Mutex
+	http     *http.Server
+	listener net.Listener
+}
+
+// NewServer crea un server con gli endpoint indicati, protetto da token.
+func NewServer(token string, endpoints map[string]Endpoint) (*Server, error) {
+	token = strings.TrimSpace(token)
+	if len(token) < MinTokenLength {
+		return nil, fmt.Errorf("API token must be at least %d characters", MinTokenLength)
+	}
+	return &Server{token: []byte(token), endpoints: endpoints}, nil
+}
+
+// Start avvia il server su 127.0.0.1:port (0 per una porta libera) e restituisce l'indirizzo effettivo.
+func (s *Server) Start(port int) (string, error) {
+	if port < 0 || port > 65535 {
+		return "", fmt.Errorf("invalid API port: %d", port)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.http != nil {
+		return "", fmt.Errorf("API server already running on %s", s.listener.Addr())
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		return "", fmt.Errorf("failed to listen on port %d: %w", port, err)
+	}
+	server := &http.Server{Handler: s.Handler(), ReadHeaderTimeout: readHeaderTimeout}
+	s.http, s.listener = server, listener
+
+	go server.Serve(listener)
+	return listener.Addr().String(), nil
+}
+
+// Addr restituisce l'indirizzo di ascolto, vuoto se il server non è avviato.
+func (s *Server) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// Shutdown smette di accettare connessioni e attende le richieste in corso fino alla scadenza di ctx.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	server := s.http
+	s.http, s.listener = nil, nil
+	s.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+	return server.Shutdown(ctx)
+}
+
+// Handler restituisce l'handler HTTP degli endpoint: POST /api/<nome> con corpo JSON e intestazione
+// "Authorization: Bearer <token>".
+func (s *Server) Handler() http.Handler {
+	return http.HandlerFunc(s.serveHTTP)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="mib-to-the-future"`)
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "missing or invalid API token"})
+		return
+	}
+
+	endpoint, ok := s.endpoints[strings.TrimPrefix(r.URL.Path, pathPrefix)]
+	if !ok || !strings.HasPrefix(r.URL.Path, pathPrefix) {
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: fmt.Sprintf("unknown endpoint %s", r.URL.Path)})
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "only POST is supported"})
+		return
+	}
+
+	body, err := readBody(w, r)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse{Error: fmt.Sprintf("request body exceeds %d bytes", MaxRequestBytes)})
+			return
+		}
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("failed to read request body: %v", err)})
+		return
+	}
+
+	result, err := endpoint(body)
+	if err != nil {
+		status := http.StatusInternalServerError
+		var requestErr *RequestError
+		if errors.As(err, &requestErr) {
+			status = http.StatusBadRequest
+		}
+		writeJSON(w, status, errorResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// authorized confronta il token Bearer in tempo costante.
+func (s *Server) authorized(r *http.Request) bool {
+	header := r.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), s.token) == 1
+}
+
+func readBody(w http.ResponseWriter, r *http.Request) (json.RawMessage, error) {
+	reader := http.MaxBytesReader(w, r.Body, MaxRequestBytes)
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// writeJSON codifica value come fanno i binding Wails, così le risposte coincidono con quelle della GUI.
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		status = http.StatusInternalServerError
+		data, _ = json.Marshal(errorResponse{Error: fmt.Sprintf("failed to encode response: %v", err)})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testToken = "0123456789abcdef"
+
+type echoParams struct {
+	Value string `json:"value"`
+}
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	server, err := NewServer(testToken, map[string]Endpoint{
+		"echo": Bind(func(params echoParams) (interface{}, error) {
+			return params, nil
+		}),
+		"fail": Bind(func(struct{}) (interface{}, error) {
+			return nil, fmt.Errorf("agent unreachable")
+		}),
+	})
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	return server
+}
+
+func doRequest(handler http.Handler, method, path, token, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestNewServerRequiresToken(t *testing.T) {
+	if _, err := NewServer("short", nil); err == nil {
+		t.Fatalf("expected a short token to be rejected")
+	}
+}
+
+func TestServerRejectsUnauthorizedRequests(t *testing.T) {
+	handler := newTestServer(t).Handler()
+
+	for name, header := range map[string]string{
+		"missing":      "",
+		"wrong token":  "Bearer fedcba9876543210",
+		"wrong scheme": "Basic " + testToken,
+	} {
+		request := httptest.NewRequest(http.MethodPost, "/api/echo", strings.NewReader(`{}`))
+		if header != "" {
+			request.Header.Set("Authorization", header)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if recorder.Code != http.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want 401", name, recorder.Code)
+		}
+		if recorder.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("%s: expected a WWW-Authenticate header", name)
+		}
+	}
+
+	// Gli endpoint sconosciuti non sono rivelati senza token.
+	if recorder := doRequest(handler, http.MethodPost, "/api/unknown", "", ""); recorder.Code != http.StatusUnauthorized {
+		t.Errorf("unknown endpoint without token: status = %d, want 401", recorder.Code)
+	}
+}
+
+func TestServerRequestHandling(t *testing.T) {
+	handler := newTestServer(t).Handler()
+
+	recorder := doRequest(handler, http.MethodPost, "/api/echo", testToken, `{"value":"ok"}`)
+	if recorder.Code != http.StatusOK || recorder.Body.String() != `{"value":"ok"}` {
+		t.Fatalf("echo: status %d body %s", recorder.Code, recorder.Body.String())
+	}
+	if got := recorder.Header().Get("Content-Type"); got != "application/json" {
+		t.Fatalf("Content-Type = %q, want application/json", got)
+	}
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"unknown endpoint", http.MethodPost, "/api/unknown", `{}`, http.StatusNotFound},
+		{"wrong method", http.MethodGet, "/api/echo", "", http.StatusMethodNotAllowed},
+		{"malformed body", http.MethodPost, "/api/echo", `{"value":`, http.StatusBadRequest},
+		{"unknown field", http.MethodPost, "/api/echo", `{"other":"x"}`, http.StatusBadRequest},
+		{"endpoint error", http.MethodPost, "/api/fail", "", http.StatusInternalServerError},
+		{"body too large", http.MethodPost, "/api/echo", `{"value":"` + strings.Repeat("x", MaxRequestBytes) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tc := range cases {
+		recorder := doRequest(handler, tc.method, tc.path, testToken, tc.body)
+		if recorder.Code != tc.status {
+			t.Errorf("%s: status = %d, want %d (body %s)", tc.name, recorder.Code, tc.status, recorder.Body.String())
+		}
+		var response errorResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil || response.Error == "" {
+			t.Errorf("%s: expected a JSON error, got %s", tc.name, recorder.Body.String())
+		}
+	}
+}
+
+func TestServerConcurrentRequests(t *testing.T) {
+	server := newTestServer(t)
+	addr, err := server.Start(0)
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if !strings.HasPrefix(addr, "127.0.0.1:") {
+		t.Fatalf("expected a loopback address, got %s", addr)
+	}
+	if _, err := server.Start(0); err == nil {
+		t.Fatalf("expected a second Start to fail")
+	}
+
+	const clients = 32
+	var wg sync.WaitGroup
+	errs := make(chan error, clients)
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			value := fmt.Sprintf("request-%d", i)
+			request, _ := http.NewRequest(http.MethodPost, "http://"+addr+"/api/echo", strings.NewReader(`{"value":"`+value+`"}`))
+			request.Header.Set("Authorization", "Bearer "+testToken)
+			response, err := http.DefaultClient.Do(request)
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer response.Body.Close()
+			body, _ := io.ReadAll(response.Body)
+			if response.StatusCode != http.StatusOK || string(body) != `{"value":"`+value+`"}` {
+				errs <- fmt.Errorf("request %d: status %d body %s", i, response.StatusCode, body)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if err := server.Shutdown(contextWithTimeout(t)); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if server.Addr() != "" {
+		t.Fatalf("expected no address after Shutdown")
+	}
+	client := http.Client{Timeout: time.Second}
+	if _, err := client.Post("http://"+addr+"/api/echo", "application/json", strings.NewReader(`{}`)); err == nil {
+		t.Fatalf("expected the server to stop accepting connections")
+	}
+}
+
+func contextWithTimeout(t *testing.T) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
@@ -7,28 +7,105 @@ import (
 	"path/filepath"
 	"sync"
 
+	"mib-to-the-future/backend/api"
 	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 // App è la struttura principale dell'applicazione.
 type App struct {
-	ctx           context.Context
+	ctx context.Context
+	// mibDB e mibInitErr si leggono con acquireMIBDB e mibNotInitializedErr: ReloadMIBDatabase li
+	// sostituisce sotto mibDBM. mibDBUsers conta le operazioni in corso sul database corrente, che
+	// viene chiuso solo quando sono terminate.
 	mibDB         *mib.Database
 	mibInitErr    error
+	mibDBUsers    *sync.WaitGroup
+	mibDBM        sync.RWMutex
+	mibReloadM    sync.Mutex
 	oidNameCache  map[string]string
 	oidBaseCache  map[string]string
 	oidNodeCache  map[string]*mib.Node
 	oidNameCacheM sync.RWMutex
+	uptimeTracker *UptimeTracker
+
+	lastLoadReports []mib.LoadReport
+	loadReportM     sync.RWMutex
+
+	// typeEnums memorizza, per modulo e tipo della sintassi, gli enum ereditati dalle textual convention.
+	typeEnums  map[string]string
+	typeEnumsM sync.Mutex
+
+	// moduleRevisions memorizza la revisione più recente dei moduli usata negli avvisi di deprecazione.
+	moduleRevisions  map[string]string
+	moduleRevisionsM sync.Mutex
+
+	// agentInterfaces memorizza per host i nomi delle interfacce (ifIndex -> ifDescr).
+	agentInterfaces  map[string]agentInterfaces
+	agentInterfacesM sync.Mutex
+
+	tableList  []*TableSummary
+	tableListM sync.RWMutex
+
+	steppers   map[string]*walkStepper
+	stepperSeq int
+	stepperM   sync.Mutex
+
+	walkStreams   map[string]*walkStream
+	walkStreamSeq int
+	walkStreamM   sync.Mutex
+
+	// pendingSets contiene le SET preparate con PrepareSet, per token.
+	pendingSets  map[string]*pendingSet
+	pendingSetsM sync.Mutex
+
+	// oidWatches contiene le sorveglianze di WatchOID, per ID, servite da un unico scheduler.
+	oidWatches      map[int64]*scheduledWatch
+	oidWatchWake    chan struct{}
+	oidWatchRunning bool
+	oidWatchM       sync.Mutex
+
+	debugCaptures       map[string]*snmp.DebugCapture
+	debugCaptureEnabled bool
+	debugCaptureM       sync.Mutex
+
+	// Registro dei task in background, annullati e attesi da Shutdown.
+	tasksCtx    context.Context
+	tasksCancel context.CancelFunc
+	tasksWG     sync.WaitGroup
+	activeTasks map[string]int
+	tasksClosed bool
+	tasksM      sync.Mutex
+
+	hostHitSeen map[string]struct{}
+	hostHitM    sync.Mutex
+
+	// uiSessionUsed indica che il frontend ha salvato almeno una sessione; uiSessionSaved, se
+	// impostato, viene chiuso dal prossimo SaveUISession (vedi BeforeClose).
+	uiSession      string
+	uiSessionDirty bool
+	uiSessionUsed  bool
+	uiSessionSaved chan struct{}
+	uiSessionM     sync.Mutex
+
+	trapForwarder     *trapForwarder
+	trapForwardCancel context.CancelFunc
+	trapForwardM      sync.Mutex
+
+	apiServer  *api.Server
+	apiServerM sync.Mutex
 }
 
 // NewApp crea una nuova istanza dell'applicazione.
 func NewApp() *App {
 	return &App{
-		oidNameCache: make(map[string]string),
-		oidBaseCache: make(map[string]string),
-		oidNodeCache: make(map[string]*mib.Node),
+		oidNameCache:  make(map[string]string),
+		oidBaseCache:  make(map[string]string),
+		oidNodeCache:  make(map[string]*mib.Node),
+		uptimeTracker: NewUptimeTracker(),
+		mibDBUsers:    &sync.WaitGroup{},
 	}
 }
 
@@ -37,12 +114,81 @@ func (a *App) mibNotInitializedErr() error {
 	if a == nil {
 		return fmt.Errorf("MIB database not initialized")
 	}
-	if a.mibInitErr != nil {
-		return fmt.Errorf("MIB database not initialized: %v", a.mibInitErr)
+	a.mibDBM.RLock()
+	initErr := a.mibInitErr
+	a.mibDBM.RUnlock()
+	if initErr != nil {
+		return fmt.Errorf("MIB database not initialized: %v", initErr)
 	}
 	return fmt.Errorf("MIB database not initialized")
 }
 
+// acquireMIBDB restituisce il database MIB corrente (nil se non inizializzato) e la funzione da
+// chiamare al termine dell'operazione. Finché release non viene chiamata ReloadMIBDatabase e
+// Shutdown non chiudono quel database. Il lock in lettura è tenuto solo per prelevare il riferimento,
+// così un'operazione può richiamare altri metodi che acquisiscono il database a loro volta.
+func (a *App) acquireMIBDB() (*mib.Database, func()) {
+	a.mibDBM.RLock()
+	if a.mibDB != nil && a.mibDBUsers == nil {
+		// App costruita senza NewApp: il contatore viene creato al primo uso.
+		a.mibDBM.RUnlock()
+		a.mibDBM.Lock()
+		if a.mibDBUsers == nil {
+			a.mibDBUsers = &sync.WaitGroup{}
+		}
+		a.mibDBM.Unlock()
+		a.mibDBM.RLock()
+	}
+	defer a.mibDBM.RUnlock()
+
+	if a.mibDB == nil {
+		return nil, func() {}
+	}
+	users := a.mibDBUsers
+	users.Add(1)
+	return a.mibDB, users.Done
+}
+
+// mibDBReady indica se il database MIB è disponibile, per i metodi che lo usano solo tramite altri metodi.
+func (a *App) mibDBReady() bool {
+	a.mibDBM.RLock()
+	defer a.mibDBM.RUnlock()
+	return a.mibDB != nil
+}
+
+// swapMIBDB sostituisce il database MIB corrente e l'errore di inizializzazione. Restituisce il
+// database precedente e il contatore delle operazioni che lo stanno ancora usando: il chiamante
+// deve attenderle prima di chiuderlo.
+func (a *App) swapMIBDB(db *mib.Database, initErr error) (*mib.Database, *sync.WaitGroup) {
+	a.mibDBM.Lock()
+	defer a.mibDBM.Unlock()
+
+	previous, users := a.mibDB, a.mibDBUsers
+	a.mibDB = db
+	a.mibInitErr = initErr
+	a.mibDBUsers = &sync.WaitGroup{}
+	return previous, users
+}
+
+// closeRetiredMIBDB attende le operazioni ancora in corso su un database sostituito da swapMIBDB e lo chiude.
+func closeRetiredMIBDB(db *mib.Database, users *sync.WaitGroup) error {
+	if db == nil {
+		return nil
+	}
+	if users != nil {
+		users.Wait()
+	}
+	return db.Close()
+}
+
+// setMIBInitErr registra l'errore di inizializzazione lasciando invariato il database corrente.
+func (a *App) setMIBInitErr(err error) error {
+	a.mibDBM.Lock()
+	a.mibInitErr = err
+	a.mibDBM.Unlock()
+	return err
+}
+
 // Startup inizializza l'applicazione al momento dell'avvio.
 func (a *App) Startup(ctx context.Context) {
 	a.ctx = ctx
@@ -56,38 +202,37 @@ func (a *App) Startup(ctx context.Context) {
 	if a.oidNodeCache == nil {
 		a.oidNodeCache = make(map[string]*mib.Node)
 	}
+	if a.uptimeTracker == nil {
+		a.uptimeTracker = NewUptimeTracker()
+	}
 
-	// Ottieni la directory di configurazione standard per l'OS corrente
-	configDir, err := os.UserConfigDir()
+	dataDir, err := a.dataDir()
 	if err != nil {
-		a.mibInitErr = fmt.Errorf("failed to resolve user config dir: %w", err)
-		runtime.LogError(ctx, a.mibInitErr.Error())
+		runtime.LogError(ctx, a.setMIBInitErr(err).Error())
 		return
 	}
 
-	// Crea il path per i dati della nostra app
-	dataDir := filepath.Join(configDir, "MIB to the Future")
-
-	// Inizializza database MIB
-	a.mibDB, err = mib.NewDatabase(dataDir)
+	// Inizializza database MIB ed esegui le migrazioni prima di renderlo disponibile
+	db, err := openMIBDatabase(dataDir)
 	if err != nil {
-		a.mibInitErr = fmt.Errorf("failed to initialize MIB database in %s: %w", dataDir, err)
-		runtime.LogError(ctx, a.mibInitErr.Error())
+		runtime.LogError(ctx, a.setMIBInitErr(err).Error())
 		return
 	}
-	a.mibInitErr = nil
+	a.swapMIBDB(db, nil)
 
-	// Esegui migrazioni del database
-	if err := a.runMigrations(); err != nil {
-		a.mibInitErr = fmt.Errorf("database migration failed: %w", err)
-		runtime.LogError(ctx, a.mibInitErr.Error())
-		return
+	// Le directory MIB dell'utente devono essere note prima dell'inizializzazione di gosmi
+	a.loadMIBSearchDirectories()
+
+	// I moduli caricati con una versione precedente del catalogo vanno ricaricati: la verifica
+	// precede il precaricamento, che popola il catalogo dei soli MIB standard.
+	backfill, err := a.mibCatalogNeedsBackfill()
+	if err != nil {
+		runtime.LogWarning(ctx, fmt.Sprintf("Failed to check the MIB catalog version: %v", err))
 	}
 
 	// Precarica i MIB standard comuni all'avvio per evitare errori di dipendenze mancanti
 	runtime.LogInfo(ctx, "Preloading standard MIB modules...")
-	parser := mib.NewParser(a.mibDB)
-	if err := parser.PreloadStandardMIBs(dataDir); err != nil {
+	if err := a.preloadStandardMIBs(dataDir); err != nil {
 		// Non è un errore fatale, logga e continua
 		runtime.LogWarning(ctx, fmt.Sprintf("Failed to preload some standard MIBs: %v", err))
 	} else {
@@ -95,21 +240,96 @@ func (a *App) Startup(ctx context.Context) {
 	}
 
 	runtime.LogInfo(ctx, fmt.Sprintf("MIB database ready at: %s", dataDir))
+
+	a.loadTrapForwarding()
+	a.loadOIDWatches()
+
+	// Aggiorna in background il catalogo e verifica i collegamenti parent_oid lasciati da caricamenti parziali
+	if err := a.goBackground("mib-integrity", func(context.Context) {
+		if backfill {
+			if err := a.backfillMIBCatalog(dataDir); err != nil {
+				runtime.LogWarning(ctx, fmt.Sprintf("MIB catalog update failed: %v", err))
+			}
+		}
+		a.checkMIBIntegrity()
+	}); err != nil {
+		runtime.LogWarning(ctx, fmt.Sprintf("MIB integrity check not started: %v", err))
+	}
+}
+
+// appDataDirName è il nome della cartella dei dati dell'applicazione nella directory di configurazione dell'utente.
+const appDataDirName = "MIB to the Future"
+
+// dataDir restituisce la cartella dei dati dell'applicazione (database, MIB estratti e scaricati)
+// nella directory di configurazione standard per l'OS corrente.
+func (a *App) dataDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve user config dir: %w", err)
+	}
+	return filepath.Join(configDir, appDataDirName), nil
+}
+
+// openMIBDatabase apre il database MIB in dataDir ed esegue le migrazioni. In caso di errore il
+// database viene chiuso.
+func openMIBDatabase(dataDir string) (*mib.Database, error) {
+	db, err := mib.NewDatabase(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize MIB database in %s: %w", dataDir, err)
+	}
+	if err := runMigrations(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("database migration failed: %w", err)
+	}
+	return db, nil
 }
 
 // runMigrations esegue le migrazioni del database.
-func (a *App) runMigrations() error {
-	if a.mibDB == nil {
+func runMigrations(db *mib.Database) error {
+	if db == nil {
 		return fmt.Errorf("database not initialized")
 	}
 
-	return a.mibDB.EnsureHostConfigSchema()
+	return db.EnsureHostConfigSchema()
+}
+
+// preloadStandardMIBs precarica i MIB standard comuni nel database corrente.
+func (a *App) preloadStandardMIBs(dataDir string) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+	return mib.NewParser(db).PreloadStandardMIBs(dataDir)
 }
 
-// shutdown chiude l'applicazione.
-func (a *App) shutdown(ctx context.Context) {
-	if a.mibDB != nil {
-		a.mibDB.Close()
+// Shutdown chiude l'applicazione: annulla i task in background e le richieste SNMP in corso,
+// ne attende la conclusione per un tempo limitato e infine chiude il database.
+func (a *App) Shutdown(ctx context.Context) {
+	// Il server API si ferma per primo: le richieste in corso terminano prima dell'annullamento dei task.
+	if err := a.StopAPIServer(); err != nil && ctx != nil {
+		runtime.LogWarning(ctx, err.Error())
+	}
+
+	if !a.stopBackgroundTasks(shutdownGracePeriod) && ctx != nil {
+		runtime.LogWarning(ctx, fmt.Sprintf("Background tasks still running at shutdown: %v", a.runningTasks()))
+	}
+
+	a.stepperM.Lock()
+	a.steppers = nil
+	a.stepperM.Unlock()
+
+	if err := a.flushUISession(); err != nil && ctx != nil {
+		runtime.LogWarning(ctx, fmt.Sprintf("Failed to save UI session at shutdown: %v", err))
+	}
+
+	// Il riferimento resta impostato: le chiamate successive falliscono sul database chiuso.
+	a.mibDBM.Lock()
+	db, users := a.mibDB, a.mibDBUsers
+	a.mibDBUsers = &sync.WaitGroup{}
+	a.mibDBM.Unlock()
+	if err := closeRetiredMIBDB(db, users); err != nil && ctx != nil {
+		runtime.LogError(ctx, fmt.Sprintf("Failed to close MIB database: %v", err))
 	}
 }
 
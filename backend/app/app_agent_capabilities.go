@@ -0,0 +1,88 @@
+package app
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"mib-to-the-future/backend/snmp"
+)
+
+const (
+	// sysORTableOID è la tabella SNMPv2-MIB in cui l'agent elenca le capability (moduli MIB) supportate.
+	sysORTableOID = "1.3.6.1.2.1.1.9"
+	// sysOREntryOID è l'entry di sysORTable; le colonne sono sysORID (2), sysORDescr (3) e sysORUpTime (4).
+	sysOREntryOID = sysORTableOID + ".1"
+)
+
+// AgentCapEntry è una riga di sysORTable: l'OID della capability dichiarata dall'agent, il nome
+// risolto con i MIB caricati, la descrizione e l'uptime a cui la riga è stata registrata.
+type AgentCapEntry struct {
+	Index        int    `json:"index"`
+	OID          string `json:"oid"`
+	ResolvedName string `json:"resolvedName"`
+	Description  string `json:"description"`
+	Uptime       string `json:"uptime"`
+}
+
+// GetSNMPAgentMIB legge la sysORTable dell'agent e restituisce, ordinate per indice, le capability
+// che dichiara di implementare. Un agent senza sysORTable restituisce una lista vuota.
+func (a *App) GetSNMPAgentMIB(config snmp.Config) ([]AgentCapEntry, error) {
+	results, err := a.SNMPWalk(config, sysORTableOID)
+	if err != nil {
+		return nil, err
+	}
+	return a.parseSysORTable(results), nil
+}
+
+// parseSysORTable raggruppa per indice i varbind della sysORTable.
+func (a *App) parseSysORTable(results []snmp.Result) []AgentCapEntry {
+	byIndex := make(map[int]*AgentCapEntry)
+	for _, result := range results {
+		oid := normalizeOIDKey(result.OID)
+		if !isOIDWithinSubtree(sysOREntryOID, oid) {
+			continue
+		}
+		column, instance, ok := strings.Cut(strings.TrimPrefix(oid, sysOREntryOID+"."), ".")
+		if !ok {
+			continue
+		}
+		index, err := strconv.Atoi(instance)
+		if err != nil {
+			continue
+		}
+
+		entry, exists := byIndex[index]
+		if !exists {
+			entry = &AgentCapEntry{Index: index}
+			byIndex[index] = entry
+		}
+
+		raw := result.RawValue
+		if raw == "" {
+			raw = result.Value
+		}
+		switch column {
+		case "2": // sysORID
+			entry.OID = normalizeOIDKey(raw)
+			entry.ResolvedName = a.resolveOIDName(entry.OID)
+		case "3": // sysORDescr
+			entry.Description = raw
+			if text, ok := formatDisplayString(raw); ok {
+				entry.Description = text
+			}
+		case "4": // sysORUpTime
+			entry.Uptime = raw
+			if uptime, ok := formatTimeTicks(raw); ok {
+				entry.Uptime = uptime
+			}
+		}
+	}
+
+	entries := make([]AgentCapEntry, 0, len(byIndex))
+	for _, entry := range byIndex {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Index < entries[j].Index })
+	return entries
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestGetSNMPAgentMIB(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.6.3.1", Name: "snmpMIB", Type: "node"},
+		&mib.Node{OID: "1.3.6.1.2.1.49", Name: "tcpMIB", Type: "node"},
+	)
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.1.5.0":      {Type: gosnmp.OctetString, Value: []byte("router")},
+		"1.3.6.1.2.1.1.9.1.2.1":  {Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1"},
+		"1.3.6.1.2.1.1.9.1.2.10": {Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.2.1.49"},
+		"1.3.6.1.2.1.1.9.1.3.1":  {Type: gosnmp.OctetString, Value: []byte("The MIB module for SNMPv2 entities")},
+		"1.3.6.1.2.1.1.9.1.3.10": {Type: gosnmp.OctetString, Value: []byte("The MIB module for managing TCP implementations")},
+		"1.3.6.1.2.1.1.9.1.4.1":  {Type: gosnmp.TimeTicks, Value: uint32(150)},
+		"1.3.6.1.2.1.1.9.1.4.10": {Type: gosnmp.TimeTicks, Value: uint32(0)},
+		"1.3.6.1.2.1.2.1.0":      {Type: gosnmp.Integer, Value: 2},
+	})
+
+	entries, err := app.GetSNMPAgentMIB(agent.config())
+	if err != nil {
+		t.Fatalf("GetSNMPAgentMIB() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", entries)
+	}
+
+	first := entries[0]
+	if first.Index != 1 || first.OID != "1.3.6.1.6.3.1" || first.ResolvedName != "snmpMIB" {
+		t.Fatalf("unexpected first entry: %+v", first)
+	}
+	if first.Description != "The MIB module for SNMPv2 entities" || first.Uptime != "1.50s" {
+		t.Fatalf("unexpected first entry values: %+v", first)
+	}
+	if second := entries[1]; second.Index != 10 || second.ResolvedName != "tcpMIB" || second.Uptime != "0s" {
+		t.Fatalf("unexpected second entry: %+v", second)
+	}
+}
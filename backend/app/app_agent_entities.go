@@ -0,0 +1,100 @@
+package app
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"mib-to-the-future/backend/snmp"
+)
+
+const (
+	// entPhysicalEntryOID è l'entry di entPhysicalTable (ENTITY-MIB), l'inventario fisico dell'agent.
+	entPhysicalEntryOID = "1.3.6.1.2.1.47.1.1.1.1"
+	// physicalClassSyntax è la sintassi di PhysicalClass (RFC 6933), usata per le etichette anche
+	// quando ENTITY-MIB non è caricato.
+	physicalClassSyntax = "INTEGER { other(1), unknown(2), chassis(3), backplane(4), container(5), powerSupply(6), " +
+		"fan(7), sensor(8), module(9), port(10), stack(11), cpu(12), energyObject(13), battery(14), storageDrive(15) }"
+)
+
+// PhysicalEntity è una riga di entPhysicalTable: un componente fisico del dispositivo, con la classe
+// (chassis, module, port, sensor, ...) e l'indice del componente che lo contiene (0 per la radice).
+type PhysicalEntity struct {
+	Index       int    `json:"index"`
+	Description string `json:"description"`
+	Name        string `json:"name"`
+	Class       string `json:"class"`
+	ContainedIn int    `json:"containedIn"`
+	IsFRU       bool   `json:"isFRU"`
+}
+
+// GetSNMPAgentEntities legge la entPhysicalTable dell'agent e restituisce, ordinato per indice,
+// l'inventario fisico del dispositivo. Un agent senza ENTITY-MIB restituisce una lista vuota.
+func (a *App) GetSNMPAgentEntities(config snmp.Config) ([]PhysicalEntity, error) {
+	results, err := a.SNMPWalk(config, entPhysicalEntryOID)
+	if err != nil {
+		return nil, err
+	}
+	return parseEntPhysicalTable(results), nil
+}
+
+// parseEntPhysicalTable raggruppa per indice i varbind delle colonne usate di entPhysicalTable.
+func parseEntPhysicalTable(results []snmp.Result) []PhysicalEntity {
+	classes := parseEnumMapping(physicalClassSyntax)
+	byIndex := make(map[int]*PhysicalEntity)
+	for _, result := range results {
+		oid := normalizeOIDKey(result.OID)
+		if !isOIDWithinSubtree(entPhysicalEntryOID, oid) {
+			continue
+		}
+		column, instance, ok := strings.Cut(strings.TrimPrefix(oid, entPhysicalEntryOID+"."), ".")
+		if !ok {
+			continue
+		}
+		index, err := strconv.Atoi(instance)
+		if err != nil {
+			continue
+		}
+
+		entity, exists := byIndex[index]
+		if !exists {
+			entity = &PhysicalEntity{Index: index}
+			byIndex[index] = entity
+		}
+
+		raw := strings.TrimSpace(result.RawValue)
+		if raw == "" {
+			raw = strings.TrimSpace(result.Value)
+		}
+		switch column {
+		case "2": // entPhysicalDescr
+			entity.Description = displayText(raw)
+		case "4": // entPhysicalContainedIn
+			entity.ContainedIn, _ = strconv.Atoi(raw)
+		case "5": // entPhysicalClass
+			entity.Class = raw
+			if label, ok := classes[raw]; ok {
+				entity.Class = label
+			}
+		case "7": // entPhysicalName
+			entity.Name = displayText(raw)
+		case "16": // entPhysicalIsFRU (TruthValue: true(1), false(2))
+			entity.IsFRU = raw == "1"
+		}
+	}
+
+	entities := make([]PhysicalEntity, 0, len(byIndex))
+	for _, entity := range byIndex {
+		entities = append(entities, *entity)
+	}
+	sort.Slice(entities, func(i, j int) bool { return entities[i].Index < entities[j].Index })
+	return entities
+}
+
+// displayText decodifica un valore OCTET STRING come testo, lasciandolo invariato se non è leggibile.
+func displayText(raw string) string {
+	if text, ok := formatDisplayString(raw); ok {
+		return text
+	}
+	return raw
+}
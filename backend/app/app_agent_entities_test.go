@@ -0,0 +1,43 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestGetSNMPAgentEntities(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.47.1.1.1.1.2.1":   {Type: gosnmp.OctetString, Value: []byte("Cisco 2960 chassis")},
+		"1.3.6.1.2.1.47.1.1.1.1.2.10":  {Type: gosnmp.OctetString, Value: []byte("GigabitEthernet0/1")},
+		"1.3.6.1.2.1.47.1.1.1.1.4.1":   {Type: gosnmp.Integer, Value: 0},
+		"1.3.6.1.2.1.47.1.1.1.1.4.10":  {Type: gosnmp.Integer, Value: 1},
+		"1.3.6.1.2.1.47.1.1.1.1.5.1":   {Type: gosnmp.Integer, Value: 3},
+		"1.3.6.1.2.1.47.1.1.1.1.5.10":  {Type: gosnmp.Integer, Value: 10},
+		"1.3.6.1.2.1.47.1.1.1.1.7.1":   {Type: gosnmp.OctetString, Value: []byte("1")},
+		"1.3.6.1.2.1.47.1.1.1.1.7.10":  {Type: gosnmp.OctetString, Value: []byte("Gi0/1")},
+		"1.3.6.1.2.1.47.1.1.1.1.16.1":  {Type: gosnmp.Integer, Value: 1},
+		"1.3.6.1.2.1.47.1.1.1.1.16.10": {Type: gosnmp.Integer, Value: 2},
+		"1.3.6.1.2.1.47.1.2.1.1.2.1":   {Type: gosnmp.OctetString, Value: []byte("outside the table")},
+	})
+
+	entities, err := app.GetSNMPAgentEntities(agent.config())
+	if err != nil {
+		t.Fatalf("GetSNMPAgentEntities() error = %v", err)
+	}
+	if len(entities) != 2 {
+		t.Fatalf("expected 2 entities, got %+v", entities)
+	}
+
+	chassis := entities[0]
+	if chassis.Index != 1 || chassis.Class != "chassis" || chassis.ContainedIn != 0 || !chassis.IsFRU {
+		t.Fatalf("unexpected chassis: %+v", chassis)
+	}
+	if chassis.Description != "Cisco 2960 chassis" || chassis.Name != "1" {
+		t.Fatalf("unexpected chassis text: %+v", chassis)
+	}
+	if port := entities[1]; port.Index != 10 || port.Class != "port" || port.ContainedIn != 1 || port.IsFRU || port.Name != "Gi0/1" {
+		t.Fatalf("unexpected port: %+v", port)
+	}
+}
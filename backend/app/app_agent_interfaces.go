@@ -0,0 +1,121 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"mib-to-the-future/backend/snmp"
+)
+
+const (
+	// ifDescrOID è la colonna ifDescr di ifTable (IF-MIB), indicizzata per ifIndex.
+	ifDescrOID = "1.3.6.1.2.1.2.2.1.2"
+	// agentInterfacesTTL è la durata dei nomi di interfaccia memorizzati per un host.
+	agentInterfacesTTL = 5 * time.Minute
+)
+
+// ifIndexedEntries sono le entry di tabella con ifIndex come unico indice, le cui istanze vengono
+// etichettate con il nome dell'interfaccia. Il database non conserva la clausola INDEX, per cui
+// l'elenco è fisso: ifTable, ifXTable (IF-MIB) e dot3StatsTable (EtherLike-MIB).
+var ifIndexedEntries = []string{
+	"1.3.6.1.2.1.2.2.1",
+	"1.3.6.1.2.1.31.1.1.1",
+	"1.3.6.1.2.1.10.7.2.1",
+}
+
+// agentInterfaces associa ifIndex e ifDescr delle interfacce di un host, con l'ora della lettura.
+type agentInterfaces struct {
+	names     map[string]string
+	fetchedAt time.Time
+}
+
+// CacheAgentInterfaces legge ifDescr dalla ifTable dell'agent e memorizza per 5 minuti il nome di
+// ogni interfaccia, usato per etichettare le istanze indicizzate per ifIndex (es. ifInOctets[eth0]).
+func (a *App) CacheAgentInterfaces(config snmp.Config) error {
+	host := strings.TrimSpace(config.Host)
+	if host == "" {
+		return fmt.Errorf("host is required")
+	}
+
+	results, err := a.SNMPWalk(config, ifDescrOID)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]string, len(results))
+	for _, result := range results {
+		oid := normalizeOIDKey(result.OID)
+		if !isOIDWithinSubtree(ifDescrOID, oid) {
+			continue
+		}
+		ifIndex := strings.TrimPrefix(oid, ifDescrOID+".")
+		raw := result.RawValue
+		if raw == "" {
+			raw = result.Value
+		}
+		name, ok := formatDisplayString(raw)
+		if !ok {
+			name = raw
+		}
+		if name = strings.TrimSpace(name); name != "" {
+			names[ifIndex] = name
+		}
+	}
+
+	a.agentInterfacesM.Lock()
+	defer a.agentInterfacesM.Unlock()
+	if a.agentInterfaces == nil {
+		a.agentInterfaces = make(map[string]agentInterfaces)
+	}
+	a.agentInterfaces[host] = agentInterfaces{names: names, fetchedAt: time.Now()}
+	return nil
+}
+
+// GetCachedInterfaceName restituisce il nome dell'interfaccia ifIndex dell'host, se letto da
+// CacheAgentInterfaces da meno di 5 minuti.
+func (a *App) GetCachedInterfaceName(host string, ifIndex string) (string, bool) {
+	host = strings.TrimSpace(host)
+	ifIndex = strings.TrimSpace(ifIndex)
+
+	a.agentInterfacesM.Lock()
+	defer a.agentInterfacesM.Unlock()
+
+	cached, ok := a.agentInterfaces[host]
+	if !ok {
+		return "", false
+	}
+	if time.Since(cached.fetchedAt) > agentInterfacesTTL {
+		delete(a.agentInterfaces, host)
+		return "", false
+	}
+	name, ok := cached.names[ifIndex]
+	return name, ok
+}
+
+// decorateInterfaceIndex sostituisce nel nome risolto l'ifIndex dell'istanza con il nome
+// dell'interfaccia, per le colonne delle tabelle indicizzate per ifIndex.
+func (a *App) decorateInterfaceIndex(result *snmp.Result) {
+	if result.Host == "" || result.ResolvedName == "" {
+		return
+	}
+
+	oid := normalizeOIDKey(result.OID)
+	for _, entry := range ifIndexedEntries {
+		if !isOIDWithinSubtree(entry, oid) {
+			continue
+		}
+		_, ifIndex, ok := strings.Cut(strings.TrimPrefix(oid, entry+"."), ".")
+		if !ok || strings.Contains(ifIndex, ".") {
+			return
+		}
+		suffix := "[" + ifIndex + "]"
+		if !strings.HasSuffix(result.ResolvedName, suffix) {
+			return
+		}
+		if name, ok := a.GetCachedInterfaceName(result.Host, ifIndex); ok {
+			result.ResolvedName = strings.TrimSuffix(result.ResolvedName, suffix) + "[" + name + "]"
+		}
+		return
+	}
+}
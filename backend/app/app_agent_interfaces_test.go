@@ -0,0 +1,55 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestCacheAgentInterfacesDecoratesIfIndex(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.2", Name: "ifDescr", Type: "column", Syntax: "DisplayString"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.10", Name: "ifInOctets", Type: "column", Syntax: "Counter32"},
+	)
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.2.2.1.2.1":   {Type: gosnmp.OctetString, Value: []byte("lo")},
+		"1.3.6.1.2.1.2.2.1.2.10":  {Type: gosnmp.OctetString, Value: []byte("eth0")},
+		"1.3.6.1.2.1.2.2.1.10.10": {Type: gosnmp.Counter32, Value: uint(1234)},
+		"1.3.6.1.2.1.2.2.1.10.99": {Type: gosnmp.Counter32, Value: uint(5)},
+	})
+	config := agent.config()
+
+	if err := app.CacheAgentInterfaces(config); err != nil {
+		t.Fatalf("CacheAgentInterfaces() error = %v", err)
+	}
+	if name, ok := app.GetCachedInterfaceName(config.Host, "10"); !ok || name != "eth0" {
+		t.Fatalf("expected eth0 for ifIndex 10, got %q (found %v)", name, ok)
+	}
+
+	result, err := app.SNMPGet(config, "1.3.6.1.2.1.2.2.1.10.10")
+	if err != nil {
+		t.Fatalf("SNMPGet() error = %v", err)
+	}
+	if result.ResolvedName != "ifInOctets[eth0]" {
+		t.Fatalf("expected ifInOctets[eth0], got %q", result.ResolvedName)
+	}
+
+	unknown, err := app.SNMPGet(config, "1.3.6.1.2.1.2.2.1.10.99")
+	if err != nil {
+		t.Fatalf("SNMPGet() error = %v", err)
+	}
+	if unknown.ResolvedName != "ifInOctets[99]" {
+		t.Fatalf("expected unknown ifIndex to keep the numeric label, got %q", unknown.ResolvedName)
+	}
+
+	app.agentInterfaces[config.Host] = agentInterfaces{
+		names:     app.agentInterfaces[config.Host].names,
+		fetchedAt: time.Now().Add(-agentInterfacesTTL - time.Second),
+	}
+	if _, ok := app.GetCachedInterfaceName(config.Host, "10"); ok {
+		t.Fatalf("expected cached interfaces to expire after %v", agentInterfacesTTL)
+	}
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"mib-to-the-future/backend/snmp"
+)
+
+// metricRateUnit è l'unità di RatePerSec per i contatori.
+const metricRateUnit = "/s"
+
+// MetricResult è una metrica numerica letta da GetSNMPAgentMetrics. Per i contatori RatePerSec è
+// l'incremento al secondo tra T0 e T1 e Unit vale "/s"; per gauge e interi T1 è il valore corrente,
+// RatePerSec è 0 e Unit è vuoto.
+type MetricResult struct {
+	OID        string  `json:"oid"`
+	Name       string  `json:"name"`
+	T0         uint64  `json:"t0"`
+	T1         uint64  `json:"t1"`
+	RatePerSec float64 `json:"ratePerSec"`
+	Unit       string  `json:"unit"`
+}
+
+// GetSNMPAgentMetrics legge gli OID numerici indicati due volte a distanza di windowSec secondi e
+// calcola la velocità dei contatori: per i Counter32 gestisce il wrap a 32 bit, per i Counter64 usa
+// la semplice differenza. Gauge32 e gli altri interi non negativi riportano il valore corrente in T1.
+// Un OID assente sull'agent o con un valore non numerico rende la chiamata un errore.
+func (a *App) GetSNMPAgentMetrics(config snmp.Config, metricOIDs []string, windowSec int) ([]MetricResult, error) {
+	if len(metricOIDs) == 0 {
+		return nil, fmt.Errorf("at least one metric OID is required")
+	}
+	if windowSec < 1 || windowSec > maxPollIntervalSeconds {
+		return nil, fmt.Errorf("window must be between 1 and %d seconds", maxPollIntervalSeconds)
+	}
+
+	normalized := make([]string, len(metricOIDs))
+	for i, oid := range metricOIDs {
+		if err := validateOIDInput(oid); err != nil {
+			return nil, err
+		}
+		normalized[i] = a.normalizeScalarOID(oid)
+	}
+
+	a.persistHostUsage(config)
+
+	first, second, elapsed, err := a.pollTwice(config, normalized, windowSec)
+	if err != nil {
+		return nil, err
+	}
+	return buildMetricResults(first, second, elapsed, a.counterBits)
+}
+
+// buildMetricResults accoppia i due campioni per OID, nell'ordine del primo, e calcola le velocità.
+func buildMetricResults(first, second []snmp.Result, elapsed time.Duration, counterBits func(snmp.Result) int) ([]MetricResult, error) {
+	later := make(map[string]snmp.Result, len(second))
+	for _, result := range second {
+		later[normalizeOIDKey(result.OID)] = result
+	}
+
+	metrics := make([]MetricResult, 0, len(first))
+	for _, t0 := range first {
+		key := normalizeOIDKey(t0.OID)
+		t1, ok := later[key]
+		if !ok || isMissingInstanceType(t0.Type) || isMissingInstanceType(t1.Type) {
+			return nil, fmt.Errorf("metric %s is not available on the agent", key)
+		}
+
+		v0, err := strconv.ParseUint(strings.TrimSpace(t0.Value), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("metric %s is not an unsigned numeric value (%s)", key, t0.Type)
+		}
+		v1, err := strconv.ParseUint(strings.TrimSpace(t1.Value), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("metric %s is not an unsigned numeric value (%s)", key, t1.Type)
+		}
+
+		metric := MetricResult{OID: key, Name: t0.ResolvedName, T0: v0, T1: v1}
+		if bits := counterBits(t0); bits > 0 {
+			delta, _ := counterDelta(t0.Value, t1.Value, bits)
+			if seconds := elapsed.Seconds(); seconds > 0 {
+				metric.RatePerSec = float64(delta) / seconds
+			}
+			metric.Unit = metricRateUnit
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics, nil
+}
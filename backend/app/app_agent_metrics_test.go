@@ -0,0 +1,80 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestBuildMetricResultsComputesRates(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.10", Name: "ifInOctets", Type: "column", Syntax: "Counter32"},
+		&mib.Node{OID: "1.3.6.1.2.1.31.1.1.1.6", Name: "ifHCInOctets", Type: "column", Syntax: "Counter64"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.5", Name: "ifSpeed", Type: "column", Syntax: "Gauge32"},
+	)
+
+	first := []snmp.Result{
+		{OID: ".1.3.6.1.2.1.2.2.1.10.1", Value: "4294967000", Type: "Counter32", ResolvedName: "ifInOctets[1]"},
+		{OID: ".1.3.6.1.2.1.31.1.1.1.6.1", Value: "10000000000", Type: "Counter64", ResolvedName: "ifHCInOctets[1]"},
+		{OID: ".1.3.6.1.2.1.2.2.1.5.1", Value: "100000000", Type: "Gauge32", ResolvedName: "ifSpeed[1]"},
+	}
+	second := []snmp.Result{
+		{OID: ".1.3.6.1.2.1.2.2.1.5.1", Value: "1000000000", Type: "Gauge32"},
+		{OID: ".1.3.6.1.2.1.2.2.1.10.1", Value: "704", Type: "Counter32"},
+		{OID: ".1.3.6.1.2.1.31.1.1.1.6.1", Value: "10000005000", Type: "Counter64"},
+	}
+
+	metrics, err := buildMetricResults(first, second, 10*time.Second, app.counterBits)
+	if err != nil {
+		t.Fatalf("buildMetricResults() error = %v", err)
+	}
+	if len(metrics) != 3 {
+		t.Fatalf("metrics = %d, want 3", len(metrics))
+	}
+
+	// Il Counter32 è tornato a zero: 296 fino al wrap più 704.
+	wrapped := metrics[0]
+	if wrapped.OID != "1.3.6.1.2.1.2.2.1.10.1" || wrapped.Name != "ifInOctets[1]" || wrapped.RatePerSec != 100 || wrapped.Unit != "/s" {
+		t.Fatalf("unexpected Counter32 metric: %+v", wrapped)
+	}
+	if hc := metrics[1]; hc.RatePerSec != 500 || hc.T1 != 10000005000 {
+		t.Fatalf("unexpected Counter64 metric: %+v", hc)
+	}
+	if gauge := metrics[2]; gauge.T1 != 1000000000 || gauge.RatePerSec != 0 || gauge.Unit != "" {
+		t.Fatalf("unexpected Gauge32 metric: %+v", gauge)
+	}
+
+	text := []snmp.Result{{OID: "1.3.6.1.2.1.1.5.0", Value: "router", Type: "OctetString"}}
+	if _, err := buildMetricResults(text, text, time.Second, app.counterBits); err == nil {
+		t.Fatalf("expected a non-numeric metric to be rejected")
+	}
+	missing := []snmp.Result{{OID: "1.3.6.1.2.1.2.2.1.10.9", Type: "NoSuchInstance"}}
+	if _, err := buildMetricResults(missing, missing, time.Second, app.counterBits); err == nil {
+		t.Fatalf("expected a missing metric to be rejected")
+	}
+}
+
+func TestGetSNMPAgentMetrics(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.10", Name: "ifInOctets", Type: "column", Syntax: "Counter32"},
+	)
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.2.2.1.10.1": {Type: gosnmp.Counter32, Value: uint(1000)},
+	})
+
+	if _, err := app.GetSNMPAgentMetrics(agent.config(), []string{"1.3.6.1.2.1.2.2.1.10.1"}, 0); err == nil {
+		t.Fatalf("expected a zero window to be rejected")
+	}
+
+	metrics, err := app.GetSNMPAgentMetrics(agent.config(), []string{"1.3.6.1.2.1.2.2.1.10.1"}, 1)
+	if err != nil {
+		t.Fatalf("GetSNMPAgentMetrics() error = %v", err)
+	}
+	if len(metrics) != 1 || metrics[0].Name != "ifInOctets[1]" || metrics[0].T0 != 1000 || metrics[0].T1 != 1000 || metrics[0].RatePerSec != 0 {
+		t.Fatalf("unexpected metrics: %+v", metrics)
+	}
+}
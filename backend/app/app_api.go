@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"mib-to-the-future/backend/api"
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// apiOIDRequest sono i parametri degli endpoint snmpGet e snmpWalk.
+type apiOIDRequest struct {
+	Config snmp.Config `json:"config"`
+	OID    string      `json:"oid"`
+}
+
+// apiTableRequest sono i parametri dell'endpoint fetchTableData.
+type apiTableRequest struct {
+	Config        snmp.Config `json:"config"`
+	TableOID      string      `json:"tableOid"`
+	SortColumn    string      `json:"sortColumn"`
+	SortDirection string      `json:"sortDirection"`
+}
+
+// apiTranslateRequest sono i parametri dell'endpoint translateOids.
+type apiTranslateRequest struct {
+	OIDs []string `json:"oids"`
+}
+
+// apiEndpoints associa gli endpoint del server API ai binding usati dalla GUI, così validazione,
+// arricchimento dei risultati e codifica JSON sono gli stessi.
+func (a *App) apiEndpoints() map[string]api.Endpoint {
+	return map[string]api.Endpoint{
+		"snmpGet": api.Bind(func(params apiOIDRequest) (interface{}, error) {
+			return a.SNMPGet(params.Config, params.OID)
+		}),
+		"snmpWalk": api.Bind(func(params apiOIDRequest) (interface{}, error) {
+			return a.SNMPWalk(params.Config, params.OID)
+		}),
+		"fetchTableData": api.Bind(func(params apiTableRequest) (interface{}, error) {
+			return a.FetchTableData(params.Config, params.TableOID, params.SortColumn, params.SortDirection)
+		}),
+		"translateOids": api.Bind(func(params apiTranslateRequest) (interface{}, error) {
+			return a.TranslateOIDs(params.OIDs)
+		}),
+		"listHosts": api.Bind(func(struct{}) (interface{}, error) {
+			hosts, err := a.ListHosts()
+			if err != nil {
+				return nil, err
+			}
+			// Le credenziali non escono dall'applicazione: chi ha il token vede solo i segnaposto.
+			masked := make([]mib.HostConfig, 0, len(hosts))
+			for _, host := range hosts {
+				masked = append(masked, host.Masked())
+			}
+			return masked, nil
+		}),
+	}
+}
+
+// StartAPIServer avvia il server HTTP per l'automazione su 127.0.0.1:port (0 per una porta libera),
+// protetto dal token indicato, e restituisce l'indirizzo di ascolto. Il server è spento finché non
+// viene avviato esplicitamente e si ferma con StopAPIServer o alla chiusura dell'applicazione.
+// Gli endpoint accettano POST /api/<nome> con "Authorization: Bearer <token>".
+func (a *App) StartAPIServer(port int, token string) (string, error) {
+	a.apiServerM.Lock()
+	defer a.apiServerM.Unlock()
+
+	if a.apiServer != nil {
+		return "", fmt.Errorf("API server already running on %s", a.apiServer.Addr())
+	}
+
+	server, err := api.NewServer(token, a.apiEndpoints())
+	if err != nil {
+		return "", err
+	}
+	addr, err := server.Start(port)
+	if err != nil {
+		return "", err
+	}
+	a.apiServer = server
+
+	if a.ctx != nil {
+		runtime.LogInfo(a.ctx, fmt.Sprintf("API server listening on %s", addr))
+	}
+	return addr, nil
+}
+
+// StopAPIServer ferma il server API attendendo le richieste in corso. Non fa nulla se non è avviato.
+func (a *App) StopAPIServer() error {
+	a.apiServerM.Lock()
+	server := a.apiServer
+	a.apiServer = nil
+	a.apiServerM.Unlock()
+
+	if server == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to stop API server: %w", err)
+	}
+	if a.ctx != nil {
+		runtime.LogInfo(a.ctx, "API server stopped")
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func postAPI(t *testing.T, addr, token, endpoint, body string) (int, []byte) {
+	t.Helper()
+	request, err := http.NewRequest(http.MethodPost, "http://"+addr+"/api/"+endpoint, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("POST %s error = %v", endpoint, err)
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("read %s response error = %v", endpoint, err)
+	}
+	return response.StatusCode, data
+}
+
+func TestAPIServerMatchesBindings(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.1.5", Name: "sysName", Type: "scalar", Syntax: "DisplayString", ParentOID: "1.3.6.1.2.1.1"},
+	)
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.1.5.0": {Type: gosnmp.OctetString, Value: []byte("router")},
+	})
+	const token = "automation-token-0001"
+
+	if _, err := app.StartAPIServer(0, "short"); err == nil {
+		t.Fatalf("expected a short token to be rejected")
+	}
+	addr, err := app.StartAPIServer(0, token)
+	if err != nil {
+		t.Fatalf("StartAPIServer() error = %v", err)
+	}
+	t.Cleanup(func() { app.StopAPIServer() })
+	if _, err := app.StartAPIServer(0, token); err == nil {
+		t.Fatalf("expected a second StartAPIServer to fail")
+	}
+
+	translations, err := app.TranslateOIDs([]string{"1.3.6.1.2.1.1.5.0", "1.3.6.1.4.1.9999"})
+	if err != nil {
+		t.Fatalf("TranslateOIDs() error = %v", err)
+	}
+	expected, _ := json.Marshal(translations)
+	status, body := postAPI(t, addr, token, "translateOids", `{"oids":["1.3.6.1.2.1.1.5.0","1.3.6.1.4.1.9999"]}`)
+	if status != http.StatusOK || string(body) != string(expected) {
+		t.Fatalf("translateOids: status %d body %s, want %s", status, body, expected)
+	}
+
+	request, _ := json.Marshal(apiOIDRequest{Config: agent.config(), OID: "1.3.6.1.2.1.1.5.0"})
+	status, body = postAPI(t, addr, token, "snmpGet", string(request))
+	if status != http.StatusOK {
+		t.Fatalf("snmpGet: status %d body %s", status, body)
+	}
+	var viaAPI snmp.Result
+	if err := json.Unmarshal(body, &viaAPI); err != nil {
+		t.Fatalf("snmpGet: invalid body %s: %v", body, err)
+	}
+	direct, err := app.SNMPGet(agent.config(), "1.3.6.1.2.1.1.5.0")
+	if err != nil {
+		t.Fatalf("SNMPGet() error = %v", err)
+	}
+	// Tempo di risposta e timestamp cambiano da una richiesta all'altra.
+	viaAPI.ResponseTime, viaAPI.Timestamp = direct.ResponseTime, direct.Timestamp
+	if got, _ := json.Marshal(viaAPI); string(got) != mustMarshal(t, direct) {
+		t.Fatalf("snmpGet via API = %s, want %s", got, mustMarshal(t, direct))
+	}
+	if viaAPI.DisplayValue != "router" || viaAPI.ResolvedName != "sysName" {
+		t.Fatalf("expected an enriched result, got %+v", viaAPI)
+	}
+
+	status, body = postAPI(t, addr, token, "snmpGet", `{"config":{"host":"127.0.0.1"},"oid":"not-an-oid"}`)
+	if status != http.StatusInternalServerError || !strings.Contains(string(body), "error") {
+		t.Fatalf("snmpGet with invalid OID: status %d body %s", status, body)
+	}
+	if status, _ := postAPI(t, addr, "wrong-token-000000", "listHosts", ""); status != http.StatusUnauthorized {
+		t.Fatalf("listHosts with wrong token: status %d, want 401", status)
+	}
+
+	if err := app.StopAPIServer(); err != nil {
+		t.Fatalf("StopAPIServer() error = %v", err)
+	}
+	if err := app.StopAPIServer(); err != nil {
+		t.Fatalf("StopAPIServer() on a stopped server error = %v", err)
+	}
+}
+
+func mustMarshal(t *testing.T, value interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	return string(data)
+}
+
+func TestAPIListHostsMasksCredentials(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+	if err := app.mibDB.EnsureHostConfigSchema(); err != nil {
+		t.Fatalf("EnsureHostConfigSchema error: %v", err)
+	}
+	if _, err := app.SaveHost(mib.HostConfig{
+		Address:          "192.0.2.20",
+		Version:          "v3",
+		SecurityLevel:    "authPriv",
+		SecurityUsername: "admin",
+		AuthProtocol:     "SHA",
+		AuthPassword:     "auth-secret",
+		PrivProtocol:     "AES",
+		PrivPassword:     "priv-secret",
+	}); err != nil {
+		t.Fatalf("SaveHost error: %v", err)
+	}
+
+	result, err := app.apiEndpoints()["listHosts"](nil)
+	if err != nil {
+		t.Fatalf("listHosts error: %v", err)
+	}
+	body := mustMarshal(t, result)
+	if strings.Contains(body, "auth-secret") || strings.Contains(body, "priv-secret") {
+		t.Fatalf("listHosts leaked credentials: %s", body)
+	}
+	if !strings.Contains(body, mib.MaskedPassword) {
+		t.Fatalf("expected masked credentials, got %s", body)
+	}
+}
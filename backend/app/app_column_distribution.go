@@ -0,0 +1,104 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"mib-to-the-future/backend/snmp"
+)
+
+// ColumnValueCount è il numero di istanze di una colonna che condividono lo stesso valore formattato.
+// Truncated indica che Value è stato accorciato e può raggruppare valori originali diversi.
+type ColumnValueCount struct {
+	Value     string `json:"value"`
+	Count     int    `json:"count"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// ColumnValueDistribution è la distribuzione dei valori di una colonna, dal più frequente al meno frequente.
+type ColumnValueDistribution struct {
+	ColumnOID  string             `json:"columnOid"`
+	ColumnName string             `json:"columnName"`
+	Total      int                `json:"total"`
+	Values     []ColumnValueCount `json:"values"`
+}
+
+// GetColumnValueDistribution esegue un WALK della sola colonna columnOID e ne raggruppa le istanze per
+// valore formattato (con le etichette degli enum), restituendo i conteggi in ordine decrescente e il
+// totale delle istanze. I valori più lunghi di AppSettings.DistributionValueMaxLength caratteri vengono
+// troncati prima del raggruppamento, così le colonne di testo non fanno crescere i gruppi senza limite.
+func (a *App) GetColumnValueDistribution(config snmp.Config, columnOID string) (*ColumnValueDistribution, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	normalized := normalizeOIDKey(columnOID)
+	if normalized == "" {
+		return nil, fmt.Errorf("column OID is required")
+	}
+	node, err := db.GetNode(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve column %s: %w", normalized, err)
+	}
+	if node.Type != "column" {
+		return nil, fmt.Errorf("%s (%s) is not a table column", node.Name, node.OID)
+	}
+
+	settings, err := a.GetAppSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := a.SNMPWalk(config, node.OID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildColumnValueDistribution(node.OID, node.Name, results, settings.DistributionValueMaxLength), nil
+}
+
+// buildColumnValueDistribution conta i risultati per valore, troncando i valori oltre maxLength rune.
+// A parità di conteggio i valori sono in ordine alfabetico, per un risultato stabile.
+func buildColumnValueDistribution(oid, name string, results []snmp.Result, maxLength int) *ColumnValueDistribution {
+	distribution := &ColumnValueDistribution{
+		ColumnOID:  oid,
+		ColumnName: name,
+		Values:     []ColumnValueCount{},
+	}
+
+	positions := make(map[string]int)
+	for _, result := range results {
+		if isMissingInstanceType(result.Type) || strings.EqualFold(result.Type, "EndOfMibView") {
+			continue
+		}
+		value := result.DisplayValue
+		if value == "" {
+			value = result.Value
+		}
+
+		truncated := false
+		if runes := []rune(value); maxLength > 0 && len(runes) > maxLength {
+			value, truncated = string(runes[:maxLength])+"…", true
+		}
+
+		distribution.Total++
+		position, ok := positions[value]
+		if !ok {
+			position = len(distribution.Values)
+			positions[value] = position
+			distribution.Values = append(distribution.Values, ColumnValueCount{Value: value, Truncated: truncated})
+		}
+		distribution.Values[position].Count++
+	}
+
+	sort.SliceStable(distribution.Values, func(i, j int) bool {
+		if distribution.Values[i].Count != distribution.Values[j].Count {
+			return distribution.Values[i].Count > distribution.Values[j].Count
+		}
+		return distribution.Values[i].Value < distribution.Values[j].Value
+	})
+	return distribution
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestGetColumnValueDistribution(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.2.2", Name: "ifTable", Type: "table", ParentOID: "1.3.6.1.2.1.2"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1", Name: "ifEntry", Type: "row", ParentOID: "1.3.6.1.2.1.2.2"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.2", Name: "ifDescr", Type: "column", Syntax: "DisplayString", ParentOID: "1.3.6.1.2.1.2.2.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.3", Name: "ifType", Type: "column", Syntax: "INTEGER {other(1), ethernetCsmacd(6), softwareLoopback(24)}", ParentOID: "1.3.6.1.2.1.2.2.1"},
+	)
+
+	long := strings.Repeat("x", 80)
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.2.2.1.2.1": {Type: gosnmp.OctetString, Value: []byte(long + "a")},
+		"1.3.6.1.2.1.2.2.1.2.2": {Type: gosnmp.OctetString, Value: []byte(long + "b")},
+		"1.3.6.1.2.1.2.2.1.2.3": {Type: gosnmp.OctetString, Value: []byte("lo")},
+		"1.3.6.1.2.1.2.2.1.3.1": {Type: gosnmp.Integer, Value: 6},
+		"1.3.6.1.2.1.2.2.1.3.2": {Type: gosnmp.Integer, Value: 24},
+		"1.3.6.1.2.1.2.2.1.3.3": {Type: gosnmp.Integer, Value: 6},
+	})
+
+	distribution, err := app.GetColumnValueDistribution(agent.config(), "1.3.6.1.2.1.2.2.1.3")
+	if err != nil {
+		t.Fatalf("GetColumnValueDistribution(ifType) error = %v", err)
+	}
+	if distribution.Total != 3 || len(distribution.Values) != 2 {
+		t.Fatalf("unexpected ifType distribution: %+v", distribution)
+	}
+	if first := distribution.Values[0]; first.Value != "ethernetCsmacd (6)" || first.Count != 2 {
+		t.Fatalf("unexpected most frequent value: %+v", first)
+	}
+
+	distribution, err = app.GetColumnValueDistribution(agent.config(), "1.3.6.1.2.1.2.2.1.2")
+	if err != nil {
+		t.Fatalf("GetColumnValueDistribution(ifDescr) error = %v", err)
+	}
+	if distribution.Total != 3 || len(distribution.Values) != 2 {
+		t.Fatalf("unexpected ifDescr distribution: %+v", distribution)
+	}
+	if first := distribution.Values[0]; first.Count != 2 || !first.Truncated || len([]rune(first.Value)) != defaultAppSettings().DistributionValueMaxLength+1 {
+		t.Fatalf("expected the long descriptions to be truncated into one group, got %+v", first)
+	}
+
+	if _, err := app.GetColumnValueDistribution(agent.config(), "1.3.6.1.2.1.2.2.1"); err == nil {
+		t.Fatalf("expected an error for a non-column OID")
+	}
+}
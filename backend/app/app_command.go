@@ -0,0 +1,80 @@
+package app
+
+import (
+	"strings"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+)
+
+// setValuePlaceholder sostituisce il valore nei comandi snmpset generati, da completare a mano.
+const setValuePlaceholder = "<value>"
+
+// GenerateCommand genera il comando net-snmp equivalente a un'operazione (get, getnext, walk,
+// bulkwalk, set) con versione, credenziali, porta, timeout e OID numerico.
+// Per set il tipo è dedotto dalla sintassi MIB e il valore è lasciato come segnaposto.
+func (a *App) GenerateCommand(config snmp.Config, operation string, oid string) (string, error) {
+	return a.generateCommand(config, operation, oid, false)
+}
+
+// GenerateMaskedCommand è come GenerateCommand ma sostituisce community e password con un segnaposto,
+// così il comando può essere condiviso senza esporre credenziali.
+func (a *App) GenerateMaskedCommand(config snmp.Config, operation string, oid string) (string, error) {
+	return a.generateCommand(config, operation, oid, true)
+}
+
+func (a *App) generateCommand(config snmp.Config, operation string, oid string, mask bool) (string, error) {
+	if err := validateOIDInput(oid); err != nil {
+		return "", err
+	}
+
+	target := normalizeOIDKey(oid)
+	opts := snmp.CommandOptions{MaskSecrets: mask}
+
+	switch strings.ToLower(strings.TrimSpace(operation)) {
+	case "get":
+		target = normalizeOIDKey(a.normalizeScalarOID(target))
+	case "set":
+		target = normalizeOIDKey(a.normalizeScalarOID(target))
+		opts.SetType = netSNMPSetType(a.lookupNodeForOID(target))
+		opts.SetValue = setValuePlaceholder
+	}
+
+	return snmp.BuildNetSNMPCommand(config, operation, target, opts)
+}
+
+// netSNMPSetType restituisce il codice di tipo snmpset corrispondente alla sintassi del nodo.
+func netSNMPSetType(node *mib.Node) string {
+	if node == nil {
+		return "s"
+	}
+	base := syntaxBaseName(node.Syntax)
+	if base == "bits" {
+		return "b"
+	}
+	expected, ok := syntaxAgentTypes[base]
+	if !ok {
+		if parseEnumMapping(node.Syntax) != nil {
+			return "i"
+		}
+		return "s"
+	}
+	switch expected[0] {
+	case "Integer":
+		return "i"
+	case "Gauge32":
+		return "u"
+	case "Counter32":
+		return "c"
+	case "Counter64":
+		return "C"
+	case "TimeTicks":
+		return "t"
+	case "IPAddress":
+		return "a"
+	case "ObjectIdentifier":
+		return "o"
+	default:
+		return "s"
+	}
+}
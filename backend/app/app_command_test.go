@@ -0,0 +1,46 @@
+package app
+
+import (
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+)
+
+func TestGenerateCommand(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.1", Name: "system", Type: "node"},
+		&mib.Node{OID: "1.3.6.1.2.1.1.5", Name: "sysName", Type: "scalar", Syntax: "DisplayString (0..255)", ParentOID: "1.3.6.1.2.1.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1", Name: "ifEntry", Type: "row"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.7", Name: "ifAdminStatus", Type: "column", Syntax: "INTEGER {up(1), down(2), testing(3)}", ParentOID: "1.3.6.1.2.1.2.2.1"},
+	)
+	config := snmp.Config{Host: "192.0.2.1", Community: "public", WriteCommunity: "private"}
+
+	got, err := app.GenerateCommand(config, "get", "1.3.6.1.2.1.1.5")
+	if err != nil {
+		t.Fatalf("GenerateCommand(get) error = %v", err)
+	}
+	if want := "snmpget -v2c -c public -t 5 -r 2 192.0.2.1 .1.3.6.1.2.1.1.5.0"; got != want {
+		t.Errorf("GenerateCommand(get) = %s, want %s", got, want)
+	}
+
+	got, err = app.GenerateCommand(config, "set", "1.3.6.1.2.1.2.2.1.7.3")
+	if err != nil {
+		t.Fatalf("GenerateCommand(set) error = %v", err)
+	}
+	if want := "snmpset -v2c -c private -t 5 -r 2 192.0.2.1 .1.3.6.1.2.1.2.2.1.7.3 i '<value>'"; got != want {
+		t.Errorf("GenerateCommand(set) = %s, want %s", got, want)
+	}
+
+	got, err = app.GenerateMaskedCommand(config, "walk", "1.3.6.1.2.1.1")
+	if err != nil {
+		t.Fatalf("GenerateMaskedCommand(walk) error = %v", err)
+	}
+	if want := "snmpwalk -v2c -c ******** -t 5 -r 2 192.0.2.1 .1.3.6.1.2.1.1"; got != want {
+		t.Errorf("GenerateMaskedCommand(walk) = %s, want %s", got, want)
+	}
+
+	if _, err := app.GenerateCommand(config, "get", "1..3"); err == nil {
+		t.Error("expected invalid OID to be rejected")
+	}
+}
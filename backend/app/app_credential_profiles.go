@@ -0,0 +1,56 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"mib-to-the-future/backend/mib"
+)
+
+// GetCredentialProfiles restituisce i profili di credenziali condivisi tra host.
+func (a *App) GetCredentialProfiles() ([]mib.CredentialProfile, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	profiles, err := db.ListCredentialProfiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list credential profiles: %w", err)
+	}
+	return profiles, nil
+}
+
+// SaveCredentialProfile crea o aggiorna un profilo di credenziali; gli host che lo usano
+// adottano subito le nuove credenziali.
+func (a *App) SaveCredentialProfile(profile mib.CredentialProfile) (*mib.CredentialProfile, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	saved, err := db.SaveCredentialProfile(profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save credential profile: %w", err)
+	}
+	return saved, nil
+}
+
+// DeleteCredentialProfile rimuove un profilo non più usato da alcun host.
+func (a *App) DeleteCredentialProfile(name string) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("profile name is required")
+	}
+
+	if err := db.DeleteCredentialProfile(name); err != nil {
+		return fmt.Errorf("failed to delete credential profile: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"mib-to-the-future/backend/snmp"
+)
+
+// SetSNMPDebugCapture abilita o disabilita la cattura dei pacchetti SNMP (disabilitata di default).
+// Disabilitandola vengono scartati i pacchetti già catturati.
+func (a *App) SetSNMPDebugCapture(enabled bool) {
+	a.debugCaptureM.Lock()
+	defer a.debugCaptureM.Unlock()
+
+	a.debugCaptureEnabled = enabled
+	if !enabled {
+		a.debugCaptures = nil
+	}
+}
+
+// GetSNMPDebugCapture restituisce gli ultimi pacchetti scambiati con l'host indicato,
+// in esadecimale e con la community mascherata.
+func (a *App) GetSNMPDebugCapture(host string) []snmp.CapturedPacket {
+	a.debugCaptureM.Lock()
+	capture := a.debugCaptures[strings.TrimSpace(host)]
+	a.debugCaptureM.Unlock()
+
+	if capture == nil {
+		return []snmp.CapturedPacket{}
+	}
+	return capture.Packets()
+}
+
+// ClearSNMPDebugCapture svuota i pacchetti catturati per l'host indicato.
+func (a *App) ClearSNMPDebugCapture(host string) {
+	a.debugCaptureM.Lock()
+	capture := a.debugCaptures[strings.TrimSpace(host)]
+	a.debugCaptureM.Unlock()
+
+	if capture != nil {
+		capture.Clear()
+	}
+}
+
+// newSNMPClient crea un client SNMP legato al ciclo di vita dell'app, collegandolo al buffer di cattura
+// dell'host quando il debug è attivo.
+func (a *App) newSNMPClient(config snmp.Config) (*snmp.Client, error) {
+	client, err := snmp.NewClient(a.withStoredHostOptions(config))
+	if err != nil {
+		return nil, err
+	}
+	// Le richieste in corso vengono interrotte da Shutdown.
+	client.SetContext(a.backgroundContext())
+
+	a.debugCaptureM.Lock()
+	defer a.debugCaptureM.Unlock()
+
+	if !a.debugCaptureEnabled {
+		return client, nil
+	}
+
+	host := strings.TrimSpace(config.Host)
+	if a.debugCaptures == nil {
+		a.debugCaptures = make(map[string]*snmp.DebugCapture)
+	}
+	capture, ok := a.debugCaptures[host]
+	if !ok {
+		capture = snmp.NewDebugCapture(snmp.DefaultDebugCapturePackets)
+		a.debugCaptures[host] = capture
+	}
+	client.SetDebugCapture(capture)
+	return client, nil
+}
+
+// TestSNMPGet esegue una GET in modalità raw per il debug dell'agent: nessun fallback di versione né
+// correzione dell'istanza, e nel risultato tipo di PDU, request-id, error-status/index, parametri
+// dell'engine SNMPv3 e dump esadecimale della risposta.
+func (a *App) TestSNMPGet(config snmp.Config, oid string) (*snmp.DiagnosticResult, error) {
+	if err := validateOIDInput(oid); err != nil {
+		return nil, err
+	}
+
+	client, err := a.newSNMPClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SNMP client: %v", err)
+	}
+
+	result, err := client.GetDiagnostic(normalizeOIDKey(oid))
+	if err != nil {
+		return result, fmt.Errorf("SNMP GET failed: %v", err)
+	}
+
+	result.Version = strings.ToLower(strings.TrimSpace(config.Version))
+	if result.Version == "" {
+		result.Version = "v2c"
+	}
+	a.enrichResult(config.Host, &result.Result)
+	return result, nil
+}
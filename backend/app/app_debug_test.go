@@ -0,0 +1,44 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestTestSNMPGetReportsRawPDUFields(t *testing.T) {
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.1.5.0": {Type: gosnmp.OctetString, Value: []byte("router-1")},
+	})
+	app := setupTestAppWithNodes(t, &mib.Node{OID: "1.3.6.1.2.1.1.5", Name: "sysName", Type: "scalar"})
+	app.SetSNMPDebugCapture(true)
+
+	result, err := app.TestSNMPGet(agent.config(), "1.3.6.1.2.1.1.5.0")
+	if err != nil {
+		t.Fatalf("TestSNMPGet error: %v", err)
+	}
+	if result.PDUType != gosnmp.GetResponse.String() || result.ErrorStatus != gosnmp.NoError.String() || result.ErrorIndex != 0 {
+		t.Fatalf("unexpected PDU fields: %+v", result)
+	}
+	if result.RequestID == 0 || result.Value != "0x726f757465722d31" || result.ResolvedName == "" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.RawPDU == "" || !strings.Contains(result.RawPDU, "**") {
+		t.Fatalf("expected raw PDU with masked community, got %q", result.RawPDU)
+	}
+	if packets := app.GetSNMPDebugCapture(agent.config().Host); len(packets) != 2 {
+		t.Fatalf("expected the exchange in the host debug capture, got %d packets", len(packets))
+	}
+
+	// Nessuna correzione automatica: l'OID senza istanza resta noSuchInstance.
+	result, err = app.TestSNMPGet(agent.config(), "1.3.6.1.2.1.1.5")
+	if err != nil {
+		t.Fatalf("TestSNMPGet error: %v", err)
+	}
+	if result.Type != gosnmp.NoSuchInstance.String() || result.AutoCorrectedFrom != "" {
+		t.Fatalf("expected raw noSuchInstance, got %+v", result)
+	}
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"strconv"
+
+	"mib-to-the-future/backend/mib"
+)
+
+// rawDescriptionsMetadataKey è la chiave di app_metadata che sceglie la descrizione mostrata nei
+// dettagli dei nodi: quella originale del MIB invece di quella ripulita.
+const rawDescriptionsMetadataKey = "keep_raw_descriptions"
+
+// GetKeepRawDescriptions indica se i dettagli dei nodi mostrano la descrizione originale del MIB,
+// con spaziatura e paragrafi, invece di quella ripulita (predefinita).
+func (a *App) GetKeepRawDescriptions() (bool, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return false, a.mibNotInitializedErr()
+	}
+
+	raw, ok, err := db.GetMetadata(rawDescriptionsMetadataKey)
+	if err != nil || !ok {
+		return false, err
+	}
+	keep, _ := strconv.ParseBool(raw)
+	return keep, nil
+}
+
+// SetKeepRawDescriptions salva la preferenza tra descrizione originale e ripulita.
+func (a *App) SetKeepRawDescriptions(keep bool) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+	return db.SetMetadata(rawDescriptionsMetadataKey, strconv.FormatBool(keep))
+}
+
+// applyDescriptionPreference completa RawDescription (il database la conserva solo se diversa da
+// Description) e, se richiesto, usa la forma originale come Description.
+func applyDescriptionPreference(node *mib.Node, keepRaw bool) {
+	if node == nil {
+		return
+	}
+	if node.RawDescription == "" {
+		node.RawDescription = node.Description
+	}
+	if keepRaw {
+		node.Description = node.RawDescription
+	}
+}
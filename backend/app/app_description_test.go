@@ -0,0 +1,53 @@
+package app
+
+import (
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+)
+
+func TestGetMIBNodeHonorsRawDescriptionPreference(t *testing.T) {
+	raw := "The status of the link.\n\n    +------+------+\n    | up   | 1    |\n    +------+------+"
+	app := setupTestAppWithNodes(t,
+		&mib.Node{
+			OID:            "1.3.6.1.2.1.2.2.1.8",
+			Name:           "ifOperStatus",
+			Type:           "column",
+			Description:    "The status of the link.\n+------+------+\n| up   | 1    |\n+------+------+",
+			RawDescription: raw,
+		},
+		&mib.Node{OID: "1.3.6.1.2.1.1.5", Name: "sysName", Type: "scalar", Description: "An administratively-assigned name."},
+	)
+
+	node, err := app.GetMIBNode("1.3.6.1.2.1.2.2.1.8")
+	if err != nil {
+		t.Fatalf("GetMIBNode() error = %v", err)
+	}
+	if node.Description == raw || node.RawDescription != raw {
+		t.Fatalf("expected cleaned description by default and raw form alongside, got %q / %q", node.Description, node.RawDescription)
+	}
+
+	if err := app.SetKeepRawDescriptions(true); err != nil {
+		t.Fatalf("SetKeepRawDescriptions() error = %v", err)
+	}
+	if keep, err := app.GetKeepRawDescriptions(); err != nil || !keep {
+		t.Fatalf("GetKeepRawDescriptions() = %v, %v; want true", keep, err)
+	}
+
+	node, err = app.GetMIBNode("1.3.6.1.2.1.2.2.1.8")
+	if err != nil {
+		t.Fatalf("GetMIBNode() error = %v", err)
+	}
+	if node.Description != raw {
+		t.Fatalf("expected raw description, got %q", node.Description)
+	}
+
+	// Senza una forma originale distinta la descrizione ripulita vale per entrambe.
+	scalar, err := app.GetMIBNode("1.3.6.1.2.1.1.5")
+	if err != nil {
+		t.Fatalf("GetMIBNode() error = %v", err)
+	}
+	if scalar.Description != "An administratively-assigned name." || scalar.RawDescription != scalar.Description {
+		t.Fatalf("unexpected fallback descriptions: %q / %q", scalar.Description, scalar.RawDescription)
+	}
+}
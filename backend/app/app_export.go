@@ -0,0 +1,130 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"mib-to-the-future/backend/snmp"
+)
+
+// prometheusNumericTypes elenca i tipi SNMP esportabili come metriche Prometheus.
+var prometheusNumericTypes = map[string]string{
+	"integer":      "gauge",
+	"gauge32":      "gauge",
+	"uinteger32":   "gauge",
+	"timeticks":    "gauge",
+	"opaquefloat":  "gauge",
+	"opaquedouble": "gauge",
+	"counter32":    "counter",
+	"counter64":    "counter",
+}
+
+// prometheusFamily raggruppa i campioni di una stessa metrica per emetterli in modo contiguo.
+type prometheusFamily struct {
+	name    string
+	help    string
+	kind    string
+	samples []string
+}
+
+// ExportResultsPrometheus converte i risultati SNMP numerici nel formato di esposizione testuale di Prometheus.
+// Il nome della metrica deriva dal nome risolto dell'OID, con le etichette `oid` e `host`;
+// la riga HELP riporta la descrizione del nodo MIB e, per gli oggetti deprecated o obsolete, l'avviso di stato.
+// I valori non numerici vengono ignorati.
+func (a *App) ExportResultsPrometheus(results []snmp.Result) (string, error) {
+	families := []*prometheusFamily{}
+	byName := make(map[string]*prometheusFamily)
+
+	for _, result := range results {
+		kind, ok := prometheusNumericTypes[strings.ToLower(strings.TrimSpace(result.Type))]
+		if !ok {
+			continue
+		}
+
+		raw := strings.TrimSpace(result.RawValue)
+		if raw == "" {
+			raw = strings.TrimSpace(result.Value)
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			continue
+		}
+
+		name := prometheusMetricName(result.ResolvedName, result.OID)
+		family, exists := byName[name]
+		if !exists {
+			family = &prometheusFamily{name: name, kind: kind}
+			if node := a.lookupNodeForOID(result.OID); node != nil {
+				family.help = node.Description
+			}
+			if result.StatusWarning != "" {
+				family.help = strings.TrimSpace(family.help + " (" + result.StatusWarning + ")")
+			}
+			byName[name] = family
+			families = append(families, family)
+		}
+
+		family.samples = append(family.samples, fmt.Sprintf("%s{oid=\"%s\",host=\"%s\"} %s",
+			name,
+			escapePrometheusLabel(normalizeOIDKey(result.OID)),
+			escapePrometheusLabel(result.Host),
+			strconv.FormatFloat(value, 'g', -1, 64),
+		))
+	}
+
+	var builder strings.Builder
+	for _, family := range families {
+		help := family.help
+		if help == "" {
+			help = family.name
+		}
+		fmt.Fprintf(&builder, "# HELP %s %s\n", family.name, escapePrometheusHelp(help))
+		fmt.Fprintf(&builder, "# TYPE %s %s\n", family.name, family.kind)
+		for _, sample := range family.samples {
+			builder.WriteString(sample)
+			builder.WriteByte('\n')
+		}
+	}
+
+	return builder.String(), nil
+}
+
+// prometheusMetricName ricava un nome di metrica valido dal nome risolto (senza indice) o, in mancanza, dall'OID.
+func prometheusMetricName(resolvedName, oid string) string {
+	base := strings.TrimSpace(resolvedName)
+	if idx := strings.Index(base, "["); idx >= 0 {
+		base = base[:idx]
+	}
+	if base == "" {
+		base = "snmp_" + normalizeOIDKey(oid)
+	}
+
+	var builder strings.Builder
+	for i, r := range base {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', r == ':':
+			builder.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				builder.WriteByte('_')
+			}
+			builder.WriteRune(r)
+		default:
+			builder.WriteByte('_')
+		}
+	}
+	return builder.String()
+}
+
+// escapePrometheusLabel applica l'escape richiesto per i valori delle etichette.
+func escapePrometheusLabel(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return replacer.Replace(value)
+}
+
+// escapePrometheusHelp applica l'escape richiesto per il testo HELP, compattandolo su una riga.
+func escapePrometheusHelp(value string) string {
+	compact := strings.Join(strings.Fields(value), " ")
+	return strings.ReplaceAll(compact, `\`, `\\`)
+}
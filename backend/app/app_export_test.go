@@ -0,0 +1,52 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+)
+
+func TestExportResultsPrometheus(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{
+			OID:         "1.3.6.1.2.1.2.2.1.10",
+			Name:        "ifInOctets",
+			Type:        "column",
+			ParentOID:   "1.3.6.1.2.1.2.2.1",
+			Description: "The total number of octets\n received on the interface.",
+		},
+	)
+
+	results := []snmp.Result{
+		{OID: ".1.3.6.1.2.1.2.2.1.10.1", Value: "1200", Type: "Counter32", ResolvedName: "ifInOctets[1]", Host: "10.0.0.1"},
+		{OID: ".1.3.6.1.2.1.1.5.0", Value: "0x726f75746572", Type: "OctetString", ResolvedName: "sysName", Host: "10.0.0.1"},
+		{OID: ".1.3.6.1.2.1.2.2.1.10.2", Value: "42", Type: "Counter32", ResolvedName: "ifInOctets[2]", Host: "10.0.0.1"},
+	}
+
+	output, err := app.ExportResultsPrometheus(results)
+	if err != nil {
+		t.Fatalf("ExportResultsPrometheus() error = %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"# HELP ifInOctets The total number of octets received on the interface.",
+		"# TYPE ifInOctets counter",
+		`ifInOctets{oid="1.3.6.1.2.1.2.2.1.10.1",host="10.0.0.1"} 1200`,
+		`ifInOctets{oid="1.3.6.1.2.1.2.2.1.10.2",host="10.0.0.1"} 42`,
+		"",
+	}, "\n")
+	if output != expected {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", output, expected)
+	}
+}
+
+func TestPrometheusMetricName(t *testing.T) {
+	if got := prometheusMetricName("mib-2", ""); got != "mib_2" {
+		t.Errorf("prometheusMetricName(mib-2) = %q", got)
+	}
+	if got := prometheusMetricName("", ".1.3.6"); got != "snmp_1_3_6" {
+		t.Errorf("prometheusMetricName(oid) = %q", got)
+	}
+}
@@ -0,0 +1,163 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	// defaultFanoutConcurrency è il numero di GET contemporanee usato se non indicato.
+	defaultFanoutConcurrency = 5
+	maxFanoutConcurrency     = 50
+	// fanoutSlowThreshold è l'attesa dopo cui un host ancora senza risposta viene segnalato come lento.
+	fanoutSlowThreshold = 2 * time.Second
+	// fanoutProgressEvent è l'evento Wails con l'avanzamento di SNMPGetFanout.
+	fanoutProgressEvent = "fanout:progress"
+)
+
+// Tipi di errore riportati da SNMPGetFanout.
+const (
+	fanoutErrorConfig  = "config"
+	fanoutErrorTimeout = "timeout"
+	fanoutErrorSNMP    = "snmp"
+)
+
+// FanoutResult è l'esito della GET su un singolo host: il valore oppure l'errore con il suo tipo
+// (config per host senza configurazione salvata, timeout, snmp per gli altri errori).
+type FanoutResult struct {
+	Host       string       `json:"host"`
+	Result     *snmp.Result `json:"result,omitempty"`
+	ErrorKind  string       `json:"errorKind,omitempty"`
+	Error      string       `json:"error,omitempty"`
+	DurationMs int64        `json:"durationMs"`
+}
+
+// FanoutProgress è il payload dell'evento fanout:progress. Status vale "slow" quando un host supera
+// fanoutSlowThreshold senza rispondere, "done" o "failed" al suo completamento.
+type FanoutProgress struct {
+	Host      string `json:"host"`
+	Status    string `json:"status"`
+	Completed int    `json:"completed"`
+	Total     int    `json:"total"`
+}
+
+// fanoutTarget è un host da interrogare con la sua configurazione salvata (nil se assente).
+type fanoutTarget struct {
+	host   string
+	config *snmp.Config
+}
+
+// SNMPGetFanout legge lo stesso OID da più host usando le credenziali salvate in host_configs, con al massimo
+// concurrency richieste contemporanee (0 per il valore predefinito). Ritorna un risultato per host, nello stesso
+// ordine; gli host senza configurazione salvata sono riportati come errori di configurazione e non interrogati.
+// L'avanzamento, inclusi gli host lenti, è notificato con l'evento fanout:progress.
+func (a *App) SNMPGetFanout(hostAddresses []string, oid string, concurrency int) ([]FanoutResult, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+	if err := validateOIDInput(oid); err != nil {
+		return nil, err
+	}
+	if concurrency < 0 || concurrency > maxFanoutConcurrency {
+		return nil, fmt.Errorf("concurrency must be between 1 and %d, or 0 for the default", maxFanoutConcurrency)
+	}
+	if concurrency == 0 {
+		concurrency = defaultFanoutConcurrency
+	}
+
+	targets := make([]fanoutTarget, 0, len(hostAddresses))
+	seen := make(map[string]bool, len(hostAddresses))
+	for _, address := range hostAddresses {
+		address = strings.TrimSpace(address)
+		if address == "" || seen[address] {
+			continue
+		}
+		seen[address] = true
+
+		host, err := db.GetHost(address)
+		if err != nil {
+			return nil, err
+		}
+		target := fanoutTarget{host: address}
+		if host != nil {
+			config := hostConfigToSNMP(host)
+			target.config = &config
+		}
+		targets = append(targets, target)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("at least one host is required")
+	}
+
+	return runFanout(targets, concurrency, fanoutSlowThreshold, func(config snmp.Config) (*snmp.Result, error) {
+		return a.SNMPGet(config, oid)
+	}, a.emitFanoutProgress), nil
+}
+
+func (a *App) emitFanoutProgress(progress FanoutProgress) {
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, fanoutProgressEvent, progress)
+	}
+}
+
+// runFanout esegue get sui target con al massimo concurrency richieste contemporanee e notifica l'avanzamento con progress.
+func runFanout(targets []fanoutTarget, concurrency int, slowAfter time.Duration, get func(snmp.Config) (*snmp.Result, error), progress func(FanoutProgress)) []FanoutResult {
+	results := make([]FanoutResult, len(targets))
+	total := len(targets)
+
+	var (
+		progressM sync.Mutex
+		completed int
+	)
+	report := func(host, status string, done bool) {
+		progressM.Lock()
+		defer progressM.Unlock()
+		if done {
+			completed++
+		}
+		progress(FanoutProgress{Host: host, Status: status, Completed: completed, Total: total})
+	}
+
+	runBounded(total, concurrency, func(i int) {
+		target := targets[i]
+		entry := FanoutResult{Host: target.host}
+
+		if target.config == nil {
+			entry.ErrorKind = fanoutErrorConfig
+			entry.Error = fmt.Sprintf("host %s has no saved configuration", target.host)
+			results[i] = entry
+			report(target.host, "failed", true)
+			return
+		}
+
+		start := time.Now()
+		slowTimer := time.AfterFunc(slowAfter, func() { report(target.host, "slow", false) })
+		result, err := get(*target.config)
+		slowTimer.Stop()
+		entry.DurationMs = time.Since(start).Milliseconds()
+
+		status := "done"
+		if err != nil {
+			status = "failed"
+			entry.ErrorKind = fanoutErrorSNMP
+			if isTimeoutError(err) {
+				entry.ErrorKind = fanoutErrorTimeout
+			}
+			entry.Error = err.Error()
+		} else {
+			entry.Result = result
+		}
+		results[i] = entry
+		report(target.host, status, true)
+	})
+
+	return results
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestRunFanoutBoundsConcurrencyAndClassifiesErrors(t *testing.T) {
+	targets := []fanoutTarget{
+		{host: "a", config: &snmp.Config{Host: "a"}},
+		{host: "missing"},
+		{host: "slow", config: &snmp.Config{Host: "slow"}},
+		{host: "dead", config: &snmp.Config{Host: "dead"}},
+		{host: "b", config: &snmp.Config{Host: "b"}},
+	}
+
+	var active, peak int32
+	get := func(config snmp.Config) (*snmp.Result, error) {
+		current := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			previous := atomic.LoadInt32(&peak)
+			if current <= previous || atomic.CompareAndSwapInt32(&peak, previous, current) {
+				break
+			}
+		}
+
+		switch config.Host {
+		case "slow":
+			time.Sleep(50 * time.Millisecond)
+		case "dead":
+			return nil, errors.New("SNMP GET failed: request timeout (after 2 retries)")
+		}
+		return &snmp.Result{OID: "1.3.6.1.2.1.1.5.0", Value: config.Host}, nil
+	}
+
+	var (
+		eventsM sync.Mutex
+		events  []FanoutProgress
+	)
+	results := runFanout(targets, 2, 10*time.Millisecond, get, func(progress FanoutProgress) {
+		eventsM.Lock()
+		events = append(events, progress)
+		eventsM.Unlock()
+	})
+
+	if peak > 2 {
+		t.Fatalf("expected at most 2 concurrent GETs, got %d", peak)
+	}
+	if len(results) != len(targets) {
+		t.Fatalf("expected %d results, got %d", len(targets), len(results))
+	}
+	for i, target := range targets {
+		if results[i].Host != target.host {
+			t.Fatalf("result %d is for %s, want %s", i, results[i].Host, target.host)
+		}
+	}
+	if results[0].Result == nil || results[0].Result.Value != "a" {
+		t.Fatalf("unexpected result for a: %+v", results[0])
+	}
+	if results[1].ErrorKind != fanoutErrorConfig || results[1].Result != nil {
+		t.Fatalf("expected configuration error for missing host, got %+v", results[1])
+	}
+	if results[3].ErrorKind != fanoutErrorTimeout {
+		t.Fatalf("expected timeout error for dead host, got %+v", results[3])
+	}
+
+	eventsM.Lock()
+	defer eventsM.Unlock()
+	slow := false
+	for _, event := range events {
+		if event.Host == "slow" && event.Status == "slow" {
+			slow = true
+		}
+	}
+	if !slow {
+		t.Fatalf("expected a slow progress event, got %+v", events)
+	}
+	if last := events[len(events)-1]; last.Completed != len(targets) || last.Total != len(targets) {
+		t.Fatalf("unexpected final progress: %+v", last)
+	}
+}
+
+func TestSNMPGetFanoutUsesSavedHostConfigs(t *testing.T) {
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.1.1.0": {Type: gosnmp.OctetString, Value: []byte("fw")},
+	})
+	app := setupTestAppWithNodes(t, &mib.Node{OID: "1.3.6.1.2.1.1.1", Name: "sysDescr", Type: "scalar"})
+
+	if err := app.mibDB.EnsureHostConfigSchema(); err != nil {
+		t.Fatalf("EnsureHostConfigSchema error: %v", err)
+	}
+
+	config := agent.config()
+	if _, err := app.mibDB.SaveHost(mib.HostConfig{Address: config.Host, Port: config.Port, Community: "public", Version: "v2c"}); err != nil {
+		t.Fatalf("SaveHost error: %v", err)
+	}
+
+	results, err := app.SNMPGetFanout([]string{config.Host, "192.0.2.99", config.Host}, "1.3.6.1.2.1.1.1", 0)
+	if err != nil {
+		t.Fatalf("SNMPGetFanout error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected duplicate hosts to be collapsed, got %+v", results)
+	}
+	if results[0].Result == nil || results[0].Result.Value != "0x6677" || results[0].Error != "" {
+		t.Fatalf("unexpected result for saved host: %+v", results[0])
+	}
+	if results[1].ErrorKind != fanoutErrorConfig {
+		t.Fatalf("expected unknown host to be a configuration error, got %+v", results[1])
+	}
+
+	if _, err := app.SNMPGetFanout([]string{config.Host}, "1.3.6.1.2.1.1.1", maxFanoutConcurrency+1); err == nil {
+		t.Fatalf("expected excessive concurrency to be rejected")
+	}
+}
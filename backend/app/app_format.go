@@ -1,6 +1,7 @@
 package app
 
 import (
+	"encoding/base64"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
@@ -8,11 +9,13 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf16"
 	"unicode/utf8"
 
 	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
 )
 
 // formatTimeTicks converte un valore TimeTicks in formato leggibile.
@@ -246,6 +249,83 @@ func formatInetAddress(raw string) (string, bool) {
 	}
 }
 
+// Valori di InetAddressType (INET-ADDRESS-MIB) gestiti da formatInetAddressTyped.
+const (
+	inetAddressTypeIPv4  = 1
+	inetAddressTypeIPv6  = 2
+	inetAddressTypeIPv4z = 3
+	inetAddressTypeIPv6z = 4
+	inetAddressTypeDNS   = 16
+)
+
+// FormatInetAddressTyped formatta un InetAddress interpretandolo secondo l'InetAddressType associato
+// (1 ipv4, 2 ipv6, 3 ipv4z, 4 ipv6z, 16 dns). Gli indirizzi con zona sono resi come `indirizzo%zona`.
+// Ritorna false se il valore non è coerente con il tipo indicato.
+func (a *App) FormatInetAddressTyped(addrType int, raw string) (string, bool) {
+	return formatInetAddressTyped(addrType, raw)
+}
+
+func formatInetAddressTyped(addrType int, raw string) (string, bool) {
+	data, ok := parseHexLikeString(raw)
+	if addrType == inetAddressTypeDNS {
+		if !ok || !isDNSNameBytes(data) {
+			// Il nome è già in forma testuale.
+			data = []byte(strings.TrimSpace(raw))
+		}
+		if isDNSNameBytes(data) {
+			return string(data), true
+		}
+		return "", false
+	}
+	if !ok {
+		// Valore già in forma testuale (es. inserito dall'utente).
+		parsed := net.ParseIP(strings.TrimSpace(raw))
+		if parsed == nil {
+			return "", false
+		}
+		switch {
+		case addrType == inetAddressTypeIPv4 && parsed.To4() != nil:
+			return parsed.String(), true
+		case addrType == inetAddressTypeIPv6 && parsed.To4() == nil:
+			return parsed.String(), true
+		}
+		return "", false
+	}
+
+	switch addrType {
+	case inetAddressTypeIPv4:
+		if len(data) == net.IPv4len {
+			return net.IP(data).String(), true
+		}
+	case inetAddressTypeIPv6:
+		if len(data) == net.IPv6len {
+			return net.IP(data).String(), true
+		}
+	case inetAddressTypeIPv4z:
+		if len(data) == net.IPv4len+4 {
+			return fmt.Sprintf("%s%%%d", net.IP(data[:net.IPv4len]), binary.BigEndian.Uint32(data[net.IPv4len:])), true
+		}
+	case inetAddressTypeIPv6z:
+		if len(data) == net.IPv6len+4 {
+			return fmt.Sprintf("%s%%%d", net.IP(data[:net.IPv6len]), binary.BigEndian.Uint32(data[net.IPv6len:])), true
+		}
+	}
+	return "", false
+}
+
+// isDNSNameBytes verifica che i byte siano ASCII visibile, come richiesto per un InetAddressDNS.
+func isDNSNameBytes(data []byte) bool {
+	if len(data) == 0 {
+		return false
+	}
+	for _, b := range data {
+		if b <= ' ' || b > '~' {
+			return false
+		}
+	}
+	return true
+}
+
 // formatDisplayString formatta una DisplayString verificando che sia ASCII stampabile.
 func formatDisplayString(raw string) (string, bool) {
 	trimmed := strings.TrimSpace(raw)
@@ -497,6 +577,73 @@ func parseEnumMapping(syntax string) map[string]string {
 	return mapping
 }
 
+// resolveEnumSetValue traduce un'etichetta enumerata (es. "down") nel valore numerico atteso dal SET.
+// Si applica solo ai tipi interi con un mapping enum nella sintassi del nodo; negli altri casi il valore
+// è restituito invariato. Un valore che non corrisponde né a un'etichetta né a un intero produce un errore
+// con l'elenco delle etichette ammesse; un'etichetta numerica che contraddice il numero digitato è ambigua.
+func resolveEnumSetValue(node *mib.Node, valueType string, value interface{}) (interface{}, error) {
+	if node == nil {
+		return value, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(valueType)) {
+	case "integer", "int", "enum", "enumerated":
+	default:
+		return value, nil
+	}
+	raw, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+	if strings.Contains(strings.ToLower(node.Syntax), "bits") {
+		return value, nil
+	}
+	mapping := parseEnumMapping(node.Syntax)
+	if mapping == nil {
+		return value, nil
+	}
+
+	labels := make(map[string]int64, len(mapping))
+	for rawValue, label := range mapping {
+		num, err := strconv.ParseInt(rawValue, 10, 64)
+		if err != nil {
+			continue
+		}
+		labels[strings.ToLower(label)] = num
+	}
+
+	trimmed := strings.TrimSpace(raw)
+	number, numErr := strconv.ParseInt(trimmed, 10, 64)
+	if enumValue, found := labels[strings.ToLower(trimmed)]; found {
+		if numErr == nil && number != enumValue {
+			return nil, fmt.Errorf("ambiguous value %q for %s: matches label %s(%d) and integer %d", raw, node.Name, trimmed, enumValue, number)
+		}
+		return enumValue, nil
+	}
+	if numErr == nil {
+		return number, nil
+	}
+
+	allowed := make([]int64, 0, len(labels))
+	seen := make(map[int64]string, len(labels))
+	for rawValue, label := range mapping {
+		num, err := strconv.ParseInt(rawValue, 10, 64)
+		if err != nil {
+			continue
+		}
+		if _, dup := seen[num]; dup {
+			continue
+		}
+		seen[num] = label
+		allowed = append(allowed, num)
+	}
+	sort.Slice(allowed, func(i, j int) bool { return allowed[i] < allowed[j] })
+	parts := make([]string, len(allowed))
+	for i, num := range allowed {
+		parts[i] = fmt.Sprintf("%s(%d)", seen[num], num)
+	}
+	return nil, fmt.Errorf("invalid value %q for %s: expected an integer or one of %s", raw, node.Name, strings.Join(parts, ", "))
+}
+
 // formatValueWithSyntax formatta un valore SNMP usando le informazioni della sintassi MIB.
 func formatValueWithSyntax(rawValue string, valueType string, node *mib.Node) (string, bool) {
 	if node == nil {
@@ -538,7 +685,7 @@ func formatValueWithSyntax(rawValue string, valueType string, node *mib.Node) (s
 	}
 
 	mapping := parseEnumMapping(syntax)
-	if strings.Contains(loweredSyntax, "bits") && mapping != nil {
+	if isBitsSyntax(syntax) && mapping != nil {
 		if formatted, ok := formatBits(normalizedRaw, mapping); ok {
 			return formatted, true
 		}
@@ -564,3 +711,273 @@ func formatValueWithSyntax(rawValue string, valueType string, node *mib.Node) (s
 
 	return rawValue, false
 }
+
+// GetValueRepresentations restituisce le rappresentazioni alternative del valore di un risultato SNMP
+// (decimale, esadecimale, binario, ASCII, UTF-8, UTF-16, base64 e, per i TimeTicks, durata e istante di avvio).
+// Le rappresentazioni non applicabili al tipo di valore vengono omesse.
+func (a *App) GetValueRepresentations(result snmp.Result) map[string]string {
+	return buildValueRepresentations(result, time.Now())
+}
+
+// buildValueRepresentations calcola le rappresentazioni di un valore usando `now` come riferimento
+// quando il risultato non riporta il timestamp di acquisizione.
+func buildValueRepresentations(result snmp.Result, now time.Time) map[string]string {
+	reps := make(map[string]string)
+
+	raw := strings.TrimSpace(result.RawValue)
+	if raw == "" {
+		raw = strings.TrimSpace(result.Value)
+	}
+	if raw == "" {
+		return reps
+	}
+
+	switch strings.ToLower(strings.TrimSpace(result.Type)) {
+	case "integer", "counter32", "gauge32", "counter64", "uinteger32", "timeticks":
+		addNumericRepresentations(reps, raw)
+		if strings.EqualFold(result.Type, "timeticks") {
+			addTimeTicksRepresentations(reps, raw, result.Timestamp, now)
+		}
+		return reps
+	case "ipaddress":
+		if ip := net.ParseIP(raw).To4(); ip != nil {
+			addNumericRepresentations(reps, strconv.FormatUint(uint64(binary.BigEndian.Uint32(ip)), 10))
+		}
+		return reps
+	}
+
+	if !strings.HasPrefix(strings.ToLower(raw), "0x") {
+		return reps
+	}
+	data, ok := parseHexLikeString(raw)
+	if !ok || len(data) == 0 {
+		return reps
+	}
+	addByteRepresentations(reps, data)
+	return reps
+}
+
+// addNumericRepresentations aggiunge le forme decimale, esadecimale e binaria di un intero.
+// I valori negativi riportano solo la forma decimale.
+func addNumericRepresentations(reps map[string]string, raw string) {
+	if value, err := strconv.ParseUint(raw, 10, 64); err == nil {
+		reps["decimal"] = strconv.FormatUint(value, 10)
+		reps["hex"] = fmt.Sprintf("0x%X", value)
+		reps["binary"] = strconv.FormatUint(value, 2)
+		return
+	}
+	if value, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		reps["decimal"] = strconv.FormatInt(value, 10)
+	}
+}
+
+// addTimeTicksRepresentations aggiunge la durata leggibile e l'istante assoluto (acquisizione meno uptime).
+func addTimeTicksRepresentations(reps map[string]string, raw string, capturedAt string, now time.Time) {
+	if duration, ok := formatTimeTicks(raw); ok {
+		reps["duration"] = duration
+	}
+
+	ticks, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || ticks < 0 {
+		return
+	}
+
+	reference := now
+	if parsed, err := time.Parse(time.RFC3339, strings.TrimSpace(capturedAt)); err == nil {
+		reference = parsed
+	}
+	reps["timestamp"] = reference.Add(-time.Duration(ticks) * 10 * time.Millisecond).Format(time.RFC3339)
+}
+
+// addByteRepresentations aggiunge le rappresentazioni di un payload binario (OCTET STRING, BITS, Opaque).
+func addByteRepresentations(reps map[string]string, data []byte) {
+	hexParts := make([]string, len(data))
+	binParts := make([]string, len(data))
+	for i, b := range data {
+		hexParts[i] = fmt.Sprintf("%02X", b)
+		binParts[i] = fmt.Sprintf("%08b", b)
+	}
+	reps["hex"] = strings.Join(hexParts, " ")
+	reps["binary"] = strings.Join(binParts, " ")
+	reps["base64"] = base64.StdEncoding.EncodeToString(data)
+
+	if isStrictASCII(data) {
+		reps["ascii"] = string(data)
+	}
+
+	if utf8.Valid(data) {
+		if str := sanitizedString(string(data)); str != "" {
+			reps["utf8"] = str
+		}
+	}
+
+	if looksLikeUTF16(data) {
+		if str, ok := decodeUTF16Bytes(data); ok {
+			if s := sanitizedString(str); s != "" {
+				reps["utf16"] = s
+			}
+		}
+	}
+}
+
+// isStrictASCII verifica che i byte siano ASCII stampabile (inclusi tab e a capo).
+func isStrictASCII(data []byte) bool {
+	for _, b := range data {
+		if b == '\t' || b == '\n' || b == '\r' {
+			continue
+		}
+		if b < 32 || b > 126 {
+			return false
+		}
+	}
+	return true
+}
+
+// syntaxAgentTypes associa i tipi base MIB (e le textual convention più comuni) ai tipi gosnmp
+// che un agent conforme dovrebbe restituire.
+var syntaxAgentTypes = map[string][]string{
+	"integer":              {"Integer"},
+	"integer32":            {"Integer"},
+	"timeinterval":         {"Integer"},
+	"truthvalue":           {"Integer"},
+	"rowstatus":            {"Integer"},
+	"storagetype":          {"Integer"},
+	"testandincr":          {"Integer"},
+	"interfaceindex":       {"Integer"},
+	"interfaceindexorzero": {"Integer"},
+	"inetaddresstype":      {"Integer"},
+	"unsigned32":           {"Gauge32", "Uinteger32"},
+	"gauge32":              {"Gauge32", "Uinteger32"},
+	"gauge":                {"Gauge32", "Uinteger32"},
+	"counter32":            {"Counter32"},
+	"counter":              {"Counter32"},
+	"counter64":            {"Counter64"},
+	"timeticks":            {"TimeTicks"},
+	"timestamp":            {"TimeTicks"},
+	"ipaddress":            {"IPAddress"},
+	"object identifier":    {"ObjectIdentifier"},
+	"autonomoustype":       {"ObjectIdentifier"},
+	"variablepointer":      {"ObjectIdentifier"},
+	"rowpointer":           {"ObjectIdentifier"},
+	"octet string":         {"OctetString"},
+	"displaystring":        {"OctetString"},
+	"snmpadminstring":      {"OctetString"},
+	"physaddress":          {"OctetString"},
+	"macaddress":           {"OctetString"},
+	"dateandtime":          {"OctetString"},
+	"inetaddress":          {"OctetString"},
+	"bits":                 {"OctetString", "BitString"},
+	"opaque":               {"Opaque", "OpaqueFloat", "OpaqueDouble"},
+}
+
+// syntaxBaseName estrae dalla sintassi MIB il nome del tipo in minuscolo, senza range né enum.
+func syntaxBaseName(syntax string) string {
+	return strings.ToLower(mib.SyntaxTypeName(syntax))
+}
+
+// checkAgentType confronta il tipo restituito dall'agent con quello atteso dalla sintassi MIB.
+// Ritorna i tipi attesi e true solo se la sintassi è nota e il tipo non è compatibile;
+// eccezioni (noSuchObject, endOfMibView, ...) e valori Null non sono considerati mismatch.
+func checkAgentType(syntax, agentType string) (string, bool) {
+	agentType = strings.TrimSpace(agentType)
+	switch strings.ToLower(agentType) {
+	case "", "null", "nosuchobject", "nosuchinstance", "endofmibview", "unknowntype":
+		return "", false
+	}
+
+	expected, ok := syntaxAgentTypes[syntaxBaseName(syntax)]
+	if !ok {
+		return "", false
+	}
+	for _, candidate := range expected {
+		if strings.EqualFold(candidate, agentType) {
+			return "", false
+		}
+	}
+	return strings.Join(expected, " or "), true
+}
+
+// withTypeEnum restituisce il nodo con la sintassi completata dagli enum della textual convention
+// da cui deriva, quando la sintassi del nodo non li riporta (es. ifType con IANAifType-MIB caricato
+// dopo IF-MIB). Se non ci sono enum da aggiungere restituisce il nodo invariato.
+func (a *App) withTypeEnum(node *mib.Node) *mib.Node {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if node == nil || db == nil || parseEnumMapping(node.Syntax) != nil {
+		return node
+	}
+	typeName := mib.SyntaxTypeName(node.Syntax)
+	if typeName == "" {
+		return node
+	}
+
+	key := node.Module + "::" + typeName
+	a.typeEnumsM.Lock()
+	enum, cached := a.typeEnums[key]
+	a.typeEnumsM.Unlock()
+
+	if !cached {
+		if chain, err := db.ResolveTypeChain(node.Syntax, node.Module); err == nil && chain != nil && chain.Enum != "" {
+			enum = chain.Enum
+			if strings.EqualFold(chain.BaseType, "bits") {
+				enum = "BITS " + enum
+			}
+		}
+		a.typeEnumsM.Lock()
+		if a.typeEnums == nil {
+			a.typeEnums = make(map[string]string)
+		}
+		a.typeEnums[key] = enum
+		a.typeEnumsM.Unlock()
+	}
+
+	if enum == "" {
+		return node
+	}
+	decorated := *node
+	decorated.Syntax = node.Syntax + " " + enum
+	return &decorated
+}
+
+// GetBitsMapping restituisce le etichette dei bit di un oggetto BITS (posizione -> nome), per
+// comporre il valore di una SET. Se la sintassi del nodo non riporta i bit vengono presi dalla
+// textual convention da cui deriva. oid può essere anche un'istanza dell'oggetto.
+func (a *App) GetBitsMapping(oid string) (map[string]string, error) {
+	if !a.mibDBReady() {
+		return nil, a.mibNotInitializedErr()
+	}
+	if err := validateOIDInput(oid); err != nil {
+		return nil, err
+	}
+
+	node := a.lookupNodeForOID(oid)
+	if node == nil {
+		return nil, fmt.Errorf("no MIB object found for %s", normalizeOIDKey(oid))
+	}
+	node = a.withTypeEnum(node)
+	if !isBitsSyntax(node.Syntax) {
+		return nil, fmt.Errorf("%s is not a BITS object", node.Name)
+	}
+	mapping := parseEnumMapping(node.Syntax)
+	if mapping == nil {
+		return nil, fmt.Errorf("no named bits defined for %s", node.Name)
+	}
+	return mapping, nil
+}
+
+// isBitsSyntax indica se la sintassi, eventualmente completata da withTypeEnum, è di tipo BITS.
+func isBitsSyntax(syntax string) bool {
+	for _, field := range strings.FieldsFunc(syntax, func(r rune) bool { return r == ' ' || r == '{' || r == '(' }) {
+		if strings.EqualFold(field, "bits") {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidateTypeEnums scarta gli enum memorizzati dopo il caricamento o la rimozione di moduli.
+func (a *App) invalidateTypeEnums() {
+	a.typeEnumsM.Lock()
+	a.typeEnums = nil
+	a.typeEnumsM.Unlock()
+}
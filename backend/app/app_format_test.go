@@ -1,9 +1,14 @@
 package app
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/gosnmp/gosnmp"
 )
 
 func TestFormatValueWithSyntax_IntegerEnumsDontTriggerBits(t *testing.T) {
@@ -55,3 +60,230 @@ func TestFormatValueWithSyntax_DisplayStringDecoding(t *testing.T) {
 		t.Fatalf("expected UTF16 decoding to Software, got %q (ok=%v)", formatted, ok)
 	}
 }
+
+func TestBuildValueRepresentations_TimeTicks(t *testing.T) {
+	result := snmp.Result{
+		Value:     "360000",
+		Type:      "TimeTicks",
+		Timestamp: "2024-01-01T12:00:00Z",
+	}
+
+	reps := buildValueRepresentations(result, time.Now())
+
+	if reps["decimal"] != "360000" || reps["hex"] != "0x57E40" {
+		t.Fatalf("unexpected numeric representations: %v", reps)
+	}
+	if reps["duration"] != "1h 0m 0s" {
+		t.Fatalf("duration = %q, want %q", reps["duration"], "1h 0m 0s")
+	}
+	if reps["timestamp"] != "2024-01-01T11:00:00Z" {
+		t.Fatalf("timestamp = %q, want %q", reps["timestamp"], "2024-01-01T11:00:00Z")
+	}
+	if _, ok := reps["base64"]; ok {
+		t.Fatalf("base64 should be omitted for numeric values")
+	}
+}
+
+func TestBuildValueRepresentations_OctetString(t *testing.T) {
+	reps := buildValueRepresentations(snmp.Result{Value: "0x65746830", Type: "OctetString"}, time.Now())
+
+	if reps["ascii"] != "eth0" || reps["utf8"] != "eth0" {
+		t.Fatalf("expected textual representations, got %v", reps)
+	}
+	if reps["hex"] != "65 74 68 30" || reps["base64"] != "ZXRoMA==" {
+		t.Fatalf("unexpected byte representations: %v", reps)
+	}
+	if _, ok := reps["decimal"]; ok {
+		t.Fatalf("decimal should be omitted for octet strings")
+	}
+
+	binary := buildValueRepresentations(snmp.Result{Value: "0x00ff10", Type: "OctetString"}, time.Now())
+	if _, ok := binary["ascii"]; ok {
+		t.Fatalf("ascii should be omitted for binary payloads, got %q", binary["ascii"])
+	}
+}
+
+func TestResolveEnumSetValue(t *testing.T) {
+	node := &mib.Node{Name: "ifAdminStatus", Syntax: "INTEGER { up(1), down(2), testing(3) }"}
+
+	cases := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{name: "label", value: "down", want: int64(2)},
+		{name: "label case-insensitive", value: " Testing ", want: int64(3)},
+		{name: "numeric string", value: "1", want: int64(1)},
+		{name: "numeric value untouched", value: 2, want: 2},
+	}
+	for _, tc := range cases {
+		got, err := resolveEnumSetValue(node, "integer", tc.value)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: got %#v, want %#v", tc.name, got, tc.want)
+		}
+	}
+
+	_, err := resolveEnumSetValue(node, "integer", "sideways")
+	if err == nil || !strings.Contains(err.Error(), "up(1), down(2), testing(3)") {
+		t.Fatalf("expected error listing allowed labels, got %v", err)
+	}
+
+	// Tipi non interi e nodi senza enum non vengono toccati.
+	if got, err := resolveEnumSetValue(node, "string", "down"); err != nil || got != "down" {
+		t.Fatalf("expected string SET to be left untouched, got %#v (err=%v)", got, err)
+	}
+	plain := &mib.Node{Name: "ifMtu", Syntax: "Integer32"}
+	if got, err := resolveEnumSetValue(plain, "integer", "abc"); err != nil || got != "abc" {
+		t.Fatalf("expected non-enum node to be left untouched, got %#v (err=%v)", got, err)
+	}
+}
+
+func TestResolveEnumSetValue_NumericLookingLabels(t *testing.T) {
+	// Etichette che sembrano numeri: un'etichetta coerente con il proprio valore è accettata,
+	// una che contraddice il numero digitato è ambigua.
+	node := &mib.Node{Name: "oddEnum", Syntax: "INTEGER { 10(2), 3(3), 1e2(4) }"}
+
+	if got, err := resolveEnumSetValue(node, "integer", "3"); err != nil || got != int64(3) {
+		t.Fatalf("expected consistent numeric label to resolve to 3, got %#v (err=%v)", got, err)
+	}
+	if _, err := resolveEnumSetValue(node, "integer", "10"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected ambiguity error for label 10(2), got %v", err)
+	}
+	if got, err := resolveEnumSetValue(node, "integer", "1E2"); err != nil || got != int64(4) {
+		t.Fatalf("expected label 1e2 to resolve to 4, got %#v (err=%v)", got, err)
+	}
+}
+
+func TestCheckAgentType(t *testing.T) {
+	cases := []struct {
+		syntax       string
+		agentType    string
+		wantMismatch bool
+		wantExpected string
+	}{
+		{syntax: "Counter32", agentType: "Counter32"},
+		{syntax: "Counter32", agentType: "Gauge32", wantMismatch: true, wantExpected: "Counter32"},
+		{syntax: "INTEGER {up(1), down(2)}", agentType: "Integer"},
+		{syntax: "Integer32 (1..2147483647)", agentType: "OctetString", wantMismatch: true, wantExpected: "Integer"},
+		{syntax: "OCTET STRING (0..255)", agentType: "OctetString"},
+		{syntax: "DisplayString (0..255)", agentType: "Integer", wantMismatch: true, wantExpected: "OctetString"},
+		{syntax: "Unsigned32", agentType: "Uinteger32"},
+		{syntax: "Gauge32", agentType: "Counter64", wantMismatch: true, wantExpected: "Gauge32 or Uinteger32"},
+		{syntax: "TimeTicks", agentType: "NoSuchInstance"},
+		{syntax: "TimeTicks", agentType: "Null"},
+		{syntax: "VendorSpecificTC", agentType: "Integer"},
+	}
+
+	for _, tc := range cases {
+		expected, mismatch := checkAgentType(tc.syntax, tc.agentType)
+		if mismatch != tc.wantMismatch || expected != tc.wantExpected {
+			t.Errorf("checkAgentType(%q, %q) = %q, %v; want %q, %v", tc.syntax, tc.agentType, expected, mismatch, tc.wantExpected, tc.wantMismatch)
+		}
+	}
+}
+
+func TestFormatInetAddressTyped(t *testing.T) {
+	tests := []struct {
+		name     string
+		addrType int
+		raw      string
+		want     string
+		ok       bool
+	}{
+		{name: "ipv4", addrType: 1, raw: "0xc0a80101", want: "192.168.1.1", ok: true},
+		{name: "ipv6", addrType: 2, raw: "0xfe800000000000000000000000000001", want: "fe80::1", ok: true},
+		{name: "ipv4z", addrType: 3, raw: "0x0a00000100000003", want: "10.0.0.1%3", ok: true},
+		{name: "ipv6z", addrType: 4, raw: "0xfe80000000000000000000000000000100000002", want: "fe80::1%2", ok: true},
+		{name: "dns", addrType: 16, raw: "0x6578616d706c652e636f6d", want: "example.com", ok: true},
+		{name: "dns text", addrType: 16, raw: "cafe", want: "cafe", ok: true},
+		{name: "text ipv4", addrType: 1, raw: "10.1.2.3", want: "10.1.2.3", ok: true},
+		{name: "length mismatch", addrType: 2, raw: "0xc0a80101", ok: false},
+		{name: "text family mismatch", addrType: 1, raw: "fe80::1", ok: false},
+		{name: "unknown type", addrType: 0, raw: "0xc0a80101", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := formatInetAddressTyped(tt.addrType, tt.raw)
+			if ok != tt.ok || got != tt.want {
+				t.Fatalf("formatInetAddressTyped(%d, %q) = %q, %v; want %q, %v", tt.addrType, tt.raw, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestFetchTableDataResolvesTextualConventionEnums(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.2.2", Name: "ifTable", Type: "table", ParentOID: "1.3.6.1.2.1.2"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1", Name: "ifEntry", Type: "row", ParentOID: "1.3.6.1.2.1.2.2"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.1", Name: "ifIndex", Type: "column", Syntax: "InterfaceIndex", ParentOID: "1.3.6.1.2.1.2.2.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.3", Name: "ifType", Type: "column", Syntax: "IANAifType", ParentOID: "1.3.6.1.2.1.2.2.1"},
+	)
+	// IANAifType-MIB caricato dopo IF-MIB: la sintassi della colonna non riporta gli enum.
+	if err := app.mibDB.SaveTypeDefinitions([]mib.TypeDefinition{
+		{Module: "IANAifType-MIB", Name: "IANAifType", ParentName: "INTEGER", BaseType: "Enum", Enum: "{other(1), ethernetCsmacd(6), softwareLoopback(24)}"},
+	}); err != nil {
+		t.Fatalf("SaveTypeDefinitions() error = %v", err)
+	}
+
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.2.2.1.1.1": {Type: gosnmp.Integer, Value: 1},
+		"1.3.6.1.2.1.2.2.1.1.2": {Type: gosnmp.Integer, Value: 2},
+		"1.3.6.1.2.1.2.2.1.3.1": {Type: gosnmp.Integer, Value: 24},
+		"1.3.6.1.2.1.2.2.1.3.2": {Type: gosnmp.Integer, Value: 6},
+	})
+
+	data, err := app.FetchTableData(agent.config(), "1.3.6.1.2.1.2.2", "", "")
+	if err != nil {
+		t.Fatalf("FetchTableData() error = %v", err)
+	}
+	if len(data.Rows) != 2 {
+		t.Fatalf("expected 2 rows, got %+v", data.Rows)
+	}
+	if got := data.Rows[0]["ifType"]; got != "softwareLoopback (24)" {
+		t.Fatalf("row 1 ifType = %q, want softwareLoopback (24)", got)
+	}
+	if got := data.Rows[1]["ifType"]; got != "ethernetCsmacd (6)" {
+		t.Fatalf("row 2 ifType = %q, want ethernetCsmacd (6)", got)
+	}
+	if got := data.Rows[1]["ifIndex"]; got != "2" {
+		t.Fatalf("row 2 ifIndex = %q, want 2", got)
+	}
+}
+
+func TestGetBitsMappingResolvesTextualConvention(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.4.1.9999.1.1", Name: "testFlags", Type: "scalar", Syntax: "TestFlags", Module: "TEST-MIB"},
+		&mib.Node{OID: "1.3.6.1.4.1.9999.1.2", Name: "testInline", Type: "scalar", Syntax: "BITS {alpha(0), beta(1)}", Module: "TEST-MIB"},
+		&mib.Node{OID: "1.3.6.1.4.1.9999.1.3", Name: "testCounter", Type: "scalar", Syntax: "Counter32", Module: "TEST-MIB"},
+	)
+	if err := app.mibDB.SaveTypeDefinitions([]mib.TypeDefinition{
+		{Module: "TEST-TC-MIB", Name: "TestFlags", ParentName: "BITS", BaseType: "Bits", Enum: "{primary(0), backup(1), maintenance(9)}"},
+	}); err != nil {
+		t.Fatalf("SaveTypeDefinitions() error = %v", err)
+	}
+
+	mapping, err := app.GetBitsMapping("1.3.6.1.4.1.9999.1.1.0")
+	if err != nil {
+		t.Fatalf("GetBitsMapping() error = %v", err)
+	}
+	if len(mapping) != 3 || mapping["0"] != "primary" || mapping["9"] != "maintenance" {
+		t.Fatalf("unexpected mapping from textual convention: %v", mapping)
+	}
+
+	result := &snmp.Result{OID: "1.3.6.1.4.1.9999.1.1.0", Value: "0xc040", Type: "OctetString"}
+	app.enrichResult("", result)
+	if result.DisplayValue != "primary, backup, maintenance" {
+		t.Fatalf("DisplayValue = %q, want primary, backup, maintenance", result.DisplayValue)
+	}
+
+	if mapping, err := app.GetBitsMapping("1.3.6.1.4.1.9999.1.2"); err != nil || mapping["1"] != "beta" {
+		t.Fatalf("GetBitsMapping(inline) = %v, %v", mapping, err)
+	}
+	if _, err := app.GetBitsMapping("1.3.6.1.4.1.9999.1.3"); err == nil {
+		t.Fatalf("expected a non-BITS object to be rejected")
+	}
+}
@@ -12,11 +12,13 @@ import (
 
 // ListHosts restituisce l'elenco degli host SNMP salvati, ordinati per ultimo utilizzo.
 func (a *App) ListHosts() ([]mib.HostConfig, error) {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return nil, a.mibNotInitializedErr()
 	}
 
-	hosts, err := a.mibDB.ListHosts(0)
+	hosts, err := db.ListHosts(0)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list host configs: %w", err)
 	}
@@ -25,11 +27,13 @@ func (a *App) ListHosts() ([]mib.HostConfig, error) {
 
 // SaveHost salva o aggiorna la configurazione SNMP di un host e restituisce la versione persistita.
 func (a *App) SaveHost(config mib.HostConfig) (*mib.HostConfig, error) {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return nil, a.mibNotInitializedErr()
 	}
 
-	saved, err := a.mibDB.SaveHost(config)
+	saved, err := db.SaveHost(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to save host config: %w", err)
 	}
@@ -38,14 +42,16 @@ func (a *App) SaveHost(config mib.HostConfig) (*mib.HostConfig, error) {
 
 // TouchHost aggiorna la data dell'ultimo utilizzo per un host salvato.
 func (a *App) TouchHost(address string) error {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return a.mibNotInitializedErr()
 	}
 	if strings.TrimSpace(address) == "" {
 		return fmt.Errorf("address is required")
 	}
 
-	if err := a.mibDB.TouchHost(address); err != nil {
+	if err := db.TouchHost(address); err != nil {
 		return fmt.Errorf("failed to register host usage: %w", err)
 	}
 	return nil
@@ -53,14 +59,16 @@ func (a *App) TouchHost(address string) error {
 
 // DeleteHost rimuove definitivamente la configurazione di un host salvato.
 func (a *App) DeleteHost(address string) error {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return a.mibNotInitializedErr()
 	}
 	if strings.TrimSpace(address) == "" {
 		return fmt.Errorf("address is required")
 	}
 
-	if err := a.mibDB.DeleteHost(address); err != nil {
+	if err := db.DeleteHost(address); err != nil {
 		return fmt.Errorf("failed to delete host config: %w", err)
 	}
 	return nil
@@ -68,7 +76,9 @@ func (a *App) DeleteHost(address string) error {
 
 // persistHostUsage salva automaticamente la configurazione di un host quando viene utilizzato.
 func (a *App) persistHostUsage(config snmp.Config) {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return
 	}
 
@@ -78,23 +88,82 @@ func (a *App) persistHostUsage(config snmp.Config) {
 	}
 
 	hostConfig := mib.HostConfig{
-		Address:          address,
-		Port:             config.Port,
-		Community:        config.Community,
-		WriteCommunity:   config.WriteCommunity,
-		Version:          config.Version,
-		ContextName:      config.ContextName,
-		SecurityLevel:    config.SecurityLevel,
-		SecurityUsername: config.SecurityUsername,
-		AuthProtocol:     config.AuthProtocol,
-		AuthPassword:     config.AuthPassword,
-		PrivProtocol:     config.PrivProtocol,
-		PrivPassword:     config.PrivPassword,
-	}
-
-	if _, err := a.mibDB.SaveHost(hostConfig); err != nil {
+		Address:           address,
+		Port:              config.Port,
+		Community:         config.Community,
+		WriteCommunity:    config.WriteCommunity,
+		Version:           config.Version,
+		ContextName:       config.ContextName,
+		SecurityLevel:     config.SecurityLevel,
+		SecurityUsername:  config.SecurityUsername,
+		AuthProtocol:      config.AuthProtocol,
+		AuthPassword:      config.AuthPassword,
+		PrivProtocol:      config.PrivProtocol,
+		PrivPassword:      config.PrivPassword,
+		SourcePort:        config.SourcePort,
+		SerializeRequests: config.SerializeRequests,
+	}
+	// Un host legato a un profilo di credenziali resta legato al profilo. Le opzioni per host che il
+	// frontend non invia con le richieste restano quelle salvate.
+	if existing, err := db.GetHost(address); err == nil && existing != nil {
+		hostConfig.CredentialProfile = existing.CredentialProfile
+		if hostConfig.SourcePort == 0 {
+			hostConfig.SourcePort = existing.SourcePort
+		}
+		if !hostConfig.SerializeRequests {
+			hostConfig.SerializeRequests = existing.SerializeRequests
+		}
+	}
+
+	if _, err := db.SaveHost(hostConfig); err != nil {
 		if a.ctx != nil {
 			runtime.LogError(a.ctx, fmt.Sprintf("Failed to persist host usage: %v", err))
 		}
 	}
 }
+
+// withStoredHostOptions completa config con le opzioni salvate per l'host che la richiesta non
+// imposta, come la porta sorgente e la serializzazione delle richieste.
+func (a *App) withStoredHostOptions(config snmp.Config) snmp.Config {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return config
+	}
+
+	address := strings.TrimSpace(config.Host)
+	if address == "" {
+		return config
+	}
+	host, err := db.GetHost(address)
+	if err != nil || host == nil {
+		return config
+	}
+	if config.SourcePort == 0 {
+		config.SourcePort = host.SourcePort
+	}
+	if !config.SerializeRequests {
+		config.SerializeRequests = host.SerializeRequests
+	}
+	return config
+}
+
+// hostConfigToSNMP converte una configurazione host salvata nella configurazione del client SNMP.
+func hostConfigToSNMP(host *mib.HostConfig) snmp.Config {
+	return snmp.Config{
+		Host:              host.Address,
+		Port:              host.Port,
+		Community:         host.Community,
+		WriteCommunity:    host.WriteCommunity,
+		Version:           host.Version,
+		ContextName:       host.ContextName,
+		SecurityLevel:     host.SecurityLevel,
+		SecurityUsername:  host.SecurityUsername,
+		AuthProtocol:      host.AuthProtocol,
+		AuthPassword:      host.AuthPassword,
+		PrivProtocol:      host.PrivProtocol,
+		PrivPassword:      host.PrivPassword,
+		SourcePort:        host.SourcePort,
+		SerializeRequests: host.SerializeRequests,
+	}
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+)
+
+// maxHostHitSeen limita la cache in memoria delle coppie (host, OID) già registrate.
+const maxHostHitSeen = 50000
+
+// recordHostHit registra che il nodo MIB del risultato ha risposto sull'host. Ogni coppia viene scritta
+// una sola volta per sessione, così un WALK non genera una scrittura per ciascuna istanza.
+func (a *App) recordHostHit(host string, result *snmp.Result) {
+	db, release := a.acquireMIBDB()
+	defer release()
+
+	host = strings.TrimSpace(host)
+	if db == nil || host == "" || result == nil {
+		return
+	}
+	if result.ErrorDetail != nil || isMissingInstanceType(result.Type) || strings.EqualFold(result.Type, "EndOfMibView") {
+		return
+	}
+
+	node := a.lookupNodeForOID(result.OID)
+	if node == nil {
+		return
+	}
+
+	key := host + "|" + node.OID
+	a.hostHitM.Lock()
+	if _, seen := a.hostHitSeen[key]; seen {
+		a.hostHitM.Unlock()
+		return
+	}
+	if a.hostHitSeen == nil || len(a.hostHitSeen) >= maxHostHitSeen {
+		a.hostHitSeen = make(map[string]struct{})
+	}
+	a.hostHitSeen[key] = struct{}{}
+	a.hostHitM.Unlock()
+
+	if err := db.RecordHostOIDHits(host, []string{node.OID}); err != nil {
+		a.hostHitM.Lock()
+		delete(a.hostHitSeen, key)
+		a.hostHitM.Unlock()
+	}
+}
+
+// GetHostImplementedOIDs restituisce gli OID dei nodi MIB che hanno risposto sull'host indicato.
+func (a *App) GetHostImplementedOIDs(address string) ([]string, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	oids, err := db.GetHostOIDHits(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load implemented OIDs: %w", err)
+	}
+	return oids, nil
+}
+
+// GetMIBTreeForHost restituisce l'albero MIB come GetMIBTree, marcando con implementedOn
+// i nodi che hanno risposto sull'host indicato e omettendo i moduli nascosti per l'host.
+func (a *App) GetMIBTreeForHost(address string) ([]*mib.Node, error) {
+	tree, err := a.GetMIBTree()
+	if err != nil {
+		return nil, err
+	}
+
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return tree, nil
+	}
+
+	hiddenModules, err := a.GetHostHiddenModules(address)
+	if err != nil {
+		return nil, err
+	}
+	if len(hiddenModules) > 0 {
+		hidden := make(map[string]struct{}, len(hiddenModules))
+		for _, module := range hiddenModules {
+			hidden[module] = struct{}{}
+		}
+		tree = filterHiddenModules(tree, hidden)
+	}
+
+	oids, err := a.GetHostImplementedOIDs(address)
+	if err != nil {
+		return nil, err
+	}
+	implemented := make(map[string]struct{}, len(oids))
+	for _, oid := range oids {
+		implemented[oid] = struct{}{}
+	}
+	markImplementedNodes(tree, implemented, address)
+	return tree, nil
+}
+
+func markImplementedNodes(nodes []*mib.Node, implemented map[string]struct{}, host string) {
+	for _, node := range nodes {
+		if _, ok := implemented[node.OID]; ok {
+			node.ImplementedOn = []string{host}
+		}
+		markImplementedNodes(node.Children, implemented, host)
+	}
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+)
+
+func TestEnrichResultRecordsHostHits(t *testing.T) {
+	app := setupTestAppWithNodes(
+		t,
+		&mib.Node{OID: "1.3.6.1.2.1.1", Name: "system", Type: "node"},
+		&mib.Node{OID: "1.3.6.1.2.1.1.5", Name: "sysName", Type: "scalar", ParentOID: "1.3.6.1.2.1.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.1.6", Name: "sysLocation", Type: "scalar", ParentOID: "1.3.6.1.2.1.1"},
+	)
+
+	app.enrichResult("10.0.0.1", &snmp.Result{OID: "1.3.6.1.2.1.1.5.0", Value: "lab", Type: "OctetString"})
+	app.enrichResult("10.0.0.1", &snmp.Result{OID: "1.3.6.1.2.1.1.5.0", Value: "lab", Type: "OctetString"})
+	app.enrichResult("10.0.0.1", &snmp.Result{OID: "1.3.6.1.2.1.1.6.0", Type: "NoSuchObject"})
+	app.enrichResult("", &snmp.Result{OID: "1.3.6.1.2.1.1.6.0", Value: "rack", Type: "OctetString"})
+
+	oids, err := app.GetHostImplementedOIDs("10.0.0.1")
+	if err != nil {
+		t.Fatalf("GetHostImplementedOIDs() error = %v", err)
+	}
+	if want := []string{"1.3.6.1.2.1.1.5"}; !reflect.DeepEqual(oids, want) {
+		t.Fatalf("GetHostImplementedOIDs() = %v, want %v", oids, want)
+	}
+
+	tree, err := app.GetMIBTreeForHost("10.0.0.1")
+	if err != nil {
+		t.Fatalf("GetMIBTreeForHost() error = %v", err)
+	}
+
+	implemented := map[string][]string{}
+	var visit func(nodes []*mib.Node)
+	visit = func(nodes []*mib.Node) {
+		for _, node := range nodes {
+			if len(node.ImplementedOn) > 0 {
+				implemented[node.OID] = node.ImplementedOn
+			}
+			visit(node.Children)
+		}
+	}
+	visit(tree)
+
+	if want := map[string][]string{"1.3.6.1.2.1.1.5": {"10.0.0.1"}}; !reflect.DeepEqual(implemented, want) {
+		t.Fatalf("implemented nodes = %v, want %v", implemented, want)
+	}
+}
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ExportHostsToJSON salva tutti gli host configurati in un file JSON scelto dall'utente.
+// Le community v1/v2c e le password SNMPv3 vengono mascherate. Restituisce false se l'utente annulla il salvataggio.
+func (a *App) ExportHostsToJSON() (bool, error) {
+	if !a.mibDBReady() {
+		return false, a.mibNotInitializedErr()
+	}
+
+	data, err := a.hostsJSON()
+	if err != nil {
+		return false, err
+	}
+
+	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Hosts",
+		DefaultFilename: fmt.Sprintf("hosts-%s.json", time.Now().Format("20060102-150405")),
+		Filters: []runtime.FileFilter{
+			{DisplayName: "JSON Files", Pattern: "*.json"},
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to open save dialog: %w", err)
+	}
+	if filePath == "" {
+		return false, nil
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return false, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	runtime.LogInfo(a.ctx, fmt.Sprintf("Exported hosts to: %s", filePath))
+	return true, nil
+}
+
+// ImportHostsFromJSON legge un file JSON esportato con ExportHostsToJSON e salva ogni host.
+// Le community e le password mascherate mantengono il valore già presente nel database.
+// Restituisce il numero di host importati; le voci non valide vengono saltate.
+func (a *App) ImportHostsFromJSON() (int, error) {
+	if !a.mibDBReady() {
+		return 0, a.mibNotInitializedErr()
+	}
+
+	filePath, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Import Hosts",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "JSON Files", Pattern: "*.json"},
+			{DisplayName: "All Files", Pattern: "*.*"},
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to open file dialog: %w", err)
+	}
+	if filePath == "" {
+		return 0, nil
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	imported, err := a.importHostsJSON(data)
+	if err != nil {
+		return 0, err
+	}
+
+	runtime.LogInfo(a.ctx, fmt.Sprintf("Imported %d hosts from: %s", imported, filePath))
+	return imported, nil
+}
+
+// hostsJSON serializza gli host salvati come array JSON con community e password mascherate.
+func (a *App) hostsJSON() ([]byte, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	hosts, err := db.ListHosts(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list host configs: %w", err)
+	}
+
+	masked := make([]mib.HostConfig, 0, len(hosts))
+	for _, host := range hosts {
+		masked = append(masked, host.Masked())
+	}
+
+	data, err := json.MarshalIndent(masked, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode hosts: %w", err)
+	}
+	return data, nil
+}
+
+// importHostsJSON salva gli host contenuti nell'array JSON, ripristinando community e password mascherate.
+func (a *App) importHostsJSON(data []byte) (int, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return 0, a.mibNotInitializedErr()
+	}
+
+	var hosts []mib.HostConfig
+	if err := json.Unmarshal(data, &hosts); err != nil {
+		return 0, fmt.Errorf("invalid hosts file: %w", err)
+	}
+
+	imported := 0
+	for _, host := range hosts {
+		if host.Community == mib.MaskedPassword || host.WriteCommunity == mib.MaskedPassword ||
+			host.AuthPassword == mib.MaskedPassword || host.PrivPassword == mib.MaskedPassword {
+			existing, err := db.GetHost(host.Address)
+			if err != nil {
+				return imported, err
+			}
+			host.Community = unmaskPassword(host.Community, existing, func(h *mib.HostConfig) string { return h.Community })
+			host.WriteCommunity = unmaskPassword(host.WriteCommunity, existing, func(h *mib.HostConfig) string { return h.WriteCommunity })
+			host.AuthPassword = unmaskPassword(host.AuthPassword, existing, func(h *mib.HostConfig) string { return h.AuthPassword })
+			host.PrivPassword = unmaskPassword(host.PrivPassword, existing, func(h *mib.HostConfig) string { return h.PrivPassword })
+		}
+
+		if _, err := db.SaveHost(host); err != nil {
+			if a.ctx != nil {
+				runtime.LogWarning(a.ctx, fmt.Sprintf("Skipped host %s during import: %v", host.Address, err))
+			}
+			continue
+		}
+		imported++
+	}
+	return imported, nil
+}
+
+// unmaskPassword sostituisce il segnaposto con il valore dell'host esistente (vuoto se l'host non esiste).
+func unmaskPassword(password string, existing *mib.HostConfig, field func(*mib.HostConfig) string) string {
+	if password != mib.MaskedPassword {
+		return password
+	}
+	if existing == nil {
+		return ""
+	}
+	return field(existing)
+}
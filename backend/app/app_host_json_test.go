@@ -0,0 +1,75 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+)
+
+func TestHostsJSONRoundTripPreservesMaskedPasswords(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+	if err := app.mibDB.EnsureHostConfigSchema(); err != nil {
+		t.Fatalf("EnsureHostConfigSchema error: %v", err)
+	}
+
+	secure := mib.HostConfig{
+		Address:          "10.0.0.1",
+		Version:          "v3",
+		SecurityLevel:    "authPriv",
+		SecurityUsername: "admin",
+		AuthProtocol:     "SHA",
+		AuthPassword:     "auth-secret",
+		PrivProtocol:     "AES",
+		PrivPassword:     "priv-secret",
+	}
+	if _, err := app.mibDB.SaveHost(secure); err != nil {
+		t.Fatalf("SaveHost error: %v", err)
+	}
+	if _, err := app.mibDB.SaveHost(mib.HostConfig{Address: "10.0.0.2", Community: "ro-secret", WriteCommunity: "rw-secret"}); err != nil {
+		t.Fatalf("SaveHost error: %v", err)
+	}
+
+	data, err := app.hostsJSON()
+	if err != nil {
+		t.Fatalf("hostsJSON error: %v", err)
+	}
+	for _, secret := range []string{"auth-secret", "priv-secret", "ro-secret", "rw-secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Fatalf("expected %s to be masked, got %s", secret, data)
+		}
+	}
+
+	imported, err := app.importHostsJSON([]byte(strings.ReplaceAll(string(data), `"admin"`, `"operator"`)))
+	if err != nil {
+		t.Fatalf("importHostsJSON error: %v", err)
+	}
+	if imported != 2 {
+		t.Fatalf("expected 2 imported hosts, got %d", imported)
+	}
+
+	restored, err := app.mibDB.GetHost("10.0.0.1")
+	if err != nil || restored == nil {
+		t.Fatalf("GetHost error: %v", err)
+	}
+	if restored.SecurityUsername != "operator" || restored.AuthPassword != "auth-secret" || restored.PrivPassword != "priv-secret" {
+		t.Fatalf("unexpected restored host: %+v", restored)
+	}
+	community, err := app.mibDB.GetHost("10.0.0.2")
+	if err != nil || community == nil {
+		t.Fatalf("GetHost error: %v", err)
+	}
+	if community.Community != "ro-secret" || community.WriteCommunity != "rw-secret" {
+		t.Fatalf("expected communities to be restored, got %+v", community)
+	}
+
+	// Un host sconosciuto con password mascherate non ha credenziali da recuperare e viene saltato.
+	unknown := `[{"address":"10.0.0.3","version":"v3","securityLevel":"authNoPriv","securityUsername":"x","authProtocol":"SHA","authPassword":"***"}]`
+	if imported, err := app.importHostsJSON([]byte(unknown)); err != nil || imported != 0 {
+		t.Fatalf("expected unknown masked host to be skipped, got %d (%v)", imported, err)
+	}
+
+	if _, err := app.importHostsJSON([]byte("{")); err == nil {
+		t.Fatalf("expected malformed JSON to be rejected")
+	}
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"mib-to-the-future/backend/mib"
+)
+
+const (
+	mib2OID        = "1.3.6.1.2.1"
+	enterprisesOID = "1.3.6.1.4.1"
+)
+
+// SetHostModuleHidden nasconde o mostra un modulo MIB nell'albero di GetMIBTreeForHost per l'host indicato.
+func (a *App) SetHostModuleHidden(address string, moduleName string, hidden bool) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+	if strings.TrimSpace(address) == "" {
+		return fmt.Errorf("address is required")
+	}
+
+	if err := db.SetHostModuleHidden(address, moduleName, hidden); err != nil {
+		return fmt.Errorf("failed to update module filter: %w", err)
+	}
+	return nil
+}
+
+// GetHostHiddenModules restituisce i moduli nascosti nella vista MIB dell'host.
+func (a *App) GetHostHiddenModules(address string) ([]string, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	modules, err := db.GetHostHiddenModules(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load module filters: %w", err)
+	}
+	return modules, nil
+}
+
+// filterHiddenModules rimuove dall'albero i nodi dei moduli nascosti insieme ai loro sottoalberi.
+// Un nodo nascosto resta come percorso se ha discendenti visibili; il nodo Bookmarks e la struttura
+// standard (mib-2, i suoi antenati ed enterprises) non vengono mai rimossi.
+func filterHiddenModules(nodes []*mib.Node, hidden map[string]struct{}) []*mib.Node {
+	filtered := make([]*mib.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if strings.HasPrefix(node.Type, "bookmark") {
+			filtered = append(filtered, node)
+			continue
+		}
+
+		node.Children = filterHiddenModules(node.Children, hidden)
+		if _, isHidden := hidden[node.Module]; !isHidden || len(node.Children) > 0 || isStandardTreeNode(node.OID) {
+			filtered = append(filtered, node)
+		}
+	}
+	return filtered
+}
+
+// isStandardTreeNode indica se l'OID appartiene a mib-2 oppure è un antenato (o coincide con) mib-2 o
+// enterprises. I sottoalberi dei vendor sotto enterprises restano filtrabili.
+func isStandardTreeNode(oid string) bool {
+	oid = normalizeOIDKey(oid)
+	for _, root := range []string{mib2OID, enterprisesOID} {
+		if oid == root || isOIDWithinSubtree(oid, root) {
+			return true
+		}
+	}
+	return isOIDWithinSubtree(mib2OID, oid)
+}
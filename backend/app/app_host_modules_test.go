@@ -0,0 +1,73 @@
+package app
+
+import (
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+)
+
+func TestGetMIBTreeForHostHidesModules(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1", Name: "mib-2", Type: "node"},
+		&mib.Node{OID: "1.3.6.1.2.1.1", Name: "system", Type: "node", ParentOID: "1.3.6.1.2.1"},
+	)
+
+	vendorID, err := app.mibDB.SaveModule("VENDOR-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule() error = %v", err)
+	}
+	for _, node := range []*mib.Node{
+		{OID: "1.3.6.1.2.1.99", Name: "vendorReusedStd", Type: "node", ParentOID: "1.3.6.1.2.1"},
+		{OID: "1.3.6.1.4.1", Name: "enterprises", Type: "node"},
+		{OID: "1.3.6.1.4.1.2636", Name: "vendor", Type: "node", ParentOID: "1.3.6.1.4.1"},
+		{OID: "1.3.6.1.4.1.2636.1", Name: "vendorProducts", Type: "node", ParentOID: "1.3.6.1.4.1.2636"},
+	} {
+		if err := app.mibDB.SaveNode(node, vendorID); err != nil {
+			t.Fatalf("SaveNode(%s) error = %v", node.OID, err)
+		}
+	}
+
+	if err := app.SetHostModuleHidden("10.0.0.1", "VENDOR-MIB", true); err != nil {
+		t.Fatalf("SetHostModuleHidden() error = %v", err)
+	}
+
+	collect := func(address string) map[string]bool {
+		tree, err := app.GetMIBTreeForHost(address)
+		if err != nil {
+			t.Fatalf("GetMIBTreeForHost(%q) error = %v", address, err)
+		}
+		seen := map[string]bool{}
+		var visit func(nodes []*mib.Node)
+		visit = func(nodes []*mib.Node) {
+			for _, node := range nodes {
+				seen[node.OID] = true
+				visit(node.Children)
+			}
+		}
+		visit(tree)
+		return seen
+	}
+
+	filtered := collect("10.0.0.1")
+	for _, oid := range []string{"bookmarks", "1.3.6.1.2.1.1", "1.3.6.1.2.1.99", "1.3.6.1.4.1"} {
+		if !filtered[oid] {
+			t.Fatalf("expected %s to be kept, got %v", oid, filtered)
+		}
+	}
+	for _, oid := range []string{"1.3.6.1.4.1.2636", "1.3.6.1.4.1.2636.1"} {
+		if filtered[oid] {
+			t.Fatalf("expected %s to be hidden", oid)
+		}
+	}
+
+	if other := collect("10.0.0.2"); !other["1.3.6.1.4.1.2636.1"] {
+		t.Fatalf("expected vendor nodes to be visible for other hosts")
+	}
+
+	if err := app.SetHostModuleHidden("10.0.0.1", "VENDOR-MIB", false); err != nil {
+		t.Fatalf("SetHostModuleHidden() error = %v", err)
+	}
+	if restored := collect("10.0.0.1"); !restored["1.3.6.1.4.1.2636"] {
+		t.Fatalf("expected vendor nodes to be visible again")
+	}
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+)
+
+func TestPersistHostUsageKeepsStoredHostOptions(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+	if err := app.mibDB.EnsureHostConfigSchema(); err != nil {
+		t.Fatalf("EnsureHostConfigSchema error: %v", err)
+	}
+
+	if _, err := app.SaveHost(mib.HostConfig{
+		Address:    "192.0.2.10",
+		Port:       161,
+		Community:  "public",
+		Version:    "v2c",
+		SourcePort: 40161,
+
+		SerializeRequests: true,
+	}); err != nil {
+		t.Fatalf("SaveHost error: %v", err)
+	}
+
+	// Le richieste del frontend non includono le opzioni per host.
+	request := snmp.Config{Host: "192.0.2.10", Port: 161, Community: "private", Version: "v2c"}
+	app.persistHostUsage(request)
+
+	host, err := app.mibDB.GetHost("192.0.2.10")
+	if err != nil || host == nil {
+		t.Fatalf("GetHost error: %v", err)
+	}
+	if host.Community != "private" {
+		t.Fatalf("expected the community to follow the request, got %q", host.Community)
+	}
+	if host.SourcePort != 40161 {
+		t.Fatalf("expected stored source port to be kept, got %d", host.SourcePort)
+	}
+	if !host.SerializeRequests {
+		t.Fatalf("expected stored request serialization to be kept")
+	}
+
+	effective := app.withStoredHostOptions(request)
+	if effective.SourcePort != 40161 || !effective.SerializeRequests {
+		t.Fatalf("expected stored host options to be applied to the client, got %+v", effective)
+	}
+	explicit := request
+	explicit.SourcePort = 40999
+	if got := app.withStoredHostOptions(explicit).SourcePort; got != 40999 {
+		t.Fatalf("expected an explicit source port to win, got %d", got)
+	}
+}
@@ -0,0 +1,194 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// keyboardShortcutsMetadataKey è la chiave di app_metadata con le scorciatoie personalizzate.
+const keyboardShortcutsMetadataKey = "keyboard_shortcuts"
+
+// DefaultKeyboardShortcuts associa a ogni azione del browser MIB la combinazione di tasti predefinita.
+// Le azioni ammesse da SetKeyboardShortcut sono le chiavi di questa mappa.
+var DefaultKeyboardShortcuts = map[string]string{
+	"search":   "Ctrl+F",
+	"refresh":  "F5",
+	"get":      "Ctrl+G",
+	"getNext":  "Ctrl+N",
+	"walk":     "Ctrl+W",
+	"bulkWalk": "Ctrl+Shift+W",
+	"set":      "Ctrl+E",
+	"bookmark": "Ctrl+D",
+	"copyOID":  "Ctrl+Shift+C",
+}
+
+// keyboardModifiers elenca i modificatori riconosciuti nella forma canonica, nell'ordine in cui
+// compaiono nelle combinazioni normalizzate.
+var keyboardModifiers = []string{"Ctrl", "Alt", "Shift", "Meta"}
+
+// GetKeyboardShortcuts restituisce la mappa azione → combinazione di tasti: i valori predefiniti
+// sovrascritti da quelli salvati dall'utente. Il backend si limita a conservarle; le applica il frontend.
+func (a *App) GetKeyboardShortcuts() (map[string]string, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	shortcuts := make(map[string]string, len(DefaultKeyboardShortcuts))
+	for action, keys := range DefaultKeyboardShortcuts {
+		shortcuts[action] = keys
+	}
+
+	raw, ok, err := db.GetMetadata(keyboardShortcutsMetadataKey)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return shortcuts, nil
+	}
+
+	stored := map[string]string{}
+	if err := json.Unmarshal([]byte(raw), &stored); err != nil {
+		return nil, fmt.Errorf("invalid keyboard shortcuts: %w", err)
+	}
+	for action, keys := range stored {
+		// Le azioni non più previste vengono ignorate.
+		if _, known := DefaultKeyboardShortcuts[action]; known {
+			shortcuts[action] = keys
+		}
+	}
+	return shortcuts, nil
+}
+
+// SetKeyboardShortcut assegna una combinazione di tasti (es. "Ctrl+Shift+F") a un'azione.
+// La combinazione non può essere già assegnata a un'altra azione.
+func (a *App) SetKeyboardShortcut(action string, keys string) error {
+	action = strings.TrimSpace(action)
+	if _, known := DefaultKeyboardShortcuts[action]; !known {
+		return fmt.Errorf("unknown keyboard shortcut action %q", action)
+	}
+	normalized, err := normalizeKeyBinding(keys)
+	if err != nil {
+		return err
+	}
+
+	shortcuts, err := a.GetKeyboardShortcuts()
+	if err != nil {
+		return err
+	}
+	for other, bound := range shortcuts {
+		if other != action && strings.EqualFold(bound, normalized) {
+			return fmt.Errorf("%s is already bound to %s", normalized, other)
+		}
+	}
+	shortcuts[action] = normalized
+	return a.saveKeyboardShortcuts(shortcuts)
+}
+
+// ResetKeyboardShortcuts ripristina le combinazioni predefinite.
+func (a *App) ResetKeyboardShortcuts() error {
+	if !a.mibDBReady() {
+		return a.mibNotInitializedErr()
+	}
+	return a.saveKeyboardShortcuts(DefaultKeyboardShortcuts)
+}
+
+// normalizeKeyboardShortcuts sovrappone le combinazioni indicate a quelle predefinite, normalizzandole.
+// Le azioni sconosciute vengono ignorate; due azioni con la stessa combinazione sono un errore.
+func normalizeKeyboardShortcuts(stored map[string]string) (map[string]string, error) {
+	shortcuts := make(map[string]string, len(DefaultKeyboardShortcuts))
+	for action, keys := range DefaultKeyboardShortcuts {
+		shortcuts[action] = keys
+	}
+	for action, keys := range stored {
+		if _, known := DefaultKeyboardShortcuts[action]; !known {
+			continue
+		}
+		normalized, err := normalizeKeyBinding(keys)
+		if err != nil {
+			return nil, err
+		}
+		shortcuts[action] = normalized
+	}
+
+	actions := make([]string, 0, len(shortcuts))
+	for action := range shortcuts {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	bound := make(map[string]string, len(shortcuts))
+	for _, action := range actions {
+		key := strings.ToLower(shortcuts[action])
+		if other, exists := bound[key]; exists {
+			return nil, fmt.Errorf("%s is bound to both %s and %s", shortcuts[action], other, action)
+		}
+		bound[key] = action
+	}
+	return shortcuts, nil
+}
+
+func (a *App) saveKeyboardShortcuts(shortcuts map[string]string) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+
+	raw, err := json.Marshal(shortcuts)
+	if err != nil {
+		return fmt.Errorf("failed to encode keyboard shortcuts: %w", err)
+	}
+	return db.SetMetadata(keyboardShortcutsMetadataKey, string(raw))
+}
+
+// normalizeKeyBinding valida una combinazione "Modificatore+...+Tasto" e la riporta alla forma
+// canonica: modificatori con l'iniziale maiuscola nell'ordine Ctrl, Alt, Shift, Meta e tasti di
+// una sola lettera in maiuscolo.
+func normalizeKeyBinding(keys string) (string, error) {
+	parts := strings.Split(strings.TrimSpace(keys), "+")
+	key := strings.TrimSpace(parts[len(parts)-1])
+	if key == "" {
+		return "", fmt.Errorf("invalid key binding %q", keys)
+	}
+
+	used := make(map[string]bool, len(parts)-1)
+	for _, part := range parts[:len(parts)-1] {
+		modifier, ok := canonicalModifier(strings.TrimSpace(part))
+		if !ok {
+			return "", fmt.Errorf("invalid key binding %q: unknown modifier %q", keys, strings.TrimSpace(part))
+		}
+		used[modifier] = true
+	}
+	if _, isModifier := canonicalModifier(key); isModifier {
+		return "", fmt.Errorf("invalid key binding %q: missing key", keys)
+	}
+	if len([]rune(key)) == 1 {
+		key = strings.ToUpper(key)
+	}
+
+	normalized := make([]string, 0, len(parts))
+	for _, modifier := range keyboardModifiers {
+		if used[modifier] {
+			normalized = append(normalized, modifier)
+		}
+	}
+	return strings.Join(append(normalized, key), "+"), nil
+}
+
+// canonicalModifier riconosce un modificatore, accettando anche gli alias Control, Cmd e Option.
+func canonicalModifier(name string) (string, bool) {
+	switch strings.ToLower(name) {
+	case "ctrl", "control":
+		return "Ctrl", true
+	case "alt", "option":
+		return "Alt", true
+	case "shift":
+		return "Shift", true
+	case "meta", "cmd", "command", "super":
+		return "Meta", true
+	}
+	return "", false
+}
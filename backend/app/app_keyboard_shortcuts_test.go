@@ -0,0 +1,52 @@
+package app
+
+import "testing"
+
+func TestKeyboardShortcutsRoundTrip(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+
+	shortcuts, err := app.GetKeyboardShortcuts()
+	if err != nil {
+		t.Fatalf("GetKeyboardShortcuts() error = %v", err)
+	}
+	if shortcuts["search"] != "Ctrl+F" || shortcuts["refresh"] != "F5" {
+		t.Fatalf("unexpected defaults: %v", shortcuts)
+	}
+
+	if err := app.SetKeyboardShortcut("search", "shift+control+k"); err != nil {
+		t.Fatalf("SetKeyboardShortcut() error = %v", err)
+	}
+	shortcuts, err = app.GetKeyboardShortcuts()
+	if err != nil {
+		t.Fatalf("GetKeyboardShortcuts() error = %v", err)
+	}
+	if shortcuts["search"] != "Ctrl+Shift+K" {
+		t.Fatalf("search = %q, want Ctrl+Shift+K", shortcuts["search"])
+	}
+	if shortcuts["refresh"] != "F5" {
+		t.Fatalf("refresh = %q, want default F5", shortcuts["refresh"])
+	}
+
+	for _, tc := range []struct{ action, keys string }{
+		{"unknown", "Ctrl+U"},
+		{"walk", "F5"},
+		{"walk", "Ctrl+"},
+		{"walk", "Hyper+W"},
+		{"walk", "Ctrl+Shift"},
+	} {
+		if err := app.SetKeyboardShortcut(tc.action, tc.keys); err == nil {
+			t.Errorf("SetKeyboardShortcut(%q, %q) expected error", tc.action, tc.keys)
+		}
+	}
+
+	if err := app.ResetKeyboardShortcuts(); err != nil {
+		t.Fatalf("ResetKeyboardShortcuts() error = %v", err)
+	}
+	shortcuts, err = app.GetKeyboardShortcuts()
+	if err != nil {
+		t.Fatalf("GetKeyboardShortcuts() error = %v", err)
+	}
+	if shortcuts["search"] != "Ctrl+F" {
+		t.Fatalf("search after reset = %q, want Ctrl+F", shortcuts["search"])
+	}
+}
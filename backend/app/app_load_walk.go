@@ -0,0 +1,87 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+)
+
+// LoadWalkResult riunisce il report di caricamento di un MIB e il WALK del suo sottoalbero sul dispositivo.
+type LoadWalkResult struct {
+	Report  mib.LoadReport `json:"report"`
+	RootOID string         `json:"rootOid"`
+	Results []snmp.Result  `json:"results"`
+}
+
+// LoadAndWalk carica il file MIB indicato e ne esegue subito il WALK sul dispositivo, partendo dalla
+// MODULE-IDENTITY del modulo o, in sua assenza, dal nodo più alto definito dal modulo.
+// I risultati del WALK riportano i nomi risolti con il modulo appena caricato.
+func (a *App) LoadAndWalk(filePath string, config snmp.Config) (*LoadWalkResult, error) {
+	if !a.mibDBReady() {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	filePath = strings.TrimSpace(filePath)
+	if filePath == "" {
+		return nil, fmt.Errorf("file path is required")
+	}
+
+	reports, err := a.loadMIBFiles([]string{filePath})
+	if err != nil {
+		return nil, err
+	}
+	report := reports[0]
+
+	rootOID, err := a.moduleWalkRoot(report)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := a.SNMPWalk(config, rootOID)
+	if err != nil {
+		return nil, fmt.Errorf("MIB %s loaded, but walk of %s failed: %w", report.ModuleName, rootOID, err)
+	}
+
+	return &LoadWalkResult{Report: report, RootOID: rootOID, Results: results}, nil
+}
+
+// moduleWalkRoot individua l'OID da cui percorrere un modulo appena caricato: la MODULE-IDENTITY
+// se dichiarata, altrimenti la radice con meno archi tra i nodi del modulo.
+func (a *App) moduleWalkRoot(report mib.LoadReport) (string, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return "", a.mibNotInitializedErr()
+	}
+
+	if oid := normalizeOIDKey(report.IdentityOID); oid != "" {
+		return oid, nil
+	}
+
+	roots, err := db.GetModuleTree(report.ModuleName)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve module tree: %v", err)
+	}
+
+	best := ""
+	for _, root := range roots {
+		oid := normalizeOIDKey(root.OID)
+		if oid == "" {
+			continue
+		}
+		if best == "" {
+			best = oid
+			continue
+		}
+		arcs, bestArcs := strings.Count(oid, "."), strings.Count(best, ".")
+		if arcs < bestArcs || (arcs == bestArcs && mib.CompareOIDs(oid, best) < 0) {
+			best = oid
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("module %s defines no OIDs to walk", report.ModuleName)
+	}
+	return best, nil
+}
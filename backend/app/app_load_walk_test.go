@@ -0,0 +1,36 @@
+package app
+
+import (
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+)
+
+func TestModuleWalkRoot(t *testing.T) {
+	app := setupTestAppWithNodes(
+		t,
+		&mib.Node{OID: "1.3.6.1.4.1.9999.2.5", Name: "acmeTraps", Type: "node", ParentOID: "1.3.6.1.4.1.9999.2"},
+		&mib.Node{OID: "1.3.6.1.4.1.9999.1", Name: "acmeObjects", Type: "node", ParentOID: "1.3.6.1.4.1.9999"},
+		&mib.Node{OID: "1.3.6.1.4.1.9999.1.1", Name: "acmeVersion", Type: "scalar", ParentOID: "1.3.6.1.4.1.9999.1"},
+	)
+
+	root, err := app.moduleWalkRoot(mib.LoadReport{ModuleName: "TEST-MIB"})
+	if err != nil {
+		t.Fatalf("moduleWalkRoot() error = %v", err)
+	}
+	if root != "1.3.6.1.4.1.9999.1" {
+		t.Fatalf("moduleWalkRoot() = %q, want %q", root, "1.3.6.1.4.1.9999.1")
+	}
+
+	root, err = app.moduleWalkRoot(mib.LoadReport{ModuleName: "TEST-MIB", IdentityOID: ".1.3.6.1.4.1.9999"})
+	if err != nil {
+		t.Fatalf("moduleWalkRoot() with identity error = %v", err)
+	}
+	if root != "1.3.6.1.4.1.9999" {
+		t.Fatalf("moduleWalkRoot() with identity = %q, want %q", root, "1.3.6.1.4.1.9999")
+	}
+
+	if _, err := app.moduleWalkRoot(mib.LoadReport{ModuleName: "MISSING-MIB"}); err == nil {
+		t.Fatalf("expected error for a module without nodes")
+	}
+}
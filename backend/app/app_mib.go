@@ -15,6 +15,19 @@ import (
 
 const bookmarkRootKey = "bookmarks"
 
+// repairOrphansMetadataKey è la chiave di app_metadata che abilita la correzione dei nodi orfani
+// dopo ogni caricamento e all'avvio (predefinita).
+const repairOrphansMetadataKey = "repair_orphans_on_load"
+
+const (
+	// mibCatalogVersionMetadataKey è la chiave di app_metadata con la versione dei dati derivati dai
+	// moduli caricati (catalogo dei tipi, enum, MODULE-IDENTITY, componenti degli indici).
+	mibCatalogVersionMetadataKey = "mib_catalog_version"
+	// mibCatalogVersion va incrementata quando il caricamento dei moduli inizia a salvare nuovi dati:
+	// i moduli caricati con una versione precedente vengono ricaricati una volta all'avvio.
+	mibCatalogVersion = 1
+)
+
 // BookmarkFolderDTO rappresenta una cartella in formato serializzabile per il frontend.
 type BookmarkFolderDTO struct {
 	ID        int64     `json:"id"`
@@ -30,6 +43,10 @@ type ModuleDetails struct {
 	Tree           []*mib.Node     `json:"tree"`
 	Stats          mib.ModuleStats `json:"stats"`
 	MissingImports []string        `json:"missingImports"`
+	// IdentityOID è l'OID della MODULE-IDENTITY: quando presente Tree contiene solo il ramo che la
+	// include e StrayNodes le definizioni del modulo esterne a quel ramo.
+	IdentityOID string      `json:"identityOid,omitempty"`
+	StrayNodes  []*mib.Node `json:"strayNodes,omitempty"`
 }
 
 func folderKeyFromID(id int64) string {
@@ -53,9 +70,10 @@ func parseFolderKey(key string) (*int64, error) {
 
 // LoadMIBFile apre una finestra di dialogo per permettere all'utente di selezionare uno o più file MIB.
 // Ogni file selezionato viene parsificato e caricato nel database MIB.
-// Ritorna i nomi dei moduli MIB caricati in caso di successo, o un errore.
-func (a *App) LoadMIBFile() ([]string, error) {
-	if a.mibDB == nil {
+// Ritorna un report per ciascun file caricato (con il nome del modulo), o un errore.
+// I report dell'ultimo caricamento restano disponibili tramite GetLastLoadReport.
+func (a *App) LoadMIBFile() ([]mib.LoadReport, error) {
+	if !a.mibDBReady() {
 		return nil, a.mibNotInitializedErr()
 	}
 
@@ -76,27 +94,244 @@ func (a *App) LoadMIBFile() ([]string, error) {
 		return nil, fmt.Errorf("no file selected")
 	}
 
+	return a.loadMIBFiles(filePaths)
+}
+
+// loadMIBFiles parsifica e carica nel database i file MIB indicati, fermandosi al primo errore.
+func (a *App) loadMIBFiles(filePaths []string) ([]mib.LoadReport, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
 	// Parsifica e carica MIB
-	parser := mib.NewParser(a.mibDB)
+	parser := mib.NewParser(db)
+	parser.SetRepairOrphans(repairOrphansEnabled(db))
 
-	configDir, err := os.UserConfigDir()
+	dataDir, err := a.dataDir()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user config dir: %v", err)
+		return nil, err
 	}
-	dataDir := filepath.Join(configDir, "MIB to the Future")
 
-	moduleNames := make([]string, 0, len(filePaths))
+	reports := make([]mib.LoadReport, 0, len(filePaths))
+	defer func() {
+		a.setLastLoadReports(reports)
+		a.clearMIBCaches()
+	}()
+
 	for _, filePath := range filePaths {
-		moduleName, err := parser.LoadMIBFile(filePath, dataDir)
+		report, err := parser.LoadMIBFile(filePath, dataDir)
+		if report != nil {
+			reports = append(reports, *report)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to load MIB %s: %v", filepath.Base(filePath), err)
 		}
 
-		runtime.LogInfo(a.ctx, fmt.Sprintf("Loaded MIB module: %s", moduleName))
-		moduleNames = append(moduleNames, moduleName)
+		if a.ctx != nil {
+			runtime.LogInfo(a.ctx, fmt.Sprintf("Loaded MIB module: %s (%d nodes, %d skipped, %dms)",
+				report.ModuleName, report.TotalNodes, len(report.SkippedNodes), report.ElapsedMs))
+		}
+	}
+
+	return reports, nil
+}
+
+// GetLastLoadReport restituisce i report dell'ultimo caricamento di file MIB eseguito nella sessione.
+func (a *App) GetLastLoadReport() []mib.LoadReport {
+	a.loadReportM.RLock()
+	defer a.loadReportM.RUnlock()
+
+	reports := make([]mib.LoadReport, len(a.lastLoadReports))
+	copy(reports, a.lastLoadReports)
+	return reports
+}
+
+func (a *App) setLastLoadReports(reports []mib.LoadReport) {
+	a.loadReportM.Lock()
+	a.lastLoadReports = reports
+	a.loadReportM.Unlock()
+}
+
+// GetRepairOrphansOnLoad indica se i nodi orfani vengono corretti dopo ogni caricamento e all'avvio.
+func (a *App) GetRepairOrphansOnLoad() (bool, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return false, a.mibNotInitializedErr()
+	}
+	return repairOrphansEnabled(db), nil
+}
+
+// SetRepairOrphansOnLoad abilita o disabilita la correzione automatica dei nodi orfani dopo ogni
+// caricamento e all'avvio. La preferenza è salvata nel database.
+func (a *App) SetRepairOrphansOnLoad(enabled bool) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+	return db.SetMetadata(repairOrphansMetadataKey, strconv.FormatBool(enabled))
+}
+
+// repairOrphansEnabled legge la preferenza di correzione degli orfani; se assente o illeggibile
+// vale il predefinito (abilitata).
+func repairOrphansEnabled(db *mib.Database) bool {
+	raw, ok, err := db.GetMetadata(repairOrphansMetadataKey)
+	if err != nil || !ok {
+		return true
+	}
+	enabled, err := strconv.ParseBool(raw)
+	if err != nil {
+		return true
+	}
+	return enabled
+}
+
+// FindOrphanMIBNodes restituisce i nodi il cui parent non esiste nel database.
+func (a *App) FindOrphanMIBNodes() ([]*mib.Node, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	nodes, err := db.FindOrphanNodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orphan nodes: %v", err)
+	}
+	return nodes, nil
+}
+
+// RepairOrphanMIBNodes ricollega i nodi orfani al primo antenato esistente.
+// Ritorna il numero di collegamenti corretti.
+func (a *App) RepairOrphanMIBNodes() (int, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return 0, a.mibNotInitializedErr()
+	}
+
+	repaired, err := db.RepairOrphanNodes()
+	if err != nil {
+		return 0, fmt.Errorf("failed to repair orphan nodes: %v", err)
+	}
+	return repaired, nil
+}
+
+// checkMIBIntegrity corregge i nodi orfani all'avvio, se la correzione è abilitata, registrando
+// l'esito nel log.
+func (a *App) checkMIBIntegrity() {
+	if enabled, err := a.GetRepairOrphansOnLoad(); err != nil || !enabled {
+		return
+	}
+	repaired, err := a.RepairOrphanMIBNodes()
+	if err != nil {
+		runtime.LogWarning(a.ctx, fmt.Sprintf("MIB integrity check failed: %v", err))
+		return
+	}
+	if repaired > 0 {
+		runtime.LogInfo(a.ctx, fmt.Sprintf("MIB integrity check repaired %d orphan parent links", repaired))
+	}
+}
+
+// mibCatalogNeedsBackfill indica se i moduli nel database sono stati caricati prima della versione
+// corrente del catalogo. Un database senza moduli viene marcato subito come aggiornato.
+func (a *App) mibCatalogNeedsBackfill() (bool, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return false, a.mibNotInitializedErr()
+	}
+
+	raw, ok, err := db.GetMetadata(mibCatalogVersionMetadataKey)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		if version, err := strconv.Atoi(raw); err == nil && version >= mibCatalogVersion {
+			return false, nil
+		}
+	}
+
+	modules, err := db.ListModules()
+	if err != nil {
+		return false, err
+	}
+	if len(modules) == 0 {
+		return false, db.SetMetadata(mibCatalogVersionMetadataKey, strconv.Itoa(mibCatalogVersion))
+	}
+	return true, nil
+}
+
+// backfillMIBCatalog ricarica dai file originali i moduli salvati con una versione precedente del
+// catalogo, così tipi, enum, identità dei moduli e indici delle tabelle vengono popolati anche per
+// i moduli caricati prima della loro introduzione. I moduli il cui file non è più disponibile
+// restano invariati. Al termine il catalogo viene marcato come aggiornato.
+func (a *App) backfillMIBCatalog(dataDir string) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+
+	modules, err := db.ListModules()
+	if err != nil {
+		return err
+	}
+
+	parser := mib.NewParser(db)
+	parser.SetRepairOrphans(repairOrphansEnabled(db))
+	reloaded := 0
+	for _, module := range modules {
+		if module.FilePath == "" {
+			continue
+		}
+		if _, err := os.Stat(module.FilePath); err != nil {
+			continue
+		}
+		if _, err := parser.LoadMIBFile(module.FilePath, dataDir); err != nil {
+			if a.ctx != nil {
+				runtime.LogWarning(a.ctx, fmt.Sprintf("Failed to reload MIB module %s: %v", module.Name, err))
+			}
+			continue
+		}
+		reloaded++
+	}
+	if reloaded > 0 {
+		a.clearMIBCaches()
+		if a.ctx != nil {
+			runtime.LogInfo(a.ctx, fmt.Sprintf("Reloaded %d MIB modules to update the MIB catalog", reloaded))
+		}
 	}
 
-	return moduleNames, nil
+	return db.SetMetadata(mibCatalogVersionMetadataKey, strconv.Itoa(mibCatalogVersion))
+}
+
+// RetryMIBInitialization ripete l'estrazione dei MIB standard e la configurazione dei path di ricerca di gosmi,
+// ad esempio dopo aver corretto i permessi della cartella dati. In caso di successo precarica i MIB standard.
+// Ritorna l'errore del nuovo tentativo, se presente.
+func (a *App) RetryMIBInitialization() error {
+	dataDir, err := a.dataDir()
+	if err != nil {
+		return err
+	}
+
+	if err := mib.RetryGosmiInit(dataDir); err != nil {
+		return fmt.Errorf("MIB initialization failed: %w", err)
+	}
+
+	if a.mibDBReady() {
+		if err := a.preloadStandardMIBs(dataDir); err != nil && a.ctx != nil {
+			runtime.LogWarning(a.ctx, fmt.Sprintf("Failed to preload some standard MIBs: %v", err))
+		}
+	}
+
+	if a.ctx != nil {
+		runtime.LogInfo(a.ctx, "MIB initialization completed")
+	}
+	return nil
 }
 
 // GetMIBTree recupera e restituisce l'intero albero MIB gerarchico dal database.
@@ -104,17 +339,28 @@ func (a *App) LoadMIBFile() ([]string, error) {
 // Utile per visualizzare l'intera struttura MIB nel frontend.
 // Ritorna una slice di nodi radice dell'albero in caso di successo, o un errore.
 func (a *App) GetMIBTree() ([]*mib.Node, error) {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return nil, a.mibNotInitializedErr()
 	}
 
-	tree, err := a.mibDB.GetTree()
+	tree, err := db.GetTree()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get MIB tree: %v", err)
 	}
 
+	// Rami nascosti e preferiti riguardano solo l'albero mostrato: ricerca e navigazione per OID li raggiungono comunque.
+	if preferences, err := a.GetTreePreferences(); err != nil {
+		if a.ctx != nil {
+			runtime.LogError(a.ctx, fmt.Sprintf("Failed to load tree preferences: %v", err))
+		}
+	} else {
+		tree = applyTreePreferences(tree, preferences)
+	}
+
 	// Recupera la struttura gerarchica dei bookmark
-	hierarchy, err := a.mibDB.GetBookmarkHierarchy()
+	hierarchy, err := db.GetBookmarkHierarchy()
 	if err != nil {
 		runtime.LogError(a.ctx, fmt.Sprintf("Failed to load bookmarks: %v", err))
 		hierarchy = nil
@@ -162,12 +408,20 @@ func (a *App) buildBookmarkChildren(folder *mib.BookmarkFolder, parentKey string
 	}
 
 	for _, entry := range folder.Bookmarks {
-		original, err := a.mibDB.GetNode(entry.OID)
-		if err != nil {
+		original := a.lookupNodeForOID(entry.OID)
+		if original == nil {
 			runtime.LogWarning(a.ctx, fmt.Sprintf("Bookmark OID %s not found in MIB database", entry.OID))
 			continue
 		}
 
+		// Le istanze (es. colonne con indice) usano la convenzione name[index] di resolveOIDName.
+		name := original.Name
+		if normalizeOIDKey(entry.OID) != normalizeOIDKey(original.OID) {
+			if resolved := a.resolveOIDName(entry.OID); resolved != "" {
+				name = resolved
+			}
+		}
+
 		bookmarkType := "bookmark"
 		if original.Type != "" && original.Type != "scalar" {
 			bookmarkType = "bookmark-" + original.Type
@@ -176,7 +430,7 @@ func (a *App) buildBookmarkChildren(folder *mib.BookmarkFolder, parentKey string
 		bookmarkNode := &mib.Node{
 			ID:          original.ID,
 			OID:         entry.OID,
-			Name:        original.Name,
+			Name:        name,
 			ParentOID:   parentKey,
 			Type:        bookmarkType,
 			Syntax:      original.Syntax,
@@ -198,32 +452,114 @@ func (a *App) buildBookmarkChildren(folder *mib.BookmarkFolder, parentKey string
 //
 // Ritorna un puntatore al nodo MIB se trovato, altrimenti un errore.
 func (a *App) GetMIBNode(oid string) (*mib.Node, error) {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return nil, a.mibNotInitializedErr()
 	}
 
-	node, err := a.mibDB.GetNode(oid)
+	node, err := db.GetNode(oid)
 	if err != nil {
 		return nil, fmt.Errorf("node not found: %v", err)
 	}
 
+	chain, err := db.ResolveTypeChain(node.Syntax, node.Module)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve type chain: %v", err)
+	}
+	if chain != nil {
+		node.ResolvedBaseType = chain.BaseType
+		node.TypeChain = chain.Chain
+		node.TypeChainIncomplete = chain.Incomplete
+	}
+
+	keepRaw, err := a.GetKeepRawDescriptions()
+	if err != nil {
+		return nil, err
+	}
+	applyDescriptionPreference(node, keepRaw)
+
 	return node, nil
 }
 
+// GetMIBNodeContext restituisce un nodo MIB con il contesto necessario al pannello dei dettagli:
+// padre, fratelli, figli e percorso degli antenati dalla radice.
+// Parametri:
+//   - oid: l'Object Identifier del nodo.
+//
+// Ritorna il contesto del nodo o un errore se il nodo non esiste.
+func (a *App) GetMIBNodeContext(oid string) (*mib.NodeContext, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	ctx, err := db.GetNodeContext(oid)
+	if err != nil {
+		return nil, fmt.Errorf("node not found: %v", err)
+	}
+
+	return ctx, nil
+}
+
 // SearchMIBNodes cerca nodi nel database MIB che corrispondono a una query.
 // La ricerca viene effettuata sia sul nome del nodo che sull'OID.
 // Parametri:
 //   - query: la stringa di testo da cercare.
 //
 // Ritorna una slice di nodi MIB che corrispondono alla ricerca, o un errore.
+//
+// Deprecated: usare SearchMIBNodesAdvanced.
 func (a *App) SearchMIBNodes(query string) ([]*mib.Node, error) {
-	if a.mibDB == nil {
+	resp, err := a.SearchMIBNodesAdvanced(SearchRequest{
+		Query:    query,
+		SearchIn: []string{mib.SearchFieldName, mib.SearchFieldOID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Nodes, nil
+}
+
+// SuggestOIDs restituisce i suggerimenti di completamento per i campi OID/nome della barra di query.
+// Parametri:
+//   - prefix: testo digitato (prefisso numerico di OID oppure parte del nome).
+//   - limit: numero massimo di suggerimenti (0 per il valore predefinito).
+//
+// Ritorna i suggerimenti con OID, nome, tipo e modulo, o un errore se il prefisso numerico non è valido.
+func (a *App) SuggestOIDs(prefix string, limit int) ([]mib.OIDSuggestion, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	suggestions, err := db.SuggestOIDs(prefix, limit)
+	if err != nil {
+		return nil, fmt.Errorf("suggestion lookup failed: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// SearchMIBNodesBySyntax cerca i nodi MIB la cui sintassi contiene il pattern indicato.
+// Parametri:
+//   - pattern: il testo da cercare nella sintassi (es. "Counter64", "TruthValue").
+//   - moduleName: modulo a cui limitare la ricerca (vuoto per tutti i moduli).
+//
+// Ritorna una slice di nodi MIB corrispondenti, o un errore.
+func (a *App) SearchMIBNodesBySyntax(pattern string, moduleName string) ([]*mib.Node, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return nil, a.mibNotInitializedErr()
 	}
 
-	nodes, err := a.mibDB.SearchNodes(query)
+	nodes, err := db.SearchNodesBySyntax(pattern, moduleName)
 	if err != nil {
-		return nil, fmt.Errorf("search failed: %v", err)
+		return nil, fmt.Errorf("syntax search failed: %v", err)
 	}
 
 	return nodes, nil
@@ -231,11 +567,13 @@ func (a *App) SearchMIBNodes(query string) ([]*mib.Node, error) {
 
 // ListMIBModules restituisce l'elenco dei moduli MIB caricati con le statistiche principali.
 func (a *App) ListMIBModules() ([]mib.ModuleSummary, error) {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return nil, a.mibNotInitializedErr()
 	}
 
-	modules, err := a.mibDB.ListModules()
+	modules, err := db.ListModules()
 	if err != nil {
 		return nil, fmt.Errorf("failed to list modules: %v", err)
 	}
@@ -243,35 +581,82 @@ func (a *App) ListMIBModules() ([]mib.ModuleSummary, error) {
 	return modules, nil
 }
 
+// GetRecentlyLoadedModules restituisce i moduli caricati nelle ultime `hours` ore.
+func (a *App) GetRecentlyLoadedModules(hours int) ([]mib.ModuleSummary, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	modules, err := db.GetRecentlyLoadedModules(hours)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recently loaded modules: %v", err)
+	}
+
+	return modules, nil
+}
+
 // DeleteMIBModule rimuove un modulo MIB e tutti i suoi nodi associati dal database.
 // Parametri:
 //   - moduleName: il nome del modulo MIB da eliminare.
 //
 // Ritorna un errore se l'operazione fallisce.
 func (a *App) DeleteMIBModule(moduleName string) error {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return a.mibNotInitializedErr()
 	}
 
-	err := a.mibDB.DeleteModule(moduleName)
+	err := db.DeleteModule(moduleName)
 	if err != nil {
 		return fmt.Errorf("failed to delete module: %v", err)
 	}
+	a.clearMIBCaches()
 
 	runtime.LogInfo(a.ctx, fmt.Sprintf("Deleted MIB module: %s", moduleName))
 
 	return nil
 }
 
+// RecomputeAllStats ricalcola dal database i conteggi di tutti i moduli caricati,
+// correggendo eventuali statistiche non allineate senza ricaricare i file MIB.
+func (a *App) RecomputeAllStats() error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+
+	modules, err := db.ListModules()
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %v", err)
+	}
+
+	for _, module := range modules {
+		if err := db.RecomputeModuleStats(module.Name); err != nil {
+			return err
+		}
+	}
+
+	if a.ctx != nil {
+		runtime.LogInfo(a.ctx, fmt.Sprintf("Recomputed stats for %d MIB modules", len(modules)))
+	}
+	return nil
+}
+
 // GetMIBStats calcola e restituisce statistiche sul database MIB.
 // Le statistiche includono il numero totale di moduli, nodi, etc.
 // Ritorna una mappa con le statistiche o un errore.
 func (a *App) GetMIBStats() (map[string]int, error) {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return nil, a.mibNotInitializedErr()
 	}
 
-	stats, err := a.mibDB.GetStats()
+	stats, err := db.GetStats()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get stats: %v", err)
 	}
@@ -279,9 +664,93 @@ func (a *App) GetMIBStats() (map[string]int, error) {
 	return stats, nil
 }
 
+// GetMIBTreeStats restituisce statistiche diagnostiche sulla forma dell'albero MIB
+// (profondità massima, ampiezza massima, foglie, nodi interni e media dei figli).
+func (a *App) GetMIBTreeStats() (*mib.TreeStats, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	stats, err := db.GetTreeStats()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree stats: %v", err)
+	}
+
+	return stats, nil
+}
+
+// GetDatabaseInfo restituisce percorso, dimensione e contenuto del database SQLite, mostrati nel
+// pannello Impostazioni → Informazioni.
+func (a *App) GetDatabaseInfo() (mib.DatabaseInfo, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return mib.DatabaseInfo{}, a.mibNotInitializedErr()
+	}
+
+	info, err := db.GetDatabaseInfo()
+	if err != nil {
+		return mib.DatabaseInfo{}, fmt.Errorf("failed to get database info: %v", err)
+	}
+
+	return info, nil
+}
+
+// GetModuleNodeTypeSummary restituisce il numero di nodi per tipo (scalar, table, column, ...) di un modulo.
+func (a *App) GetModuleNodeTypeSummary(moduleName string) (map[string]int, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	summary, err := db.GetModuleNodeTypeSummary(moduleName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node type summary: %v", err)
+	}
+
+	return summary, nil
+}
+
+// GetOverallNodeTypeSummary restituisce il numero di nodi per tipo su tutti i moduli caricati.
+func (a *App) GetOverallNodeTypeSummary() (map[string]int, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	summary, err := db.GetNodeTypeSummary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node type summary: %v", err)
+	}
+
+	return summary, nil
+}
+
+// GetModuleNodeCounts restituisce il numero di nodi di ogni modulo, da mostrare accanto all'elenco dei moduli.
+func (a *App) GetModuleNodeCounts() (map[string]int, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	counts, err := db.GetModuleNodeCounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get module node counts: %v", err)
+	}
+
+	return counts, nil
+}
+
 // GetMIBModuleDetails restituisce l'albero e le statistiche relative a un modulo specifico.
 func (a *App) GetMIBModuleDetails(moduleName string) (*ModuleDetails, error) {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return nil, a.mibNotInitializedErr()
 	}
 	moduleName = strings.TrimSpace(moduleName)
@@ -289,12 +758,12 @@ func (a *App) GetMIBModuleDetails(moduleName string) (*ModuleDetails, error) {
 		return nil, fmt.Errorf("module name is empty")
 	}
 
-	summary, err := a.mibDB.GetModuleSummary(moduleName)
+	summary, err := db.GetModuleSummary(moduleName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve module summary: %v", err)
 	}
 
-	tree, err := a.mibDB.GetModuleTree(moduleName)
+	tree, err := db.GetModuleTree(moduleName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve module tree: %v", err)
 	}
@@ -309,23 +778,70 @@ func (a *App) GetMIBModuleDetails(moduleName string) (*ModuleDetails, error) {
 		MissingCount: len(summary.MissingImports),
 	}
 
-	return &ModuleDetails{
+	details := &ModuleDetails{
 		Module:         summary.Name,
 		Tree:           tree,
 		Stats:          stats,
 		MissingImports: summary.MissingImports,
-	}, nil
+		IdentityOID:    summary.IdentityOID,
+	}
+	if anchor, stray := mib.SplitModuleTree(tree, summary.IdentityOID); anchor != nil {
+		details.Tree = []*mib.Node{anchor}
+		details.StrayNodes = stray
+	}
+	return details, nil
+}
+
+// FlatNode è un nodo dell'albero di un modulo in forma piatta, con la profondità (0 per le radici).
+// Children è sempre vuoto: la gerarchia è espressa dall'ordine e da Depth.
+type FlatNode struct {
+	mib.Node
+	Depth int `json:"depth"`
+}
+
+// GetModuleTreeFlat restituisce i nodi del modulo in ordine di visita in profondità, ciascuno con la
+// propria profondità. È pensato per liste indentate ed export (CSV, XML) che non gestiscono strutture annidate.
+func (a *App) GetModuleTreeFlat(moduleName string) ([]FlatNode, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+	moduleName = strings.TrimSpace(moduleName)
+	if moduleName == "" {
+		return nil, fmt.Errorf("module name is empty")
+	}
+
+	tree, err := db.GetModuleTree(moduleName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve module tree: %v", err)
+	}
+
+	flat := []FlatNode{}
+	var visit func(nodes []*mib.Node, depth int)
+	visit = func(nodes []*mib.Node, depth int) {
+		for _, node := range nodes {
+			entry := FlatNode{Node: *node, Depth: depth}
+			entry.Children = nil
+			flat = append(flat, entry)
+			visit(node.Children, depth+1)
+		}
+	}
+	visit(tree, 0)
+	return flat, nil
 }
 
 // ExportMIBTree esporta l'intero albero MIB in formato JSON.
 // Se l'utente seleziona un percorso, il file JSON viene salvato su disco.
 // Ritorna la stringa JSON dell'albero e un errore se il salvataggio fallisce.
 func (a *App) ExportMIBTree() (string, error) {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return "", a.mibNotInitializedErr()
 	}
 
-	jsonData, err := a.mibDB.ExportTree()
+	jsonData, err := db.ExportTree()
 	if err != nil {
 		return "", fmt.Errorf("failed to export tree: %v", err)
 	}
@@ -393,11 +909,13 @@ func (a *App) SaveCSVFile(defaultFilename string, csvContent string) (bool, erro
 //
 // Ritorna un puntatore al nodo MIB se trovato, altrimenti un errore.
 func (a *App) GetMIBNodeByName(name string) (*mib.Node, error) {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return nil, a.mibNotInitializedErr()
 	}
 
-	node, err := a.mibDB.GetNodeByName(name)
+	node, err := db.GetNodeByName(name)
 	if err != nil {
 		return nil, fmt.Errorf("node not found: %v", err)
 	}
@@ -407,14 +925,16 @@ func (a *App) GetMIBNodeByName(name string) (*mib.Node, error) {
 
 // GetMIBNodeAncestors restituisce la catena di antenati di un nodo MIB a partire dall'OID fornito.
 func (a *App) GetMIBNodeAncestors(oid string) ([]*mib.Node, error) {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return nil, a.mibNotInitializedErr()
 	}
 	if oid == "" {
 		return nil, fmt.Errorf("invalid OID")
 	}
 
-	nodes, err := a.mibDB.GetNodeAncestors(oid)
+	nodes, err := db.GetNodeAncestors(oid)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve ancestors: %v", err)
 	}
@@ -423,36 +943,62 @@ func (a *App) GetMIBNodeAncestors(oid string) ([]*mib.Node, error) {
 }
 
 // ReloadMIBDatabase chiude e ricarica il database MIB dalla sua posizione su disco.
+// Il nuovo database viene aperto e migrato prima di sostituire quello corrente, così i metodi
+// invocati nel frattempo vedono sempre un database utilizzabile; il precedente viene chiuso dopo
+// la conclusione delle operazioni che lo stanno usando e le cache derivate vengono svuotate.
 // Funzione utile principalmente per scopi di debug.
 // Ritorna un errore se il ricaricamento fallisce.
 func (a *App) ReloadMIBDatabase() error {
-	if a.mibDB != nil {
-		a.mibDB.Close()
-		a.mibDB = nil
-	}
+	a.mibReloadM.Lock()
+	defer a.mibReloadM.Unlock()
 
-	configDir, err := os.UserConfigDir()
-	if err != nil {
-		a.mibInitErr = fmt.Errorf("failed to resolve user config dir: %w", err)
-		return a.mibInitErr
+	var dataDir string
+	if current, release := a.acquireMIBDB(); current != nil {
+		dataDir = filepath.Dir(current.Path())
+		release()
+	} else {
+		var err error
+		dataDir, err = a.dataDir()
+		if err != nil {
+			return a.setMIBInitErr(err)
+		}
 	}
 
-	dataDir := filepath.Join(configDir, "MIB to the Future")
-
-	db, err := mib.NewDatabase(dataDir)
+	db, err := openMIBDatabase(dataDir)
 	if err != nil {
-		a.mibInitErr = fmt.Errorf("failed to reload database from %s: %w", dataDir, err)
-		return a.mibInitErr
+		err = fmt.Errorf("failed to reload database from %s: %w", dataDir, err)
+		if !a.mibDBReady() {
+			return a.setMIBInitErr(err)
+		}
+		// Il database corrente resta in uso.
+		return err
 	}
 
-	a.mibDB = db
-	a.mibInitErr = nil
+	if err := closeRetiredMIBDB(a.swapMIBDB(db, nil)); err != nil && a.ctx != nil {
+		runtime.LogWarning(a.ctx, fmt.Sprintf("Failed to close previous MIB database: %v", err))
+	}
+	a.clearMIBCaches()
 
-	runtime.LogInfo(a.ctx, fmt.Sprintf("MIB database reloaded from: %s", dataDir))
+	if a.ctx != nil {
+		runtime.LogInfo(a.ctx, fmt.Sprintf("MIB database reloaded from: %s", dataDir))
+	}
 
 	return nil
 }
 
+// clearMIBCaches scarta tutte le informazioni memorizzate a partire dal database MIB.
+func (a *App) clearMIBCaches() {
+	a.oidNameCacheM.Lock()
+	a.oidNameCache = make(map[string]string)
+	a.oidBaseCache = make(map[string]string)
+	a.oidNodeCache = make(map[string]*mib.Node)
+	a.oidNameCacheM.Unlock()
+
+	a.invalidateTypeEnums()
+	a.invalidateModuleRevisions()
+	a.invalidateTableList()
+}
+
 // AddBookmark aggiunge un OID alla lista dei bookmark in una cartella facoltativa.
 // Parametri:
 //   - oid: l'Object Identifier da aggiungere.
@@ -460,20 +1006,25 @@ func (a *App) ReloadMIBDatabase() error {
 //
 // Ritorna un errore se l'operazione fallisce.
 func (a *App) AddBookmark(oid string, folderKey string) error {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return a.mibNotInitializedErr()
 	}
 	trimmedOID := strings.TrimSpace(oid)
 	if trimmedOID == "" {
 		return fmt.Errorf("OID is required")
 	}
+	if err := validateOIDInput(trimmedOID); err != nil {
+		return err
+	}
 
 	folderID, err := parseFolderKey(strings.TrimSpace(folderKey))
 	if err != nil {
 		return err
 	}
 
-	if err := a.mibDB.AddBookmark(trimmedOID, folderID); err != nil {
+	if err := db.AddBookmark(trimmedOID, folderID); err != nil {
 		return fmt.Errorf("failed to add bookmark: %w", err)
 	}
 
@@ -491,7 +1042,9 @@ func (a *App) AddBookmark(oid string, folderKey string) error {
 //   - oid: l'OID del bookmark da spostare.
 //   - folderKey: la chiave della cartella di destinazione (usare "bookmarks" per la root).
 func (a *App) MoveBookmark(oid string, folderKey string) error {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return a.mibNotInitializedErr()
 	}
 	trimmedOID := strings.TrimSpace(oid)
@@ -504,7 +1057,7 @@ func (a *App) MoveBookmark(oid string, folderKey string) error {
 		return err
 	}
 
-	if err := a.mibDB.MoveBookmark(trimmedOID, folderID); err != nil {
+	if err := db.MoveBookmark(trimmedOID, folderID); err != nil {
 		return fmt.Errorf("failed to move bookmark: %w", err)
 	}
 
@@ -523,7 +1076,9 @@ func (a *App) MoveBookmark(oid string, folderKey string) error {
 //
 // Ritorna un errore se l'operazione fallisce.
 func (a *App) RemoveBookmark(oid string) error {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return a.mibNotInitializedErr()
 	}
 	trimmedOID := strings.TrimSpace(oid)
@@ -531,7 +1086,7 @@ func (a *App) RemoveBookmark(oid string) error {
 		return fmt.Errorf("OID is required")
 	}
 
-	err := a.mibDB.RemoveBookmark(trimmedOID)
+	err := db.RemoveBookmark(trimmedOID)
 	if err != nil {
 		return fmt.Errorf("failed to remove bookmark: %w", err)
 	}
@@ -540,12 +1095,70 @@ func (a *App) RemoveBookmark(oid string) error {
 	return nil
 }
 
+// OrphanedBookmark descrive un bookmark che non corrisponde più ad alcun nodo MIB caricato.
+type OrphanedBookmark struct {
+	OID          string    `json:"oid"`
+	FolderKey    string    `json:"folderKey"`
+	FolderName   string    `json:"folderName"`
+	BookmarkedAt time.Time `json:"bookmarkedAt"`
+}
+
+// FindOrphanedBookmarks elenca i bookmark rimasti senza nodo MIB (ad esempio dopo DeleteMIBModule),
+// così da poterli rivedere prima di eliminarli con RemoveOrphanedBookmarks.
+func (a *App) FindOrphanedBookmarks() ([]OrphanedBookmark, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	orphaned, err := db.FindOrphanedBookmarks()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]OrphanedBookmark, 0, len(orphaned))
+	for _, bookmark := range orphaned {
+		folderKey := bookmarkRootKey
+		if bookmark.FolderID != nil {
+			folderKey = folderKeyFromID(*bookmark.FolderID)
+		}
+		result = append(result, OrphanedBookmark{
+			OID:          bookmark.OID,
+			FolderKey:    folderKey,
+			FolderName:   bookmark.FolderName,
+			BookmarkedAt: bookmark.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// RemoveOrphanedBookmarks elimina i bookmark restituiti da FindOrphanedBookmarks e ne restituisce il numero.
+func (a *App) RemoveOrphanedBookmarks() (int, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return 0, a.mibNotInitializedErr()
+	}
+
+	removed, err := db.RemoveOrphanedBookmarks()
+	if err != nil {
+		return 0, err
+	}
+	if removed > 0 {
+		runtime.LogInfo(a.ctx, fmt.Sprintf("Removed %d orphaned bookmarks", removed))
+	}
+	return removed, nil
+}
+
 // CreateBookmarkFolder crea una nuova cartella per i bookmark.
 // Parametri:
 //   - name: nome della cartella.
 //   - parentKey: chiave della cartella padre ("bookmarks" per la root).
 func (a *App) CreateBookmarkFolder(name string, parentKey string) (*BookmarkFolderDTO, error) {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return nil, a.mibNotInitializedErr()
 	}
 
@@ -554,7 +1167,7 @@ func (a *App) CreateBookmarkFolder(name string, parentKey string) (*BookmarkFold
 		return nil, err
 	}
 
-	folder, err := a.mibDB.CreateBookmarkFolder(name, parentID)
+	folder, err := db.CreateBookmarkFolder(name, parentID)
 	if err != nil {
 		return nil, err
 	}
@@ -581,7 +1194,9 @@ func (a *App) CreateBookmarkFolder(name string, parentKey string) (*BookmarkFold
 //   - folderKey: chiave della cartella da rinominare.
 //   - name: nuovo nome.
 func (a *App) RenameBookmarkFolder(folderKey string, name string) error {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return a.mibNotInitializedErr()
 	}
 
@@ -593,7 +1208,7 @@ func (a *App) RenameBookmarkFolder(folderKey string, name string) error {
 		return fmt.Errorf("cannot rename the root bookmarks folder")
 	}
 
-	if err := a.mibDB.RenameBookmarkFolder(*folderID, name); err != nil {
+	if err := db.RenameBookmarkFolder(*folderID, name); err != nil {
 		return err
 	}
 
@@ -605,7 +1220,9 @@ func (a *App) RenameBookmarkFolder(folderKey string, name string) error {
 // Parametri:
 //   - folderKey: chiave della cartella da eliminare.
 func (a *App) DeleteBookmarkFolder(folderKey string) error {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return a.mibNotInitializedErr()
 	}
 
@@ -617,7 +1234,7 @@ func (a *App) DeleteBookmarkFolder(folderKey string) error {
 		return fmt.Errorf("cannot delete the root bookmarks folder")
 	}
 
-	if err := a.mibDB.DeleteBookmarkFolder(*folderID); err != nil {
+	if err := db.DeleteBookmarkFolder(*folderID); err != nil {
 		return err
 	}
 
@@ -625,12 +1242,138 @@ func (a *App) DeleteBookmarkFolder(folderKey string) error {
 	return nil
 }
 
+// DuplicateBookmarkFolder crea una copia della cartella indicata nella destinazione, con un nuovo nome,
+// lasciando intatta l'originale. Le sotto-cartelle non vengono copiate; una cartella che contiene bookmark
+// non può essere duplicata perché ogni OID appartiene a una sola cartella.
+// Parametri:
+//   - folderKey: cartella da duplicare.
+//   - newName: nome della nuova cartella.
+//   - destinationKey: cartella di destinazione (usare "bookmarks" per la root).
+func (a *App) DuplicateBookmarkFolder(folderKey string, newName string, destinationKey string) (*BookmarkFolderDTO, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	folderID, err := parseFolderKey(strings.TrimSpace(folderKey))
+	if err != nil {
+		return nil, err
+	}
+	if folderID == nil {
+		return nil, fmt.Errorf("cannot duplicate the root bookmarks folder")
+	}
+
+	parentID, err := parseFolderKey(strings.TrimSpace(destinationKey))
+	if err != nil {
+		return nil, err
+	}
+
+	folder, err := db.DuplicateBookmarkFolder(*folderID, newName, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	parentKeyValue := bookmarkRootKey
+	if folder.ParentID != nil {
+		parentKeyValue = folderKeyFromID(*folder.ParentID)
+	}
+
+	runtime.LogInfo(a.ctx, fmt.Sprintf("Duplicated bookmark folder %s as %s (parent=%s)", folderKey, folder.Name, parentKeyValue))
+	return &BookmarkFolderDTO{
+		ID:        folder.ID,
+		Name:      folder.Name,
+		Key:       folderKeyFromID(folder.ID),
+		ParentKey: parentKeyValue,
+		CreatedAt: folder.CreatedAt,
+	}, nil
+}
+
+// MergeResult riassume l'unione di due cartelle di bookmark.
+type MergeResult struct {
+	BookmarksMoved    int `json:"bookmarksMoved"`
+	FoldersMoved      int `json:"foldersMoved"`
+	SkippedDuplicates int `json:"skippedDuplicates"`
+}
+
+// MergeBookmarkFolders unisce la cartella sorgente nella destinazione: bookmark e sotto-cartelle vengono
+// spostati e la sorgente eliminata. Le sotto-cartelle con un nome già presente nella destinazione vengono
+// unite a quelle esistenti e conteggiate in SkippedDuplicates.
+// Parametri:
+//   - sourceFolderKey: cartella da unire (viene eliminata).
+//   - destinationFolderKey: cartella che riceve i contenuti (usare "bookmarks" per la root).
+func (a *App) MergeBookmarkFolders(sourceFolderKey string, destinationFolderKey string) (*MergeResult, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	sourceID, err := parseFolderKey(strings.TrimSpace(sourceFolderKey))
+	if err != nil {
+		return nil, err
+	}
+	if sourceID == nil {
+		return nil, fmt.Errorf("cannot merge the root bookmarks folder")
+	}
+
+	destinationID, err := parseFolderKey(strings.TrimSpace(destinationFolderKey))
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := db.MergeBookmarkFolders(*sourceID, destinationID)
+	if err != nil {
+		return nil, err
+	}
+
+	runtime.LogInfo(a.ctx, fmt.Sprintf("Merged bookmark folder %s into %s (%d bookmarks, %d folders)",
+		sourceFolderKey, destinationFolderKey, merged.BookmarksMoved, merged.FoldersMoved))
+	return &MergeResult{
+		BookmarksMoved:    merged.BookmarksMoved,
+		FoldersMoved:      merged.FoldersMoved,
+		SkippedDuplicates: merged.SkippedDuplicates,
+	}, nil
+}
+
+// GetBookmarkFolderPath restituisce il percorso completo della cartella indicata, con i nomi
+// separati da " / " (es. "Network / Routers / Core"), per il breadcrumb. Per la root ritorna una stringa vuota.
+func (a *App) GetBookmarkFolderPath(folderKey string) (string, error) {
+	segments, err := a.GetBookmarkFolderPathSegments(folderKey)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(segments, " / "), nil
+}
+
+// GetBookmarkFolderPathSegments restituisce i nomi delle cartelle dalla root fino a quella indicata.
+// Per la root ritorna un elenco vuoto.
+func (a *App) GetBookmarkFolderPathSegments(folderKey string) ([]string, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	folderID, err := parseFolderKey(strings.TrimSpace(folderKey))
+	if err != nil {
+		return nil, err
+	}
+	if folderID == nil {
+		return []string{}, nil
+	}
+
+	return db.GetFolderPath(*folderID)
+}
+
 // MoveBookmarkFolder cambia il parent di una cartella.
 // Parametri:
 //   - folderKey: cartella da spostare.
 //   - parentKey: nuovo parent (usare "bookmarks" per la root).
 func (a *App) MoveBookmarkFolder(folderKey string, parentKey string) error {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return a.mibNotInitializedErr()
 	}
 
@@ -647,7 +1390,7 @@ func (a *App) MoveBookmarkFolder(folderKey string, parentKey string) error {
 		return err
 	}
 
-	if err := a.mibDB.MoveBookmarkFolder(*folderID, parentID); err != nil {
+	if err := db.MoveBookmarkFolder(*folderID, parentID); err != nil {
 		return err
 	}
 
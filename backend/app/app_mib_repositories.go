@@ -0,0 +1,322 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	// mibRepositoriesMetadataKey è la chiave di app_metadata con gli URL base dei repository MIB.
+	mibRepositoriesMetadataKey = "mib_repositories"
+	// mibDownloadMaxSize è la dimensione massima di un MIB scaricato, la stessa ammessa per i file locali.
+	mibDownloadMaxSize = 10 * 1024 * 1024
+	// mibDownloadTimeout limita la durata di ogni download, redirect compresi.
+	mibDownloadTimeout = 30 * time.Second
+	// mibMaxRedirects è il numero massimo di redirect seguiti, comunque solo verso host configurati.
+	mibMaxRedirects = 5
+	// downloadedMIBsDir è la sottodirectory dei dati dell'app in cui vengono salvati i MIB scaricati.
+	downloadedMIBsDir = "downloaded-mibs"
+)
+
+// mibModuleNamePattern accetta i nomi di modulo SMI (lettera iniziale, lettere, cifre e trattini),
+// usati sia nell'URL sia come nome del file salvato.
+var mibModuleNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*$`)
+
+// DependencyFetchResult è l'esito del download di una dipendenza mancante: Source è l'URL da cui
+// è stata scaricata, Error riassume i tentativi falliti su tutti i repository.
+type DependencyFetchResult struct {
+	Module string `json:"module"`
+	Source string `json:"source,omitempty"`
+	Loaded bool   `json:"loaded"`
+	Error  string `json:"error,omitempty"`
+}
+
+// FetchImportsReport riassume FetchMissingImports: l'esito per ciascuna dipendenza e gli import
+// ancora mancanti dopo aver ricaricato il modulo originale.
+type FetchImportsReport struct {
+	Module           string                  `json:"module"`
+	Dependencies     []DependencyFetchResult `json:"dependencies"`
+	RemainingImports []string                `json:"remainingImports"`
+}
+
+// ListMIBRepositories restituisce gli URL base dei repository MIB, nell'ordine in cui vengono provati.
+func (a *App) ListMIBRepositories() ([]string, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	repositories := []string{}
+	raw, ok, err := db.GetMetadata(mibRepositoriesMetadataKey)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if err := json.Unmarshal([]byte(raw), &repositories); err != nil {
+			return nil, fmt.Errorf("invalid MIB repositories: %w", err)
+		}
+	}
+	return repositories, nil
+}
+
+// AddMIBRepository aggiunge in fondo all'elenco un repository organizzato come <base>/<MODULO>.mib.
+func (a *App) AddMIBRepository(baseURL string) error {
+	repository, err := normalizeMIBRepositoryURL(baseURL)
+	if err != nil {
+		return err
+	}
+
+	repositories, err := a.ListMIBRepositories()
+	if err != nil {
+		return err
+	}
+	for _, existing := range repositories {
+		if existing == repository {
+			return nil
+		}
+	}
+	return a.saveMIBRepositories(append(repositories, repository))
+}
+
+// RemoveMIBRepository toglie un repository dall'elenco.
+func (a *App) RemoveMIBRepository(baseURL string) error {
+	repositories, err := a.ListMIBRepositories()
+	if err != nil {
+		return err
+	}
+
+	target := strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if normalized, err := normalizeMIBRepositoryURL(baseURL); err == nil {
+		target = normalized
+	}
+	remaining := make([]string, 0, len(repositories))
+	for _, existing := range repositories {
+		if existing != target {
+			remaining = append(remaining, existing)
+		}
+	}
+	if len(remaining) == len(repositories) {
+		return fmt.Errorf("MIB repository %s is not configured", baseURL)
+	}
+	return a.saveMIBRepositories(remaining)
+}
+
+func (a *App) saveMIBRepositories(repositories []string) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+
+	raw, err := json.Marshal(repositories)
+	if err != nil {
+		return fmt.Errorf("failed to encode MIB repositories: %w", err)
+	}
+	return db.SetMetadata(mibRepositoriesMetadataKey, string(raw))
+}
+
+// FetchMissingImports scarica dai repository configurati gli import mancanti del modulo, li carica
+// e ricarica il modulo originale per risolverne di nuovo le dipendenze. Ogni dipendenza è provata
+// su tutti i repository nell'ordine configurato; i fallimenti sono riportati singolarmente e non
+// interrompono le altre. I redirect sono seguiti solo verso gli host dei repository configurati.
+func (a *App) FetchMissingImports(moduleName string) (*FetchImportsReport, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+	moduleName = strings.TrimSpace(moduleName)
+	if moduleName == "" {
+		return nil, fmt.Errorf("module name is empty")
+	}
+
+	summary, err := db.GetModuleSummary(moduleName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve module summary: %v", err)
+	}
+	report := &FetchImportsReport{
+		Module:           summary.Name,
+		Dependencies:     []DependencyFetchResult{},
+		RemainingImports: summary.MissingImports,
+	}
+	if len(summary.MissingImports) == 0 {
+		return report, nil
+	}
+
+	repositories, err := a.ListMIBRepositories()
+	if err != nil {
+		return nil, err
+	}
+	if len(repositories) == 0 {
+		return nil, fmt.Errorf("no MIB repositories configured")
+	}
+
+	dataDir, err := a.dataDir()
+	if err != nil {
+		return nil, err
+	}
+	downloadDir := filepath.Join(dataDir, downloadedMIBsDir)
+	if err := os.MkdirAll(downloadDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create download directory: %v", err)
+	}
+
+	client := newMIBRepositoryClient(repositories)
+	loaded := 0
+	for _, dependency := range summary.MissingImports {
+		result := DependencyFetchResult{Module: dependency}
+		if !mibModuleNamePattern.MatchString(dependency) {
+			result.Error = fmt.Sprintf("invalid module name %q", dependency)
+			report.Dependencies = append(report.Dependencies, result)
+			continue
+		}
+		source, content, err := fetchRepositoryMIB(a.backgroundContext(), client, repositories, dependency)
+		if err != nil {
+			result.Error = err.Error()
+			report.Dependencies = append(report.Dependencies, result)
+			continue
+		}
+		result.Source = source
+
+		path := filepath.Join(downloadDir, dependency+".mib")
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			result.Error = fmt.Sprintf("failed to save %s: %v", dependency, err)
+		} else if _, err := a.loadMIBFiles([]string{path}); err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Loaded = true
+			loaded++
+		}
+		report.Dependencies = append(report.Dependencies, result)
+	}
+
+	if loaded > 0 && summary.FilePath != "" {
+		if _, err := a.loadMIBFiles([]string{summary.FilePath}); err != nil && a.ctx != nil {
+			runtime.LogWarning(a.ctx, fmt.Sprintf("Failed to reload %s after fetching imports: %v", summary.Name, err))
+		}
+		if refreshed, err := db.GetModuleSummary(summary.Name); err == nil {
+			report.RemainingImports = refreshed.MissingImports
+		}
+	}
+	return report, nil
+}
+
+// fetchRepositoryMIB prova a scaricare il modulo da ciascun repository e restituisce il primo
+// contenuto valido con il suo URL. L'errore riporta il motivo del fallimento per ogni repository.
+func fetchRepositoryMIB(ctx context.Context, client *http.Client, repositories []string, moduleName string) (string, []byte, error) {
+	failures := make([]string, 0, len(repositories))
+	for _, repository := range repositories {
+		source := repository + "/" + url.PathEscape(moduleName) + ".mib"
+		content, err := downloadMIB(ctx, client, source)
+		if err == nil {
+			err = validateDownloadedMIB(content, moduleName)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", source, err))
+			continue
+		}
+		return source, content, nil
+	}
+	return "", nil, fmt.Errorf("%s not found in any repository (%s)", moduleName, strings.Join(failures, "; "))
+}
+
+// downloadMIB scarica un MIB rifiutando risposte diverse da 200 e contenuti oltre mibDownloadMaxSize.
+func downloadMIB(ctx context.Context, client *http.Client, source string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %s", response.Status)
+	}
+	if response.ContentLength > mibDownloadMaxSize {
+		return nil, fmt.Errorf("file too large: %d bytes (max %d)", response.ContentLength, mibDownloadMaxSize)
+	}
+	content, err := io.ReadAll(io.LimitReader(response.Body, mibDownloadMaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > mibDownloadMaxSize {
+		return nil, fmt.Errorf("file too large (max %d bytes)", mibDownloadMaxSize)
+	}
+	return content, nil
+}
+
+// validateDownloadedMIB verifica che il contenuto scaricato sia la definizione del modulo richiesto,
+// così che una pagina d'errore servita con stato 200 non venga salvata come MIB.
+func validateDownloadedMIB(content []byte, moduleName string) error {
+	if len(content) == 0 {
+		return fmt.Errorf("empty file")
+	}
+	header := regexp.MustCompile(`(?m)^\s*` + regexp.QuoteMeta(moduleName) + `\s+DEFINITIONS\s*::=\s*BEGIN`)
+	if !header.Match(content) {
+		return fmt.Errorf("not a MIB definition of %s", moduleName)
+	}
+	return nil
+}
+
+// newMIBRepositoryClient crea il client HTTP dei download: i redirect sono ammessi solo verso gli
+// host dei repository configurati e in http/https.
+func newMIBRepositoryClient(repositories []string) *http.Client {
+	allowedHosts := make(map[string]struct{}, len(repositories))
+	for _, repository := range repositories {
+		if parsed, err := url.Parse(repository); err == nil {
+			allowedHosts[strings.ToLower(parsed.Host)] = struct{}{}
+		}
+	}
+
+	return &http.Client{
+		Timeout: mibDownloadTimeout,
+		CheckRedirect: func(request *http.Request, via []*http.Request) error {
+			if len(via) >= mibMaxRedirects {
+				return fmt.Errorf("stopped after %d redirects", mibMaxRedirects)
+			}
+			if request.URL.Scheme != "http" && request.URL.Scheme != "https" {
+				return fmt.Errorf("redirect to unsupported scheme %s refused", request.URL.Scheme)
+			}
+			if _, ok := allowedHosts[strings.ToLower(request.URL.Host)]; !ok {
+				return fmt.Errorf("redirect to %s refused: host is not a configured MIB repository", request.URL.Host)
+			}
+			return nil
+		},
+	}
+}
+
+// normalizeMIBRepositoryURL valida un URL base http/https e lo riporta alla forma senza "/" finale.
+func normalizeMIBRepositoryURL(baseURL string) (string, error) {
+	baseURL = strings.TrimSpace(baseURL)
+	if baseURL == "" {
+		return "", fmt.Errorf("repository URL is required")
+	}
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid repository URL %s: %w", baseURL, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("invalid repository URL %s: only http and https are supported", baseURL)
+	}
+	if parsed.Host == "" {
+		return "", fmt.Errorf("invalid repository URL %s: missing host", baseURL)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", fmt.Errorf("invalid repository URL %s: query and fragment are not allowed", baseURL)
+	}
+	return strings.TrimRight(parsed.String(), "/"), nil
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testDependencyMIB = `-- Test dependency
+TEST-DEP-MIB DEFINITIONS ::= BEGIN
+END
+`
+
+func TestMIBRepositoriesRoundTrip(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+
+	if err := app.AddMIBRepository("https://mibs.example.com/repo/"); err != nil {
+		t.Fatalf("AddMIBRepository() error = %v", err)
+	}
+	if err := app.AddMIBRepository("https://mibs.example.com/repo"); err != nil {
+		t.Fatalf("AddMIBRepository() duplicate error = %v", err)
+	}
+	for _, invalid := range []string{"", "ftp://mibs.example.com", "https://", "https://mibs.example.com/?q=1"} {
+		if err := app.AddMIBRepository(invalid); err == nil {
+			t.Errorf("AddMIBRepository(%q) expected error", invalid)
+		}
+	}
+
+	repositories, err := app.ListMIBRepositories()
+	if err != nil {
+		t.Fatalf("ListMIBRepositories() error = %v", err)
+	}
+	if len(repositories) != 1 || repositories[0] != "https://mibs.example.com/repo" {
+		t.Fatalf("repositories = %v", repositories)
+	}
+
+	if err := app.RemoveMIBRepository("https://mibs.example.com/repo/"); err != nil {
+		t.Fatalf("RemoveMIBRepository() error = %v", err)
+	}
+	if err := app.RemoveMIBRepository("https://mibs.example.com/repo"); err == nil {
+		t.Fatalf("expected error removing an unknown repository")
+	}
+}
+
+func TestFetchMissingImportsRequiresRepositories(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+	if err := app.mibDB.UpdateModuleMetadata("TEST-MIB", 0, []string{"TEST-DEP-MIB"}); err != nil {
+		t.Fatalf("UpdateModuleMetadata() error = %v", err)
+	}
+
+	if _, err := app.FetchMissingImports("TEST-MIB"); err == nil || !strings.Contains(err.Error(), "no MIB repositories") {
+		t.Fatalf("expected missing repositories error, got %v", err)
+	}
+}
+
+func TestFetchRepositoryMIBTriesEachRepository(t *testing.T) {
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testDependencyMIB))
+	}))
+	defer foreign.Close()
+
+	repository := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/empty/TEST-DEP-MIB.mib":
+			http.NotFound(w, r)
+		case "/html/TEST-DEP-MIB.mib":
+			w.Write([]byte("<html>not found</html>"))
+		case "/redirect/TEST-DEP-MIB.mib":
+			http.Redirect(w, r, foreign.URL+"/TEST-DEP-MIB.mib", http.StatusFound)
+		case "/huge/TEST-DEP-MIB.mib":
+			w.Write([]byte(testDependencyMIB + strings.Repeat("-", mibDownloadMaxSize)))
+		case "/moved/TEST-DEP-MIB.mib":
+			http.Redirect(w, r, "/good/TEST-DEP-MIB.mib", http.StatusMovedPermanently)
+		case "/good/TEST-DEP-MIB.mib":
+			w.Write([]byte(testDependencyMIB))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer repository.Close()
+
+	failing := []string{
+		repository.URL + "/empty",
+		repository.URL + "/html",
+		repository.URL + "/redirect",
+		repository.URL + "/huge",
+	}
+	client := newMIBRepositoryClient(failing)
+	if _, _, err := fetchRepositoryMIB(context.Background(), client, failing, "TEST-DEP-MIB"); err == nil {
+		t.Fatalf("expected all repositories to fail")
+	} else {
+		for _, reason := range []string{"404", "not a MIB definition", "refused", "too large"} {
+			if !strings.Contains(err.Error(), reason) {
+				t.Errorf("error %q does not report %q", err, reason)
+			}
+		}
+	}
+
+	repositories := append(failing, repository.URL+"/moved")
+	client = newMIBRepositoryClient(repositories)
+	source, content, err := fetchRepositoryMIB(context.Background(), client, repositories, "TEST-DEP-MIB")
+	if err != nil {
+		t.Fatalf("fetchRepositoryMIB() error = %v", err)
+	}
+	if source != repository.URL+"/moved/TEST-DEP-MIB.mib" || string(content) != testDependencyMIB {
+		t.Fatalf("unexpected download from %s: %q", source, content)
+	}
+}
@@ -0,0 +1,139 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"mib-to-the-future/backend/mib"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// mibSearchDirectoriesMetadataKey è la chiave di app_metadata con le directory MIB aggiunte dall'utente.
+const mibSearchDirectoriesMetadataKey = "mib_search_directories"
+
+// ListMIBSearchDirectories restituisce le directory MIB aggiunte al search path, nell'ordine di ricerca.
+func (a *App) ListMIBSearchDirectories() ([]string, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	directories := []string{}
+	raw, ok, err := db.GetMetadata(mibSearchDirectoriesMetadataKey)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if err := json.Unmarshal([]byte(raw), &directories); err != nil {
+			return nil, fmt.Errorf("invalid MIB search directories: %w", err)
+		}
+	}
+	return directories, nil
+}
+
+// AddMIBSearchDirectory aggiunge in fondo al search path una directory esistente e leggibile.
+// La directory resta configurata ai riavvii e vale subito per i caricamenti successivi.
+func (a *App) AddMIBSearchDirectory(path string) error {
+	directory, err := validateMIBSearchDirectory(path)
+	if err != nil {
+		return err
+	}
+
+	directories, err := a.ListMIBSearchDirectories()
+	if err != nil {
+		return err
+	}
+	for _, existing := range directories {
+		if existing == directory {
+			return nil
+		}
+	}
+	return a.saveMIBSearchDirectories(append(directories, directory))
+}
+
+// RemoveMIBSearchDirectory toglie una directory dal search path. Non ha effetto sui moduli già
+// caricati: vale per i caricamenti successivi.
+func (a *App) RemoveMIBSearchDirectory(path string) error {
+	directories, err := a.ListMIBSearchDirectories()
+	if err != nil {
+		return err
+	}
+
+	target := strings.TrimSpace(path)
+	if absolute, err := filepath.Abs(target); err == nil {
+		target = filepath.Clean(absolute)
+	}
+	remaining := make([]string, 0, len(directories))
+	for _, existing := range directories {
+		if existing != target {
+			remaining = append(remaining, existing)
+		}
+	}
+	if len(remaining) == len(directories) {
+		return fmt.Errorf("MIB search directory %s is not configured", path)
+	}
+	return a.saveMIBSearchDirectories(remaining)
+}
+
+// saveMIBSearchDirectories salva l'elenco e lo applica al search path di gosmi.
+func (a *App) saveMIBSearchDirectories(directories []string) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+
+	raw, err := json.Marshal(directories)
+	if err != nil {
+		return fmt.Errorf("failed to encode MIB search directories: %w", err)
+	}
+	if err := db.SetMetadata(mibSearchDirectoriesMetadataKey, string(raw)); err != nil {
+		return err
+	}
+	mib.SetCustomSearchPaths(directories)
+	return nil
+}
+
+// loadMIBSearchDirectories applica all'avvio le directory salvate, prima dell'inizializzazione di gosmi.
+func (a *App) loadMIBSearchDirectories() {
+	directories, err := a.ListMIBSearchDirectories()
+	if err != nil {
+		if a.ctx != nil {
+			runtime.LogWarning(a.ctx, fmt.Sprintf("Failed to load MIB search directories: %v", err))
+		}
+		return
+	}
+	mib.SetCustomSearchPaths(directories)
+}
+
+// validateMIBSearchDirectory restituisce il percorso assoluto della directory, verificando che
+// esista e che il contenuto sia leggibile.
+func validateMIBSearchDirectory(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", fmt.Errorf("directory path is required")
+	}
+
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid directory path %s: %w", path, err)
+	}
+	absolute = filepath.Clean(absolute)
+
+	info, err := os.Stat(absolute)
+	if err != nil {
+		return "", fmt.Errorf("MIB search directory %s not accessible: %w", absolute, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", absolute)
+	}
+	if _, err := os.ReadDir(absolute); err != nil {
+		return "", fmt.Errorf("MIB search directory %s is not readable: %w", absolute, err)
+	}
+	return absolute, nil
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+)
+
+func TestMIBSearchDirectories(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+	t.Cleanup(func() { mib.SetCustomSearchPaths(nil) })
+
+	shared := t.TempDir()
+	if err := app.AddMIBSearchDirectory(shared); err != nil {
+		t.Fatalf("AddMIBSearchDirectory() error = %v", err)
+	}
+	if err := app.AddMIBSearchDirectory(shared + string(os.PathSeparator)); err != nil {
+		t.Fatalf("AddMIBSearchDirectory() duplicate error = %v", err)
+	}
+
+	directories, err := app.ListMIBSearchDirectories()
+	if err != nil {
+		t.Fatalf("ListMIBSearchDirectories() error = %v", err)
+	}
+	if len(directories) != 1 || directories[0] != filepath.Clean(shared) {
+		t.Fatalf("unexpected directories: %v", directories)
+	}
+
+	if err := app.AddMIBSearchDirectory(filepath.Join(shared, "missing")); err == nil {
+		t.Fatalf("expected a missing directory to be rejected")
+	}
+	file := filepath.Join(shared, "IF-MIB.txt")
+	if err := os.WriteFile(file, []byte("IF-MIB DEFINITIONS ::= BEGIN END"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := app.AddMIBSearchDirectory(file); err == nil {
+		t.Fatalf("expected a file to be rejected as search directory")
+	}
+
+	if err := app.RemoveMIBSearchDirectory(shared); err != nil {
+		t.Fatalf("RemoveMIBSearchDirectory() error = %v", err)
+	}
+	if directories, _ := app.ListMIBSearchDirectories(); len(directories) != 0 {
+		t.Fatalf("expected no directories after removal, got %v", directories)
+	}
+	if err := app.RemoveMIBSearchDirectory(shared); err == nil {
+		t.Fatalf("expected removing an unknown directory to fail")
+	}
+}
@@ -0,0 +1,32 @@
+package app
+
+import "testing"
+
+func TestGetBookmarkFolderPathJoinsNames(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+
+	network, err := app.mibDB.CreateBookmarkFolder("Network", nil)
+	if err != nil {
+		t.Fatalf("CreateBookmarkFolder() error = %v", err)
+	}
+	routers, err := app.mibDB.CreateBookmarkFolder("Routers", &network.ID)
+	if err != nil {
+		t.Fatalf("CreateBookmarkFolder() error = %v", err)
+	}
+	core, err := app.mibDB.CreateBookmarkFolder("Core", &routers.ID)
+	if err != nil {
+		t.Fatalf("CreateBookmarkFolder() error = %v", err)
+	}
+
+	path, err := app.GetBookmarkFolderPath(folderKeyFromID(core.ID))
+	if err != nil {
+		t.Fatalf("GetBookmarkFolderPath() error = %v", err)
+	}
+	if path != "Network / Routers / Core" {
+		t.Fatalf("GetBookmarkFolderPath() = %q, want %q", path, "Network / Routers / Core")
+	}
+
+	if path, err := app.GetBookmarkFolderPath(""); err != nil || path != "" {
+		t.Fatalf("GetBookmarkFolderPath(root) = %q, %v; want empty path", path, err)
+	}
+}
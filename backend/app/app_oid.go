@@ -16,9 +16,21 @@ func normalizeOIDKey(oid string) string {
 	for strings.HasPrefix(key, ".") {
 		key = strings.TrimPrefix(key, ".")
 	}
+	// Gli OID con più di mib.MaxOIDArcs componenti sono trattati come non validi (chiave vuota).
+	if strings.Count(key, ".") >= mib.MaxOIDArcs {
+		return ""
+	}
 	return key
 }
 
+// validateOIDInput rifiuta subito gli OID malformati ricevuti dal frontend, prima di contattare agent o database.
+func validateOIDInput(oid string) error {
+	if err := mib.ValidateOID(oid); err != nil {
+		return err
+	}
+	return nil
+}
+
 // splitSegments divide un OID nei suoi segmenti numerici.
 func splitSegments(oid string) []string {
 	norm := normalizeOIDKey(oid)
@@ -28,9 +40,21 @@ func splitSegments(oid string) []string {
 	return strings.Split(norm, ".")
 }
 
+// isOIDWithinSubtree verifica che `oid` sia un discendente stretto di `root`.
+func isOIDWithinSubtree(root, oid string) bool {
+	normalizedRoot := normalizeOIDKey(root)
+	normalizedOID := normalizeOIDKey(oid)
+	if normalizedRoot == "" || normalizedOID == "" {
+		return false
+	}
+	return strings.HasPrefix(normalizedOID, normalizedRoot+".")
+}
+
 // lookupNodeForOID cerca il nodo MIB corrispondente a un OID, usando la cache.
 func (a *App) lookupNodeForOID(oid string) *mib.Node {
-	if a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return nil
 	}
 	normalized := normalizeOIDKey(oid)
@@ -48,7 +72,7 @@ func (a *App) lookupNodeForOID(oid string) *mib.Node {
 	segments := splitSegments(normalized)
 	for len(segments) > 0 {
 		candidate := strings.Join(segments, ".")
-		if node, err := a.mibDB.GetNode(candidate); err == nil && node != nil {
+		if node, err := db.GetNode(candidate); err == nil && node != nil {
 			a.oidNameCacheM.Lock()
 			a.oidNodeCache[normalized] = node
 			a.oidNameCacheM.Unlock()
@@ -63,6 +87,72 @@ func (a *App) lookupNodeForOID(oid string) *mib.Node {
 	return nil
 }
 
+// maxTranslateOIDs limita il numero di OID tradotti da una chiamata a TranslateOIDs.
+const maxTranslateOIDs = 10000
+
+// OIDTranslation associa un OID numerico al nome risolto dalle MIB caricate (es. ifDescr[3]).
+// Name è vuoto se nessuna MIB caricata copre l'OID.
+type OIDTranslation struct {
+	OID  string `json:"oid"`
+	Name string `json:"name"`
+}
+
+// TranslateOIDs traduce gli OID numerici nei nomi simbolici, con la stessa risoluzione usata per i
+// risultati SNMP. I risultati seguono l'ordine degli OID richiesti.
+func (a *App) TranslateOIDs(oids []string) ([]OIDTranslation, error) {
+	if !a.mibDBReady() {
+		return nil, a.mibNotInitializedErr()
+	}
+	if len(oids) == 0 {
+		return nil, fmt.Errorf("at least one OID is required")
+	}
+	if len(oids) > maxTranslateOIDs {
+		return nil, fmt.Errorf("too many OIDs: %d (maximum %d)", len(oids), maxTranslateOIDs)
+	}
+
+	translations := make([]OIDTranslation, len(oids))
+	for i, oid := range oids {
+		if err := validateOIDInput(oid); err != nil {
+			return nil, err
+		}
+		normalized := normalizeOIDKey(oid)
+		translations[i] = OIDTranslation{OID: normalized, Name: a.resolveOIDName(normalized)}
+	}
+	return translations, nil
+}
+
+// GetOIDModule restituisce il modulo MIB che definisce l'OID o, se l'OID non è un nodo noto (es. un'istanza),
+// il suo antenato più vicino. Restituisce una stringa vuota se nessun antenato appartiene a un modulo.
+func (a *App) GetOIDModule(oid string) (string, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return "", a.mibNotInitializedErr()
+	}
+	if err := validateOIDInput(oid); err != nil {
+		return "", err
+	}
+
+	node := a.lookupNodeForOID(oid)
+	if node == nil {
+		return "", nil
+	}
+	if node.Module != "" {
+		return node.Module, nil
+	}
+
+	ancestors, err := db.GetNodeAncestors(node.OID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve ancestors of %s: %w", oid, err)
+	}
+	for _, ancestor := range ancestors {
+		if ancestor != nil && ancestor.Module != "" {
+			return ancestor.Module, nil
+		}
+	}
+	return "", nil
+}
+
 // cacheBaseName memorizza nella cache il nome base di un OID.
 func (a *App) cacheBaseName(key, name string) {
 	if key == "" || name == "" {
@@ -119,7 +209,9 @@ func (a *App) getResolvedName(key string) (string, bool) {
 
 // resolveOIDName risolve un OID numerico nel suo nome simbolico (es. 1.3.6.1.2.1.1.5 -> sysName).
 func (a *App) resolveOIDName(oid string) string {
-	if oid == "" || a.mibDB == nil {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if oid == "" || db == nil {
 		return ""
 	}
 
@@ -198,7 +290,7 @@ func (a *App) resolveOIDName(oid string) string {
 	var lastErr error
 
 	for _, cand := range candidates {
-		node, err := a.mibDB.GetNode(cand.oid)
+		node, err := db.GetNode(cand.oid)
 		if err != nil {
 			lastErr = err
 			continue
@@ -213,7 +305,7 @@ func (a *App) resolveOIDName(oid string) string {
 		return label
 	}
 
-	ancestors, err := a.mibDB.GetNodeAncestors(primaryKey)
+	ancestors, err := db.GetNodeAncestors(primaryKey)
 	if err != nil {
 		lastErr = err
 	} else {
@@ -234,24 +326,32 @@ func (a *App) resolveOIDName(oid string) string {
 		}
 	}
 
-	if lastErr != nil {
+	if lastErr != nil && a.ctx != nil {
 		runtime.LogDebug(a.ctx, fmt.Sprintf("resolveOIDName fallback for %s: %v", primaryKey, lastErr))
 	}
 	a.cacheResolvedName("", primaryKey)
 	return ""
 }
 
-// enrichResult arricchisce un risultato SNMP con il nome risolto dell'OID.
-func (a *App) enrichResult(result *snmp.Result) {
+// enrichResult arricchisce un risultato SNMP con il nome risolto dell'OID e aggiorna il tracking
+// del sysUpTime per l'host che lo ha restituito.
+func (a *App) enrichResult(host string, result *snmp.Result) {
 	if result == nil {
 		return
 	}
+	if result.Host == "" {
+		result.Host = strings.TrimSpace(host)
+	}
 	name := a.resolveOIDName(result.OID)
 	result.ResolvedName = name
 	a.decorateResultValue(result)
+	result.StatusWarning = a.statusWarning(a.lookupNodeForOID(result.OID))
+	a.observeUptime(host, result)
+	a.recordHostHit(host, result)
 }
 
-// decorateResultValue formatta il valore di un risultato SNMP usando le informazioni MIB.
+// decorateResultValue formatta il valore di un risultato SNMP usando le informazioni MIB e, per le
+// tabelle indicizzate per ifIndex, sostituisce l'indice nel nome risolto con il nome dell'interfaccia.
 func (a *App) decorateResultValue(result *snmp.Result) {
 	if result == nil {
 		return
@@ -266,8 +366,19 @@ func (a *App) decorateResultValue(result *snmp.Result) {
 		if node.Syntax != "" {
 			result.Syntax = node.Syntax
 		}
-		if formatted, ok := formatValueWithSyntax(raw, result.Type, node); ok {
+		if formatted, ok := formatValueWithSyntax(raw, result.Type, a.withTypeEnum(node)); ok {
 			result.DisplayValue = formatted
 		}
 	}
+
+	result.TypeMismatch = false
+	result.ExpectedSyntax = ""
+	if node != nil {
+		if expected, mismatch := checkAgentType(node.Syntax, result.Type); mismatch {
+			result.TypeMismatch = true
+			result.ExpectedSyntax = expected
+		}
+	}
+
+	a.decorateInterfaceIndex(result)
 }
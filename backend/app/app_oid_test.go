@@ -1,6 +1,7 @@
 package app
 
 import (
+	"strings"
 	"testing"
 
 	"mib-to-the-future/backend/mib"
@@ -51,7 +52,7 @@ func TestEnrichResultAddsResolvedNameForScalarOID(t *testing.T) {
 		Type:  "OctetString",
 	}
 
-	app.enrichResult(result)
+	app.enrichResult("", result)
 
 	if result.ResolvedName != "sysName" {
 		t.Fatalf("ResolvedName = %q, want %q", result.ResolvedName, "sysName")
@@ -73,7 +74,7 @@ func TestEnrichResultAddsResolvedNameForColumnOID(t *testing.T) {
 		Type:  "OctetString",
 	}
 
-	app.enrichResult(result)
+	app.enrichResult("", result)
 
 	if result.ResolvedName != "ifDescr[10]" {
 		t.Fatalf("ResolvedName = %q, want %q", result.ResolvedName, "ifDescr[10]")
@@ -96,9 +97,126 @@ func TestEnrichResultAddsResolvedNameWithMultipleIndexSegments(t *testing.T) {
 		Type:  "Integer",
 	}
 
-	app.enrichResult(result)
+	app.enrichResult("", result)
 
 	if result.ResolvedName != "metricValue[10.42]" {
 		t.Fatalf("ResolvedName = %q, want %q", result.ResolvedName, "metricValue[10.42]")
 	}
 }
+
+func TestEnrichResultFlagsAgentTypeMismatch(t *testing.T) {
+	app := setupTestAppWithNodes(
+		t,
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1", Name: "ifEntry", Type: "row"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.10", Name: "ifInOctets", Type: "column", Syntax: "Counter32", ParentOID: "1.3.6.1.2.1.2.2.1"},
+	)
+
+	result := &snmp.Result{OID: "1.3.6.1.2.1.2.2.1.10.1", Value: "1234", Type: "Gauge32"}
+	app.enrichResult("", result)
+	if !result.TypeMismatch || result.ExpectedSyntax != "Counter32" {
+		t.Fatalf("expected Gauge32 for Counter32 column to be flagged, got %+v", result)
+	}
+
+	result = &snmp.Result{OID: "1.3.6.1.2.1.2.2.1.10.2", Value: "1234", Type: "Counter32"}
+	app.enrichResult("", result)
+	if result.TypeMismatch || result.ExpectedSyntax != "" {
+		t.Fatalf("expected matching type not to be flagged, got %+v", result)
+	}
+}
+
+func TestIsOIDWithinSubtree(t *testing.T) {
+	tests := []struct {
+		root, oid string
+		want      bool
+	}{
+		{"1.3.6.1.2.1.2.2.1.2", ".1.3.6.1.2.1.2.2.1.2.1", true},
+		{"1.3.6.1.2.1.2.2.1.2", "1.3.6.1.2.1.2.2.1.3.1", false},
+		{"1.3.6.1.2.1.2.2.1.2", "1.3.6.1.2.1.2.2.1.20.1", false},
+		{"1.3.6.1.2.1.2.2.1.2", "1.3.6.1.2.1.2.2.1.2", false},
+	}
+
+	for _, tt := range tests {
+		if got := isOIDWithinSubtree(tt.root, tt.oid); got != tt.want {
+			t.Errorf("isOIDWithinSubtree(%q, %q) = %v, want %v", tt.root, tt.oid, got, tt.want)
+		}
+	}
+}
+
+func TestDeepOIDsAreRejected(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.1", Name: "system", Type: "node"},
+	)
+
+	deep := "1.3.6.1.2.1.1" + strings.Repeat(".1", mib.MaxOIDArcs)
+	if key := normalizeOIDKey(deep); key != "" {
+		t.Fatalf("normalizeOIDKey() = %q, want empty key for deep OID", key)
+	}
+	if segments := splitSegments(deep); segments != nil {
+		t.Fatalf("splitSegments() returned %d segments for deep OID", len(segments))
+	}
+	if name := app.resolveOIDName(deep); name != "" {
+		t.Fatalf("resolveOIDName() = %q, want empty name for deep OID", name)
+	}
+	if err := validateOIDInput(deep); err == nil {
+		t.Fatalf("validateOIDInput() expected error for deep OID")
+	}
+
+	if name := app.resolveOIDName("1.3.6.1.2.1.1"); name != "system" {
+		t.Fatalf("resolveOIDName() = %q, want system", name)
+	}
+}
+
+func TestGetMIBNodeResolvesTypeChain(t *testing.T) {
+	app := setupTestAppWithNodes(
+		t,
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.1", Name: "ifIndex", Type: "column", Syntax: "InterfaceIndex (1..2147483647)"},
+	)
+	if err := app.mibDB.SaveTypeDefinitions([]mib.TypeDefinition{
+		{Module: "IF-MIB", Name: "InterfaceIndex", ParentName: "Integer32", BaseType: "Integer32"},
+	}); err != nil {
+		t.Fatalf("SaveTypeDefinitions() error = %v", err)
+	}
+
+	node, err := app.GetMIBNode("1.3.6.1.2.1.2.2.1.1")
+	if err != nil {
+		t.Fatalf("GetMIBNode() error = %v", err)
+	}
+	if node.ResolvedBaseType != "Integer32" || strings.Join(node.TypeChain, ",") != "InterfaceIndex,Integer32" || node.TypeChainIncomplete {
+		t.Fatalf("unexpected type chain: base %q chain %v incomplete %v", node.ResolvedBaseType, node.TypeChain, node.TypeChainIncomplete)
+	}
+}
+
+func TestGetOIDModuleUsesNearestAncestor(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.4.1.9999", Name: "testVendor", Type: "node"},
+	)
+	ifModule, err := app.mibDB.SaveModule("IF-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule() error = %v", err)
+	}
+	if err := app.mibDB.SaveNode(&mib.Node{OID: "1.3.6.1.2.1.2.2.1.10", Name: "ifInOctets", Type: "column"}, ifModule); err != nil {
+		t.Fatalf("SaveNode() error = %v", err)
+	}
+
+	tests := []struct {
+		oid  string
+		want string
+	}{
+		{oid: "1.3.6.1.2.1.2.2.1.10.3", want: "IF-MIB"},
+		{oid: ".1.3.6.1.4.1.9999.1.2", want: "TEST-MIB"},
+		{oid: "1.0.8802", want: ""},
+	}
+	for _, tt := range tests {
+		got, err := app.GetOIDModule(tt.oid)
+		if err != nil {
+			t.Fatalf("GetOIDModule(%s) error = %v", tt.oid, err)
+		}
+		if got != tt.want {
+			t.Fatalf("GetOIDModule(%s) = %q, want %q", tt.oid, got, tt.want)
+		}
+	}
+
+	if _, err := app.GetOIDModule("not-an-oid"); err == nil {
+		t.Fatalf("expected invalid OID to be rejected")
+	}
+}
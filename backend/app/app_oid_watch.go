@@ -0,0 +1,373 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	// oidChangedEvent è l'evento Wails emesso quando un OID sorvegliato cambia secondo la sua modalità.
+	oidChangedEvent = "oid:changed"
+	// Limiti dell'intervallo di controllo di WatchOID, in secondi.
+	minOIDWatchIntervalSeconds = 5
+	maxOIDWatchIntervalSeconds = 86400
+)
+
+// Modalità di confronto di WatchOID.
+const (
+	watchCompareAnyChange       = "any-change"
+	watchCompareNumericIncrease = "numeric-increase"
+	watchCompareNumericDecrease = "numeric-decrease"
+)
+
+// OIDChangedEvent è il payload dell'evento `oid:changed`, con il valore precedente e quello nuovo
+// sia grezzi sia formattati.
+type OIDChangedEvent struct {
+	WatchID         int64  `json:"watchId"`
+	Host            string `json:"host"`
+	OID             string `json:"oid"`
+	ResolvedName    string `json:"resolvedName,omitempty"`
+	CompareMode     string `json:"compareMode"`
+	OldValue        string `json:"oldValue"`
+	NewValue        string `json:"newValue"`
+	OldDisplayValue string `json:"oldDisplayValue"`
+	NewDisplayValue string `json:"newDisplayValue"`
+	ChangedAt       string `json:"changedAt"`
+}
+
+// scheduledWatch è una sorveglianza in carico allo scheduler con l'istante del prossimo controllo.
+type scheduledWatch struct {
+	watch   mib.OIDWatch
+	nextRun time.Time
+}
+
+// WatchOID sorveglia oid su config.Host ogni intervalSeconds secondi ed emette `oid:changed` quando il
+// valore cambia secondo compareMode: "any-change" (predefinita), "numeric-increase" o "numeric-decrease".
+// Sorveglianza e ultimo valore sono salvati nel database, così riprendono al riavvio; le credenziali
+// sono quelle dell'host salvato. Sorvegliare di nuovo lo stesso OID ne aggiorna intervallo e modalità.
+// Il primo controllo registra il valore di partenza senza emettere eventi.
+func (a *App) WatchOID(config snmp.Config, oid string, intervalSeconds int, compareMode string) (*mib.OIDWatch, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+	if err := validateOIDInput(oid); err != nil {
+		return nil, err
+	}
+	host := strings.TrimSpace(config.Host)
+	if host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+	if intervalSeconds < minOIDWatchIntervalSeconds || intervalSeconds > maxOIDWatchIntervalSeconds {
+		return nil, fmt.Errorf("watch interval must be between %d and %d seconds", minOIDWatchIntervalSeconds, maxOIDWatchIntervalSeconds)
+	}
+	mode, err := normalizeWatchCompareMode(compareMode)
+	if err != nil {
+		return nil, err
+	}
+
+	a.persistHostUsage(config)
+
+	watch, err := db.SaveOIDWatch(mib.OIDWatch{
+		Host:            host,
+		OID:             a.normalizeScalarOID(oid),
+		IntervalSeconds: intervalSeconds,
+		CompareMode:     mode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.scheduleOIDWatches([]mib.OIDWatch{*watch})
+	if a.ctx != nil {
+		runtime.LogInfo(a.ctx, fmt.Sprintf("Watching %s on %s every %ds (%s)", watch.OID, watch.Host, watch.IntervalSeconds, watch.CompareMode))
+	}
+	return watch, nil
+}
+
+// ListOIDWatches restituisce le sorveglianze salvate con l'ultimo valore osservato.
+func (a *App) ListOIDWatches() ([]mib.OIDWatch, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+	return db.ListOIDWatches()
+}
+
+// UnwatchOID interrompe ed elimina una sorveglianza.
+func (a *App) UnwatchOID(id int64) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+
+	if err := db.DeleteOIDWatch(id); err != nil {
+		return err
+	}
+	a.oidWatchM.Lock()
+	delete(a.oidWatches, id)
+	a.oidWatchM.Unlock()
+	return nil
+}
+
+// loadOIDWatches riprende all'avvio le sorveglianze salvate.
+func (a *App) loadOIDWatches() {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return
+	}
+
+	watches, err := db.ListOIDWatches()
+	if err != nil {
+		if a.ctx != nil {
+			runtime.LogWarning(a.ctx, fmt.Sprintf("Failed to load OID watches: %v", err))
+		}
+		return
+	}
+	if len(watches) > 0 {
+		a.scheduleOIDWatches(watches)
+	}
+}
+
+func normalizeWatchCompareMode(mode string) (string, error) {
+	switch normalized := strings.ToLower(strings.TrimSpace(mode)); normalized {
+	case "":
+		return watchCompareAnyChange, nil
+	case watchCompareAnyChange, watchCompareNumericIncrease, watchCompareNumericDecrease:
+		return normalized, nil
+	default:
+		return "", fmt.Errorf("unsupported compare mode %q", mode)
+	}
+}
+
+// scheduleOIDWatches affida le sorveglianze allo scheduler, con il primo controllo immediato, e lo
+// avvia se non è in esecuzione. Tutte le sorveglianze condividono un'unica goroutine.
+func (a *App) scheduleOIDWatches(watches []mib.OIDWatch) {
+	now := time.Now()
+
+	a.oidWatchM.Lock()
+	defer a.oidWatchM.Unlock()
+	if a.oidWatches == nil {
+		a.oidWatches = make(map[int64]*scheduledWatch)
+	}
+	if a.oidWatchWake == nil {
+		a.oidWatchWake = make(chan struct{}, 1)
+	}
+	for _, watch := range watches {
+		a.oidWatches[watch.ID] = &scheduledWatch{watch: watch, nextRun: now}
+	}
+
+	if a.oidWatchRunning {
+		select {
+		case a.oidWatchWake <- struct{}{}:
+		default:
+		}
+		return
+	}
+	a.oidWatchRunning = true
+	if err := a.goBackground("oid-watch", a.runOIDWatches); err != nil {
+		a.oidWatchRunning = false
+		if a.ctx != nil {
+			runtime.LogWarning(a.ctx, fmt.Sprintf("OID watch scheduler not started: %v", err))
+		}
+	}
+}
+
+// runOIDWatches è il ciclo dello scheduler: a ogni risveglio controlla le sorveglianze scadute e
+// attende la successiva. Termina quando non restano sorveglianze o alla chiusura dell'applicazione.
+func (a *App) runOIDWatches(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	a.oidWatchM.Lock()
+	wake := a.oidWatchWake
+	a.oidWatchM.Unlock()
+
+	for {
+		select {
+		case <-ctx.Done():
+			a.oidWatchM.Lock()
+			a.oidWatchRunning = false
+			a.oidWatchM.Unlock()
+			return
+		case <-timer.C:
+		case <-wake:
+		}
+
+		// Le sorveglianze scadute durante un controllo lento vengono riprese subito.
+		for {
+			due, wait, ok := a.dueOIDWatches(time.Now())
+			if !ok {
+				return
+			}
+			if len(due) == 0 {
+				timer.Reset(wait)
+				break
+			}
+			a.checkOIDWatches(due)
+		}
+	}
+}
+
+// dueOIDWatches restituisce le sorveglianze da controllare a now, pianificandone il controllo
+// successivo, e l'attesa fino alla prossima scadenza. Se non restano sorveglianze segna lo scheduler
+// come fermo e ritorna false.
+func (a *App) dueOIDWatches(now time.Time) ([]mib.OIDWatch, time.Duration, bool) {
+	a.oidWatchM.Lock()
+	defer a.oidWatchM.Unlock()
+
+	if len(a.oidWatches) == 0 {
+		a.oidWatchRunning = false
+		return nil, 0, false
+	}
+
+	var due []mib.OIDWatch
+	var next time.Time
+	for _, scheduled := range a.oidWatches {
+		if !scheduled.nextRun.After(now) {
+			due = append(due, scheduled.watch)
+			scheduled.nextRun = now.Add(time.Duration(scheduled.watch.IntervalSeconds) * time.Second)
+		}
+		if next.IsZero() || scheduled.nextRun.Before(next) {
+			next = scheduled.nextRun
+		}
+	}
+	return due, next.Sub(now), true
+}
+
+// checkOIDWatches legge le sorveglianze scadute con una sola GET per host, interrogando gli host in parallelo.
+func (a *App) checkOIDWatches(due []mib.OIDWatch) {
+	byHost := make(map[string][]mib.OIDWatch)
+	for _, watch := range due {
+		byHost[watch.Host] = append(byHost[watch.Host], watch)
+	}
+
+	var wg sync.WaitGroup
+	for host, watches := range byHost {
+		wg.Add(1)
+		go func(host string, watches []mib.OIDWatch) {
+			defer wg.Done()
+			if err := a.checkHostOIDWatches(host, watches); err != nil && a.ctx != nil {
+				runtime.LogWarning(a.ctx, fmt.Sprintf("OID watch on %s failed: %v", host, err))
+			}
+		}(host, watches)
+	}
+	wg.Wait()
+}
+
+// checkHostOIDWatches esegue la GET degli OID sorvegliati su un host, salva i nuovi valori ed emette
+// `oid:changed` per quelli che soddisfano la modalità di confronto.
+func (a *App) checkHostOIDWatches(host string, watches []mib.OIDWatch) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+
+	saved, err := db.GetHost(host)
+	if err != nil {
+		return err
+	}
+	if saved == nil {
+		return fmt.Errorf("no saved configuration for host")
+	}
+	config := hostConfigToSNMP(saved)
+
+	oids := make([]string, len(watches))
+	for i, watch := range watches {
+		oids[i] = watch.OID
+	}
+	var results []snmp.Result
+	if _, err := a.runWithVersionFallback(config, func(client *snmp.Client) error {
+		var opErr error
+		results, opErr = client.GetMany(oids)
+		return opErr
+	}); err != nil {
+		return err
+	}
+
+	byOID := make(map[string]*snmp.Result, len(results))
+	for i := range results {
+		byOID[normalizeOIDKey(results[i].OID)] = &results[i]
+	}
+
+	now := time.Now()
+	for _, watch := range watches {
+		result, ok := byOID[normalizeOIDKey(watch.OID)]
+		if !ok || isMissingInstanceType(result.Type) {
+			continue
+		}
+		a.enrichResult(host, result)
+		value, display := result.Value, pollDisplayValue(*result)
+
+		if err := db.UpdateOIDWatchValue(watch.ID, value, display, now); err != nil {
+			return err
+		}
+		a.oidWatchM.Lock()
+		if scheduled, exists := a.oidWatches[watch.ID]; exists {
+			scheduled.watch.LastValue = value
+			scheduled.watch.LastDisplayValue = display
+			scheduled.watch.LastCheckedAt = now.UTC().Format(time.RFC3339)
+		}
+		a.oidWatchM.Unlock()
+
+		if watch.LastCheckedAt == "" || !watchValueChanged(watch.CompareMode, watch.LastValue, value) {
+			continue
+		}
+		event := OIDChangedEvent{
+			WatchID:         watch.ID,
+			Host:            host,
+			OID:             watch.OID,
+			ResolvedName:    result.ResolvedName,
+			CompareMode:     watch.CompareMode,
+			OldValue:        watch.LastValue,
+			NewValue:        value,
+			OldDisplayValue: watch.LastDisplayValue,
+			NewDisplayValue: display,
+			ChangedAt:       now.Format(time.RFC3339),
+		}
+		if a.ctx != nil {
+			runtime.LogInfo(a.ctx, fmt.Sprintf("%s on %s changed: %s -> %s", watch.OID, host, event.OldDisplayValue, event.NewDisplayValue))
+			runtime.EventsEmit(a.ctx, oidChangedEvent, event)
+		}
+	}
+	return nil
+}
+
+// watchValueChanged applica la modalità di confronto. Le modalità numeriche ignorano i valori non
+// numerici e confrontano in precisione arbitraria, così i Counter64 non perdono cifre.
+func watchValueChanged(mode, previous, current string) bool {
+	if mode == watchCompareAnyChange {
+		return previous != current
+	}
+
+	old, ok := new(big.Rat).SetString(strings.TrimSpace(previous))
+	if !ok {
+		return false
+	}
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(current))
+	if !ok {
+		return false
+	}
+	switch mode {
+	case watchCompareNumericIncrease:
+		return value.Cmp(old) > 0
+	case watchCompareNumericDecrease:
+		return value.Cmp(old) < 0
+	default:
+		return false
+	}
+}
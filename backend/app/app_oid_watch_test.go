@@ -0,0 +1,103 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestWatchValueChanged(t *testing.T) {
+	cases := []struct {
+		mode     string
+		previous string
+		current  string
+		want     bool
+	}{
+		{watchCompareAnyChange, "router", "router", false},
+		{watchCompareAnyChange, "router", "switch", true},
+		{watchCompareNumericIncrease, "10", "11", true},
+		{watchCompareNumericIncrease, "11", "10", false},
+		{watchCompareNumericIncrease, "18446744073709551614", "18446744073709551615", true},
+		{watchCompareNumericDecrease, "11", "10", true},
+		{watchCompareNumericDecrease, "10", "10", false},
+		{watchCompareNumericDecrease, "10", "n/a", false},
+	}
+	for _, tc := range cases {
+		if got := watchValueChanged(tc.mode, tc.previous, tc.current); got != tc.want {
+			t.Errorf("watchValueChanged(%s, %q, %q) = %v, want %v", tc.mode, tc.previous, tc.current, got, tc.want)
+		}
+	}
+
+	if _, err := normalizeWatchCompareMode("sometimes"); err == nil {
+		t.Fatalf("expected an unknown compare mode to be rejected")
+	}
+}
+
+func TestWatchOIDPersistsLastValue(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.1.5", Name: "sysName", Type: "scalar", Syntax: "DisplayString", Access: "read-write"},
+	)
+	if err := app.mibDB.EnsureHostConfigSchema(); err != nil {
+		t.Fatalf("EnsureHostConfigSchema error: %v", err)
+	}
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.1.5.0": {Type: gosnmp.OctetString, Value: []byte("router")},
+	})
+
+	if _, err := app.WatchOID(agent.config(), "1.3.6.1.2.1.1.5.0", 1, ""); err == nil {
+		t.Fatalf("expected an interval below the minimum to be rejected")
+	}
+
+	watch, err := app.WatchOID(agent.config(), "1.3.6.1.2.1.1.5.0", minOIDWatchIntervalSeconds, "")
+	if err != nil {
+		t.Fatalf("WatchOID() error = %v", err)
+	}
+	if watch.CompareMode != watchCompareAnyChange {
+		t.Fatalf("compare mode = %q, want %q", watch.CompareMode, watchCompareAnyChange)
+	}
+
+	// Il primo controllo parte subito e registra il valore di partenza.
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		watches, err := app.ListOIDWatches()
+		if err != nil {
+			t.Fatalf("ListOIDWatches() error = %v", err)
+		}
+		if len(watches) == 1 && watches[0].LastCheckedAt != "" {
+			*watch = watches[0]
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("watch was not checked: %+v", watches)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if watch.LastDisplayValue != "router" || watch.LastValue == "" {
+		t.Fatalf("unexpected baseline value: %+v", watch)
+	}
+
+	if _, err := app.SNMPSet(agent.config(), "1.3.6.1.2.1.1.5.0", "string", "switch"); err != nil {
+		t.Fatalf("SNMPSet() error = %v", err)
+	}
+	if err := app.checkHostOIDWatches(watch.Host, []mib.OIDWatch{*watch}); err != nil {
+		t.Fatalf("checkHostOIDWatches() error = %v", err)
+	}
+	watches, err := app.ListOIDWatches()
+	if err != nil {
+		t.Fatalf("ListOIDWatches() error = %v", err)
+	}
+	if watches[0].LastDisplayValue != "switch" || watches[0].LastValue == watch.LastValue {
+		t.Fatalf("unexpected last value: %+v", watches[0])
+	}
+
+	if err := app.UnwatchOID(watch.ID); err != nil {
+		t.Fatalf("UnwatchOID() error = %v", err)
+	}
+	if watches, _ := app.ListOIDWatches(); len(watches) != 0 {
+		t.Fatalf("expected no watches after UnwatchOID, got %d", len(watches))
+	}
+	app.Shutdown(nil)
+}
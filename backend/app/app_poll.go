@@ -0,0 +1,172 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"mib-to-the-future/backend/snmp"
+)
+
+// maxPollIntervalSeconds limita l'attesa tra i due campioni di PollOIDs.
+const maxPollIntervalSeconds = 3600
+
+// PollResult riporta i due campioni di un OID letti da PollOIDs.
+// Delta e Rate sono valorizzati solo per i contatori (Counter32/Counter64).
+type PollResult struct {
+	OID          string  `json:"oid"`
+	ResolvedName string  `json:"resolvedName,omitempty"`
+	T0Value      string  `json:"t0Value"`
+	T1Value      string  `json:"t1Value"`
+	Delta        string  `json:"delta,omitempty"`
+	Rate         float64 `json:"rate"`
+}
+
+// PollOIDs esegue due GET degli OID indicati a distanza di intervalSec secondi (polling manuale one-shot).
+// Per i nodi con sintassi Counter32/Counter64 calcola differenza e velocità al secondo, gestendo il wrap
+// del contatore; per gli altri tipi riporta solo i due valori.
+func (a *App) PollOIDs(config snmp.Config, oids []string, intervalSec int) ([]PollResult, error) {
+	if len(oids) == 0 {
+		return nil, fmt.Errorf("at least one OID is required")
+	}
+	if intervalSec < 1 || intervalSec > maxPollIntervalSeconds {
+		return nil, fmt.Errorf("interval must be between 1 and %d seconds", maxPollIntervalSeconds)
+	}
+
+	normalized := make([]string, len(oids))
+	for i, oid := range oids {
+		if err := validateOIDInput(oid); err != nil {
+			return nil, err
+		}
+		normalized[i] = a.normalizeScalarOID(oid)
+	}
+
+	a.persistHostUsage(config)
+
+	first, second, elapsed, err := a.pollTwice(config, normalized, intervalSec)
+	if err != nil {
+		return nil, err
+	}
+	return buildPollResults(first, second, elapsed, a.counterBits), nil
+}
+
+// pollTwice legge gli OID due volte a distanza di intervalSec secondi e restituisce i due campioni,
+// già arricchiti, con il tempo effettivamente trascorso tra le due letture.
+func (a *App) pollTwice(config snmp.Config, oids []string, intervalSec int) ([]snmp.Result, []snmp.Result, time.Duration, error) {
+	sample := func(config snmp.Config) ([]snmp.Result, string, time.Time, error) {
+		var results []snmp.Result
+		start := time.Now()
+		version, err := a.runWithVersionFallback(config, func(client *snmp.Client) error {
+			var opErr error
+			results, opErr = client.GetMany(oids)
+			return opErr
+		})
+		return results, version, start, err
+	}
+
+	first, version, firstAt, err := sample(config)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("first poll failed: %v", err)
+	}
+
+	select {
+	case <-time.After(time.Duration(intervalSec) * time.Second):
+	case <-a.backgroundContext().Done():
+		return nil, nil, 0, fmt.Errorf("polling cancelled")
+	}
+
+	// Il secondo campione usa la versione che ha risposto al primo, evitando un nuovo fallback.
+	config.Version = version
+	second, _, secondAt, err := sample(config)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("second poll failed: %v", err)
+	}
+
+	for i := range first {
+		first[i].Version = version
+		a.enrichResult(config.Host, &first[i])
+	}
+	for i := range second {
+		second[i].Version = version
+		a.enrichResult(config.Host, &second[i])
+	}
+	return first, second, secondAt.Sub(firstAt), nil
+}
+
+// counterBits restituisce l'ampiezza (32 o 64) del contatore a cui appartiene il risultato, 0 se non è un contatore.
+// La sintassi del nodo MIB ha la precedenza sul tipo restituito dall'agent.
+func (a *App) counterBits(result snmp.Result) int {
+	if node := a.lookupNodeForOID(result.OID); node != nil && strings.TrimSpace(node.Syntax) != "" {
+		return counterBitsForType(syntaxBaseName(node.Syntax))
+	}
+	return counterBitsForType(strings.ToLower(strings.TrimSpace(result.Type)))
+}
+
+func counterBitsForType(name string) int {
+	switch {
+	case name == "counter64" || strings.HasSuffix(name, "counter64"):
+		return 64
+	case name == "counter" || name == "counter32" || strings.HasSuffix(name, "counter32"):
+		return 32
+	default:
+		return 0
+	}
+}
+
+// buildPollResults accoppia i due campioni per OID, nell'ordine del primo, calcolando delta e rate dei contatori.
+func buildPollResults(first, second []snmp.Result, elapsed time.Duration, counterBits func(snmp.Result) int) []PollResult {
+	later := make(map[string]snmp.Result, len(second))
+	for _, result := range second {
+		later[normalizeOIDKey(result.OID)] = result
+	}
+
+	results := make([]PollResult, 0, len(first))
+	for _, t0 := range first {
+		key := normalizeOIDKey(t0.OID)
+		poll := PollResult{OID: key, ResolvedName: t0.ResolvedName, T0Value: pollDisplayValue(t0)}
+
+		t1, ok := later[key]
+		if !ok {
+			results = append(results, poll)
+			continue
+		}
+		poll.T1Value = pollDisplayValue(t1)
+
+		if bits := counterBits(t0); bits > 0 && !isMissingInstanceType(t0.Type) && !isMissingInstanceType(t1.Type) {
+			if delta, ok := counterDelta(t0.Value, t1.Value, bits); ok {
+				poll.Delta = strconv.FormatUint(delta, 10)
+				if seconds := elapsed.Seconds(); seconds > 0 {
+					poll.Rate = float64(delta) / seconds
+				}
+			}
+		}
+		results = append(results, poll)
+	}
+	return results
+}
+
+// counterDelta calcola t1-t0 per un contatore a 32 o 64 bit, considerando un eventuale wrap.
+func counterDelta(t0, t1 string, bits int) (uint64, bool) {
+	v0, err := strconv.ParseUint(strings.TrimSpace(t0), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	v1, err := strconv.ParseUint(strings.TrimSpace(t1), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	delta := v1 - v0
+	if bits == 32 {
+		delta &= 0xFFFFFFFF
+	}
+	return delta, true
+}
+
+func pollDisplayValue(result snmp.Result) string {
+	if result.DisplayValue != "" {
+		return result.DisplayValue
+	}
+	return result.Value
+}
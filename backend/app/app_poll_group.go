@@ -0,0 +1,130 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ListPollGroups restituisce i gruppi di polling salvati, ordinati per nome.
+func (a *App) ListPollGroups() ([]mib.PollGroup, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	groups, err := db.ListPollGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list poll groups: %w", err)
+	}
+	return groups, nil
+}
+
+// SavePollGroup crea o aggiorna un gruppo di polling sostituendone l'elenco di OID.
+func (a *App) SavePollGroup(name string, oids []string) (*mib.PollGroup, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	group, err := db.SavePollGroup(name, oids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save poll group: %w", err)
+	}
+
+	if a.ctx != nil {
+		runtime.LogInfo(a.ctx, fmt.Sprintf("Saved poll group: %s (%d OIDs)", group.Name, len(group.OIDs)))
+	}
+	return group, nil
+}
+
+// RenamePollGroup rinomina un gruppo di polling esistente.
+func (a *App) RenamePollGroup(oldName string, newName string) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+
+	if err := db.RenamePollGroup(oldName, newName); err != nil {
+		return err
+	}
+
+	if a.ctx != nil {
+		runtime.LogInfo(a.ctx, fmt.Sprintf("Renamed poll group %s to %s", oldName, strings.TrimSpace(newName)))
+	}
+	return nil
+}
+
+// DeletePollGroup elimina un gruppo di polling.
+func (a *App) DeletePollGroup(name string) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+
+	if err := db.DeletePollGroup(name); err != nil {
+		return fmt.Errorf("failed to delete poll group: %w", err)
+	}
+
+	if a.ctx != nil {
+		runtime.LogInfo(a.ctx, fmt.Sprintf("Deleted poll group: %s", name))
+	}
+	return nil
+}
+
+// RunPollGroup esegue un GET di tutti gli OID del gruppo indicato.
+// Parametri:
+//   - config: la configurazione per la connessione SNMP.
+//   - groupName: il nome del gruppo di polling.
+//
+// Ritorna i risultati arricchiti nell'ordine degli OID del gruppo.
+func (a *App) RunPollGroup(config snmp.Config, groupName string) ([]snmp.Result, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	group, err := db.GetPollGroup(groupName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load poll group: %w", err)
+	}
+	if group == nil {
+		return nil, fmt.Errorf("poll group %q not found", groupName)
+	}
+	if len(group.OIDs) == 0 {
+		return []snmp.Result{}, nil
+	}
+
+	oids := make([]string, len(group.OIDs))
+	for i, oid := range group.OIDs {
+		oids[i] = a.normalizeScalarOID(oid)
+	}
+
+	a.persistHostUsage(config)
+
+	var results []snmp.Result
+	version, err := a.runWithVersionFallback(config, func(client *snmp.Client) error {
+		var opErr error
+		results, opErr = client.GetMany(oids)
+		return opErr
+	})
+	if err != nil {
+		return results, fmt.Errorf("poll group %s failed: %v", group.Name, err)
+	}
+
+	for i := range results {
+		results[i].Version = version
+		a.enrichResult(config.Host, &results[i])
+	}
+
+	return results, nil
+}
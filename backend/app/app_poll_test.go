@@ -0,0 +1,82 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+)
+
+func TestCounterDeltaHandlesWrap(t *testing.T) {
+	tests := []struct {
+		name   string
+		t0, t1 string
+		bits   int
+		want   uint64
+	}{
+		{name: "counter32", t0: "100", t1: "350", bits: 32, want: 250},
+		{name: "counter32 wrap", t0: "4294967290", t1: "4", bits: 32, want: 10},
+		{name: "counter64 wrap", t0: "18446744073709551615", t1: "9", bits: 64, want: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := counterDelta(tt.t0, tt.t1, tt.bits)
+			if !ok || got != tt.want {
+				t.Fatalf("counterDelta(%s, %s, %d) = %d, %v; want %d", tt.t0, tt.t1, tt.bits, got, ok, tt.want)
+			}
+		})
+	}
+
+	if _, ok := counterDelta("abc", "1", 32); ok {
+		t.Fatalf("expected non-numeric sample to be rejected")
+	}
+}
+
+func TestPollResultsComputeCounterRates(t *testing.T) {
+	app := setupTestAppWithNodes(
+		t,
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.10", Name: "ifInOctets", Type: "column", Syntax: "Counter32"},
+		&mib.Node{OID: "1.3.6.1.2.1.1.5", Name: "sysName", Type: "scalar", Syntax: "DisplayString (0..255)"},
+	)
+
+	first := []snmp.Result{
+		{OID: ".1.3.6.1.2.1.2.2.1.10.1", Value: "1000", Type: "Counter32"},
+		{OID: ".1.3.6.1.2.1.1.5.0", Value: "router", Type: "OctetString"},
+	}
+	second := []snmp.Result{
+		{OID: ".1.3.6.1.2.1.1.5.0", Value: "router-b", Type: "OctetString"},
+		{OID: ".1.3.6.1.2.1.2.2.1.10.1", Value: "6000", Type: "Counter32"},
+	}
+
+	results := buildPollResults(first, second, 10*time.Second, app.counterBits)
+	if len(results) != 2 {
+		t.Fatalf("results = %d, want 2", len(results))
+	}
+
+	counter := results[0]
+	if counter.OID != "1.3.6.1.2.1.2.2.1.10.1" || counter.T0Value != "1000" || counter.T1Value != "6000" {
+		t.Fatalf("unexpected counter samples: %+v", counter)
+	}
+	if counter.Delta != "5000" || counter.Rate != 500 {
+		t.Fatalf("counter delta/rate = %s/%v, want 5000/500", counter.Delta, counter.Rate)
+	}
+
+	text := results[1]
+	if text.T0Value != "router" || text.T1Value != "router-b" || text.Delta != "" || text.Rate != 0 {
+		t.Fatalf("unexpected non-counter result: %+v", text)
+	}
+}
+
+func TestPollOIDsValidatesInterval(t *testing.T) {
+	app := NewApp()
+	config := snmp.Config{Host: "127.0.0.1"}
+
+	if _, err := app.PollOIDs(config, nil, 5); err == nil {
+		t.Fatalf("expected error without OIDs")
+	}
+	if _, err := app.PollOIDs(config, []string{"1.3.6.1.2.1.1.3.0"}, 0); err == nil {
+		t.Fatalf("expected error for zero interval")
+	}
+}
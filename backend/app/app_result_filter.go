@@ -0,0 +1,112 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+
+	"mib-to-the-future/backend/snmp"
+)
+
+// Operatori supportati da ResultPredicate.
+const (
+	predicateEquals   = "equals"
+	predicateContains = "contains"
+	predicateGreater  = "gt"
+	predicateLess     = "lt"
+)
+
+// ResultPredicate è la condizione applicata da FilterResults. Field sceglie il valore confrontato:
+// "display" (predefinito) usa il valore formattato, "raw" quello restituito dall'agent.
+type ResultPredicate struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+// FilterResults restituisce i risultati che soddisfano il predicato, nell'ordine originale.
+// Il confronto equals e contains ignora maiuscole e minuscole; per i valori enumerati ("down (2)")
+// equals accetta anche la sola etichetta. gt e lt confrontano i valori numerici e scartano quelli
+// non numerici. Con un operatore sconosciuto non viene restituito alcun risultato.
+func (a *App) FilterResults(results []snmp.Result, predicate ResultPredicate) []snmp.Result {
+	filtered := []snmp.Result{}
+
+	match, ok := predicate.matcher()
+	if !ok {
+		return filtered
+	}
+	for _, result := range results {
+		if match(predicate.valueOf(result)) {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
+
+// valueOf restituisce il valore del risultato indicato da Field.
+func (p ResultPredicate) valueOf(result snmp.Result) string {
+	raw := result.RawValue
+	if raw == "" {
+		raw = result.Value
+	}
+	if strings.EqualFold(strings.TrimSpace(p.Field), "raw") {
+		return raw
+	}
+	if result.DisplayValue != "" {
+		return result.DisplayValue
+	}
+	return raw
+}
+
+// matcher prepara il confronto una sola volta, così i walk lunghi non rielaborano il valore atteso.
+func (p ResultPredicate) matcher() (func(value string) bool, bool) {
+	expected := strings.TrimSpace(p.Value)
+
+	switch strings.ToLower(strings.TrimSpace(p.Operator)) {
+	case predicateEquals:
+		return func(value string) bool {
+			value = strings.TrimSpace(value)
+			if strings.EqualFold(value, expected) {
+				return true
+			}
+			label, _, enumerated := strings.Cut(value, " (")
+			return enumerated && strings.HasSuffix(value, ")") && strings.EqualFold(label, expected)
+		}, true
+	case predicateContains:
+		lowered := strings.ToLower(expected)
+		return func(value string) bool {
+			return strings.Contains(strings.ToLower(value), lowered)
+		}, true
+	case predicateGreater, predicateLess:
+		threshold, err := strconv.ParseFloat(expected, 64)
+		if err != nil {
+			return nil, false
+		}
+		greater := strings.EqualFold(strings.TrimSpace(p.Operator), predicateGreater)
+		return func(value string) bool {
+			number, ok := numericResultValue(value)
+			if !ok {
+				return false
+			}
+			if greater {
+				return number > threshold
+			}
+			return number < threshold
+		}, true
+	}
+	return nil, false
+}
+
+// numericResultValue estrae il numero da un valore grezzo ("42") o formattato con il grezzo tra
+// parentesi ("up (1)").
+func numericResultValue(value string) (float64, bool) {
+	value = strings.TrimSpace(value)
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		return number, true
+	}
+	if open := strings.LastIndex(value, " ("); open >= 0 && strings.HasSuffix(value, ")") {
+		if number, err := strconv.ParseFloat(value[open+2:len(value)-1], 64); err == nil {
+			return number, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"testing"
+
+	"mib-to-the-future/backend/snmp"
+)
+
+func TestFilterResults(t *testing.T) {
+	app := NewApp()
+	results := []snmp.Result{
+		{OID: "1.3.6.1.2.1.2.2.1.8.1", Type: "Integer", Value: "1", RawValue: "1", DisplayValue: "up (1)"},
+		{OID: "1.3.6.1.2.1.2.2.1.8.2", Type: "Integer", Value: "2", RawValue: "2", DisplayValue: "down (2)"},
+		{OID: "1.3.6.1.2.1.2.2.1.8.3", Type: "Integer", Value: "2", RawValue: "2", DisplayValue: "down (2)"},
+		{OID: "1.3.6.1.2.1.2.2.1.2.1", Type: "OctetString", Value: "0x65746830", DisplayValue: "eth0"},
+		{OID: "1.3.6.1.2.1.2.2.1.10.1", Type: "Counter32", Value: "1500"},
+	}
+
+	oids := func(filtered []snmp.Result) []string {
+		list := []string{}
+		for _, result := range filtered {
+			list = append(list, result.OID)
+		}
+		return list
+	}
+
+	tests := []struct {
+		name      string
+		predicate ResultPredicate
+		want      []string
+	}{
+		{"enum label", ResultPredicate{Operator: "equals", Value: "DOWN"}, []string{"1.3.6.1.2.1.2.2.1.8.2", "1.3.6.1.2.1.2.2.1.8.3"}},
+		{"raw equals", ResultPredicate{Field: "raw", Operator: "equals", Value: "1"}, []string{"1.3.6.1.2.1.2.2.1.8.1"}},
+		{"contains", ResultPredicate{Operator: "contains", Value: "eth"}, []string{"1.3.6.1.2.1.2.2.1.2.1"}},
+		{"greater than", ResultPredicate{Operator: "gt", Value: "1"}, []string{"1.3.6.1.2.1.2.2.1.8.2", "1.3.6.1.2.1.2.2.1.8.3", "1.3.6.1.2.1.2.2.1.10.1"}},
+		{"less than", ResultPredicate{Field: "raw", Operator: "lt", Value: "2"}, []string{"1.3.6.1.2.1.2.2.1.8.1"}},
+		{"invalid threshold", ResultPredicate{Operator: "gt", Value: "abc"}, []string{}},
+		{"unknown operator", ResultPredicate{Operator: "matches", Value: "x"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := oids(app.FilterResults(results, tt.predicate))
+			if len(got) != len(tt.want) {
+				t.Fatalf("FilterResults() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("FilterResults() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"fmt"
+
+	"mib-to-the-future/backend/mib"
+)
+
+// SearchRequest descrive una ricerca avanzata nel database MIB.
+// SearchIn accetta "name", "oid" e "description" (vuoto equivale a nome e OID);
+// i filtri vuoti vengono ignorati.
+type SearchRequest struct {
+	Query        string   `json:"query"`
+	SearchIn     []string `json:"searchIn"`
+	FilterType   string   `json:"filterType"`
+	FilterAccess string   `json:"filterAccess"`
+	FilterStatus string   `json:"filterStatus"`
+	FilterModule string   `json:"filterModule"`
+	Limit        int      `json:"limit"`
+	Offset       int      `json:"offset"`
+}
+
+// SearchResponse contiene la pagina di risultati e il totale delle corrispondenze.
+type SearchResponse struct {
+	Nodes []*mib.Node `json:"nodes"`
+	Total int         `json:"total"`
+	Query string      `json:"query"`
+}
+
+// SearchMIBNodesAdvanced cerca nodi MIB combinando testo libero sui campi richiesti e filtri
+// su tipo, accesso, stato e modulo, con paginazione tramite Limit/Offset.
+func (a *App) SearchMIBNodesAdvanced(req SearchRequest) (*SearchResponse, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	nodes, total, err := db.SearchNodesAdvanced(mib.NodeSearchQuery{
+		Query:  req.Query,
+		Fields: req.SearchIn,
+		Type:   req.FilterType,
+		Access: req.FilterAccess,
+		Status: req.FilterStatus,
+		Module: req.FilterModule,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %v", err)
+	}
+
+	return &SearchResponse{
+		Nodes: nodes,
+		Total: total,
+		Query: req.Query,
+	}, nil
+}
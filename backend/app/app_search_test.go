@@ -0,0 +1,170 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+)
+
+func setupSearchTestApp(t *testing.T) *App {
+	t.Helper()
+
+	app := setupTestAppWithNodes(
+		t,
+		&mib.Node{OID: "1.3.6.1.2.1.1", Name: "system", Type: "node"},
+		&mib.Node{OID: "1.3.6.1.2.1.1.1", Name: "sysDescr", ParentOID: "1.3.6.1.2.1.1", Type: "scalar", Access: "read-only", Status: "current", Description: "A textual description of the entity."},
+		&mib.Node{OID: "1.3.6.1.2.1.1.4", Name: "sysContact", ParentOID: "1.3.6.1.2.1.1", Type: "scalar", Access: "read-write", Status: "current", Description: "The contact person for this managed node."},
+		&mib.Node{OID: "1.3.6.1.2.1.1.9", Name: "sysORTable", ParentOID: "1.3.6.1.2.1.1", Type: "table", Access: "not-accessible", Status: "current"},
+		&mib.Node{OID: "1.3.6.1.2.1.4.1", Name: "ipForwarding", ParentOID: "1.3.6.1.2.1.4", Type: "scalar", Access: "read-write", Status: "deprecated", Description: "Indicates whether this entity is acting as an IP gateway."},
+	)
+
+	moduleID, err := app.mibDB.SaveModule("IF-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule() error = %v", err)
+	}
+	ifNodes := []*mib.Node{
+		{OID: "1.3.6.1.2.1.2.2", Name: "ifTable", Type: "table", Access: "not-accessible", Status: "current", Description: "A list of interface entries."},
+		{OID: "1.3.6.1.2.1.2.2.1.2", Name: "ifDescr", ParentOID: "1.3.6.1.2.1.2.2.1", Type: "column", Access: "read-only", Status: "current", Description: "A textual string containing information about the interface."},
+		{OID: "1.3.6.1.2.1.2.2.1.7", Name: "ifAdminStatus", ParentOID: "1.3.6.1.2.1.2.2.1", Type: "column", Access: "read-write", Status: "current", Description: "The desired state of the interface."},
+		{OID: "1.3.6.1.2.1.2.2.1.12", Name: "ifInNUcastPkts", ParentOID: "1.3.6.1.2.1.2.2.1", Type: "column", Access: "read-only", Status: "deprecated"},
+	}
+	for _, node := range ifNodes {
+		if err := app.mibDB.SaveNode(node, moduleID); err != nil {
+			t.Fatalf("SaveNode(%s) error = %v", node.OID, err)
+		}
+	}
+
+	return app
+}
+
+func searchResultNames(resp *SearchResponse) []string {
+	names := make([]string, len(resp.Nodes))
+	for i, node := range resp.Nodes {
+		names[i] = node.Name
+	}
+	return names
+}
+
+func TestSearchMIBNodesAdvancedFilterCombinations(t *testing.T) {
+	app := setupSearchTestApp(t)
+
+	cases := []struct {
+		name      string
+		req       SearchRequest
+		wantNames []string
+		wantTotal int
+	}{
+		{
+			name:      "name only",
+			req:       SearchRequest{Query: "Descr"},
+			wantNames: []string{"sysDescr", "ifDescr"},
+			wantTotal: 2,
+		},
+		{
+			// L'ordinamento segue le componenti numeriche dell'OID: .7 precede .12.
+			name:      "oid prefix",
+			req:       SearchRequest{Query: "1.3.6.1.2.1.2.2.1", SearchIn: []string{"oid"}},
+			wantNames: []string{"ifDescr", "ifAdminStatus", "ifInNUcastPkts"},
+			wantTotal: 3,
+		},
+		{
+			name:      "description only",
+			req:       SearchRequest{Query: "interface", SearchIn: []string{"description"}},
+			wantNames: []string{"ifTable", "ifDescr", "ifAdminStatus"},
+			wantTotal: 3,
+		},
+		{
+			name:      "name or description",
+			req:       SearchRequest{Query: "contact", SearchIn: []string{"name", "description"}},
+			wantNames: []string{"sysContact"},
+			wantTotal: 1,
+		},
+		{
+			name:      "type filter without query",
+			req:       SearchRequest{FilterType: "table"},
+			wantNames: []string{"sysORTable", "ifTable"},
+			wantTotal: 2,
+		},
+		{
+			name:      "access filter with query",
+			req:       SearchRequest{Query: "sys", FilterAccess: "read-write"},
+			wantNames: []string{"sysContact"},
+			wantTotal: 1,
+		},
+		{
+			name:      "status filter",
+			req:       SearchRequest{FilterStatus: "deprecated"},
+			wantNames: []string{"ifInNUcastPkts", "ipForwarding"},
+			wantTotal: 2,
+		},
+		{
+			name:      "module filter",
+			req:       SearchRequest{FilterModule: "IF-MIB", FilterType: "column"},
+			wantNames: []string{"ifDescr", "ifAdminStatus", "ifInNUcastPkts"},
+			wantTotal: 3,
+		},
+		{
+			name:      "all filters combined",
+			req:       SearchRequest{Query: "if", SearchIn: []string{"name"}, FilterType: "column", FilterAccess: "read-only", FilterStatus: "current", FilterModule: "IF-MIB"},
+			wantNames: []string{"ifDescr"},
+			wantTotal: 1,
+		},
+		{
+			name:      "like wildcards are literal",
+			req:       SearchRequest{Query: "%", SearchIn: []string{"name", "description"}},
+			wantNames: []string{},
+			wantTotal: 0,
+		},
+		{
+			name:      "pagination keeps total",
+			req:       SearchRequest{FilterAccess: "read-write", Limit: 2, Offset: 1},
+			wantNames: []string{"ifAdminStatus", "ipForwarding"},
+			wantTotal: 3,
+		},
+		{
+			name:      "pagination in oid order",
+			req:       SearchRequest{FilterModule: "IF-MIB", FilterType: "column", Limit: 1, Offset: 2},
+			wantNames: []string{"ifInNUcastPkts"},
+			wantTotal: 3,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := app.SearchMIBNodesAdvanced(tc.req)
+			if err != nil {
+				t.Fatalf("SearchMIBNodesAdvanced() error = %v", err)
+			}
+			if got := searchResultNames(resp); !reflect.DeepEqual(got, tc.wantNames) {
+				t.Fatalf("names = %v, want %v", got, tc.wantNames)
+			}
+			if resp.Total != tc.wantTotal {
+				t.Fatalf("total = %d, want %d", resp.Total, tc.wantTotal)
+			}
+			if resp.Query != tc.req.Query {
+				t.Fatalf("query = %q, want %q", resp.Query, tc.req.Query)
+			}
+		})
+	}
+}
+
+func TestSearchMIBNodesAdvancedRejectsUnknownField(t *testing.T) {
+	app := setupSearchTestApp(t)
+
+	if _, err := app.SearchMIBNodesAdvanced(SearchRequest{Query: "sys", SearchIn: []string{"syntax"}}); err == nil {
+		t.Fatalf("expected error for unsupported search field")
+	}
+}
+
+func TestSearchMIBNodesDelegatesToAdvanced(t *testing.T) {
+	app := setupSearchTestApp(t)
+
+	nodes, err := app.SearchMIBNodes("1.3.6.1.2.1.1.")
+	if err != nil {
+		t.Fatalf("SearchMIBNodes() error = %v", err)
+	}
+	if len(nodes) != 3 {
+		t.Fatalf("expected the three system children, got %d", len(nodes))
+	}
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	// uiSessionSaveEvent chiede al frontend, alla chiusura della finestra, di salvare lo stato corrente
+	// con SaveUISession.
+	uiSessionSaveEvent = "ui-session:save"
+	// uiSessionSaveTimeout limita l'attesa del salvataggio richiesto con uiSessionSaveEvent.
+	uiSessionSaveTimeout = 2 * time.Second
+)
+
+// SaveUISession salva lo stato dell'interfaccia (JSON definito dal frontend, al massimo 256KB).
+// Lo stato resta in memoria anche se la scrittura fallisce e viene ritentato alla chiusura dell'applicazione.
+func (a *App) SaveUISession(state string) error {
+	if err := mib.ValidateUISessionState(state); err != nil {
+		return err
+	}
+
+	a.uiSessionM.Lock()
+	a.uiSession = state
+	a.uiSessionDirty = true
+	a.uiSessionUsed = true
+	a.uiSessionM.Unlock()
+
+	err := a.flushUISession()
+
+	a.uiSessionM.Lock()
+	if a.uiSessionSaved != nil {
+		close(a.uiSessionSaved)
+		a.uiSessionSaved = nil
+	}
+	a.uiSessionM.Unlock()
+	return err
+}
+
+// BeforeClose è l'hook OnBeforeClose di Wails: se il frontend ha già salvato la sessione gli chiede
+// lo stato più recente con uiSessionSaveEvent e ne attende il salvataggio per al massimo
+// uiSessionSaveTimeout, poi scrive l'eventuale stato in sospeso. Non impedisce mai la chiusura.
+func (a *App) BeforeClose(ctx context.Context) bool {
+	if ctx != nil {
+		a.awaitUISessionSave(uiSessionSaveTimeout, func() { runtime.EventsEmit(ctx, uiSessionSaveEvent) })
+	}
+
+	if err := a.flushUISession(); err != nil && ctx != nil {
+		runtime.LogWarning(ctx, fmt.Sprintf("Failed to save UI session before closing: %v", err))
+	}
+	return false
+}
+
+// awaitUISessionSave invoca request e attende la successiva chiamata a SaveUISession, entro timeout.
+// Se il frontend non ha mai salvato una sessione non attende nulla.
+func (a *App) awaitUISessionSave(timeout time.Duration, request func()) {
+	a.uiSessionM.Lock()
+	if !a.uiSessionUsed {
+		a.uiSessionM.Unlock()
+		return
+	}
+	saved := make(chan struct{})
+	a.uiSessionSaved = saved
+	a.uiSessionM.Unlock()
+
+	request()
+
+	select {
+	case <-saved:
+	case <-time.After(timeout):
+		a.uiSessionM.Lock()
+		if a.uiSessionSaved == saved {
+			a.uiSessionSaved = nil
+		}
+		a.uiSessionM.Unlock()
+	}
+}
+
+// LoadUISession restituisce l'ultimo stato dell'interfaccia salvato, o una stringa vuota se assente.
+// Se la versione più recente non è leggibile viene usata quella precedente.
+func (a *App) LoadUISession() (string, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return "", a.mibNotInitializedErr()
+	}
+
+	session, err := db.LoadUISession()
+	if err != nil {
+		return "", fmt.Errorf("failed to load UI session: %w", err)
+	}
+	if session == nil {
+		return "", nil
+	}
+	return string(session.State), nil
+}
+
+// ClearUISession elimina lo stato dell'interfaccia salvato e tutte le versioni precedenti.
+func (a *App) ClearUISession() error {
+	a.uiSessionM.Lock()
+	a.uiSession = ""
+	a.uiSessionDirty = false
+	a.uiSessionM.Unlock()
+
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+	return db.ClearUISession()
+}
+
+// flushUISession scrive lo stato in memoria, se non ancora salvato.
+func (a *App) flushUISession() error {
+	a.uiSessionM.Lock()
+	defer a.uiSessionM.Unlock()
+
+	if !a.uiSessionDirty {
+		return nil
+	}
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+	if err := db.SaveUISession(a.uiSession); err != nil {
+		return fmt.Errorf("failed to save UI session: %w", err)
+	}
+	a.uiSessionDirty = false
+	return nil
+}
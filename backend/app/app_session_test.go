@@ -0,0 +1,82 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUISessionRoundTrip(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+
+	if err := app.SaveUISession(`{"host": "10.0.0.1", "expanded": ["1.3.6.1"]}`); err != nil {
+		t.Fatalf("SaveUISession() error = %v", err)
+	}
+
+	state, err := app.LoadUISession()
+	if err != nil {
+		t.Fatalf("LoadUISession() error = %v", err)
+	}
+	if state != `{"host":"10.0.0.1","expanded":["1.3.6.1"]}` {
+		t.Fatalf("LoadUISession() = %s", state)
+	}
+
+	if err := app.SaveUISession(strings.Repeat("[", 10)); err == nil {
+		t.Fatalf("expected invalid state to be rejected")
+	}
+
+	if err := app.ClearUISession(); err != nil {
+		t.Fatalf("ClearUISession() error = %v", err)
+	}
+	if state, err := app.LoadUISession(); err != nil || state != "" {
+		t.Fatalf("LoadUISession() after clear = %q, %v", state, err)
+	}
+}
+
+func TestFlushUISessionWritesPendingState(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+
+	app.uiSession = `{"tab":"table"}`
+	app.uiSessionDirty = true
+	if err := app.flushUISession(); err != nil {
+		t.Fatalf("flushUISession() error = %v", err)
+	}
+	if app.uiSessionDirty {
+		t.Fatalf("expected session to be clean after flush")
+	}
+
+	state, err := app.LoadUISession()
+	if err != nil || state != `{"tab":"table"}` {
+		t.Fatalf("LoadUISession() = %q, %v", state, err)
+	}
+}
+
+func TestBeforeCloseWaitsForRequestedSession(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+
+	// Senza sessioni salvate dal frontend la chiusura non attende nulla.
+	requested := false
+	app.awaitUISessionSave(time.Second, func() { requested = true })
+	if requested {
+		t.Fatalf("expected no save request before the frontend uses sessions")
+	}
+
+	if err := app.SaveUISession(`{"tab":"tree"}`); err != nil {
+		t.Fatalf("SaveUISession() error = %v", err)
+	}
+	app.awaitUISessionSave(5*time.Second, func() {
+		go app.SaveUISession(`{"tab":"table"}`)
+	})
+	if state, err := app.LoadUISession(); err != nil || state != `{"tab":"table"}` {
+		t.Fatalf("LoadUISession() = %q, %v", state, err)
+	}
+
+	start := time.Now()
+	app.awaitUISessionSave(50*time.Millisecond, func() {})
+	if time.Since(start) > time.Second {
+		t.Fatalf("expected the wait to stop at the timeout")
+	}
+	if app.BeforeClose(nil) {
+		t.Fatalf("BeforeClose must not prevent closing")
+	}
+}
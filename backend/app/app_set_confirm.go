@@ -0,0 +1,150 @@
+package app
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"mib-to-the-future/backend/snmp"
+)
+
+// pendingSetTTL è il tempo entro cui una SET preparata con PrepareSet deve essere confermata.
+const pendingSetTTL = 2 * time.Minute
+
+// SetPreview descrive la SET che verrà eseguita da CommitSet, con il valore attuale letto dall'agent.
+// CurrentValueError è valorizzato se la lettura del valore attuale non è riuscita; la SET resta confermabile.
+type SetPreview struct {
+	Host              string `json:"host"`
+	OID               string `json:"oid"`
+	ResolvedName      string `json:"resolvedName"`
+	Syntax            string `json:"syntax,omitempty"`
+	Access            string `json:"access,omitempty"`
+	ValueType         string `json:"valueType"`
+	Value             string `json:"value"`
+	CurrentValue      string `json:"currentValue,omitempty"`
+	CurrentValueError string `json:"currentValueError,omitempty"`
+	StatusWarning     string `json:"statusWarning,omitempty"`
+}
+
+// PreparedSet è il risultato di PrepareSet: il token da passare a CommitSet entro ExpiresAt (RFC3339).
+type PreparedSet struct {
+	Token     string     `json:"token"`
+	ExpiresAt string     `json:"expiresAt"`
+	Preview   SetPreview `json:"preview"`
+}
+
+// pendingSet è una SET preparata in attesa di conferma.
+type pendingSet struct {
+	config    snmp.Config
+	oid       string
+	valueType string
+	value     interface{}
+	expiresAt time.Time
+}
+
+// PrepareSet valida una SET senza eseguirla e restituisce l'anteprima con il valore attuale e un token
+// monouso valido per pendingSetTTL. La SET viene eseguita solo da CommitSet con quel token; SNMPSet
+// resta disponibile per l'esecuzione immediata. Gli oggetti obsolete sono ammessi: l'anteprima riporta
+// l'avviso e la conferma del token vale come conferma esplicita.
+func (a *App) PrepareSet(config snmp.Config, oid string, valueType string, value interface{}) (*PreparedSet, error) {
+	if err := validateOIDInput(oid); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(config.Host) == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+
+	normalizedOID := a.normalizeScalarOID(oid)
+	node := a.lookupNodeForOID(normalizedOID)
+	resolved, err := resolveEnumSetValue(node, valueType, value)
+	if err != nil {
+		return nil, err
+	}
+	if err := snmp.ValidateSetValue(valueType, resolved); err != nil {
+		return nil, fmt.Errorf("invalid value for %s: %w", normalizedOID, err)
+	}
+
+	preview := SetPreview{
+		Host:          strings.TrimSpace(config.Host),
+		OID:           normalizedOID,
+		ResolvedName:  a.resolveOIDName(normalizedOID),
+		ValueType:     valueType,
+		Value:         fmt.Sprint(value),
+		StatusWarning: a.statusWarning(node),
+	}
+	if node != nil {
+		preview.Syntax = node.Syntax
+		preview.Access = node.Access
+	}
+	if current, err := a.SNMPGet(config, normalizedOID); err != nil {
+		preview.CurrentValueError = err.Error()
+	} else if current != nil {
+		preview.CurrentValue = current.DisplayValue
+		if preview.CurrentValue == "" {
+			preview.CurrentValue = current.Value
+		}
+	}
+
+	token, err := newSetToken()
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(pendingSetTTL)
+
+	a.pendingSetsM.Lock()
+	a.prunePendingSetsLocked(time.Now())
+	if a.pendingSets == nil {
+		a.pendingSets = make(map[string]*pendingSet)
+	}
+	a.pendingSets[token] = &pendingSet{
+		config:    config,
+		oid:       normalizedOID,
+		valueType: valueType,
+		value:     value,
+		expiresAt: expiresAt,
+	}
+	a.pendingSetsM.Unlock()
+
+	return &PreparedSet{Token: token, ExpiresAt: expiresAt.Format(time.RFC3339), Preview: preview}, nil
+}
+
+// CommitSet esegue la SET preparata con PrepareSet. Il token è monouso: viene consumato anche se la
+// SET fallisce. Un token sconosciuto o scaduto è un errore e non produce alcuna scrittura.
+func (a *App) CommitSet(token string) (*snmp.Result, error) {
+	token = strings.TrimSpace(token)
+
+	a.pendingSetsM.Lock()
+	pending, ok := a.pendingSets[token]
+	delete(a.pendingSets, token)
+	a.prunePendingSetsLocked(time.Now())
+	a.pendingSetsM.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("SET token not found or already used")
+	}
+	if time.Now().After(pending.expiresAt) {
+		return nil, fmt.Errorf("SET token expired, prepare the SET again")
+	}
+
+	return a.snmpSet(pending.config, pending.oid, pending.valueType, pending.value, true)
+}
+
+// prunePendingSetsLocked elimina le SET preparate scadute; richiede pendingSetsM.
+func (a *App) prunePendingSetsLocked(now time.Time) {
+	for token, pending := range a.pendingSets {
+		if now.After(pending.expiresAt) {
+			delete(a.pendingSets, token)
+		}
+	}
+}
+
+// newSetToken genera un token casuale non prevedibile per una SET preparata.
+func newSetToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate SET token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
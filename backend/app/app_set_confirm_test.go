@@ -0,0 +1,77 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestPrepareAndCommitSet(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.7", Name: "ifAdminStatus", Type: "column", Syntax: "INTEGER {up(1), down(2), testing(3)}", Access: "read-write"},
+	)
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.2.2.1.7.1": {Type: gosnmp.Integer, Value: 1},
+	})
+
+	prepared, err := app.PrepareSet(agent.config(), "1.3.6.1.2.1.2.2.1.7.1", "integer", "down")
+	if err != nil {
+		t.Fatalf("PrepareSet() error = %v", err)
+	}
+	if prepared.Token == "" || prepared.Preview.ResolvedName != "ifAdminStatus[1]" || prepared.Preview.Value != "down" {
+		t.Fatalf("unexpected prepared SET: %+v", prepared)
+	}
+	if prepared.Preview.CurrentValue != "up (1)" {
+		t.Fatalf("preview current value = %q, want up (1)", prepared.Preview.CurrentValue)
+	}
+	if value, _ := agent.value("1.3.6.1.2.1.2.2.1.7.1"); value.Value != 1 {
+		t.Fatalf("PrepareSet must not write, agent value = %v", value.Value)
+	}
+
+	result, err := app.CommitSet(prepared.Token)
+	if err != nil {
+		t.Fatalf("CommitSet() error = %v", err)
+	}
+	if result == nil {
+		t.Fatalf("CommitSet() returned no result")
+	}
+	if value, _ := agent.value("1.3.6.1.2.1.2.2.1.7.1"); value.Value != 2 {
+		t.Fatalf("agent value after commit = %v, want 2", value.Value)
+	}
+
+	if _, err := app.CommitSet(prepared.Token); err == nil {
+		t.Fatalf("expected a used token to be rejected")
+	}
+	if _, err := app.CommitSet("unknown"); err == nil {
+		t.Fatalf("expected an unknown token to be rejected")
+	}
+}
+
+func TestCommitSetRejectsExpiredToken(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.1.5.0": {Type: gosnmp.OctetString, Value: []byte("router")},
+	})
+
+	if _, err := app.PrepareSet(agent.config(), "1.3.6.1.2.1.1.5.0", "unsigned32", "-1"); err == nil {
+		t.Fatalf("expected PrepareSet to reject an invalid value")
+	}
+
+	prepared, err := app.PrepareSet(agent.config(), "1.3.6.1.2.1.1.5.0", "string", "switch")
+	if err != nil {
+		t.Fatalf("PrepareSet() error = %v", err)
+	}
+	app.pendingSetsM.Lock()
+	app.pendingSets[prepared.Token].expiresAt = time.Now().Add(-time.Second)
+	app.pendingSetsM.Unlock()
+
+	if _, err := app.CommitSet(prepared.Token); err == nil {
+		t.Fatalf("expected an expired token to be rejected")
+	}
+	if value, _ := agent.value("1.3.6.1.2.1.1.5.0"); string(value.Value.([]byte)) != "router" {
+		t.Fatalf("expired SET must not write, agent value = %v", value.Value)
+	}
+}
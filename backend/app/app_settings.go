@@ -0,0 +1,139 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"mib-to-the-future/backend/snmp"
+)
+
+const (
+	// appSettingsMetadataKey è la chiave di app_metadata con le preferenze generali, salvate come unico JSON.
+	appSettingsMetadataKey = "settings"
+	// maxSettingsSearchResults è il massimo di risultati ammesso dalla ricerca nel database MIB.
+	maxSettingsSearchResults = 1000
+)
+
+// AppSettings raccoglie le preferenze generali dell'interfaccia. I valori Default* precompilano la
+// configurazione SNMP dei nuovi host; DefaultTimeout è in secondi. MaxWalkResults e
+// TableAutoRefreshSec a 0 significano rispettivamente nessun limite e aggiornamento disattivato.
+// DateTimeFormat è "locale" per il formato del sistema o un pattern applicato dal frontend.
+// DistributionValueMaxLength è il numero di caratteri oltre il quale GetColumnValueDistribution tronca
+// i valori prima di raggrupparli.
+type AppSettings struct {
+	DefaultSNMPVersion  string `json:"defaultSnmpVersion"`
+	DefaultCommunity    string `json:"defaultCommunity"`
+	DefaultTimeout      int    `json:"defaultTimeout"`
+	DefaultRetries      int    `json:"defaultRetries"`
+	MaxSearchResults    int    `json:"maxSearchResults"`
+	MaxWalkResults      int    `json:"maxWalkResults"`
+	TreeLazyLoad        bool   `json:"treeLazyLoad"`
+	TableAutoRefreshSec int    `json:"tableAutoRefreshSec"`
+	LogLevel            string `json:"logLevel"`
+	DateTimeFormat      string `json:"dateTimeFormat"`
+
+	DistributionValueMaxLength int `json:"distributionValueMaxLength"`
+}
+
+// defaultAppSettings restituisce le preferenze usate finché l'utente non ne salva di proprie.
+func defaultAppSettings() AppSettings {
+	return AppSettings{
+		DefaultSNMPVersion:  "v2c",
+		DefaultCommunity:    "public",
+		DefaultTimeout:      int(snmp.DefaultTimeout.Seconds()),
+		DefaultRetries:      snmp.DefaultRetries,
+		MaxSearchResults:    100,
+		MaxWalkResults:      0,
+		TreeLazyLoad:        true,
+		TableAutoRefreshSec: 0,
+		LogLevel:            "info",
+		DateTimeFormat:      "locale",
+
+		DistributionValueMaxLength: 64,
+	}
+}
+
+// GetAppSettings restituisce le preferenze salvate, o quelle predefinite se non sono mai state salvate.
+// I campi assenti dal JSON salvato (ad esempio aggiunti in versioni successive) mantengono il valore predefinito.
+func (a *App) GetAppSettings() (*AppSettings, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	settings := defaultAppSettings()
+	raw, ok, err := db.GetMetadata(appSettingsMetadataKey)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if err := json.Unmarshal([]byte(raw), &settings); err != nil {
+			return nil, fmt.Errorf("invalid app settings: %w", err)
+		}
+	}
+	return &settings, nil
+}
+
+// SaveAppSettings valida e salva le preferenze generali.
+func (a *App) SaveAppSettings(settings AppSettings) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+
+	normalized, err := normalizeAppSettings(settings)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(normalized)
+	if err != nil {
+		return fmt.Errorf("failed to encode app settings: %w", err)
+	}
+	return db.SetMetadata(appSettingsMetadataKey, string(raw))
+}
+
+// normalizeAppSettings verifica i valori ammessi e riporta versione e livello di log alla forma canonica.
+// Una lunghezza massima dei valori di distribuzione non positiva viene sostituita da quella predefinita.
+func normalizeAppSettings(settings AppSettings) (AppSettings, error) {
+	settings.DefaultSNMPVersion = strings.ToLower(strings.TrimSpace(settings.DefaultSNMPVersion))
+	switch settings.DefaultSNMPVersion {
+	case "v1", "v2c", "v3":
+	default:
+		return settings, fmt.Errorf("invalid default SNMP version %q: must be v1, v2c or v3", settings.DefaultSNMPVersion)
+	}
+	if settings.DefaultTimeout <= 0 {
+		return settings, fmt.Errorf("default timeout must be greater than zero")
+	}
+	if settings.DefaultRetries < 0 {
+		return settings, fmt.Errorf("default retries cannot be negative")
+	}
+	if settings.MaxSearchResults <= 0 || settings.MaxSearchResults > maxSettingsSearchResults {
+		return settings, fmt.Errorf("max search results must be between 1 and %d", maxSettingsSearchResults)
+	}
+	if settings.MaxWalkResults < 0 {
+		return settings, fmt.Errorf("max walk results cannot be negative")
+	}
+	if settings.TableAutoRefreshSec < 0 {
+		return settings, fmt.Errorf("table auto-refresh interval cannot be negative")
+	}
+
+	settings.LogLevel = strings.ToLower(strings.TrimSpace(settings.LogLevel))
+	switch settings.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		return settings, fmt.Errorf("invalid log level %q: must be debug, info, warn or error", settings.LogLevel)
+	}
+
+	settings.DateTimeFormat = strings.TrimSpace(settings.DateTimeFormat)
+	if settings.DateTimeFormat == "" {
+		return settings, fmt.Errorf("date/time format is required")
+	}
+	if settings.DistributionValueMaxLength <= 0 {
+		// Le impostazioni salvate dal frontend prima dell'introduzione del campo lo inviano a zero.
+		settings.DistributionValueMaxLength = defaultAppSettings().DistributionValueMaxLength
+	}
+	return settings, nil
+}
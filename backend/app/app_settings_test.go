@@ -0,0 +1,60 @@
+package app
+
+import "testing"
+
+func TestAppSettingsDefaultsAndSave(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+
+	settings, err := app.GetAppSettings()
+	if err != nil {
+		t.Fatalf("GetAppSettings() error = %v", err)
+	}
+	if *settings != defaultAppSettings() {
+		t.Fatalf("expected defaults, got %+v", settings)
+	}
+
+	updated := *settings
+	updated.DefaultSNMPVersion = " V3 "
+	updated.DefaultTimeout = 10
+	updated.LogLevel = "Debug"
+	updated.TreeLazyLoad = false
+	if err := app.SaveAppSettings(updated); err != nil {
+		t.Fatalf("SaveAppSettings() error = %v", err)
+	}
+
+	settings, err = app.GetAppSettings()
+	if err != nil {
+		t.Fatalf("GetAppSettings() error = %v", err)
+	}
+	if settings.DefaultSNMPVersion != "v3" || settings.DefaultTimeout != 10 || settings.LogLevel != "debug" || settings.TreeLazyLoad {
+		t.Fatalf("unexpected saved settings: %+v", settings)
+	}
+
+	invalid := []func(*AppSettings){
+		func(s *AppSettings) { s.DefaultSNMPVersion = "v4" },
+		func(s *AppSettings) { s.DefaultTimeout = 0 },
+		func(s *AppSettings) { s.DefaultRetries = -1 },
+		func(s *AppSettings) { s.MaxSearchResults = 0 },
+		func(s *AppSettings) { s.MaxSearchResults = maxSettingsSearchResults + 1 },
+		func(s *AppSettings) { s.MaxWalkResults = -1 },
+		func(s *AppSettings) { s.TableAutoRefreshSec = -5 },
+		func(s *AppSettings) { s.LogLevel = "verbose" },
+		func(s *AppSettings) { s.DateTimeFormat = " " },
+	}
+	for i, mutate := range invalid {
+		candidate := defaultAppSettings()
+		mutate(&candidate)
+		if err := app.SaveAppSettings(candidate); err == nil {
+			t.Errorf("case %d: expected validation error for %+v", i, candidate)
+		}
+	}
+
+	candidate := defaultAppSettings()
+	candidate.DistributionValueMaxLength = 0
+	if err := app.SaveAppSettings(candidate); err != nil {
+		t.Fatalf("SaveAppSettings() with zero distribution length error = %v", err)
+	}
+	if settings, err := app.GetAppSettings(); err != nil || settings.DistributionValueMaxLength != 64 {
+		t.Fatalf("expected the default distribution length, got %+v (err %v)", settings, err)
+	}
+}
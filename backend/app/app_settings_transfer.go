@@ -0,0 +1,281 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// settingsExportVersion è la versione del formato prodotto da ExportSettings.
+const settingsExportVersion = 1
+
+// settingsExport è il documento JSON di ExportSettings: Settings associa a ogni chiave di
+// app_metadata il valore effettivo dell'impostazione, predefiniti compresi. I dati salvati in
+// tabelle proprie, come i profili di WALK, hanno una chiave dedicata.
+type settingsExport struct {
+	Version    int                        `json:"version"`
+	ExportedAt string                     `json:"exportedAt"`
+	Settings   map[string]json.RawMessage `json:"settings"`
+}
+
+// transferableSetting descrive un'impostazione esportabile. prepare decodifica e valida il valore
+// importato e restituisce la funzione che lo salva, così l'import non scrive nulla finché tutte le
+// impostazioni non sono state validate.
+type transferableSetting struct {
+	key     string
+	export  func(a *App) (interface{}, error)
+	prepare func(a *App, raw json.RawMessage) (func() error, error)
+}
+
+// transferableSettings elenca, nell'ordine di applicazione, le impostazioni gestite da
+// ExportSettings e ImportSettings.
+var transferableSettings = []transferableSetting{
+	{
+		key:    appSettingsMetadataKey,
+		export: func(a *App) (interface{}, error) { return a.GetAppSettings() },
+		prepare: func(a *App, raw json.RawMessage) (func() error, error) {
+			settings := defaultAppSettings()
+			if err := json.Unmarshal(raw, &settings); err != nil {
+				return nil, err
+			}
+			normalized, err := normalizeAppSettings(settings)
+			if err != nil {
+				return nil, err
+			}
+			return func() error { return a.SaveAppSettings(normalized) }, nil
+		},
+	},
+	{
+		key:    keyboardShortcutsMetadataKey,
+		export: func(a *App) (interface{}, error) { return a.GetKeyboardShortcuts() },
+		prepare: func(a *App, raw json.RawMessage) (func() error, error) {
+			stored := map[string]string{}
+			if err := json.Unmarshal(raw, &stored); err != nil {
+				return nil, err
+			}
+			shortcuts, err := normalizeKeyboardShortcuts(stored)
+			if err != nil {
+				return nil, err
+			}
+			return func() error { return a.saveKeyboardShortcuts(shortcuts) }, nil
+		},
+	},
+	{
+		key:    treePreferencesMetadataKey,
+		export: func(a *App) (interface{}, error) { return a.GetTreePreferences() },
+		prepare: func(a *App, raw json.RawMessage) (func() error, error) {
+			var preferences TreePreferences
+			if err := json.Unmarshal(raw, &preferences); err != nil {
+				return nil, err
+			}
+			normalized, err := normalizeTreePreferences(preferences)
+			if err != nil {
+				return nil, err
+			}
+			return func() error { return a.SetTreePreferences(normalized) }, nil
+		},
+	},
+	{
+		key:    rawDescriptionsMetadataKey,
+		export: func(a *App) (interface{}, error) { return a.GetKeepRawDescriptions() },
+		prepare: func(a *App, raw json.RawMessage) (func() error, error) {
+			var keep bool
+			if err := json.Unmarshal(raw, &keep); err != nil {
+				return nil, err
+			}
+			return func() error { return a.SetKeepRawDescriptions(keep) }, nil
+		},
+	},
+	{
+		key:    repairOrphansMetadataKey,
+		export: func(a *App) (interface{}, error) { return a.GetRepairOrphansOnLoad() },
+		prepare: func(a *App, raw json.RawMessage) (func() error, error) {
+			var enabled bool
+			if err := json.Unmarshal(raw, &enabled); err != nil {
+				return nil, err
+			}
+			return func() error { return a.SetRepairOrphansOnLoad(enabled) }, nil
+		},
+	},
+	{
+		key:    mibSearchDirectoriesMetadataKey,
+		export: func(a *App) (interface{}, error) { return a.ListMIBSearchDirectories() },
+		prepare: func(a *App, raw json.RawMessage) (func() error, error) {
+			var stored []string
+			if err := json.Unmarshal(raw, &stored); err != nil {
+				return nil, err
+			}
+			// Le directory provengono spesso da un'altra macchina: quelle non accessibili qui
+			// vengono saltate invece di bloccare l'import.
+			directories := []string{}
+			for _, path := range stored {
+				directory, err := validateMIBSearchDirectory(path)
+				if err != nil {
+					a.logSettingsImportWarning(fmt.Sprintf("Skipped MIB search directory during settings import: %v", err))
+					continue
+				}
+				directories = appendUnique(directories, directory)
+			}
+			return func() error { return a.saveMIBSearchDirectories(directories) }, nil
+		},
+	},
+	{
+		key:    mibRepositoriesMetadataKey,
+		export: func(a *App) (interface{}, error) { return a.ListMIBRepositories() },
+		prepare: func(a *App, raw json.RawMessage) (func() error, error) {
+			var stored []string
+			if err := json.Unmarshal(raw, &stored); err != nil {
+				return nil, err
+			}
+			repositories := []string{}
+			for _, baseURL := range stored {
+				repository, err := normalizeMIBRepositoryURL(baseURL)
+				if err != nil {
+					return nil, err
+				}
+				repositories = appendUnique(repositories, repository)
+			}
+			return func() error { return a.saveMIBRepositories(repositories) }, nil
+		},
+	},
+	{
+		key:    walkProfilesSettingKey,
+		export: func(a *App) (interface{}, error) { return a.ListWalkProfiles() },
+		prepare: func(a *App, raw json.RawMessage) (func() error, error) {
+			var stored []mib.WalkProfile
+			if err := json.Unmarshal(raw, &stored); err != nil {
+				return nil, err
+			}
+			profiles := make([]mib.WalkProfile, 0, len(stored))
+			for _, profile := range stored {
+				normalized, err := mib.NormalizeWalkProfile(profile)
+				if err != nil {
+					return nil, err
+				}
+				profiles = append(profiles, normalized)
+			}
+			return func() error { return a.importWalkProfiles(profiles) }, nil
+		},
+	},
+	{
+		key:    trapForwardingMetadataKey,
+		export: func(a *App) (interface{}, error) { return a.GetTrapForwardingConfig() },
+		prepare: func(a *App, raw json.RawMessage) (func() error, error) {
+			config := defaultTrapForwardingConfig()
+			if err := json.Unmarshal(raw, &config); err != nil {
+				return nil, err
+			}
+			normalized, err := normalizeTrapForwardingConfig(config)
+			if err != nil {
+				return nil, err
+			}
+			return func() error { return a.SetTrapForwardingConfig(normalized) }, nil
+		},
+	},
+}
+
+// ExportSettings restituisce come JSON tutte le impostazioni dell'applicazione salvate in app_metadata,
+// da conservare come backup o da importare su un'altra macchina con ImportSettings.
+func (a *App) ExportSettings() (string, error) {
+	if !a.mibDBReady() {
+		return "", a.mibNotInitializedErr()
+	}
+
+	document := settingsExport{
+		Version:    settingsExportVersion,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Settings:   make(map[string]json.RawMessage, len(transferableSettings)),
+	}
+	for _, setting := range transferableSettings {
+		value, err := setting.export(a)
+		if err != nil {
+			return "", fmt.Errorf("failed to read setting %s: %w", setting.key, err)
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode setting %s: %w", setting.key, err)
+		}
+		document.Settings[setting.key] = raw
+	}
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode settings: %w", err)
+	}
+	return string(data), nil
+}
+
+// ImportSettings applica le impostazioni esportate con ExportSettings. Le chiavi sconosciute vengono
+// ignorate, quelle assenti restano invariate. Tutti i valori sono validati prima di salvarne uno:
+// se uno non è valido l'import viene rifiutato senza modifiche.
+func (a *App) ImportSettings(data string) error {
+	if !a.mibDBReady() {
+		return a.mibNotInitializedErr()
+	}
+
+	var document settingsExport
+	if err := json.Unmarshal([]byte(data), &document); err != nil {
+		return fmt.Errorf("invalid settings file: %w", err)
+	}
+	if document.Version != settingsExportVersion {
+		return fmt.Errorf("unsupported settings file version %d", document.Version)
+	}
+	if document.Settings == nil {
+		return fmt.Errorf("settings file contains no settings")
+	}
+
+	known := make(map[string]struct{}, len(transferableSettings))
+	apply := make([]func() error, 0, len(transferableSettings))
+	for _, setting := range transferableSettings {
+		known[setting.key] = struct{}{}
+		raw, ok := document.Settings[setting.key]
+		if !ok {
+			continue
+		}
+		save, err := setting.prepare(a, raw)
+		if err != nil {
+			return fmt.Errorf("invalid setting %s: %w", setting.key, err)
+		}
+		apply = append(apply, save)
+	}
+
+	unknown := []string{}
+	for key := range document.Settings {
+		if _, ok := known[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		a.logSettingsImportWarning(fmt.Sprintf("Ignored unknown settings during import: %s", strings.Join(unknown, ", ")))
+	}
+
+	for _, save := range apply {
+		if err := save(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *App) logSettingsImportWarning(message string) {
+	if a.ctx != nil {
+		runtime.LogWarning(a.ctx, message)
+	}
+}
+
+// appendUnique aggiunge value a values se non è già presente.
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
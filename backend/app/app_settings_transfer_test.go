@@ -0,0 +1,122 @@
+package app
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+)
+
+func TestExportImportSettingsRoundTrip(t *testing.T) {
+	source := setupTestAppWithNodes(t)
+
+	settings := defaultAppSettings()
+	settings.DefaultCommunity = "private"
+	settings.MaxSearchResults = 250
+	if err := source.SaveAppSettings(settings); err != nil {
+		t.Fatalf("SaveAppSettings() error = %v", err)
+	}
+	if err := source.SetKeyboardShortcut("search", "ctrl+shift+f"); err != nil {
+		t.Fatalf("SetKeyboardShortcut() error = %v", err)
+	}
+	if err := source.SetKeepRawDescriptions(true); err != nil {
+		t.Fatalf("SetKeepRawDescriptions() error = %v", err)
+	}
+	if err := source.SetRepairOrphansOnLoad(false); err != nil {
+		t.Fatalf("SetRepairOrphansOnLoad() error = %v", err)
+	}
+	if err := source.AddMIBRepository("https://mibs.example.com/asn1/"); err != nil {
+		t.Fatalf("AddMIBRepository() error = %v", err)
+	}
+	if err := source.SetTreePreferences(TreePreferences{Pinned: []string{".1.3.6.1.2.1.1"}}); err != nil {
+		t.Fatalf("SetTreePreferences() error = %v", err)
+	}
+
+	if _, err := source.mibDB.SaveWalkProfile(mib.WalkProfile{Name: "Interfaces", RootOID: "1.3.6.1.2.1.2.2", UseBulk: true, MaxRepetitions: 20, MaxDurationMs: 3000}); err != nil {
+		t.Fatalf("SaveWalkProfile() error = %v", err)
+	}
+
+	exported, err := source.ExportSettings()
+	if err != nil {
+		t.Fatalf("ExportSettings() error = %v", err)
+	}
+
+	// Le directory di un'altra macchina e le chiavi sconosciute non bloccano l'import.
+	var document map[string]interface{}
+	if err := json.Unmarshal([]byte(exported), &document); err != nil {
+		t.Fatalf("exported settings are not valid JSON: %v", err)
+	}
+	values := document["settings"].(map[string]interface{})
+	values[mibSearchDirectoriesMetadataKey] = []string{t.TempDir(), "/missing/mib/directory"}
+	values["window_geometry"] = "800x600"
+	patched, _ := json.Marshal(document)
+
+	target := setupTestAppWithNodes(t)
+	// Un profilo con lo stesso nome viene aggiornato invece di essere duplicato.
+	if _, err := target.mibDB.SaveWalkProfile(mib.WalkProfile{Name: "Interfaces", RootOID: "1.3.6.1.2.1.2"}); err != nil {
+		t.Fatalf("SaveWalkProfile() error = %v", err)
+	}
+	if err := target.ImportSettings(string(patched)); err != nil {
+		t.Fatalf("ImportSettings() error = %v", err)
+	}
+
+	imported, err := target.GetAppSettings()
+	if err != nil {
+		t.Fatalf("GetAppSettings() error = %v", err)
+	}
+	if *imported != settings {
+		t.Fatalf("imported settings = %+v, want %+v", imported, settings)
+	}
+	shortcuts, err := target.GetKeyboardShortcuts()
+	if err != nil || shortcuts["search"] != "Ctrl+Shift+F" {
+		t.Fatalf("unexpected shortcuts %v (err %v)", shortcuts, err)
+	}
+	if keep, err := target.GetKeepRawDescriptions(); err != nil || !keep {
+		t.Fatalf("expected raw descriptions to be kept (err %v)", err)
+	}
+	if repair, err := target.GetRepairOrphansOnLoad(); err != nil || repair {
+		t.Fatalf("expected orphan repair to be disabled (err %v)", err)
+	}
+	if repositories, err := target.ListMIBRepositories(); err != nil || len(repositories) != 1 || repositories[0] != "https://mibs.example.com/asn1" {
+		t.Fatalf("unexpected repositories %v (err %v)", repositories, err)
+	}
+	if preferences, err := target.GetTreePreferences(); err != nil || len(preferences.Pinned) != 1 || preferences.Pinned[0] != "1.3.6.1.2.1.1" {
+		t.Fatalf("unexpected tree preferences %+v (err %v)", preferences, err)
+	}
+	if directories, err := target.ListMIBSearchDirectories(); err != nil || len(directories) != 1 {
+		t.Fatalf("expected only the existing search directory, got %v (err %v)", directories, err)
+	}
+	profiles, err := target.ListWalkProfiles()
+	if err != nil || len(profiles) != 1 {
+		t.Fatalf("expected one imported walk profile, got %+v (err %v)", profiles, err)
+	}
+	if profile := profiles[0]; profile.RootOID != "1.3.6.1.2.1.2.2" || !profile.UseBulk || profile.MaxRepetitions != 20 || profile.MaxDurationMs != 3000 {
+		t.Fatalf("unexpected imported walk profile %+v", profile)
+	}
+}
+
+func TestImportSettingsRejectsInvalidValues(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+
+	invalid := `{"version":1,"settings":{"keep_raw_descriptions":true,"settings":{"defaultSnmpVersion":"v9"}}}`
+	err := app.ImportSettings(invalid)
+	if err == nil || !strings.Contains(err.Error(), "settings") {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if keep, _ := app.GetKeepRawDescriptions(); keep {
+		t.Fatalf("a rejected import must not change any setting")
+	}
+
+	if err := app.ImportSettings(`{"version":2,"settings":{}}`); err == nil {
+		t.Fatalf("expected error for unsupported version")
+	}
+	if err := app.ImportSettings(`not json`); err == nil {
+		t.Fatalf("expected error for malformed input")
+	}
+
+	conflict := `{"version":1,"settings":{"keyboard_shortcuts":{"search":"Ctrl+G"}}}`
+	if err := app.ImportSettings(conflict); err == nil {
+		t.Fatalf("expected error for conflicting shortcuts")
+	}
+}
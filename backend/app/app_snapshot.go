@@ -0,0 +1,266 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// maxSnapshotWalkResults limita i valori raccolti per ogni bookmark di tipo tabella o colonna.
+const maxSnapshotWalkResults = 200
+
+// BookmarkSnapshot è la fotografia dei valori correnti dei bookmark su un host.
+type BookmarkSnapshot struct {
+	Host      string          `json:"host"`
+	Version   string          `json:"version"`
+	Timestamp string          `json:"timestamp"`
+	Root      *SnapshotFolder `json:"root"`
+}
+
+// SnapshotFolder rispecchia una cartella di bookmark con i valori letti.
+type SnapshotFolder struct {
+	Name    string            `json:"name"`
+	Entries []SnapshotEntry   `json:"entries"`
+	Folders []*SnapshotFolder `json:"folders,omitempty"`
+}
+
+// SnapshotEntry riporta un bookmark con i valori ottenuti o lo stato di errore.
+type SnapshotEntry struct {
+	Name        string          `json:"name"`
+	OID         string          `json:"oid"`
+	Description string          `json:"description,omitempty"`
+	Status      string          `json:"status"`
+	Error       string          `json:"error,omitempty"`
+	Truncated   bool            `json:"truncated,omitempty"`
+	Values      []SnapshotValue `json:"values"`
+}
+
+// SnapshotValue è un singolo varbind formattato.
+type SnapshotValue struct {
+	OID   string `json:"oid"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	// Warning riporta l'avviso per gli oggetti deprecated o obsolete.
+	Warning string `json:"warning,omitempty"`
+}
+
+// snapshotFetcher astrae le letture SNMP usate dallo snapshot, così da poterle sostituire nei test.
+type snapshotFetcher struct {
+	get  func(oid string) (*snmp.Result, error)
+	walk func(oid string) ([]snmp.Result, bool, error)
+}
+
+// ExportBookmarkSnapshot interroga tutti i bookmark sull'host indicato (GET per gli scalar, WALK limitato
+// per tabelle e colonne) e salva un report JSON o Markdown, in base all'estensione scelta nel dialogo.
+// Gli OID che falliscono compaiono con il relativo stato di errore. Ritorna il contenuto del report.
+func (a *App) ExportBookmarkSnapshot(config snmp.Config) (string, error) {
+	if !a.mibDBReady() {
+		return "", a.mibNotInitializedErr()
+	}
+	if strings.TrimSpace(config.Host) == "" {
+		return "", fmt.Errorf("host is required")
+	}
+
+	client, err := a.newSNMPClient(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to create SNMP client: %v", err)
+	}
+	a.persistHostUsage(config)
+
+	snapshot, err := a.buildBookmarkSnapshot(config, snapshotFetcher{
+		get: func(oid string) (*snmp.Result, error) {
+			return client.Get(a.normalizeScalarOID(oid))
+		},
+		walk: func(oid string) ([]snmp.Result, bool, error) {
+			return client.WalkN(oid, maxSnapshotWalkResults)
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	jsonData, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode snapshot: %v", err)
+	}
+
+	hostLabel := strings.NewReplacer(":", "-", "/", "-", "\\", "-").Replace(snapshot.Host)
+	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Bookmark Snapshot",
+		DefaultFilename: fmt.Sprintf("bookmarks-%s-%s.json", hostLabel, time.Now().Format("20060102-150405")),
+		Filters: []runtime.FileFilter{
+			{DisplayName: "JSON Files", Pattern: "*.json"},
+			{DisplayName: "Markdown Files", Pattern: "*.md"},
+		},
+	})
+	if err != nil || filePath == "" {
+		return string(jsonData), nil
+	}
+
+	content := string(jsonData)
+	if ext := strings.ToLower(filepath.Ext(filePath)); ext == ".md" || ext == ".markdown" {
+		content = renderSnapshotMarkdown(snapshot)
+	}
+
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write file: %v", err)
+	}
+
+	runtime.LogInfo(a.ctx, fmt.Sprintf("Exported bookmark snapshot of %s to: %s", config.Host, filePath))
+	return content, nil
+}
+
+// buildBookmarkSnapshot percorre la gerarchia dei bookmark leggendo i valori con il fetcher indicato.
+func (a *App) buildBookmarkSnapshot(config snmp.Config, fetcher snapshotFetcher) (*BookmarkSnapshot, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	hierarchy, err := db.GetBookmarkHierarchy()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bookmarks: %w", err)
+	}
+
+	version := strings.ToLower(strings.TrimSpace(config.Version))
+	if version == "" {
+		version = "v2c"
+	}
+
+	return &BookmarkSnapshot{
+		Host:      strings.TrimSpace(config.Host),
+		Version:   version,
+		Timestamp: time.Now().Format(time.RFC3339),
+		Root:      a.snapshotFolder(config.Host, hierarchy, fetcher),
+	}, nil
+}
+
+func (a *App) snapshotFolder(host string, folder *mib.BookmarkFolder, fetcher snapshotFetcher) *SnapshotFolder {
+	result := &SnapshotFolder{Name: folder.Name, Entries: []SnapshotEntry{}}
+	for _, bookmark := range folder.Bookmarks {
+		result.Entries = append(result.Entries, a.snapshotEntry(host, bookmark.OID, fetcher))
+	}
+	for _, child := range folder.Children {
+		result.Folders = append(result.Folders, a.snapshotFolder(host, child, fetcher))
+	}
+	return result
+}
+
+func (a *App) snapshotEntry(host string, oid string, fetcher snapshotFetcher) SnapshotEntry {
+	entry := SnapshotEntry{OID: oid, Name: oid, Status: "success", Values: []SnapshotValue{}}
+
+	node := a.lookupNodeForOID(oid)
+	if node != nil {
+		entry.Name = node.Name
+		entry.Description = node.Description
+	}
+
+	var results []snmp.Result
+	if node != nil && node.Type != "scalar" && node.Type != "" {
+		walked, truncated, err := fetcher.walk(oid)
+		if err != nil {
+			entry.Status = "error"
+			entry.Error = err.Error()
+			return entry
+		}
+		results = walked
+		entry.Truncated = truncated
+		if len(results) == 0 {
+			entry.Status = "empty"
+		}
+	} else {
+		result, err := fetcher.get(oid)
+		if err != nil {
+			entry.Status = "error"
+			entry.Error = err.Error()
+			return entry
+		}
+		if result != nil {
+			results = []snmp.Result{*result}
+			if isMissingInstanceType(result.Type) {
+				entry.Status = strings.ToLower(result.Type)
+			}
+		}
+	}
+
+	for i := range results {
+		a.enrichResult(host, &results[i])
+		value := results[i].DisplayValue
+		if value == "" {
+			value = results[i].Value
+		}
+		entry.Values = append(entry.Values, SnapshotValue{
+			OID:     normalizeOIDKey(results[i].OID),
+			Name:    results[i].ResolvedName,
+			Type:    results[i].Type,
+			Value:   value,
+			Warning: results[i].StatusWarning,
+		})
+	}
+	return entry
+}
+
+// renderSnapshotMarkdown produce il report Markdown, con una sezione per cartella.
+func renderSnapshotMarkdown(snapshot *BookmarkSnapshot) string {
+	var builder strings.Builder
+	builder.WriteString("# Bookmark snapshot\n\n")
+	fmt.Fprintf(&builder, "- Host: %s\n- SNMP version: %s\n- Timestamp: %s\n", snapshot.Host, snapshot.Version, snapshot.Timestamp)
+
+	var writeFolder func(folder *SnapshotFolder, path []string)
+	writeFolder = func(folder *SnapshotFolder, path []string) {
+		if len(folder.Entries) > 0 {
+			fmt.Fprintf(&builder, "\n## %s\n\n", strings.Join(path, " / "))
+			builder.WriteString("| Name | OID | Value | Description |\n|---|---|---|---|\n")
+			for _, entry := range folder.Entries {
+				description := markdownCell(entry.Description)
+				switch {
+				case entry.Status == "error":
+					fmt.Fprintf(&builder, "| %s | %s | error: %s | %s |\n", markdownCell(entry.Name), entry.OID, markdownCell(entry.Error), description)
+				case len(entry.Values) == 0:
+					fmt.Fprintf(&builder, "| %s | %s | %s | %s |\n", markdownCell(entry.Name), entry.OID, entry.Status, description)
+				default:
+					for i, value := range entry.Values {
+						name := entry.Name
+						if value.Name != "" {
+							name = value.Name
+						}
+						cellDescription := ""
+						if i == 0 {
+							cellDescription = description
+						}
+						cellValue := value.Value
+						if value.Warning != "" {
+							cellValue += " ⚠ " + value.Warning
+						}
+						fmt.Fprintf(&builder, "| %s | %s | %s | %s |\n", markdownCell(name), value.OID, markdownCell(cellValue), cellDescription)
+					}
+					if entry.Truncated {
+						fmt.Fprintf(&builder, "| %s | %s | … (truncated) | |\n", markdownCell(entry.Name), entry.OID)
+					}
+				}
+			}
+		}
+		for _, child := range folder.Folders {
+			writeFolder(child, append(append([]string{}, path...), child.Name))
+		}
+	}
+	writeFolder(snapshot.Root, []string{snapshot.Root.Name})
+
+	return builder.String()
+}
+
+// markdownCell rende un testo sicuro per una cella di tabella Markdown.
+func markdownCell(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	return strings.ReplaceAll(value, "|", `\|`)
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+)
+
+func TestBuildBookmarkSnapshot(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.1", Name: "system", Type: "node"},
+		&mib.Node{OID: "1.3.6.1.2.1.1.5", Name: "sysName", Type: "scalar", Description: "Node name", ParentOID: "1.3.6.1.2.1.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.1.6", Name: "sysLocation", Type: "scalar", ParentOID: "1.3.6.1.2.1.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1", Name: "ifEntry", Type: "row"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.2", Name: "ifDescr", Type: "column", ParentOID: "1.3.6.1.2.1.2.2.1"},
+	)
+
+	folder, err := app.mibDB.CreateBookmarkFolder("Interfaces", nil)
+	if err != nil {
+		t.Fatalf("CreateBookmarkFolder() error = %v", err)
+	}
+	for oid, folderID := range map[string]*int64{
+		"1.3.6.1.2.1.1.5":     nil,
+		"1.3.6.1.2.1.1.6":     nil,
+		"1.3.6.1.2.1.2.2.1.2": &folder.ID,
+	} {
+		if err := app.mibDB.AddBookmark(oid, folderID); err != nil {
+			t.Fatalf("AddBookmark(%s) error = %v", oid, err)
+		}
+	}
+
+	fetcher := snapshotFetcher{
+		get: func(oid string) (*snmp.Result, error) {
+			if strings.HasPrefix(oid, "1.3.6.1.2.1.1.6") {
+				return nil, errors.New("request timeout")
+			}
+			return &snmp.Result{OID: "." + oid + ".0", Type: "OctetString", Value: "core-1"}, nil
+		},
+		walk: func(oid string) ([]snmp.Result, bool, error) {
+			return []snmp.Result{
+				{OID: ".1.3.6.1.2.1.2.2.1.2.1", Type: "OctetString", Value: "eth0"},
+				{OID: ".1.3.6.1.2.1.2.2.1.2.2", Type: "OctetString", Value: "eth1"},
+			}, true, nil
+		},
+	}
+
+	snapshot, err := app.buildBookmarkSnapshot(snmp.Config{Host: "192.0.2.1"}, fetcher)
+	if err != nil {
+		t.Fatalf("buildBookmarkSnapshot() error = %v", err)
+	}
+	if snapshot.Host != "192.0.2.1" || snapshot.Version != "v2c" || snapshot.Timestamp == "" {
+		t.Fatalf("unexpected snapshot header: %+v", snapshot)
+	}
+
+	root := snapshot.Root
+	if len(root.Entries) != 2 || len(root.Folders) != 1 {
+		t.Fatalf("unexpected root folder: %+v", root)
+	}
+	byName := map[string]SnapshotEntry{}
+	for _, entry := range root.Entries {
+		byName[entry.Name] = entry
+	}
+	if entry := byName["sysName"]; entry.Status != "success" || len(entry.Values) != 1 || entry.Values[0].Value != "core-1" || entry.Description != "Node name" {
+		t.Fatalf("unexpected sysName entry: %+v", entry)
+	}
+	if entry := byName["sysLocation"]; entry.Status != "error" || entry.Error != "request timeout" {
+		t.Fatalf("expected failed OID to be reported, got %+v", entry)
+	}
+
+	columns := root.Folders[0]
+	if columns.Name != "Interfaces" || len(columns.Entries) != 1 {
+		t.Fatalf("unexpected folder: %+v", columns)
+	}
+	if entry := columns.Entries[0]; !entry.Truncated || len(entry.Values) != 2 || entry.Values[1].Name != "ifDescr[2]" {
+		t.Fatalf("unexpected column entry: %+v", entry)
+	}
+
+	markdown := renderSnapshotMarkdown(snapshot)
+	for _, want := range []string{
+		"- Host: 192.0.2.1",
+		"- SNMP version: v2c",
+		"## Bookmarks / Interfaces",
+		"| ifDescr[2] | 1.3.6.1.2.1.2.2.1.2.2 | eth1 |  |",
+		"| sysLocation | 1.3.6.1.2.1.1.6 | error: request timeout |",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("markdown report missing %q:\n%s", want, markdown)
+		}
+	}
+}
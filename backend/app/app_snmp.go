@@ -1,38 +1,73 @@
 package app
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
+	"mib-to-the-future/backend/mib"
 	"mib-to-the-future/backend/snmp"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 // SNMPGet esegue un'operazione SNMP GET su un singolo OID, aggiungendo automaticamente l'istanza `.0` per gli scalar.
+// Se l'agent risponde noSuchInstance a un OID senza `.0` non riconosciuto dalle MIB caricate, la GET viene
+// ripetuta con `.0` e il risultato riporta l'OID originale in AutoCorrectedFrom.
 // Parametri:
 //   - config: la configurazione per la connessione SNMP (host, porta, community, versione).
 //   - oid: l'Object Identifier da interrogare.
 //
 // Ritorna un puntatore a snmp.Result in caso di successo, o un errore.
 func (a *App) SNMPGet(config snmp.Config, oid string) (*snmp.Result, error) {
-	normalizedOID := a.normalizeScalarOID(oid)
-
-	client, err := snmp.NewClient(config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create SNMP client: %v", err)
+	if err := validateOIDInput(oid); err != nil {
+		return nil, err
 	}
 
+	normalizedOID := a.normalizeScalarOID(oid)
+
 	a.persistHostUsage(config)
 
-	result, err := client.Get(normalizedOID)
+	var result *snmp.Result
+	version, err := a.runWithVersionFallback(config, func(client *snmp.Client) error {
+		var opErr error
+		result, opErr = client.Get(normalizedOID)
+		if opErr != nil || !a.shouldRetryWithScalarInstance(normalizedOID, result) {
+			return opErr
+		}
+
+		// L'agent non ha l'istanza richiesta: se l'OID è uno scalar senza `.0` la GET viene ripetuta con l'istanza.
+		retried, retryErr := client.Get(appendInstanceSuffix(normalizedOID))
+		if retryErr == nil && retried != nil && !isMissingInstanceType(retried.Type) {
+			retried.AutoCorrectedFrom = normalizedOID
+			result = retried
+		}
+		return nil
+	})
 	if err != nil {
 		return result, fmt.Errorf("SNMP GET failed: %v", err)
 	}
 
-	a.enrichResult(result)
+	result.Version = version
+	a.enrichResult(config.Host, result)
 
 	return result, nil
 }
 
+// maxRequestTimeoutMs limita il timeout per richiesta accettato da SNMPGetWithTimeout.
+const maxRequestTimeoutMs = 120000
+
+// SNMPGetWithTimeout esegue SNMPGet con un timeout per richiesta di timeoutMs millisecondi al posto di
+// quello della configurazione, per gli agent lenti a rispondere.
+func (a *App) SNMPGetWithTimeout(config snmp.Config, oid string, timeoutMs int) (*snmp.Result, error) {
+	if timeoutMs <= 0 || timeoutMs > maxRequestTimeoutMs {
+		return nil, fmt.Errorf("timeout must be between 1 and %d ms", maxRequestTimeoutMs)
+	}
+	config.TimeoutMs = timeoutMs
+	return a.SNMPGet(config, oid)
+}
+
 // SNMPGetNext esegue un'operazione SNMP GETNEXT.
 // Questa operazione richiede l'OID successivo a quello specificato.
 // Parametri:
@@ -41,7 +76,11 @@ func (a *App) SNMPGet(config snmp.Config, oid string) (*snmp.Result, error) {
 //
 // Ritorna un puntatore a snmp.Result in caso di successo, o un errore.
 func (a *App) SNMPGetNext(config snmp.Config, oid string) (*snmp.Result, error) {
-	client, err := snmp.NewClient(config)
+	if err := validateOIDInput(oid); err != nil {
+		return nil, err
+	}
+
+	client, err := a.newSNMPClient(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create SNMP client: %v", err)
 	}
@@ -53,7 +92,43 @@ func (a *App) SNMPGetNext(config snmp.Config, oid string) (*snmp.Result, error)
 		return result, fmt.Errorf("SNMP GETNEXT failed: %v", err)
 	}
 
-	a.enrichResult(result)
+	a.enrichResult(config.Host, result)
+
+	return result, nil
+}
+
+// SNMPGetFirst restituisce la prima istanza presente sotto un OID (tipicamente una colonna di tabella)
+// con un singolo GETNEXT, utile per campionare un valore senza eseguire un WALK completo.
+// Parametri:
+//   - config: la configurazione per la connessione SNMP.
+//   - oid: l'Object Identifier del sottoalbero da campionare.
+//
+// Ritorna il risultato arricchito (il nome risolto riporta l'indice, es. ifDescr[1]) oppure un errore
+// se l'agent risponde con un OID esterno al sottoalbero richiesto.
+func (a *App) SNMPGetFirst(config snmp.Config, oid string) (*snmp.Result, error) {
+	if err := validateOIDInput(oid); err != nil {
+		return nil, err
+	}
+
+	root := normalizeOIDKey(oid)
+
+	client, err := a.newSNMPClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SNMP client: %v", err)
+	}
+
+	a.persistHostUsage(config)
+
+	result, err := client.GetNext(root)
+	if err != nil {
+		return result, fmt.Errorf("SNMP GETNEXT failed: %v", err)
+	}
+
+	if !isOIDWithinSubtree(root, result.OID) || strings.EqualFold(result.Type, "EndOfMibView") {
+		return nil, fmt.Errorf("no instances found under %s", root)
+	}
+
+	a.enrichResult(config.Host, result)
 
 	return result, nil
 }
@@ -64,25 +139,58 @@ func (a *App) SNMPGetNext(config snmp.Config, oid string) (*snmp.Result, error)
 //   - config: la configurazione per la connessione SNMP.
 //   - oid: l'Object Identifier radice del sottoalbero da "camminare".
 //
-// Ritorna una slice di snmp.Result in caso di successo, o un errore.
+// Ritorna una slice di snmp.Result in caso di successo, o un errore. Con config.MaxDurationMs il WALK
+// si ferma allo scadere del tempo e restituisce i risultati parziali (vedi SNMPWalkTimed).
 func (a *App) SNMPWalk(config snmp.Config, oid string) ([]snmp.Result, error) {
-	client, err := snmp.NewClient(config)
+	results, _, err := a.snmpWalk(config, oid)
+	return results, err
+}
+
+// TimedWalkResult è il risultato di un WALK con limite di durata: TimedOut indica che il WALK è stato
+// interrotto allo scadere di MaxDurationMs e che Results è parziale.
+type TimedWalkResult struct {
+	Results  []snmp.Result `json:"results"`
+	TimedOut bool          `json:"timedOut"`
+}
+
+// SNMPWalkTimed esegue un WALK come SNMPWalk, segnalando se è stato interrotto dal limite
+// config.MaxDurationMs, pensato per gli agent che rispondono lentamente senza mai fermarsi.
+func (a *App) SNMPWalkTimed(config snmp.Config, oid string) (*TimedWalkResult, error) {
+	results, timedOut, err := a.snmpWalk(config, oid)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create SNMP client: %v", err)
+		return nil, err
+	}
+	return &TimedWalkResult{Results: results, TimedOut: timedOut}, nil
+}
+
+func (a *App) snmpWalk(config snmp.Config, oid string) ([]snmp.Result, bool, error) {
+	if err := validateOIDInput(oid); err != nil {
+		return nil, false, err
 	}
 
 	a.persistHostUsage(config)
 
-	results, err := client.Walk(oid)
+	var results []snmp.Result
+	timedOut := false
+	version, err := a.runWithVersionFallback(config, func(client *snmp.Client) error {
+		var opErr error
+		results, timedOut, opErr = client.WalkWithContext(a.backgroundContext(), oid, config.MaxDuration())
+		if opErr != nil && len(results) > 0 {
+			// Risultati parziali: l'agent parla la versione richiesta, non ha senso ritentare.
+			return &partialResultsError{err: opErr}
+		}
+		return opErr
+	})
 	if err != nil {
-		return results, fmt.Errorf("SNMP WALK failed: %v", err)
+		return results, false, fmt.Errorf("SNMP WALK failed: %v", err)
 	}
 
 	for i := range results {
-		a.enrichResult(&results[i])
+		results[i].Version = version
+		a.enrichResult(config.Host, &results[i])
 	}
 
-	return results, nil
+	return results, timedOut, nil
 }
 
 // SNMPGetBulk esegue un'operazione SNMP GETBULK, una versione ottimizzata di GETNEXT.
@@ -94,7 +202,11 @@ func (a *App) SNMPWalk(config snmp.Config, oid string) ([]snmp.Result, error) {
 //
 // Ritorna una slice di snmp.Result in caso di successo, o un errore.
 func (a *App) SNMPGetBulk(config snmp.Config, oid string, maxRepetitions uint8) ([]snmp.Result, error) {
-	client, err := snmp.NewClient(config)
+	if err := validateOIDInput(oid); err != nil {
+		return nil, err
+	}
+
+	client, err := a.newSNMPClient(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create SNMP client: %v", err)
 	}
@@ -102,13 +214,69 @@ func (a *App) SNMPGetBulk(config snmp.Config, oid string, maxRepetitions uint8)
 	a.persistHostUsage(config)
 
 	results, err := client.GetBulk(oid, maxRepetitions)
+	a.logDownshifts(config.Host, client)
 	if err != nil {
 		return results, fmt.Errorf("SNMP GETBULK failed: %v", err)
 	}
 
 	for i := range results {
-		a.enrichResult(&results[i])
+		a.enrichResult(config.Host, &results[i])
+	}
+
+	return results, nil
+}
+
+// SNMPGetAllScalars legge con un'unica operazione tutti gli scalar leggibili del modulo indicato,
+// raggruppando le richieste in PDU da al massimo MaxOids varbind. I risultati sono ordinati per OID;
+// gli scalar per cui l'agent risponde NoSuchObject/NoSuchInstance hanno Status "not-supported".
+func (a *App) SNMPGetAllScalars(config snmp.Config, moduleName string) ([]snmp.Result, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	moduleName = strings.TrimSpace(moduleName)
+	if moduleName == "" {
+		return nil, fmt.Errorf("module name is required")
+	}
+
+	scalars, err := db.GetModuleScalars(moduleName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scalars of %s: %v", moduleName, err)
+	}
+	if len(scalars) == 0 {
+		return []snmp.Result{}, nil
+	}
+
+	oids := make([]string, len(scalars))
+	for i, scalar := range scalars {
+		oids[i] = appendInstanceSuffix(scalar.OID)
+	}
+
+	a.persistHostUsage(config)
+
+	var results []snmp.Result
+	version, err := a.runWithVersionFallback(config, func(client *snmp.Client) error {
+		var opErr error
+		results, opErr = client.GetMany(oids)
+		a.logDownshifts(config.Host, client)
+		return opErr
+	})
+	if err != nil {
+		return results, fmt.Errorf("SNMP GET of %s scalars failed: %v", moduleName, err)
+	}
+
+	for i := range results {
+		results[i].Version = version
+		a.enrichResult(config.Host, &results[i])
+		if isMissingInstanceType(results[i].Type) {
+			results[i].Status = "not-supported"
+		}
 	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return mib.CompareOIDs(results[i].OID, results[j].OID) < 0
+	})
 
 	return results, nil
 }
@@ -118,12 +286,38 @@ func (a *App) SNMPGetBulk(config snmp.Config, oid string, maxRepetitions uint8)
 //   - config: la configurazione per la connessione SNMP.
 //   - oid: l'Object Identifier da modificare.
 //   - valueType: il tipo di dato del valore da impostare (es. "integer", "string").
-//   - value: il valore da impostare.
+//   - value: il valore da impostare; per i nodi enumerati è accettata anche l'etichetta (es. "down").
+//
+// Gli oggetti obsolete vengono rifiutati: per modificarli serve SNMPSetConfirmed con la conferma esplicita.
 //
 // Ritorna un puntatore a snmp.Result con il nuovo valore in caso di successo, o un errore.
 func (a *App) SNMPSet(config snmp.Config, oid string, valueType string, value interface{}) (*snmp.Result, error) {
+	return a.snmpSet(config, oid, valueType, value, false)
+}
+
+// SNMPSetConfirmed esegue una SET come SNMPSet; con confirmObsolete l'utente conferma di voler modificare
+// un oggetto marcato obsolete nel MIB.
+func (a *App) SNMPSetConfirmed(config snmp.Config, oid string, valueType string, value interface{}, confirmObsolete bool) (*snmp.Result, error) {
+	return a.snmpSet(config, oid, valueType, value, confirmObsolete)
+}
+
+func (a *App) snmpSet(config snmp.Config, oid string, valueType string, value interface{}, confirmObsolete bool) (*snmp.Result, error) {
+	if err := validateOIDInput(oid); err != nil {
+		return nil, err
+	}
+
 	normalizedOID := a.normalizeScalarOID(oid)
 
+	node := a.lookupNodeForOID(normalizedOID)
+	if isObsoleteNode(node) && !confirmObsolete {
+		return nil, fmt.Errorf("SET on obsolete object %s requires explicit confirmation", node.Name)
+	}
+
+	value, err := resolveEnumSetValue(node, valueType, value)
+	if err != nil {
+		return nil, err
+	}
+
 	if strings.EqualFold(config.Version, "v3") {
 		config.WriteCommunity = ""
 	} else {
@@ -132,7 +326,7 @@ func (a *App) SNMPSet(config snmp.Config, oid string, valueType string, value in
 		}
 	}
 
-	client, err := snmp.NewClient(config)
+	client, err := a.newSNMPClient(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create SNMP client: %v", err)
 	}
@@ -144,33 +338,110 @@ func (a *App) SNMPSet(config snmp.Config, oid string, valueType string, value in
 		return result, fmt.Errorf("SNMP SET failed: %v", err)
 	}
 
-	a.enrichResult(result)
+	a.enrichResult(config.Host, result)
 
 	return result, nil
 }
 
+// logDownshifts registra quante volte il client ha ridotto la richiesta per risposte tooBig dell'agent.
+func (a *App) logDownshifts(host string, client *snmp.Client) {
+	if a.ctx == nil || client.Downshifts() == 0 {
+		return
+	}
+	runtime.LogInfo(a.ctx, fmt.Sprintf("%s answered tooBig: request reduced %d times", host, client.Downshifts()))
+}
+
+// partialResultsError segnala un errore arrivato dopo aver già ricevuto dati, che esclude il fallback di versione.
+type partialResultsError struct {
+	err error
+}
+
+func (e *partialResultsError) Error() string {
+	return e.err.Error()
+}
+
+func (e *partialResultsError) Unwrap() error {
+	return e.err
+}
+
+// runWithVersionFallback esegue op con un client creato dalla configurazione indicata. Se la configurazione
+// abilita AllowVersionFallback e una richiesta v2c scade senza risposta, ritenta una sola volta in SNMPv1.
+// Ritorna la versione con cui l'operazione è riuscita.
+func (a *App) runWithVersionFallback(config snmp.Config, op func(client *snmp.Client) error) (string, error) {
+	client, err := a.newSNMPClient(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to create SNMP client: %v", err)
+	}
+
+	version := strings.ToLower(strings.TrimSpace(config.Version))
+	if version == "" {
+		version = "v2c"
+	}
+
+	err = op(client)
+	if err == nil {
+		return version, nil
+	}
+
+	var partial *partialResultsError
+	if !config.AllowVersionFallback || version != "v2c" || errors.As(err, &partial) || !isTimeoutError(err) {
+		return "", err
+	}
+
+	fallback := config
+	fallback.Version = "v1"
+	fallbackClient, fallbackErr := a.newSNMPClient(fallback)
+	if fallbackErr != nil {
+		return "", err
+	}
+
+	if a.ctx != nil {
+		runtime.LogInfo(a.ctx, fmt.Sprintf("No v2c response from %s, retrying with SNMPv1", config.Host))
+	}
+
+	if fallbackErr := op(fallbackClient); fallbackErr != nil {
+		return "", err
+	}
+
+	if a.ctx != nil {
+		runtime.LogInfo(a.ctx, fmt.Sprintf("Host %s answered with SNMPv1 after v2c timeout", config.Host))
+	}
+	return "v1", nil
+}
+
+// isTimeoutError indica se l'errore corrisponde a una richiesta scaduta senza risposta dall'agent.
+func isTimeoutError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "timeout")
+}
+
 // normalizeScalarOID garantisce che gli OID relativi a scalar includano l'istanza `.0`.
 // Per gli altri tipi restituisce l'OID ripulito (trim degli spazi) senza modifiche.
 func (a *App) normalizeScalarOID(oid string) string {
+	db, release := a.acquireMIBDB()
+	defer release()
+
 	trimmed := strings.TrimSpace(oid)
 	if trimmed == "" {
 		return trimmed
 	}
 
-	if a.mibDB == nil {
+	if db == nil {
 		return trimmed
 	}
 
 	// Se abbiamo già il suffisso `.0`, verifichiamo che corrisponda a uno scalar
 	if strings.HasSuffix(trimmed, ".0") {
 		base := strings.TrimSuffix(trimmed, ".0")
-		if node, err := a.mibDB.GetNode(base); err == nil && node != nil && strings.EqualFold(node.Type, "scalar") {
+		if node, err := db.GetNode(base); err == nil && node != nil && strings.EqualFold(node.Type, "scalar") {
 			return trimmed
 		}
 		return trimmed
 	}
 
-	node, err := a.mibDB.GetNode(trimmed)
+	node, err := db.GetNode(trimmed)
 	if err != nil || node == nil {
 		return trimmed
 	}
@@ -182,6 +453,31 @@ func (a *App) normalizeScalarOID(oid string) string {
 	return trimmed
 }
 
+// shouldRetryWithScalarInstance indica se una GET terminata con noSuchInstance va ripetuta con `.0`:
+// l'OID non ha già l'istanza e non corrisponde a un nodo MIB noto diverso da uno scalar
+// (senza MIB caricato si assume che possa trattarsi di uno scalar).
+func (a *App) shouldRetryWithScalarInstance(oid string, result *snmp.Result) bool {
+	db, release := a.acquireMIBDB()
+	defer release()
+
+	if result == nil || !strings.EqualFold(result.Type, "NoSuchInstance") {
+		return false
+	}
+	trimmed := strings.TrimSpace(oid)
+	if trimmed == "" || strings.HasSuffix(trimmed, ".0") {
+		return false
+	}
+	if db == nil {
+		return true
+	}
+
+	node, err := db.GetNode(trimmed)
+	if err != nil || node == nil {
+		return true
+	}
+	return strings.EqualFold(node.Type, "scalar")
+}
+
 // appendInstanceSuffix aggiunge `.0` ad un OID, gestendo eventuali punti finali.
 func appendInstanceSuffix(oid string) string {
 	cleaned := strings.TrimSpace(oid)
@@ -0,0 +1,185 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestRunWithVersionFallback(t *testing.T) {
+	app := NewApp()
+	config := snmp.Config{Host: "192.0.2.1", Community: "public", Version: "v2c"}
+
+	calls := 0
+	failFirst := func(client *snmp.Client) error {
+		calls++
+		if calls == 1 {
+			return errors.New("request timeout (after 2 retries)")
+		}
+		return nil
+	}
+
+	if _, err := app.runWithVersionFallback(config, failFirst); err == nil {
+		t.Fatalf("expected error when fallback is disabled")
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single attempt without fallback, got %d", calls)
+	}
+
+	calls = 0
+	config.AllowVersionFallback = true
+	version, err := app.runWithVersionFallback(config, failFirst)
+	if err != nil {
+		t.Fatalf("runWithVersionFallback() error = %v", err)
+	}
+	if version != "v1" || calls != 2 {
+		t.Fatalf("version = %q after %d calls, want v1 after 2", version, calls)
+	}
+
+	calls = 0
+	_, err = app.runWithVersionFallback(config, func(client *snmp.Client) error {
+		calls++
+		return errors.New("connection refused")
+	})
+	if err == nil || calls != 1 {
+		t.Fatalf("non-timeout errors must not trigger fallback (calls=%d, err=%v)", calls, err)
+	}
+}
+
+func TestSNMPDebugCaptureIsOptIn(t *testing.T) {
+	app := NewApp()
+	config := snmp.Config{Host: "192.0.2.1", Community: "public"}
+
+	if _, err := app.newSNMPClient(config); err != nil {
+		t.Fatalf("newSNMPClient() error = %v", err)
+	}
+	if len(app.debugCaptures) != 0 {
+		t.Fatalf("expected no capture buffers while debug capture is disabled")
+	}
+
+	app.SetSNMPDebugCapture(true)
+	if _, err := app.newSNMPClient(config); err != nil {
+		t.Fatalf("newSNMPClient() error = %v", err)
+	}
+	if app.debugCaptures["192.0.2.1"] == nil {
+		t.Fatalf("expected a capture buffer for the host once enabled")
+	}
+	if packets := app.GetSNMPDebugCapture("192.0.2.1"); len(packets) != 0 {
+		t.Fatalf("expected empty capture before any traffic, got %d packets", len(packets))
+	}
+
+	app.SetSNMPDebugCapture(false)
+	if app.debugCaptures != nil {
+		t.Fatalf("expected capture buffers to be dropped when disabled")
+	}
+}
+
+func TestSNMPGetRetriesScalarWithInstanceSuffix(t *testing.T) {
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.1.5.0": {Type: gosnmp.OctetString, Value: []byte("router-1")},
+	})
+	app := NewApp()
+
+	result, err := app.SNMPGet(agent.config(), "1.3.6.1.2.1.1.5")
+	if err != nil {
+		t.Fatalf("SNMPGet error: %v", err)
+	}
+	if result.Value != "0x726f757465722d31" || result.AutoCorrectedFrom != "1.3.6.1.2.1.1.5" {
+		t.Fatalf("expected auto-corrected result, got %+v", result)
+	}
+	if got := agent.requestedOIDs(); !reflect.DeepEqual(got, []string{"1.3.6.1.2.1.1.5", "1.3.6.1.2.1.1.5.0"}) {
+		t.Fatalf("unexpected requests: %v", got)
+	}
+}
+
+func TestSNMPGetKeepsNoSuchInstanceWhenRetryFails(t *testing.T) {
+	agent := startTestAgent(t, nil)
+	app := NewApp()
+
+	result, err := app.SNMPGet(agent.config(), "1.3.6.1.2.1.1.5")
+	if err != nil {
+		t.Fatalf("SNMPGet error: %v", err)
+	}
+	if result.Type != "NoSuchInstance" || result.AutoCorrectedFrom != "" {
+		t.Fatalf("expected original noSuchInstance result, got %+v", result)
+	}
+}
+
+func TestSNMPGetDoesNotRetryKnownColumns(t *testing.T) {
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.2.2.1.2.0": {Type: gosnmp.OctetString, Value: []byte("unexpected")},
+	})
+	app := setupTestAppWithNodes(t, &mib.Node{OID: "1.3.6.1.2.1.2.2.1.2", Name: "ifDescr", Type: "column"})
+
+	result, err := app.SNMPGet(agent.config(), "1.3.6.1.2.1.2.2.1.2")
+	if err != nil {
+		t.Fatalf("SNMPGet error: %v", err)
+	}
+	if result.AutoCorrectedFrom != "" || len(agent.requestedOIDs()) != 1 {
+		t.Fatalf("expected no retry for a column, got %+v (requests %v)", result, agent.requestedOIDs())
+	}
+}
+
+func TestSNMPWalkTimedStopsAtMaxDuration(t *testing.T) {
+	values := map[string]gosnmp.SnmpPDU{}
+	for i := 1; i <= 20; i++ {
+		values[fmt.Sprintf("1.3.6.1.2.1.1.9.1.2.%d", i)] = gosnmp.SnmpPDU{Type: gosnmp.Integer, Value: i}
+	}
+	agent := startTestAgent(t, values)
+	agent.setDelay(40 * time.Millisecond)
+	app := NewApp()
+
+	config := agent.config()
+	config.MaxDurationMs = 200
+	walk, err := app.SNMPWalkTimed(config, "1.3.6.1.2.1.1.9")
+	if err != nil {
+		t.Fatalf("SNMPWalkTimed error: %v", err)
+	}
+	if !walk.TimedOut {
+		t.Fatalf("expected walk to time out, got %d results", len(walk.Results))
+	}
+	if len(walk.Results) == 0 || len(walk.Results) >= len(values) {
+		t.Fatalf("expected partial results, got %d", len(walk.Results))
+	}
+
+	// Senza limite il WALK arriva in fondo al sottoalbero.
+	agent.setDelay(0)
+	walk, err = app.SNMPWalkTimed(agent.config(), "1.3.6.1.2.1.1.9")
+	if err != nil {
+		t.Fatalf("SNMPWalkTimed error: %v", err)
+	}
+	if walk.TimedOut || len(walk.Results) != len(values) {
+		t.Fatalf("expected complete walk, got timedOut=%v with %d results", walk.TimedOut, len(walk.Results))
+	}
+}
+
+func TestSNMPGetWithTimeout(t *testing.T) {
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.1.5.0": {Type: gosnmp.OctetString, Value: []byte("router")},
+	})
+	agent.setDelay(300 * time.Millisecond)
+	app := NewApp()
+
+	start := time.Now()
+	if _, err := app.SNMPGetWithTimeout(agent.config(), "1.3.6.1.2.1.1.5.0", 50); err == nil {
+		t.Fatalf("expected the GET to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected the short timeout to be used, GET took %v", elapsed)
+	}
+
+	agent.setDelay(0)
+	if _, err := app.SNMPGetWithTimeout(agent.config(), "1.3.6.1.2.1.1.5.0", 2000); err != nil {
+		t.Fatalf("SNMPGetWithTimeout error: %v", err)
+	}
+	if _, err := app.SNMPGetWithTimeout(agent.config(), "1.3.6.1.2.1.1.5.0", 0); err == nil {
+		t.Fatalf("expected a zero timeout to be rejected")
+	}
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"mib-to-the-future/backend/mib"
+)
+
+// statusWarning restituisce l'avviso da mostrare per un oggetto deprecated o obsolete, con la revisione
+// del modulo quando è nota (es. "object deprecated since IF-MIB rev 2000-06-14"). Per gli oggetti
+// current o sconosciuti restituisce una stringa vuota.
+func (a *App) statusWarning(node *mib.Node) string {
+	if node == nil {
+		return ""
+	}
+	status := strings.ToLower(strings.TrimSpace(node.Status))
+	if status != "deprecated" && status != "obsolete" {
+		return ""
+	}
+
+	revision := a.moduleRevision(node.Module)
+	if revision == "" {
+		return status
+	}
+	return fmt.Sprintf("object %s since %s rev %s", status, node.Module, revision)
+}
+
+// isObsoleteNode indica se il nodo MIB è marcato obsolete.
+func isObsoleteNode(node *mib.Node) bool {
+	return node != nil && strings.EqualFold(strings.TrimSpace(node.Status), "obsolete")
+}
+
+// moduleRevision restituisce la revisione più recente del modulo, memorizzandola per i risultati successivi.
+func (a *App) moduleRevision(module string) string {
+	db, release := a.acquireMIBDB()
+	defer release()
+
+	module = strings.TrimSpace(module)
+	if module == "" || db == nil {
+		return ""
+	}
+
+	a.moduleRevisionsM.Lock()
+	revision, cached := a.moduleRevisions[module]
+	a.moduleRevisionsM.Unlock()
+	if cached {
+		return revision
+	}
+
+	revision, err := db.GetModuleRevision(module)
+	if err != nil {
+		revision = ""
+	}
+	a.moduleRevisionsM.Lock()
+	if a.moduleRevisions == nil {
+		a.moduleRevisions = make(map[string]string)
+	}
+	a.moduleRevisions[module] = revision
+	a.moduleRevisionsM.Unlock()
+	return revision
+}
+
+// invalidateModuleRevisions scarta le revisioni memorizzate dopo il caricamento o la rimozione di moduli.
+func (a *App) invalidateModuleRevisions() {
+	a.moduleRevisionsM.Lock()
+	a.moduleRevisions = nil
+	a.moduleRevisionsM.Unlock()
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestEnrichResultStatusWarning(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.12", Name: "ifInNUcastPkts", Type: "column", Status: "deprecated"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.21", Name: "ifOutQLen", Type: "column", Status: "current"},
+	)
+
+	result := &snmp.Result{OID: "1.3.6.1.2.1.2.2.1.12.1", Type: "Counter32", Value: "5"}
+	app.enrichResult("192.0.2.1", result)
+	if result.StatusWarning != "deprecated" {
+		t.Fatalf("expected bare deprecated warning without revision, got %q", result.StatusWarning)
+	}
+
+	if err := app.mibDB.UpdateModuleRevision("TEST-MIB", "2000-06-14"); err != nil {
+		t.Fatalf("UpdateModuleRevision() error = %v", err)
+	}
+	app.invalidateModuleRevisions()
+
+	result = &snmp.Result{OID: "1.3.6.1.2.1.2.2.1.12.1", Type: "Counter32", Value: "5"}
+	app.enrichResult("192.0.2.1", result)
+	if result.StatusWarning != "object deprecated since TEST-MIB rev 2000-06-14" {
+		t.Fatalf("unexpected warning: %q", result.StatusWarning)
+	}
+
+	result = &snmp.Result{OID: "1.3.6.1.2.1.2.2.1.21.1", Type: "Gauge32", Value: "0"}
+	app.enrichResult("192.0.2.1", result)
+	if result.StatusWarning != "" {
+		t.Fatalf("expected no warning for current objects, got %q", result.StatusWarning)
+	}
+}
+
+func TestSNMPSetRequiresConfirmationForObsoleteObjects(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.4.1.99999.1", Name: "legacyMode", Type: "scalar", Access: "read-write", Syntax: "Integer32", Status: "obsolete"},
+	)
+	if err := app.mibDB.EnsureHostConfigSchema(); err != nil {
+		t.Fatalf("EnsureHostConfigSchema() error = %v", err)
+	}
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.4.1.99999.1.0": {Type: gosnmp.Integer, Value: 2},
+	})
+
+	if _, err := app.SNMPSet(agent.config(), "1.3.6.1.4.1.99999.1.0", "integer", 2); err == nil || !strings.Contains(err.Error(), "confirmation") {
+		t.Fatalf("expected SET on an obsolete object to require confirmation, got %v", err)
+	}
+	if requested := agent.requestedOIDs(); len(requested) != 0 {
+		t.Fatalf("expected no request without confirmation, got %v", requested)
+	}
+
+	result, err := app.SNMPSetConfirmed(agent.config(), "1.3.6.1.4.1.99999.1.0", "integer", 2, true)
+	if err != nil {
+		t.Fatalf("SNMPSetConfirmed() error = %v", err)
+	}
+	if result.StatusWarning != "obsolete" {
+		t.Fatalf("expected obsolete warning on the SET result, got %q", result.StatusWarning)
+	}
+}
+
+func TestSnapshotMarkdownIncludesStatusWarning(t *testing.T) {
+	snapshot := &BookmarkSnapshot{Root: &SnapshotFolder{Name: "Root", Entries: []SnapshotEntry{{
+		Name:   "ifInNUcastPkts",
+		OID:    "1.3.6.1.2.1.2.2.1.12",
+		Status: "success",
+		Values: []SnapshotValue{{OID: "1.3.6.1.2.1.2.2.1.12.1", Name: "ifInNUcastPkts.1", Value: "5", Warning: "deprecated"}},
+	}}}}
+
+	if report := renderSnapshotMarkdown(snapshot); !strings.Contains(report, "5 ⚠ deprecated") {
+		t.Fatalf("expected the warning in the report, got:\n%s", report)
+	}
+}
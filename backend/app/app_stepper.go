@@ -0,0 +1,219 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+)
+
+// maxStepperHistory limita i passi conservati per sessione; i più vecchi vengono scartati.
+const maxStepperHistory = 1000
+
+// stepperIdleTTL è il tempo di inattività dopo cui una sessione di stepping non chiusa dal frontend
+// viene eliminata.
+const stepperIdleTTL = 30 * time.Minute
+
+// walkStepper è lo stato leggero di una sessione di navigazione GETNEXT passo-passo.
+// Non mantiene connessioni aperte: ogni passo crea il proprio client dalla configurazione salvata.
+type walkStepper struct {
+	config   snmp.Config
+	cursor   string
+	finished bool
+	history  []snmp.Result
+	lastUsed time.Time
+}
+
+// WalkStepperState descrive lo stato corrente di una sessione di stepping.
+type WalkStepperState struct {
+	SessionID string `json:"sessionId"`
+	Host      string `json:"host"`
+	Cursor    string `json:"cursor"`
+	Finished  bool   `json:"finished"`
+	Steps     int    `json:"steps"`
+}
+
+// StartWalkStepper apre una sessione di stepping GETNEXT a partire da startOID.
+// Ritorna l'ID di sessione da usare con StepNext, JumpWalkStepper e GetStepperHistory.
+func (a *App) StartWalkStepper(config snmp.Config, startOID string) (string, error) {
+	if err := validateOIDInput(startOID); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(config.Host) == "" {
+		return "", fmt.Errorf("host is required")
+	}
+
+	a.stepperM.Lock()
+	defer a.stepperM.Unlock()
+
+	a.pruneSteppersLocked(time.Now())
+	if a.steppers == nil {
+		a.steppers = make(map[string]*walkStepper)
+	}
+	a.stepperSeq++
+	id := fmt.Sprintf("stepper-%d", a.stepperSeq)
+	a.steppers[id] = &walkStepper{
+		config:   config,
+		cursor:   normalizeOIDKey(startOID),
+		history:  []snmp.Result{},
+		lastUsed: time.Now(),
+	}
+	return id, nil
+}
+
+// StepNext esegue un singolo GETNEXT dall'ultimo OID restituito e lo aggiunge alla cronologia.
+// A fine MIB (endOfMibView, OID che non avanza o noSuchName in SNMPv1) la sessione viene marcata
+// come conclusa e il metodo ritorna nil senza errore; i passi successivi non interrogano più l'agent
+// finché il cursore non viene spostato con JumpWalkStepper.
+func (a *App) StepNext(sessionID string) (*snmp.Result, error) {
+	a.stepperM.Lock()
+	session, ok := a.stepperLocked(sessionID)
+	if !ok {
+		a.stepperM.Unlock()
+		return nil, fmt.Errorf("stepper session %s not found", sessionID)
+	}
+	if session.finished {
+		a.stepperM.Unlock()
+		return nil, nil
+	}
+	config := session.config
+	cursor := session.cursor
+	a.stepperM.Unlock()
+
+	client, err := a.newSNMPClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SNMP client: %v", err)
+	}
+
+	result, err := client.GetNext(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("SNMP GETNEXT failed: %v", err)
+	}
+
+	a.stepperM.Lock()
+	defer a.stepperM.Unlock()
+
+	session, ok = a.stepperLocked(sessionID)
+	if !ok {
+		return nil, fmt.Errorf("stepper session %s was closed", sessionID)
+	}
+	if session.cursor != cursor {
+		// Il cursore è stato spostato durante la richiesta: il risultato non è più pertinente.
+		return nil, fmt.Errorf("stepper cursor moved to %s during the request", session.cursor)
+	}
+	if !session.advance(result) {
+		return nil, nil
+	}
+
+	a.enrichResult(config.Host, &session.history[len(session.history)-1])
+	step := session.history[len(session.history)-1]
+	return &step, nil
+}
+
+// advance applica il risultato di un GETNEXT alla sessione. Ritorna false se l'agent ha segnalato
+// la fine della MIB, nel qual caso la sessione viene marcata come conclusa.
+func (s *walkStepper) advance(result *snmp.Result) bool {
+	if result == nil || strings.EqualFold(result.Type, "EndOfMibView") {
+		s.finished = true
+		return false
+	}
+
+	next := normalizeOIDKey(result.OID)
+	if next == "" || mib.CompareOIDs(next, s.cursor) <= 0 {
+		s.finished = true
+		return false
+	}
+
+	s.cursor = next
+	s.history = append(s.history, *result)
+	if len(s.history) > maxStepperHistory {
+		s.history = append([]snmp.Result(nil), s.history[len(s.history)-maxStepperHistory:]...)
+	}
+	return true
+}
+
+// JumpWalkStepper sposta il cursore della sessione su un OID arbitrario; il passo successivo
+// proseguirà da lì. La cronologia viene mantenuta.
+func (a *App) JumpWalkStepper(sessionID string, oid string) (*WalkStepperState, error) {
+	if err := validateOIDInput(oid); err != nil {
+		return nil, err
+	}
+
+	a.stepperM.Lock()
+	defer a.stepperM.Unlock()
+
+	session, ok := a.stepperLocked(sessionID)
+	if !ok {
+		return nil, fmt.Errorf("stepper session %s not found", sessionID)
+	}
+	session.cursor = normalizeOIDKey(oid)
+	session.finished = false
+	return session.state(sessionID), nil
+}
+
+// GetStepperHistory restituisce i varbind ottenuti finora dalla sessione, dal più vecchio al più recente.
+func (a *App) GetStepperHistory(sessionID string) ([]snmp.Result, error) {
+	a.stepperM.Lock()
+	defer a.stepperM.Unlock()
+
+	session, ok := a.stepperLocked(sessionID)
+	if !ok {
+		return nil, fmt.Errorf("stepper session %s not found", sessionID)
+	}
+
+	history := make([]snmp.Result, len(session.history))
+	copy(history, session.history)
+	return history, nil
+}
+
+// GetWalkStepperState restituisce cursore e stato di una sessione di stepping.
+func (a *App) GetWalkStepperState(sessionID string) (*WalkStepperState, error) {
+	a.stepperM.Lock()
+	defer a.stepperM.Unlock()
+
+	session, ok := a.stepperLocked(sessionID)
+	if !ok {
+		return nil, fmt.Errorf("stepper session %s not found", sessionID)
+	}
+	return session.state(sessionID), nil
+}
+
+// stepperLocked restituisce la sessione indicata aggiornandone l'ultimo utilizzo, dopo aver eliminato
+// quelle inattive da più di stepperIdleTTL; richiede stepperM.
+func (a *App) stepperLocked(sessionID string) (*walkStepper, bool) {
+	now := time.Now()
+	a.pruneSteppersLocked(now)
+	session, ok := a.steppers[sessionID]
+	if ok {
+		session.lastUsed = now
+	}
+	return session, ok
+}
+
+// pruneSteppersLocked elimina le sessioni di stepping inattive da più di stepperIdleTTL; richiede stepperM.
+func (a *App) pruneSteppersLocked(now time.Time) {
+	for id, session := range a.steppers {
+		if now.Sub(session.lastUsed) > stepperIdleTTL {
+			delete(a.steppers, id)
+		}
+	}
+}
+
+// CloseWalkStepper elimina una sessione di stepping e la relativa cronologia.
+func (a *App) CloseWalkStepper(sessionID string) {
+	a.stepperM.Lock()
+	delete(a.steppers, sessionID)
+	a.stepperM.Unlock()
+}
+
+func (s *walkStepper) state(sessionID string) *WalkStepperState {
+	return &WalkStepperState{
+		SessionID: sessionID,
+		Host:      s.config.Host,
+		Cursor:    s.cursor,
+		Finished:  s.finished,
+		Steps:     len(s.history),
+	}
+}
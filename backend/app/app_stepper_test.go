@@ -0,0 +1,124 @@
+package app
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"mib-to-the-future/backend/snmp"
+)
+
+func TestWalkStepperAdvanceAndJump(t *testing.T) {
+	app := NewApp()
+
+	id, err := app.StartWalkStepper(snmp.Config{Host: "192.0.2.1"}, ".1.3.6.1.2.1.1")
+	if err != nil {
+		t.Fatalf("StartWalkStepper() error = %v", err)
+	}
+	session := app.steppers[id]
+	if session.cursor != "1.3.6.1.2.1.1" {
+		t.Fatalf("unexpected initial cursor %q", session.cursor)
+	}
+
+	if !session.advance(&snmp.Result{OID: ".1.3.6.1.2.1.1.1.0", Type: "OctetString", Value: "router"}) {
+		t.Fatalf("expected first step to advance")
+	}
+	if !session.advance(&snmp.Result{OID: ".1.3.6.1.2.1.1.2.0", Type: "ObjectIdentifier", Value: ".1.3.6.1.4.1.9"}) {
+		t.Fatalf("expected second step to advance")
+	}
+	if session.cursor != "1.3.6.1.2.1.1.2.0" {
+		t.Fatalf("cursor = %q, want last returned OID", session.cursor)
+	}
+
+	// Un agent che restituisce un OID non successivo (es. noSuchName in v1) chiude la sessione.
+	if session.advance(&snmp.Result{OID: ".1.3.6.1.2.1.1.2.0", Type: "Null"}) {
+		t.Fatalf("expected non-increasing OID to end the session")
+	}
+
+	state, err := app.GetWalkStepperState(id)
+	if err != nil {
+		t.Fatalf("GetWalkStepperState() error = %v", err)
+	}
+	if !state.Finished || state.Steps != 2 {
+		t.Fatalf("unexpected state after end of MIB: %+v", state)
+	}
+
+	// A sessione conclusa StepNext non contatta l'agent.
+	result, err := app.StepNext(id)
+	if err != nil || result != nil {
+		t.Fatalf("StepNext() on finished session = %v, %v; want nil, nil", result, err)
+	}
+
+	state, err = app.JumpWalkStepper(id, "1.3.6.1.2.1.2.2.1.2")
+	if err != nil {
+		t.Fatalf("JumpWalkStepper() error = %v", err)
+	}
+	if state.Finished || state.Cursor != "1.3.6.1.2.1.2.2.1.2" {
+		t.Fatalf("unexpected state after jump: %+v", state)
+	}
+
+	if session.advance(&snmp.Result{OID: ".1.3.6.1.2.1.2.2.1.2.1", Type: "EndOfMibView"}) {
+		t.Fatalf("expected endOfMibView to end the session")
+	}
+
+	history, err := app.GetStepperHistory(id)
+	if err != nil {
+		t.Fatalf("GetStepperHistory() error = %v", err)
+	}
+	if len(history) != 2 || history[0].Value != "router" {
+		t.Fatalf("unexpected history: %+v", history)
+	}
+
+	if _, err := app.JumpWalkStepper(id, "1..2"); err == nil {
+		t.Fatalf("expected invalid OID to be rejected")
+	}
+
+	app.CloseWalkStepper(id)
+	if _, err := app.GetStepperHistory(id); err == nil {
+		t.Fatalf("expected closed session to be gone")
+	}
+	if _, err := app.StepNext(id); err == nil {
+		t.Fatalf("expected StepNext on closed session to fail")
+	}
+}
+
+func TestWalkStepperHistoryIsBounded(t *testing.T) {
+	stepper := &walkStepper{cursor: "1.3.6.1"}
+	for i := 1; i <= maxStepperHistory+5; i++ {
+		oid := "1.3.6.1." + strconv.Itoa(i)
+		if !stepper.advance(&snmp.Result{OID: oid, Type: "Integer"}) {
+			t.Fatalf("step %d unexpectedly ended the session", i)
+		}
+	}
+	if len(stepper.history) != maxStepperHistory {
+		t.Fatalf("history length = %d, want %d", len(stepper.history), maxStepperHistory)
+	}
+	if stepper.history[0].OID != "1.3.6.1.6" {
+		t.Fatalf("expected oldest entries to be dropped, first = %s", stepper.history[0].OID)
+	}
+}
+
+func TestWalkStepperIdleSessionsExpire(t *testing.T) {
+	app := NewApp()
+
+	idle, err := app.StartWalkStepper(snmp.Config{Host: "192.0.2.1"}, "1.3.6.1.2.1.1")
+	if err != nil {
+		t.Fatalf("StartWalkStepper() error = %v", err)
+	}
+	active, err := app.StartWalkStepper(snmp.Config{Host: "192.0.2.2"}, "1.3.6.1.2.1.2")
+	if err != nil {
+		t.Fatalf("StartWalkStepper() error = %v", err)
+	}
+	app.steppers[idle].lastUsed = time.Now().Add(-stepperIdleTTL - time.Minute)
+	app.steppers[active].lastUsed = time.Now().Add(-stepperIdleTTL + time.Minute)
+
+	if _, err := app.GetWalkStepperState(idle); err == nil {
+		t.Fatalf("expected the idle session to be evicted")
+	}
+	if _, err := app.GetWalkStepperState(active); err != nil {
+		t.Fatalf("GetWalkStepperState() error = %v", err)
+	}
+	if time.Since(app.steppers[active].lastUsed) > time.Minute {
+		t.Fatalf("expected access to refresh the session")
+	}
+}
@@ -1,7 +1,9 @@
 package app
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
 	"strings"
@@ -11,36 +13,73 @@ import (
 	"mib-to-the-future/backend/snmp"
 )
 
+// rowStatusDestroy è il valore RowStatus `destroy(6)` che elimina una riga (SNMPv2-TC).
+const rowStatusDestroy = 6
+
+// ErrNoRowStatus indica che la tabella non espone una colonna RowStatus per la gestione delle righe.
+var ErrNoRowStatus = errors.New("table has no RowStatus column")
+
 // TableColumn descrive una colonna di una tabella SNMP con i metadati derivati dal MIB.
 type TableColumn struct {
-	Key         string `json:"key"`
-	Label       string `json:"label"`
-	OID         string `json:"oid"`
-	Type        string `json:"type"`
-	Syntax      string `json:"syntax,omitempty"`
-	Access      string `json:"access,omitempty"`
-	Description string `json:"description,omitempty"`
+	Key         string          `json:"key"`
+	Label       string          `json:"label"`
+	OID         string          `json:"oid"`
+	Type        ColumnValueType `json:"type"`
+	Syntax      string          `json:"syntax,omitempty"`
+	Access      string          `json:"access,omitempty"`
+	Description string          `json:"description,omitempty"`
+}
+
+// TableSummary descrive una tabella SNMP definita nei moduli caricati, con la relativa entry e il numero di colonne.
+type TableSummary struct {
+	TableNode   *mib.Node `json:"tableNode"`
+	RowNode     *mib.Node `json:"rowNode"`
+	ColumnCount int       `json:"columnCount"`
+	Module      string    `json:"module"`
 }
 
+// HostTableData associa a un host il risultato (o l'errore) della lettura di una tabella.
+type HostTableData struct {
+	Host  string             `json:"host"`
+	Data  *TableDataResponse `json:"data,omitempty"`
+	Error string             `json:"error,omitempty"`
+}
+
+// maxMultiHostTableWorkers limita le letture di tabella eseguite in parallelo da FetchTableDataMultiHost.
+const maxMultiHostTableWorkers = 5
+
 // TableRow rappresenta un record della tabella dove ogni chiave corrisponde a una colonna.
 type TableRow map[string]string
 
+// TableRowTyped è la variante di TableRow in cui le colonne numeriche sono numeri JSON,
+// così che il frontend possa ordinarle numericamente.
+type TableRowTyped map[string]interface{}
+
 // TableDataResponse incapsula metadati e righe della tabella SNMP richiesta dal frontend.
 type TableDataResponse struct {
 	TableOID string        `json:"tableOid"`
 	EntryOID string        `json:"entryOid"`
 	Columns  []TableColumn `json:"columns"`
 	Rows     []TableRow    `json:"rows"`
+	// TypedRows è valorizzato solo da FetchTableDataTyped, nello stesso ordine di Rows.
+	TypedRows []TableRowTyped `json:"typedRows,omitempty"`
+	// IndexColumns elenca, nell'ordine della clausola INDEX, le chiavi di riga con i valori decodificati
+	// dal suffisso di istanza; è vuoto se l'indice della riga non è noto.
+	IndexColumns []string `json:"indexColumns,omitempty"`
 }
 
 // FetchTableData esegue un WALK sull'entry della tabella per restituire righe e colonne formattate per il frontend.
 // Parametri:
 //   - config: configurazione SNMP da utilizzare per la connessione.
 //   - tableOID: l'OID del nodo tabella (o di un suo discendente) da interrogare.
+//   - sortColumn: chiave della colonna di ordinamento (o "__instance"); vuota per l'ordine naturale degli indici.
+//   - sortDirection: "asc" (predefinito) o "desc".
 //
 // Ritorna i metadati della tabella e le righe ottenute dal dispositivo SNMP.
-func (a *App) FetchTableData(config snmp.Config, tableOID string) (*TableDataResponse, error) {
-	if a.mibDB == nil {
+func (a *App) FetchTableData(config snmp.Config, tableOID string, sortColumn string, sortDirection string) (*TableDataResponse, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
 		return nil, a.mibNotInitializedErr()
 	}
 
@@ -49,7 +88,7 @@ func (a *App) FetchTableData(config snmp.Config, tableOID string) (*TableDataRes
 		return nil, fmt.Errorf("table OID is required")
 	}
 
-	node, err := a.mibDB.GetNode(normalized)
+	node, err := db.GetNode(normalized)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve table %s: %w", normalized, err)
 	}
@@ -88,11 +127,86 @@ func (a *App) FetchTableData(config snmp.Config, tableOID string) (*TableDataRes
 	}
 
 	response.Rows = buildTableRows(results, columns)
+	if index, err := db.GetRowIndex(rowNode.OID); err == nil && len(index) > 0 {
+		applyTableIndex(response.Rows, index)
+		for _, component := range index {
+			response.IndexColumns = append(response.IndexColumns, component.Name)
+		}
+	}
+	if err := sortTableRows(response.Rows, response.Columns, sortColumn, sortDirection); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// FetchTableDataTyped si comporta come FetchTableData e popola anche TypedRows, dove le colonne intere
+// (integer, counter, gauge, timeticks) e float sono numeri invece che stringhe.
+func (a *App) FetchTableDataTyped(config snmp.Config, tableOID string, sortColumn string, sortDirection string) (*TableDataResponse, error) {
+	response, err := a.FetchTableData(config, tableOID, sortColumn, sortDirection)
+	if err != nil {
+		return nil, err
+	}
+	response.TypedRows = buildTypedTableRows(response.Rows, response.Columns)
 	return response, nil
 }
 
+// buildTypedTableRows converte le righe testuali in righe tipizzate. Le colonne numeriche usano il valore
+// grezzo (il valore visualizzato può essere formattato, es. i TimeTicks); se non è un numero resta una stringa.
+func buildTypedTableRows(rows []TableRow, columns []TableColumn) []TableRowTyped {
+	typed := make([]TableRowTyped, len(rows))
+	for i, row := range rows {
+		typedRow := make(TableRowTyped, len(row))
+		for key, value := range row {
+			typedRow[key] = value
+		}
+		for _, column := range columns {
+			value, ok := row[column.Key]
+			if !ok {
+				continue
+			}
+			if raw, ok := row[column.Key+"__raw"]; ok && raw != "" {
+				if number, ok := parseTypedCell(column.Type, raw); ok {
+					typedRow[column.Key] = number
+					continue
+				}
+			}
+			if number, ok := parseTypedCell(column.Type, value); ok {
+				typedRow[column.Key] = number
+			}
+		}
+		typed[i] = typedRow
+	}
+	return typed
+}
+
+// parseTypedCell interpreta il valore di una cella numerica; uint64 copre i Counter64 oltre int64.
+func parseTypedCell(columnType ColumnValueType, value string) (interface{}, bool) {
+	value = strings.TrimSpace(value)
+	switch columnType {
+	case ColumnTypeInteger:
+		if number, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return number, true
+		}
+		if number, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return number, true
+		}
+	case ColumnTypeFloat:
+		// NaN e Inf non sono rappresentabili in JSON.
+		if number, err := strconv.ParseFloat(value, 64); err == nil && !math.IsNaN(number) && !math.IsInf(number, 0) {
+			return number, true
+		}
+	}
+	return nil, false
+}
+
 // resolveTableSchema risolve lo schema di una tabella SNMP partendo da un nodo table, row o column.
 func (a *App) resolveTableSchema(node *mib.Node) (*mib.Node, *mib.Node, []*mib.Node, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, nil, nil, a.mibNotInitializedErr()
+	}
+
 	if node == nil {
 		return nil, nil, nil, fmt.Errorf("table node is nil")
 	}
@@ -115,7 +229,7 @@ func (a *App) resolveTableSchema(node *mib.Node) (*mib.Node, *mib.Node, []*mib.N
 			return nil, nil, nil, fmt.Errorf("row %s è privo di tabella padre", node.Name)
 		}
 
-		tableNode, err := a.mibDB.GetNode(parentOID)
+		tableNode, err := db.GetNode(parentOID)
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("failed to resolve table for row %s: %w", node.Name, err)
 		}
@@ -126,7 +240,7 @@ func (a *App) resolveTableSchema(node *mib.Node) (*mib.Node, *mib.Node, []*mib.N
 			return nil, nil, nil, fmt.Errorf("column %s è privo di nodo row padre", node.Name)
 		}
 
-		rowNode, err := a.mibDB.GetNode(parentRowOID)
+		rowNode, err := db.GetNode(parentRowOID)
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("failed to resolve row for column %s: %w", node.Name, err)
 		}
@@ -141,7 +255,7 @@ func (a *App) resolveTableSchema(node *mib.Node) (*mib.Node, *mib.Node, []*mib.N
 			return nil, nil, nil, fmt.Errorf("row %s è privo di tabella padre", rowNode.Name)
 		}
 
-		tableNode, err := a.mibDB.GetNode(tableOID)
+		tableNode, err := db.GetNode(tableOID)
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("failed to resolve table for column %s: %w", node.Name, err)
 		}
@@ -153,7 +267,13 @@ func (a *App) resolveTableSchema(node *mib.Node) (*mib.Node, *mib.Node, []*mib.N
 
 // resolveTableRowAndColumns trova il nodo row e le colonne di una tabella.
 func (a *App) resolveTableRowAndColumns(tableNode *mib.Node) (*mib.Node, []*mib.Node, error) {
-	children, err := a.mibDB.GetChildren(tableNode.OID)
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, nil, a.mibNotInitializedErr()
+	}
+
+	children, err := db.GetChildren(tableNode.OID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load childrens for table %s: %w", tableNode.Name, err)
 	}
@@ -180,7 +300,13 @@ func (a *App) resolveTableRowAndColumns(tableNode *mib.Node) (*mib.Node, []*mib.
 
 // resolveRowColumns recupera tutte le colonne di un nodo row.
 func (a *App) resolveRowColumns(rowNode *mib.Node) ([]*mib.Node, error) {
-	children, err := a.mibDB.GetChildren(rowNode.OID)
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	children, err := db.GetChildren(rowNode.OID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load columns for row %s: %w", rowNode.Name, err)
 	}
@@ -249,7 +375,7 @@ func buildTableRows(results []snmp.Result, columns []*mib.Node) []TableRow {
 			row, ok := rows[suffix]
 			if !ok {
 				row = make(TableRow)
-				row["__instance"] = suffix
+				row[tableInstanceKey] = suffix
 				rows[suffix] = row
 				order = append(order, suffix)
 			}
@@ -280,10 +406,41 @@ func buildTableRows(results []snmp.Result, columns []*mib.Node) []TableRow {
 		formatted = append(formatted, rows[key])
 	}
 
+	applyTypedInetAddresses(formatted, columns)
 	return formatted
 }
 
+// applyTypedInetAddresses riformatta le colonne InetAddress precedute da una colonna InetAddressType,
+// come avviene nelle tabelle dell'INET-ADDRESS-MIB (es. ipAddressAddrType/ipAddressAddr).
+func applyTypedInetAddresses(rows []TableRow, columns []*mib.Node) {
+	for i := 0; i+1 < len(columns); i++ {
+		typeColumn, addrColumn := columns[i], columns[i+1]
+		if typeColumn == nil || addrColumn == nil {
+			continue
+		}
+		if syntaxBaseName(typeColumn.Syntax) != "inetaddresstype" || syntaxBaseName(addrColumn.Syntax) != "inetaddress" {
+			continue
+		}
+
+		for _, row := range rows {
+			addrType, err := strconv.Atoi(strings.TrimSpace(row[typeColumn.Name+"__raw"]))
+			if err != nil {
+				continue
+			}
+			raw, ok := row[addrColumn.Name+"__raw"]
+			if !ok {
+				continue
+			}
+			if formatted, ok := formatInetAddressTyped(addrType, raw); ok {
+				row[addrColumn.Name] = formatted
+			}
+		}
+	}
+}
+
 // makeColumnLabel genera un'etichetta leggibile dal nome di una colonna MIB.
+// Separa le parole camelCase, mantiene uniti gli acronimi (anche con suffisso di versione, es. "IPv6")
+// e rende maiuscola l'iniziale di ogni parola.
 func makeColumnLabel(name string) string {
 	cleaned := strings.TrimSpace(name)
 	if cleaned == "" {
@@ -292,6 +449,7 @@ func makeColumnLabel(name string) string {
 
 	cleaned = strings.ReplaceAll(cleaned, "_", " ")
 	cleaned = strings.ReplaceAll(cleaned, "-", " ")
+	cleaned = strings.TrimSpace(cleaned)
 
 	runes := []rune(cleaned)
 	var builder strings.Builder
@@ -310,9 +468,9 @@ func makeColumnLabel(name string) string {
 			continue
 		}
 
-		if i == 0 {
+		if i == 0 || prevWasSpace {
 			builder.WriteRune(unicode.ToUpper(r))
-		} else if unicode.IsUpper(r) && !prevWasSpace && (prevWasLowerOrDigit || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+		} else if unicode.IsUpper(r) && (prevWasLowerOrDigit || startsCapitalizedWord(runes, i)) {
 			builder.WriteRune(' ')
 			builder.WriteRune(r)
 		} else {
@@ -326,13 +484,77 @@ func makeColumnLabel(name string) string {
 	return builder.String()
 }
 
+// startsCapitalizedWord indica se la maiuscola in posizione i, preceduta da un acronimo, apre una nuova
+// parola (es. la "A" di "IPAddress"). Una minuscola seguita da una cifra è invece il suffisso di versione
+// dell'acronimo stesso (es. "IPv6", "SNMPv2") e non va separata.
+func startsCapitalizedWord(runes []rune, i int) bool {
+	j := i + 1
+	if j >= len(runes) || !unicode.IsLower(runes[j]) {
+		return false
+	}
+	for j < len(runes) && unicode.IsLower(runes[j]) {
+		j++
+	}
+	return j >= len(runes) || !unicode.IsDigit(runes[j])
+}
+
+// ColumnValueType classifica il contenuto di una colonna per scegliere il renderer delle celle nella UI.
+type ColumnValueType string
+
+const (
+	ColumnTypeInteger    ColumnValueType = "integer"
+	ColumnTypeFloat      ColumnValueType = "float"
+	ColumnTypeIPAddress  ColumnValueType = "ipaddress"
+	ColumnTypeMACAddress ColumnValueType = "macaddress"
+	ColumnTypeBoolean    ColumnValueType = "boolean"
+	ColumnTypeEnum       ColumnValueType = "enum"
+	ColumnTypeDateTime   ColumnValueType = "datetime"
+	ColumnTypeString     ColumnValueType = "string"
+)
+
 // inferColumnValueType deduce il tipo di dato di una colonna dalla sua sintassi.
-func inferColumnValueType(syntax string) string {
-	if syntax == "" {
-		return "string"
+// Le textual convention note vengono riconosciute per nome; per le altre si ricorre a euristiche sul testo.
+func inferColumnValueType(syntax string) ColumnValueType {
+	if strings.TrimSpace(syntax) == "" {
+		return ColumnTypeString
 	}
 
 	lowered := strings.ToLower(syntax)
+	base := syntaxBaseName(syntax)
+
+	switch base {
+	case "truthvalue":
+		return ColumnTypeBoolean
+	case "bits":
+		return ColumnTypeString
+	case "dateandtime":
+		return ColumnTypeDateTime
+	case "ipaddress", "inetaddress", "inetaddressipv4", "inetaddressipv6":
+		return ColumnTypeIPAddress
+	case "macaddress", "physaddress":
+		return ColumnTypeMACAddress
+	}
+
+	if parseEnumMapping(syntax) != nil {
+		return ColumnTypeEnum
+	}
+	if strings.Contains(lowered, "float") || strings.Contains(lowered, "double") {
+		return ColumnTypeFloat
+	}
+
+	if expected, ok := syntaxAgentTypes[base]; ok {
+		switch expected[0] {
+		case "Integer", "Gauge32", "Counter32", "Counter64", "TimeTicks":
+			return ColumnTypeInteger
+		default:
+			return ColumnTypeString
+		}
+	}
+
+	// Sintassi sconosciuta: "PrintableString" contiene "int" ma resta una stringa.
+	if strings.Contains(lowered, "string") {
+		return ColumnTypeString
+	}
 	hints := []string{
 		"int",
 		"counter",
@@ -340,20 +562,15 @@ func inferColumnValueType(syntax string) string {
 		"unsigned",
 		"timeticks",
 		"time ticks",
-		"float",
-		"double",
-		"bits",
-		"enum",
 		"numeric",
 	}
-
 	for _, hint := range hints {
 		if strings.Contains(lowered, hint) {
-			return "number"
+			return ColumnTypeInteger
 		}
 	}
 
-	return "string"
+	return ColumnTypeString
 }
 
 // compareIndexPaths confronta due indici di tabella numericamente.
@@ -403,9 +620,425 @@ func compareIndexPaths(a, b string) int {
 	return 0
 }
 
-// sortInstanceKeys ordina le chiavi di istanza in ordine naturale.
+// sortInstanceKeys ordina le chiavi di istanza in ordine naturale, segmento per segmento: le tabelle
+// indicizzate per indirizzo IPv4 (es. ipRouteTable) risultano così ordinate per indirizzo.
 func sortInstanceKeys(keys []string) {
 	sort.Slice(keys, func(i, j int) bool {
 		return compareIndexPaths(keys[i], keys[j]) < 0
 	})
 }
+
+// tableInstanceKey è la chiave di riga che contiene il suffisso di istanza.
+const tableInstanceKey = "__instance"
+
+// sortTableRows ordina le righe sulla colonna indicata usandone il tipo dedotto: confronto numerico
+// sui valori raw per le colonne numeriche, ordine naturale degli indici per l'istanza e confronto
+// testuale case-insensitive negli altri casi. Le righe senza valore vanno sempre in fondo.
+func sortTableRows(rows []TableRow, columns []TableColumn, sortColumn string, sortDirection string) error {
+	key := strings.TrimSpace(sortColumn)
+	if key == "" {
+		return nil
+	}
+
+	descending := false
+	switch strings.ToLower(strings.TrimSpace(sortDirection)) {
+	case "", "asc":
+	case "desc":
+		descending = true
+	default:
+		return fmt.Errorf("invalid sort direction %q", sortDirection)
+	}
+
+	var compare func(a, b string) int
+	if key == tableInstanceKey {
+		compare = compareIndexPaths
+	} else {
+		var column *TableColumn
+		for i := range columns {
+			if columns[i].Key == key {
+				column = &columns[i]
+				break
+			}
+		}
+		if column == nil {
+			return fmt.Errorf("unknown sort column %q", sortColumn)
+		}
+
+		switch column.Type {
+		case ColumnTypeInteger, ColumnTypeFloat, ColumnTypeEnum, ColumnTypeBoolean:
+			compare = compareNumericValues
+		case ColumnTypeIPAddress:
+			compare = compareIndexPaths
+		default:
+			compare = compareTextValues
+		}
+	}
+
+	sortKey := func(row TableRow) (string, bool) {
+		if key == tableInstanceKey {
+			value, ok := row[key]
+			return value, ok && value != ""
+		}
+		if raw, ok := row[key+"__raw"]; ok && raw != "" {
+			return raw, true
+		}
+		value, ok := row[key]
+		return value, ok && value != ""
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		valueA, okA := sortKey(rows[i])
+		valueB, okB := sortKey(rows[j])
+		if !okA || !okB {
+			return okA && !okB
+		}
+
+		result := compare(valueA, valueB)
+		if descending {
+			result = -result
+		}
+		return result < 0
+	})
+	return nil
+}
+
+// compareNumericValues confronta due valori come numeri; i valori non numerici seguono quelli numerici.
+func compareNumericValues(a, b string) int {
+	numA, errA := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	numB, errB := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	switch {
+	case errA == nil && errB == nil:
+		if numA < numB {
+			return -1
+		}
+		if numA > numB {
+			return 1
+		}
+		return 0
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return compareTextValues(a, b)
+	}
+}
+
+// compareTextValues confronta due stringhe ignorando maiuscole e minuscole.
+func compareTextValues(a, b string) int {
+	if result := strings.Compare(strings.ToLower(a), strings.ToLower(b)); result != 0 {
+		return result
+	}
+	return strings.Compare(a, b)
+}
+
+// SNMPTableDeleteRow elimina una riga di tabella impostando la colonna RowStatus a `destroy(6)`.
+// Parametri:
+//   - config: configurazione SNMP da utilizzare per la connessione.
+//   - tableOID: l'OID del nodo tabella (o di un suo discendente).
+//   - instanceKey: l'indice della riga da eliminare (es. "1" o "192.168.1.1").
+//
+// Ritorna il risultato del SET oppure ErrNoRowStatus se la tabella non prevede RowStatus.
+// Dopo il SET verifica con un GET che l'istanza risulti NoSuchInstance.
+func (a *App) SNMPTableDeleteRow(config snmp.Config, tableOID string, instanceKey string) (*snmp.Result, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	normalized := normalizeOIDKey(tableOID)
+	if normalized == "" {
+		return nil, fmt.Errorf("table OID is required")
+	}
+
+	instance := normalizeOIDKey(instanceKey)
+	if instance == "" {
+		return nil, fmt.Errorf("row instance is required")
+	}
+
+	node, err := db.GetNode(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve table %s: %w", normalized, err)
+	}
+
+	_, _, columns, err := a.resolveTableSchema(node)
+	if err != nil {
+		return nil, err
+	}
+
+	statusColumn := findRowStatusColumn(columns)
+	if statusColumn == nil {
+		return nil, ErrNoRowStatus
+	}
+
+	targetOID := normalizeOIDKey(statusColumn.OID) + "." + instance
+
+	result, err := a.SNMPSet(config, targetOID, "integer", rowStatusDestroy)
+	if err != nil {
+		return result, err
+	}
+
+	check, err := a.SNMPGet(config, targetOID)
+	if err != nil {
+		return result, fmt.Errorf("row %s deleted but verification failed: %w", instance, err)
+	}
+	if !isMissingInstanceType(check.Type) {
+		return result, fmt.Errorf("row %s is still present after destroy (RowStatus %s)", instance, check.Value)
+	}
+
+	return result, nil
+}
+
+// findRowStatusColumn individua la colonna con sintassi RowStatus tra quelle della riga.
+func findRowStatusColumn(columns []*mib.Node) *mib.Node {
+	for _, column := range columns {
+		if column == nil {
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(column.Syntax), "RowStatus") {
+			return column
+		}
+	}
+	return nil
+}
+
+// isMissingInstanceType indica se il tipo restituito da un GET segnala un'istanza inesistente.
+func isMissingInstanceType(valueType string) bool {
+	switch strings.ToLower(strings.TrimSpace(valueType)) {
+	case "nosuchinstance", "nosuchobject":
+		return true
+	default:
+		return false
+	}
+}
+
+// GetTableRowCount conta le righe di una tabella SNMP eseguendo il WALK di una sola colonna leggibile,
+// evitando di recuperare i valori di tutte le colonne come FetchTableData.
+// Parametri:
+//   - config: configurazione SNMP da utilizzare per la connessione.
+//   - tableOID: l'OID del nodo tabella (o di un suo discendente).
+//
+// Ritorna il numero di istanze trovate o un errore.
+func (a *App) GetTableRowCount(config snmp.Config, tableOID string) (int, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return 0, a.mibNotInitializedErr()
+	}
+
+	normalized := normalizeOIDKey(tableOID)
+	if normalized == "" {
+		return 0, fmt.Errorf("table OID is required")
+	}
+
+	node, err := db.GetNode(normalized)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve table %s: %w", normalized, err)
+	}
+
+	tableNode, _, columns, err := a.resolveTableSchema(node)
+	if err != nil {
+		return 0, err
+	}
+
+	column := findReadableColumn(columns)
+	if column == nil {
+		return 0, fmt.Errorf("table %s has no readable columns", tableNode.Name)
+	}
+
+	client, err := a.newSNMPClient(config)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create SNMP client: %v", err)
+	}
+
+	a.persistHostUsage(config)
+
+	results, err := client.Walk(column.OID)
+	if err != nil {
+		return 0, fmt.Errorf("SNMP WALK failed: %v", err)
+	}
+
+	count := 0
+	for _, result := range results {
+		if isMissingInstanceType(result.Type) || strings.EqualFold(result.Type, "EndOfMibView") {
+			continue
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// findReadableColumn restituisce la prima colonna con accesso in lettura (read-only, read-write, read-create).
+func findReadableColumn(columns []*mib.Node) *mib.Node {
+	for _, column := range columns {
+		if column == nil {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(column.Access)), "read-") {
+			return column
+		}
+	}
+	return nil
+}
+
+// GetMIBTableList restituisce tutte le tabelle definite nei moduli MIB caricati, con entry e numero di colonne.
+// Il risultato viene memorizzato e invalidato quando si caricano o eliminano moduli; ogni chiamata
+// ne restituisce una copia, così il chiamante può modificarla senza alterare la cache.
+func (a *App) GetMIBTableList() ([]*TableSummary, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	a.tableListM.RLock()
+	cached := a.tableList
+	a.tableListM.RUnlock()
+	if cached != nil {
+		return copyTableSummaries(cached), nil
+	}
+
+	tables, err := db.GetNodesByType("table")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %v", err)
+	}
+
+	summaries := make([]*TableSummary, 0, len(tables))
+	for _, table := range tables {
+		summary := &TableSummary{TableNode: table, Module: table.Module}
+
+		children, err := db.GetChildren(table.OID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load entry of table %s: %v", table.Name, err)
+		}
+		for _, child := range children {
+			if child.Type == "row" {
+				summary.RowNode = child
+				break
+			}
+		}
+
+		if summary.RowNode != nil {
+			columns, err := db.GetChildren(summary.RowNode.OID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load columns of table %s: %v", table.Name, err)
+			}
+			for _, column := range columns {
+				if column.Type == "column" {
+					summary.ColumnCount++
+				}
+			}
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	a.tableListM.Lock()
+	a.tableList = summaries
+	a.tableListM.Unlock()
+
+	return copyTableSummaries(summaries), nil
+}
+
+// copyTableSummaries copia le voci dell'elenco delle tabelle insieme ai nodi di tabella e di entry.
+func copyTableSummaries(summaries []*TableSummary) []*TableSummary {
+	copies := make([]*TableSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		copied := *summary
+		copied.TableNode = copyMIBNode(summary.TableNode)
+		copied.RowNode = copyMIBNode(summary.RowNode)
+		copies = append(copies, &copied)
+	}
+	return copies
+}
+
+// copyMIBNode copia un nodo e le sue slice; i figli restano condivisi.
+func copyMIBNode(node *mib.Node) *mib.Node {
+	if node == nil {
+		return nil
+	}
+	copied := *node
+	copied.Children = append([]*mib.Node(nil), node.Children...)
+	copied.NotificationObjects = append([]string(nil), node.NotificationObjects...)
+	copied.Index = append([]mib.IndexComponent(nil), node.Index...)
+	copied.ImplementedOn = append([]string(nil), node.ImplementedOn...)
+	copied.TypeChain = append([]string(nil), node.TypeChain...)
+	return &copied
+}
+
+// GetSNMPWritableTables restituisce le tabelle che hanno almeno una colonna read-write,
+// per individuare rapidamente quelle configurabili tramite SET.
+func (a *App) GetSNMPWritableTables() ([]*TableSummary, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	writable, err := db.GetWritableTables()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list writable tables: %v", err)
+	}
+	if len(writable) == 0 {
+		return []*TableSummary{}, nil
+	}
+
+	all, err := a.GetMIBTableList()
+	if err != nil {
+		return nil, err
+	}
+
+	writableOIDs := make(map[string]struct{}, len(writable))
+	for _, table := range writable {
+		writableOIDs[table.OID] = struct{}{}
+	}
+
+	summaries := make([]*TableSummary, 0, len(writable))
+	for _, summary := range all {
+		if _, ok := writableOIDs[summary.TableNode.OID]; ok {
+			summaries = append(summaries, summary)
+		}
+	}
+	return summaries, nil
+}
+
+// invalidateTableList scarta l'elenco delle tabelle memorizzato da GetMIBTableList.
+func (a *App) invalidateTableList() {
+	a.tableListM.Lock()
+	a.tableList = nil
+	a.tableListM.Unlock()
+}
+
+// FetchTableDataMultiHost legge la stessa tabella da più host in parallelo (al massimo
+// maxMultiHostTableWorkers richieste contemporanee), per confrontarle affiancate.
+// Ritorna un elemento per ciascuna configurazione, nello stesso ordine; gli errori dei singoli
+// host sono riportati nel campo Error senza interrompere gli altri.
+func (a *App) FetchTableDataMultiHost(configs []snmp.Config, tableOID string) ([]*HostTableData, error) {
+	if !a.mibDBReady() {
+		return nil, a.mibNotInitializedErr()
+	}
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("at least one host is required")
+	}
+
+	return fetchTableDataMultiHost(configs, func(config snmp.Config) (*TableDataResponse, error) {
+		return a.FetchTableData(config, tableOID, "", "")
+	}), nil
+}
+
+func fetchTableDataMultiHost(configs []snmp.Config, fetch func(snmp.Config) (*TableDataResponse, error)) []*HostTableData {
+	results := make([]*HostTableData, len(configs))
+	runBounded(len(configs), maxMultiHostTableWorkers, func(i int) {
+		entry := &HostTableData{Host: configs[i].Host}
+		data, err := fetch(configs[i])
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.Data = data
+		}
+		results[i] = entry
+	})
+	return results
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"encoding/hex"
+	"net"
+	"strconv"
+	"strings"
+
+	"mib-to-the-future/backend/mib"
+)
+
+// indexValue è il valore decodificato di un componente dell'indice di una riga.
+type indexValue struct {
+	name  string
+	value string
+}
+
+// decodeTableIndex scompone il suffisso di istanza di una riga nei valori dei componenti della
+// clausola INDEX, consumando per ciascuno il numero corretto di sub-identifier: uno per gli interi,
+// FixedLength per le stringhe a dimensione fissa, lunghezza e byte per stringhe e OID variabili
+// (solo i byte per l'ultimo componente IMPLIED). Ritorna false se il suffisso non corrisponde all'indice.
+func decodeTableIndex(suffix string, index []mib.IndexComponent) ([]indexValue, bool) {
+	if len(index) == 0 || suffix == "" {
+		return nil, false
+	}
+
+	parts := strings.Split(suffix, ".")
+	subIDs := make([]uint64, len(parts))
+	for i, part := range parts {
+		value, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, false
+		}
+		subIDs[i] = value
+	}
+
+	values := make([]indexValue, 0, len(index))
+	position := 0
+	for _, component := range index {
+		remaining := subIDs[position:]
+		var consumed []uint64
+		switch component.Kind {
+		case mib.IndexKindInteger:
+			if len(remaining) == 0 {
+				return nil, false
+			}
+			values = append(values, indexValue{name: component.Name, value: strconv.FormatUint(remaining[0], 10)})
+			position++
+			continue
+		case mib.IndexKindString, mib.IndexKindOID:
+			length, skip := component.FixedLength, 0
+			switch {
+			case component.Kind == mib.IndexKindString && length > 0:
+			case component.Implied:
+				length = len(remaining)
+			default:
+				if len(remaining) == 0 {
+					return nil, false
+				}
+				length, skip = int(remaining[0]), 1
+			}
+			if len(remaining) < skip+length {
+				return nil, false
+			}
+			consumed = remaining[skip : skip+length]
+			position += skip + length
+		default:
+			return nil, false
+		}
+
+		if component.Kind == mib.IndexKindOID {
+			arcs := make([]string, len(consumed))
+			for i, arc := range consumed {
+				arcs[i] = strconv.FormatUint(arc, 10)
+			}
+			values = append(values, indexValue{name: component.Name, value: strings.Join(arcs, ".")})
+			continue
+		}
+
+		data := make([]byte, len(consumed))
+		for i, b := range consumed {
+			if b > 255 {
+				return nil, false
+			}
+			data[i] = byte(b)
+		}
+		values = append(values, indexValue{name: component.Name, value: formatIndexOctets(data, component.Syntax)})
+	}
+
+	if position != len(subIDs) {
+		return nil, false
+	}
+	return values, true
+}
+
+// formatIndexOctets rende leggibile un componente OCTET STRING dell'indice: indirizzi MAC e IPv4 nella
+// forma consueta, testo stampabile così com'è, altrimenti esadecimale.
+func formatIndexOctets(data []byte, syntax string) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	raw := "0x" + hex.EncodeToString(data)
+	lowered := strings.ToLower(syntax)
+	switch {
+	case strings.Contains(lowered, "macaddress") || strings.Contains(lowered, "physaddress"):
+		if formatted, ok := formatMacAddress(raw); ok {
+			return formatted
+		}
+	case syntaxBaseName(syntax) == "ipaddress" && len(data) == net.IPv4len:
+		return net.IP(data).String()
+	}
+
+	if isPrintableASCIIBytes(data) {
+		if text := sanitizedString(string(data)); text != "" {
+			return text
+		}
+	}
+	return raw
+}
+
+// applyTableIndex aggiunge a ogni riga i valori decodificati dell'indice, con il nome del componente
+// come chiave. I valori letti dall'agent per la stessa colonna hanno la precedenza.
+func applyTableIndex(rows []TableRow, index []mib.IndexComponent) {
+	for _, row := range rows {
+		values, ok := decodeTableIndex(row[tableInstanceKey], index)
+		if !ok {
+			continue
+		}
+		for _, value := range values {
+			if _, exists := row[value.name]; !exists {
+				row[value.name] = value.value
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestDecodeTableIndex(t *testing.T) {
+	macIndex := []mib.IndexComponent{{Name: "dot1dTpFdbAddress", Syntax: "MacAddress", Kind: mib.IndexKindString, FixedLength: 6}}
+	stringIndex := []mib.IndexComponent{{Name: "nsExtendToken", Syntax: "DisplayString", Kind: mib.IndexKindString}}
+	mixedIndex := []mib.IndexComponent{
+		{Name: "vacmGroupName", Syntax: "SnmpAdminString", Kind: mib.IndexKindString},
+		{Name: "vacmAccessContextPrefix", Syntax: "SnmpAdminString", Kind: mib.IndexKindString},
+		{Name: "vacmAccessSecurityModel", Syntax: "SnmpSecurityModel", Kind: mib.IndexKindInteger},
+	}
+	impliedIndex := []mib.IndexComponent{{Name: "snmpTargetAddrName", Syntax: "SnmpAdminString", Kind: mib.IndexKindString, Implied: true}}
+	addressIndex := []mib.IndexComponent{
+		{Name: "ipNetToMediaIfIndex", Kind: mib.IndexKindInteger},
+		{Name: "ipNetToMediaNetAddress", Syntax: "IpAddress", Kind: mib.IndexKindString, FixedLength: 4},
+	}
+	oidIndex := []mib.IndexComponent{{Name: "hrSWRunID", Kind: mib.IndexKindOID}}
+
+	tests := []struct {
+		name   string
+		suffix string
+		index  []mib.IndexComponent
+		want   map[string]string
+		ok     bool
+	}{
+		{name: "mac address", suffix: "0.26.43.60.77.94", index: macIndex, want: map[string]string{"dot1dTpFdbAddress": "00:1A:2B:3C:4D:5E"}, ok: true},
+		{name: "length-prefixed string", suffix: "4.116.101.115.116", index: stringIndex, want: map[string]string{"nsExtendToken": "test"}, ok: true},
+		{name: "binary string", suffix: "2.0.255", index: stringIndex, want: map[string]string{"nsExtendToken": "0x00ff"}, ok: true},
+		{name: "empty string", suffix: "0", index: stringIndex, want: map[string]string{"nsExtendToken": ""}, ok: true},
+		{name: "mixed", suffix: "2.97.98.0.3", index: mixedIndex, want: map[string]string{"vacmGroupName": "ab", "vacmAccessContextPrefix": "", "vacmAccessSecurityModel": "3"}, ok: true},
+		{name: "implied", suffix: "116.49", index: impliedIndex, want: map[string]string{"snmpTargetAddrName": "t1"}, ok: true},
+		{name: "ip address", suffix: "2.192.168.1.1", index: addressIndex, want: map[string]string{"ipNetToMediaIfIndex": "2", "ipNetToMediaNetAddress": "192.168.1.1"}, ok: true},
+		{name: "oid", suffix: "3.1.3.6", index: oidIndex, want: map[string]string{"hrSWRunID": "1.3.6"}, ok: true},
+		{name: "short fixed string", suffix: "0.26.43", index: macIndex},
+		{name: "trailing sub-identifiers", suffix: "1.97.5", index: stringIndex},
+		{name: "length beyond suffix", suffix: "5.97", index: stringIndex},
+		{name: "byte out of range", suffix: "1.300", index: stringIndex},
+		{name: "no index", suffix: "1", index: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, ok := decodeTableIndex(tt.suffix, tt.index)
+			if ok != tt.ok {
+				t.Fatalf("decodeTableIndex(%q) ok = %v, want %v (values %+v)", tt.suffix, ok, tt.ok, values)
+			}
+			if !ok {
+				return
+			}
+			if len(values) != len(tt.index) {
+				t.Fatalf("expected %d values, got %+v", len(tt.index), values)
+			}
+			for i, value := range values {
+				if value.name != tt.index[i].Name || value.value != tt.want[value.name] {
+					t.Fatalf("value %d = %+v, want %s=%q", i, value, tt.index[i].Name, tt.want[tt.index[i].Name])
+				}
+			}
+		})
+	}
+}
+
+func TestFetchTableDataDecodesIndex(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+	moduleID, err := app.mibDB.SaveModule("TEST-INDEX-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule() error = %v", err)
+	}
+	if err := app.mibDB.SaveNodes([]*mib.Node{
+		{OID: "1.3.6.1.2.1.17.4.3", Name: "dot1dTpFdbTable", Type: "table", ParentOID: "1.3.6.1.2.1.17.4"},
+		{OID: "1.3.6.1.2.1.17.4.3.1", Name: "dot1dTpFdbEntry", Type: "row", ParentOID: "1.3.6.1.2.1.17.4.3", Index: []mib.IndexComponent{
+			{OID: "1.3.6.1.2.1.17.4.3.1.1", Name: "dot1dTpFdbAddress", Syntax: "MacAddress", Kind: mib.IndexKindString, FixedLength: 6},
+		}},
+		{OID: "1.3.6.1.2.1.17.4.3.1.2", Name: "dot1dTpFdbPort", Type: "column", Syntax: "Integer32", Access: "read-only", ParentOID: "1.3.6.1.2.1.17.4.3.1"},
+		{OID: "1.3.6.1.4.1.8072.1.3.2.2", Name: "nsExtendConfigTable", Type: "table", ParentOID: "1.3.6.1.4.1.8072.1.3.2"},
+		{OID: "1.3.6.1.4.1.8072.1.3.2.2.1", Name: "nsExtendConfigEntry", Type: "row", ParentOID: "1.3.6.1.4.1.8072.1.3.2.2", Index: []mib.IndexComponent{
+			{OID: "1.3.6.1.4.1.8072.1.3.2.2.1.1", Name: "nsExtendToken", Syntax: "DisplayString", Kind: mib.IndexKindString},
+		}},
+		{OID: "1.3.6.1.4.1.8072.1.3.2.2.1.2", Name: "nsExtendCommand", Type: "column", Syntax: "DisplayString", Access: "read-create", ParentOID: "1.3.6.1.4.1.8072.1.3.2.2.1"},
+	}, moduleID); err != nil {
+		t.Fatalf("SaveNodes() error = %v", err)
+	}
+
+	agent := startTestAgent(t, map[string]gosnmp.SnmpPDU{
+		"1.3.6.1.2.1.17.4.3.1.2.0.26.43.60.77.94":            {Type: gosnmp.Integer, Value: 3},
+		"1.3.6.1.4.1.8072.1.3.2.2.1.2.4.116.101.115.116":     {Type: gosnmp.OctetString, Value: []byte("/bin/true")},
+		"1.3.6.1.4.1.8072.1.3.2.2.1.2.5.117.112.116.105.109": {Type: gosnmp.OctetString, Value: []byte("/usr/bin/uptime")},
+	})
+
+	macTable, err := app.FetchTableData(agent.config(), "1.3.6.1.2.1.17.4.3", "", "")
+	if err != nil {
+		t.Fatalf("FetchTableData(fdb) error = %v", err)
+	}
+	if len(macTable.Rows) != 1 || len(macTable.IndexColumns) != 1 || macTable.IndexColumns[0] != "dot1dTpFdbAddress" {
+		t.Fatalf("unexpected fdb table: %+v", macTable)
+	}
+	if got := macTable.Rows[0]["dot1dTpFdbAddress"]; got != "00:1A:2B:3C:4D:5E" {
+		t.Fatalf("dot1dTpFdbAddress = %q, want 00:1A:2B:3C:4D:5E", got)
+	}
+
+	stringTable, err := app.FetchTableData(agent.config(), "1.3.6.1.4.1.8072.1.3.2.2", "", "")
+	if err != nil {
+		t.Fatalf("FetchTableData(extend) error = %v", err)
+	}
+	if len(stringTable.Rows) != 2 {
+		t.Fatalf("expected 2 extend rows, got %+v", stringTable.Rows)
+	}
+	for i, token := range []string{"test", "uptim"} {
+		if got := stringTable.Rows[i]["nsExtendToken"]; got != token {
+			t.Fatalf("row %d nsExtendToken = %q, want %q", i, got, token)
+		}
+	}
+}
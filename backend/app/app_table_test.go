@@ -0,0 +1,411 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+)
+
+func TestSNMPTableDeleteRow_NoRowStatus(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.2.2", Name: "ifTable", Type: "table", ParentOID: "1.3.6.1.2.1.2"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1", Name: "ifEntry", Type: "row", ParentOID: "1.3.6.1.2.1.2.2"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.2", Name: "ifDescr", Type: "column", Syntax: "DisplayString", ParentOID: "1.3.6.1.2.1.2.2.1"},
+	)
+
+	_, err := app.SNMPTableDeleteRow(snmp.Config{Host: "127.0.0.1"}, "1.3.6.1.2.1.2.2", "1")
+	if !errors.Is(err, ErrNoRowStatus) {
+		t.Fatalf("SNMPTableDeleteRow() error = %v, want ErrNoRowStatus", err)
+	}
+}
+
+func TestFindRowStatusColumn(t *testing.T) {
+	columns := []*mib.Node{
+		{OID: "1.3.6.1.4.1.9999.1.1.2", Name: "testName", Syntax: "DisplayString"},
+		{OID: "1.3.6.1.4.1.9999.1.1.3", Name: "testRowStatus", Syntax: "RowStatus {active(1), destroy(6)}"},
+	}
+
+	got := findRowStatusColumn(columns)
+	if got == nil || got.Name != "testRowStatus" {
+		t.Fatalf("findRowStatusColumn() = %v, want testRowStatus", got)
+	}
+}
+
+func TestFindReadableColumn(t *testing.T) {
+	columns := []*mib.Node{
+		{OID: "1.3.6.1.2.1.4.21.1.1", Name: "ipRouteIndex", Access: "not-accessible"},
+		{OID: "1.3.6.1.2.1.4.21.1.2", Name: "ipRouteIfIndex", Access: "read-write"},
+	}
+
+	got := findReadableColumn(columns)
+	if got == nil || got.Name != "ipRouteIfIndex" {
+		t.Fatalf("findReadableColumn() = %v, want ipRouteIfIndex", got)
+	}
+
+	if got := findReadableColumn(columns[:1]); got != nil {
+		t.Fatalf("findReadableColumn() = %v, want nil", got)
+	}
+}
+
+func TestGetMIBTableListCachesUntilInvalidated(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.2.2", Name: "ifTable", Type: "table", ParentOID: "1.3.6.1.2.1.2"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1", Name: "ifEntry", Type: "row", ParentOID: "1.3.6.1.2.1.2.2"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.1", Name: "ifIndex", Type: "column", ParentOID: "1.3.6.1.2.1.2.2.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.2", Name: "ifDescr", Type: "column", ParentOID: "1.3.6.1.2.1.2.2.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.4.20", Name: "ipAddrTable", Type: "table", ParentOID: "1.3.6.1.2.1.4"},
+	)
+
+	tables, err := app.GetMIBTableList()
+	if err != nil {
+		t.Fatalf("GetMIBTableList() error = %v", err)
+	}
+	if len(tables) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(tables))
+	}
+
+	ifTable := tables[0]
+	if ifTable.TableNode.Name != "ifTable" || ifTable.RowNode == nil || ifTable.RowNode.Name != "ifEntry" {
+		t.Fatalf("unexpected ifTable summary: %+v", ifTable)
+	}
+	if ifTable.ColumnCount != 2 || ifTable.Module != "TEST-MIB" {
+		t.Fatalf("unexpected ifTable counts: %+v", ifTable)
+	}
+	if tables[1].RowNode != nil || tables[1].ColumnCount != 0 {
+		t.Fatalf("expected table without entry to have no row, got %+v", tables[1])
+	}
+
+	moduleID, err := app.mibDB.GetModuleID("TEST-MIB")
+	if err != nil {
+		t.Fatalf("GetModuleID() error = %v", err)
+	}
+	if err := app.mibDB.SaveNode(&mib.Node{OID: "1.3.6.1.2.1.1.9", Name: "sysORTable", Type: "table", ParentOID: "1.3.6.1.2.1.1"}, moduleID); err != nil {
+		t.Fatalf("SaveNode() error = %v", err)
+	}
+
+	cached, err := app.GetMIBTableList()
+	if err != nil {
+		t.Fatalf("GetMIBTableList() error = %v", err)
+	}
+	if len(cached) != 2 {
+		t.Fatalf("expected cached list to be reused, got %d tables", len(cached))
+	}
+
+	// Le modifiche del chiamante non alterano la cache.
+	cached[0].ColumnCount = 99
+	cached[0].TableNode.Name = "mutated"
+	again, err := app.GetMIBTableList()
+	if err != nil {
+		t.Fatalf("GetMIBTableList() error = %v", err)
+	}
+	if again[0].ColumnCount == 99 || again[0].TableNode.Name == "mutated" {
+		t.Fatalf("expected cached list to be isolated from callers, got %+v", again[0].TableNode)
+	}
+
+	app.invalidateTableList()
+	refreshed, err := app.GetMIBTableList()
+	if err != nil {
+		t.Fatalf("GetMIBTableList() error = %v", err)
+	}
+	if len(refreshed) != 3 || refreshed[0].TableNode.Name != "sysORTable" {
+		t.Fatalf("expected refreshed list with sysORTable first, got %d tables", len(refreshed))
+	}
+}
+
+func TestFetchTableDataMultiHostBoundsConcurrencyAndKeepsFailures(t *testing.T) {
+	configs := make([]snmp.Config, 12)
+	for i := range configs {
+		configs[i] = snmp.Config{Host: fmt.Sprintf("192.0.2.%d", i+1)}
+	}
+
+	var running, peak int32
+	results := fetchTableDataMultiHost(configs, func(config snmp.Config) (*TableDataResponse, error) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			observed := atomic.LoadInt32(&peak)
+			if current <= observed || atomic.CompareAndSwapInt32(&peak, observed, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		if config.Host == "192.0.2.3" {
+			return nil, errors.New("request timeout")
+		}
+		return &TableDataResponse{TableOID: "1.3.6.1.2.1.2.2"}, nil
+	})
+
+	if peak > maxMultiHostTableWorkers {
+		t.Fatalf("peak concurrency = %d, want <= %d", peak, maxMultiHostTableWorkers)
+	}
+	if len(results) != len(configs) {
+		t.Fatalf("expected %d results, got %d", len(configs), len(results))
+	}
+	for i, result := range results {
+		if result.Host != configs[i].Host {
+			t.Fatalf("result %d host = %s, want %s", i, result.Host, configs[i].Host)
+		}
+	}
+	if results[2].Error != "request timeout" || results[2].Data != nil {
+		t.Fatalf("expected failure for third host, got %+v", results[2])
+	}
+	if results[0].Error != "" || results[0].Data == nil {
+		t.Fatalf("expected data for first host, got %+v", results[0])
+	}
+}
+
+func TestFetchTableDataMultiHostReportsPerHostErrors(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+
+	results, err := app.FetchTableDataMultiHost([]snmp.Config{{Host: "192.0.2.1"}, {Host: "192.0.2.2"}}, "1.3.6.1.2.1.2.2")
+	if err != nil {
+		t.Fatalf("FetchTableDataMultiHost() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Error == "" || results[1].Error == "" {
+		t.Fatalf("expected per-host errors for unknown table, got %+v", results)
+	}
+
+	if _, err := app.FetchTableDataMultiHost(nil, "1.3.6.1.2.1.2.2"); err == nil {
+		t.Fatalf("expected error without hosts")
+	}
+}
+
+func TestMakeColumnLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "ifDescr", want: "If Descr"},
+		{name: "sysUpTime", want: "Sys Up Time"},
+		{name: "IPv6AddressType", want: "IPv6 Address Type"},
+		{name: "ipAddressIfIndex", want: "Ip Address If Index"},
+		{name: "ifHCInOctets", want: "If HC In Octets"},
+		{name: "snmpTargetAddrTAddress", want: "Snmp Target Addr T Address"},
+		{name: "dot1dBasePort", want: "Dot1d Base Port"},
+		{name: "_private_col", want: "Private Col"},
+		{name: "trailing-dash-", want: "Trailing Dash"},
+		{name: "  spaced   name ", want: "Spaced Name"},
+		{name: "", want: ""},
+		{name: "   ", want: ""},
+		{name: "x", want: "X"},
+		{name: "OID", want: "OID"},
+	}
+
+	for _, tt := range tests {
+		if got := makeColumnLabel(tt.name); got != tt.want {
+			t.Errorf("makeColumnLabel(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestInferColumnValueType(t *testing.T) {
+	tests := []struct {
+		syntax string
+		want   ColumnValueType
+	}{
+		{syntax: "Counter32", want: ColumnTypeInteger},
+		{syntax: "Counter64", want: ColumnTypeInteger},
+		{syntax: "Gauge32", want: ColumnTypeInteger},
+		{syntax: "TimeTicks", want: ColumnTypeInteger},
+		{syntax: "Integer32 (0..2147483647)", want: ColumnTypeInteger},
+		{syntax: "Unsigned32", want: ColumnTypeInteger},
+		{syntax: "InterfaceIndex (1..2147483647)", want: ColumnTypeInteger},
+		{syntax: "DisplayString (0..255)", want: ColumnTypeString},
+		{syntax: "OCTET STRING", want: ColumnTypeString},
+		{syntax: "OctetString", want: ColumnTypeString},
+		{syntax: "PrintableString", want: ColumnTypeString},
+		{syntax: "OBJECT IDENTIFIER", want: ColumnTypeString},
+		{syntax: "IpAddress", want: ColumnTypeIPAddress},
+		{syntax: "InetAddress (0..255)", want: ColumnTypeIPAddress},
+		{syntax: "MacAddress", want: ColumnTypeMACAddress},
+		{syntax: "PhysAddress", want: ColumnTypeMACAddress},
+		{syntax: "TruthValue {true(1), false(2)}", want: ColumnTypeBoolean},
+		{syntax: "INTEGER {up(1), down(2), testing(3)}", want: ColumnTypeEnum},
+		{syntax: "BITS {up(0), down(1)}", want: ColumnTypeString},
+		{syntax: "DateAndTime (8 | 11)", want: ColumnTypeDateTime},
+		{syntax: "Float32TC", want: ColumnTypeFloat},
+		{syntax: "", want: ColumnTypeString},
+	}
+
+	for _, tt := range tests {
+		if got := inferColumnValueType(tt.syntax); got != tt.want {
+			t.Errorf("inferColumnValueType(%q) = %q, want %q", tt.syntax, got, tt.want)
+		}
+	}
+}
+
+func TestSortTableRows(t *testing.T) {
+	columns := []TableColumn{
+		{Key: "ifDescr", Type: ColumnTypeString},
+		{Key: "ifSpeed", Type: ColumnTypeInteger},
+	}
+	newRows := func() []TableRow {
+		return []TableRow{
+			{"__instance": "10", "ifDescr": "eth10", "ifSpeed": "1 Gbps", "ifSpeed__raw": "1000000000"},
+			{"__instance": "2", "ifDescr": "Eth2", "ifSpeed": "100 Mbps", "ifSpeed__raw": "100000000"},
+			{"__instance": "1.5", "ifDescr": "lo"},
+			{"__instance": "9", "ifDescr": "eth1", "ifSpeed": "10 Mbps", "ifSpeed__raw": "10000000"},
+		}
+	}
+	instances := func(rows []TableRow) []string {
+		keys := make([]string, len(rows))
+		for i, row := range rows {
+			keys[i] = row["__instance"]
+		}
+		return keys
+	}
+
+	tests := []struct {
+		column    string
+		direction string
+		want      []string
+	}{
+		{column: "__instance", direction: "asc", want: []string{"1.5", "2", "9", "10"}},
+		{column: "__instance", direction: "desc", want: []string{"10", "9", "2", "1.5"}},
+		{column: "ifSpeed", direction: "asc", want: []string{"9", "2", "10", "1.5"}},
+		{column: "ifSpeed", direction: "desc", want: []string{"10", "2", "9", "1.5"}},
+		{column: "ifDescr", direction: "", want: []string{"9", "10", "2", "1.5"}},
+		{column: "", direction: "", want: []string{"10", "2", "1.5", "9"}},
+	}
+
+	for _, tt := range tests {
+		rows := newRows()
+		if err := sortTableRows(rows, columns, tt.column, tt.direction); err != nil {
+			t.Fatalf("sortTableRows(%q, %q) error = %v", tt.column, tt.direction, err)
+		}
+		if got := instances(rows); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("sortTableRows(%q, %q) = %v, want %v", tt.column, tt.direction, got, tt.want)
+		}
+	}
+
+	if err := sortTableRows(newRows(), columns, "ifMissing", "asc"); err == nil {
+		t.Error("expected error for unknown sort column")
+	}
+	if err := sortTableRows(newRows(), columns, "ifDescr", "sideways"); err == nil {
+		t.Error("expected error for invalid sort direction")
+	}
+}
+
+func TestBuildTableRowsSortsIPv4KeyedInstances(t *testing.T) {
+	columns := []*mib.Node{{OID: "1.3.6.1.2.1.4.21.1.1", Name: "ipRouteDest"}}
+	results := []snmp.Result{
+		{OID: ".1.3.6.1.2.1.4.21.1.1.192.168.1.0", Value: "192.168.1.0"},
+		{OID: ".1.3.6.1.2.1.4.21.1.1.10.0.0.0", Value: "10.0.0.0"},
+		{OID: ".1.3.6.1.2.1.4.21.1.1.192.168.10.0", Value: "192.168.10.0"},
+		{OID: ".1.3.6.1.2.1.4.21.1.1.0.0.0.0", Value: "0.0.0.0"},
+		{OID: ".1.3.6.1.2.1.4.21.1.1.172.16.0.0", Value: "172.16.0.0"},
+	}
+
+	rows := buildTableRows(results, columns)
+	got := make([]string, len(rows))
+	for i, row := range rows {
+		got[i] = row["__instance"]
+	}
+	want := []string{"0.0.0.0", "10.0.0.0", "172.16.0.0", "192.168.1.0", "192.168.10.0"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("instance order = %v, want %v", got, want)
+	}
+}
+
+func TestBuildTableRowsFormatsTypedInetAddresses(t *testing.T) {
+	columns := []*mib.Node{
+		{OID: "1.3.6.1.2.1.4.34.1.1", Name: "ipAddressAddrType", Syntax: "InetAddressType {unknown(0), ipv4(1), ipv6(2), ipv4z(3), ipv6z(4), dns(16)}"},
+		{OID: "1.3.6.1.2.1.4.34.1.2", Name: "ipAddressAddr", Syntax: "InetAddress (0..255)"},
+	}
+	results := []snmp.Result{
+		{OID: ".1.3.6.1.2.1.4.34.1.1.1", Value: "3", Type: "Integer"},
+		{OID: ".1.3.6.1.2.1.4.34.1.1.2", Value: "2", Type: "Integer"},
+		{OID: ".1.3.6.1.2.1.4.34.1.2.1", Value: "0x0a00000100000003", Type: "OctetString"},
+		{OID: ".1.3.6.1.2.1.4.34.1.2.2", Value: "0xfe800000000000000000000000000001", Type: "OctetString"},
+	}
+
+	rows := buildTableRows(results, columns)
+	if len(rows) != 2 {
+		t.Fatalf("rows = %d, want 2", len(rows))
+	}
+	if got := rows[0]["ipAddressAddr"]; got != "10.0.0.1%3" {
+		t.Fatalf("ipv4z address = %q, want %q", got, "10.0.0.1%3")
+	}
+	if got := rows[1]["ipAddressAddr"]; got != "fe80::1" {
+		t.Fatalf("ipv6 address = %q, want %q", got, "fe80::1")
+	}
+	if got := rows[1]["ipAddressAddr__raw"]; got != "0xfe800000000000000000000000000001" {
+		t.Fatalf("raw address = %q, want it unchanged", got)
+	}
+}
+
+func TestBuildTypedTableRows(t *testing.T) {
+	columns := []TableColumn{
+		{Key: "ifInOctets", Type: ColumnTypeInteger},
+		{Key: "ifHCInOctets", Type: ColumnTypeInteger},
+		{Key: "ifLastChange", Type: ColumnTypeInteger},
+		{Key: "ifOperStatus", Type: ColumnTypeEnum},
+		{Key: "ifDescr", Type: ColumnTypeString},
+	}
+	rows := []TableRow{{
+		"__instance":        "1",
+		"ifInOctets":        "1024",
+		"ifHCInOctets":      "18446744073709551615",
+		"ifLastChange":      "1 day, 0:00:00",
+		"ifLastChange__raw": "8640000",
+		"ifOperStatus":      "up(1)",
+		"ifOperStatus__raw": "1",
+		"ifDescr":           "42",
+	}}
+
+	typed := buildTypedTableRows(rows, columns)
+	if len(typed) != 1 {
+		t.Fatalf("expected 1 typed row, got %d", len(typed))
+	}
+	row := typed[0]
+	if row["ifInOctets"] != int64(1024) || row["ifHCInOctets"] != uint64(18446744073709551615) || row["ifLastChange"] != int64(8640000) {
+		t.Fatalf("expected numeric cells, got %#v", row)
+	}
+	if row["ifOperStatus"] != "up(1)" || row["ifDescr"] != "42" || row["__instance"] != "1" {
+		t.Fatalf("expected non-numeric columns to stay strings, got %#v", row)
+	}
+	if _, err := json.Marshal(typed); err != nil {
+		t.Fatalf("typed rows must encode as JSON: %v", err)
+	}
+}
+
+func TestSortInstanceKeysOrdersIPv4Addresses(t *testing.T) {
+	keys := []string{"192.168.1.254", "10.0.0.10", "10.0.0.9", "9.255.255.255"}
+	sortInstanceKeys(keys)
+	want := []string{"9.255.255.255", "10.0.0.9", "10.0.0.10", "192.168.1.254"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Fatalf("sortInstanceKeys() = %v, want %v", keys, want)
+	}
+}
+
+func TestGetSNMPWritableTables(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.2.2", Name: "ifTable", Type: "table", ParentOID: "1.3.6.1.2.1.2"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1", Name: "ifEntry", Type: "row", ParentOID: "1.3.6.1.2.1.2.2"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.2", Name: "ifDescr", Type: "column", Access: "read-only", ParentOID: "1.3.6.1.2.1.2.2.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.7", Name: "ifAdminStatus", Type: "column", Access: "read-write", ParentOID: "1.3.6.1.2.1.2.2.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.20", Name: "decoyTable", Type: "table", ParentOID: "1.3.6.1.2.1.2"},
+		&mib.Node{OID: "1.3.6.1.2.1.4.20", Name: "ipAddrTable", Type: "table", ParentOID: "1.3.6.1.2.1.4"},
+		&mib.Node{OID: "1.3.6.1.2.1.4.20.1", Name: "ipAddrEntry", Type: "row", ParentOID: "1.3.6.1.2.1.4.20"},
+		&mib.Node{OID: "1.3.6.1.2.1.4.20.1.1", Name: "ipAdEntAddr", Type: "column", Access: "read-only", ParentOID: "1.3.6.1.2.1.4.20.1"},
+	)
+
+	tables, err := app.GetSNMPWritableTables()
+	if err != nil {
+		t.Fatalf("GetSNMPWritableTables() error = %v", err)
+	}
+	if len(tables) != 1 || tables[0].TableNode.Name != "ifTable" {
+		t.Fatalf("expected only ifTable to be writable, got %+v", tables)
+	}
+	if tables[0].RowNode == nil || tables[0].ColumnCount != 2 {
+		t.Fatalf("expected full summary for ifTable, got %+v", tables[0])
+	}
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// shutdownGracePeriod è l'attesa massima per la conclusione dei task in background alla chiusura.
+const shutdownGracePeriod = 5 * time.Second
+
+// backgroundContext restituisce il contesto condiviso dai task in background, creandolo se necessario.
+// Viene annullato da Shutdown.
+func (a *App) backgroundContext() context.Context {
+	a.tasksM.Lock()
+	defer a.tasksM.Unlock()
+	return a.backgroundContextLocked()
+}
+
+func (a *App) backgroundContextLocked() context.Context {
+	if a.tasksCtx == nil {
+		a.tasksCtx, a.tasksCancel = context.WithCancel(context.Background())
+	}
+	return a.tasksCtx
+}
+
+// goBackground avvia fn in una goroutine registrata, così che Shutdown possa annullarla e attenderla.
+// Dopo Shutdown i nuovi task vengono rifiutati.
+func (a *App) goBackground(name string, fn func(ctx context.Context)) error {
+	a.tasksM.Lock()
+	if a.tasksClosed {
+		a.tasksM.Unlock()
+		return fmt.Errorf("application is shutting down")
+	}
+	ctx := a.backgroundContextLocked()
+	if a.activeTasks == nil {
+		a.activeTasks = make(map[string]int)
+	}
+	a.activeTasks[name]++
+	a.tasksWG.Add(1)
+	a.tasksM.Unlock()
+
+	go func() {
+		defer func() {
+			a.tasksM.Lock()
+			if a.activeTasks[name]--; a.activeTasks[name] <= 0 {
+				delete(a.activeTasks, name)
+			}
+			a.tasksM.Unlock()
+			a.tasksWG.Done()
+		}()
+		fn(ctx)
+	}()
+	return nil
+}
+
+// runningTasks restituisce i nomi dei task in background ancora attivi, in ordine alfabetico.
+func (a *App) runningTasks() []string {
+	a.tasksM.Lock()
+	defer a.tasksM.Unlock()
+
+	names := make([]string, 0, len(a.activeTasks))
+	for name := range a.activeTasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// stopBackgroundTasks annulla il contesto condiviso e attende i task registrati fino a timeout.
+// Ritorna false se qualche task non si è concluso in tempo.
+func (a *App) stopBackgroundTasks(timeout time.Duration) bool {
+	a.tasksM.Lock()
+	a.tasksClosed = true
+	if a.tasksCancel != nil {
+		a.tasksCancel()
+	}
+	a.tasksM.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		a.tasksWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// runBounded esegue task(i) per ogni i in [0, count) con al massimo limit esecuzioni contemporanee
+// (almeno una) e ritorna quando sono tutte concluse.
+func runBounded(count, limit int, task func(i int)) {
+	if limit < 1 {
+		limit = 1
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < limit && worker < count; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				task(i)
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"mib-to-the-future/backend/snmp"
+)
+
+func TestShutdownCancelsBackgroundTasks(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	if err := app.goBackground("poll", func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		close(stopped)
+	}); err != nil {
+		t.Fatalf("goBackground() error = %v", err)
+	}
+	<-started
+
+	if tasks := app.runningTasks(); len(tasks) != 1 || tasks[0] != "poll" {
+		t.Fatalf("runningTasks() = %v, want [poll]", tasks)
+	}
+
+	app.Shutdown(context.Background())
+
+	select {
+	case <-stopped:
+	default:
+		t.Fatalf("expected Shutdown to wait for the cancelled task")
+	}
+	if tasks := app.runningTasks(); len(tasks) != 0 {
+		t.Fatalf("expected no running tasks after shutdown, got %v", tasks)
+	}
+	if err := app.goBackground("late", func(context.Context) {}); err == nil {
+		t.Fatalf("expected new tasks to be rejected after shutdown")
+	}
+	if app.backgroundContext().Err() == nil {
+		t.Fatalf("expected SNMP clients to receive a cancelled context after shutdown")
+	}
+	if _, err := app.mibDB.ListModules(); err == nil {
+		t.Fatalf("expected database to be closed after shutdown")
+	}
+
+	// Le richieste create dopo la chiusura falliscono subito invece di attendere il timeout.
+	client, err := app.newSNMPClient(snmp.Config{Host: "192.0.2.1"})
+	if err != nil {
+		t.Fatalf("newSNMPClient() error = %v", err)
+	}
+	start := time.Now()
+	if _, err := client.Get("1.3.6.1.2.1.1.3.0"); err == nil {
+		t.Fatalf("expected GET on a cancelled context to fail")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("GET after shutdown took %v, expected immediate failure", elapsed)
+	}
+}
+
+func TestStopBackgroundTasksTimesOut(t *testing.T) {
+	app := NewApp()
+	release := make(chan struct{})
+	defer close(release)
+
+	if err := app.goBackground("stuck", func(context.Context) { <-release }); err != nil {
+		t.Fatalf("goBackground() error = %v", err)
+	}
+	if app.stopBackgroundTasks(20 * time.Millisecond) {
+		t.Fatalf("expected stopBackgroundTasks to report a task that ignores cancellation")
+	}
+}
+
+func TestRunBoundedLimitsConcurrency(t *testing.T) {
+	var running, peak int32
+	done := make([]int32, 10)
+	runBounded(len(done), 3, func(i int) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			observed := atomic.LoadInt32(&peak)
+			if current <= observed || atomic.CompareAndSwapInt32(&peak, observed, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&done[i], 1)
+	})
+
+	if peak > 3 {
+		t.Fatalf("peak concurrency = %d, want <= 3", peak)
+	}
+	for i, count := range done {
+		if count != 1 {
+			t.Fatalf("task %d ran %d times, want 1", i, count)
+		}
+	}
+
+	// Un limite non positivo esegue comunque i task, uno alla volta.
+	ran := 0
+	runBounded(2, 0, func(int) { ran++ })
+	if ran != 2 {
+		t.Fatalf("expected 2 tasks with a zero limit, got %d", ran)
+	}
+}
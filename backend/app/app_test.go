@@ -1,6 +1,9 @@
 package app
 
 import (
+	"fmt"
+	"reflect"
+	"sync"
 	"testing"
 
 	"mib-to-the-future/backend/mib"
@@ -123,3 +126,136 @@ func TestGetMIBModuleDetails(t *testing.T) {
 		t.Errorf("details.MissingImports = %v, want %v", details.MissingImports, missing)
 	}
 }
+
+func TestGetModuleTreeFlat(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.2", Name: "interfaces", Type: "node"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2", Name: "ifTable", Type: "table", ParentOID: "1.3.6.1.2.1.2"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1", Name: "ifEntry", Type: "node", ParentOID: "1.3.6.1.2.1.2.2"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.10", Name: "ifInOctets", Type: "column", ParentOID: "1.3.6.1.2.1.2.2.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.2", Name: "ifDescr", Type: "column", ParentOID: "1.3.6.1.2.1.2.2.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.1", Name: "ifNumber", Type: "scalar", ParentOID: "1.3.6.1.2.1.2"},
+	)
+
+	flat, err := app.GetModuleTreeFlat("TEST-MIB")
+	if err != nil {
+		t.Fatalf("GetModuleTreeFlat() error = %v", err)
+	}
+
+	got := []string{}
+	for _, node := range flat {
+		got = append(got, fmt.Sprintf("%d:%s", node.Depth, node.Name))
+		if len(node.Children) != 0 {
+			t.Fatalf("expected flat node %s without children", node.Name)
+		}
+	}
+	want := []string{"0:interfaces", "1:ifNumber", "1:ifTable", "2:ifEntry", "3:ifDescr", "3:ifInOctets"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("GetModuleTreeFlat() = %v, want %v", got, want)
+	}
+
+	if _, err := app.GetModuleTreeFlat(" "); err == nil {
+		t.Fatalf("expected empty module name to be rejected")
+	}
+}
+
+// TestReloadMIBDatabaseConcurrentSearch ricarica ripetutamente il database mentre altre goroutine
+// eseguono ricerche: nessuna ricerca deve fallire o usare un database già chiuso (go test -race).
+func TestReloadMIBDatabaseConcurrentSearch(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.1", Name: "system", Type: "node"},
+		&mib.Node{OID: "1.3.6.1.2.1.1.5", Name: "sysName", Type: "scalar", ParentOID: "1.3.6.1.2.1.1"},
+	)
+	t.Cleanup(func() {
+		closeRetiredMIBDB(app.swapMIBDB(nil, nil))
+	})
+
+	stop := make(chan struct{})
+	errs := make(chan error, 4)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				nodes, err := app.SearchMIBNodes("sysName")
+				if err == nil && len(nodes) == 0 {
+					err = fmt.Errorf("sysName not found")
+				}
+				if err == nil {
+					_ = app.resolveOIDName("1.3.6.1.2.1.1.5.0")
+				}
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 20; i++ {
+		if err := app.ReloadMIBDatabase(); err != nil {
+			close(stop)
+			wg.Wait()
+			t.Fatalf("ReloadMIBDatabase() error = %v", err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+
+	select {
+	case err := <-errs:
+		t.Fatalf("search during reload failed: %v", err)
+	default:
+	}
+	if name := app.resolveOIDName("1.3.6.1.2.1.1.5.0"); name != "sysName" {
+		t.Fatalf("resolveOIDName() after reload = %q, want sysName", name)
+	}
+}
+
+func TestRepairOrphansOnLoadIsPersisted(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+
+	enabled, err := app.GetRepairOrphansOnLoad()
+	if err != nil || !enabled {
+		t.Fatalf("expected orphan repair enabled by default, got %v (err=%v)", enabled, err)
+	}
+	if err := app.SetRepairOrphansOnLoad(false); err != nil {
+		t.Fatalf("SetRepairOrphansOnLoad() error = %v", err)
+	}
+	enabled, err = app.GetRepairOrphansOnLoad()
+	if err != nil || enabled {
+		t.Fatalf("expected orphan repair disabled after saving, got %v (err=%v)", enabled, err)
+	}
+}
+
+func TestMIBCatalogBackfillRunsOnce(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+
+	backfill, err := app.mibCatalogNeedsBackfill()
+	if err != nil || !backfill {
+		t.Fatalf("expected modules loaded before the catalog to need a backfill, got %v (err=%v)", backfill, err)
+	}
+	if err := app.backfillMIBCatalog(t.TempDir()); err != nil {
+		t.Fatalf("backfillMIBCatalog() error = %v", err)
+	}
+	if backfill, err := app.mibCatalogNeedsBackfill(); err != nil || backfill {
+		t.Fatalf("expected no backfill after the update, got %v (err=%v)", backfill, err)
+	}
+
+	fresh := setupTestAppWithNodes(t)
+	if err := fresh.mibDB.DeleteModule("TEST-MIB"); err != nil {
+		t.Fatalf("DeleteModule() error = %v", err)
+	}
+	if backfill, err := fresh.mibCatalogNeedsBackfill(); err != nil || backfill {
+		t.Fatalf("expected an empty database not to need a backfill, got %v (err=%v)", backfill, err)
+	}
+	if _, ok, _ := fresh.mibDB.GetMetadata(mibCatalogVersionMetadataKey); !ok {
+		t.Fatalf("expected the catalog version to be recorded for an empty database")
+	}
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"mib-to-the-future/backend/snmp"
+)
+
+// snmpTrapOIDOID è l'istanza di SNMPv2-MIB::snmpTrapOID che identifica il tipo di notifica.
+const snmpTrapOIDOID = "1.3.6.1.6.3.1.1.4.1.0"
+
+// DecodedVarbind è un varbind di una trap con il nome risolto e il suo ruolo nella notifica.
+type DecodedVarbind struct {
+	OID                  string `json:"oid"`
+	Name                 string `json:"name"`
+	Type                 string `json:"type"`
+	Value                string `json:"value"`
+	DisplayValue         string `json:"displayValue"`
+	IsTrapOID            bool   `json:"isTrapOid"`
+	IsNotificationObject bool   `json:"isNotificationObject"`
+}
+
+// DecodedTrap è la rappresentazione leggibile di una trap SNMPv2c/v3.
+type DecodedTrap struct {
+	TrapOID  string           `json:"trapOid"`
+	TrapName string           `json:"trapName"`
+	Module   string           `json:"module"`
+	Varbinds []DecodedVarbind `json:"varbinds"`
+}
+
+// DecodeTrap etichetta i varbind di una trap: risolve i nomi, marca il varbind snmpTrapOID
+// e quelli dichiarati nella clausola OBJECTS della notifica, e ricava il nome della notifica
+// dal valore di snmpTrapOID.
+func (a *App) DecodeTrap(varbinds []snmp.Result) (*DecodedTrap, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	trap := &DecodedTrap{Varbinds: make([]DecodedVarbind, 0, len(varbinds))}
+	for _, varbind := range varbinds {
+		if normalizeTrapOID(varbind.OID) == snmpTrapOIDOID {
+			trap.TrapOID = normalizeTrapOID(varbind.Value)
+			break
+		}
+	}
+	if trap.TrapOID == "" {
+		return nil, fmt.Errorf("trap has no snmpTrapOID varbind")
+	}
+
+	payload := make(map[string]struct{})
+	// Il nome si usa solo con corrispondenza esatta: un antenato (es. snmpTraps) sarebbe fuorviante.
+	if node := a.lookupNodeForOID(trap.TrapOID); node != nil && normalizeTrapOID(node.OID) == trap.TrapOID {
+		trap.TrapName = node.Name
+		trap.Module = node.Module
+	}
+	objects, err := db.GetNotificationObjects(trap.TrapOID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notification objects: %v", err)
+	}
+	for _, object := range objects {
+		payload[object] = struct{}{}
+	}
+
+	for _, varbind := range varbinds {
+		result := varbind
+		a.decorateResultValue(&result)
+
+		oid := normalizeTrapOID(varbind.OID)
+		decoded := DecodedVarbind{
+			OID:          oid,
+			Name:         a.resolveOIDName(oid),
+			Type:         varbind.Type,
+			Value:        varbind.Value,
+			DisplayValue: result.DisplayValue,
+			IsTrapOID:    oid == snmpTrapOIDOID,
+		}
+		if node := a.lookupNodeForOID(oid); node != nil {
+			_, decoded.IsNotificationObject = payload[strings.TrimPrefix(node.OID, ".")]
+		}
+		if decoded.IsTrapOID {
+			decoded.DisplayValue = trap.TrapOID
+			if trap.TrapName != "" {
+				decoded.DisplayValue = trap.TrapName
+			}
+		}
+		trap.Varbinds = append(trap.Varbinds, decoded)
+	}
+
+	return trap, nil
+}
+
+// normalizeTrapOID rimuove spazi e il punto iniziale con cui gosnmp riporta gli OID.
+func normalizeTrapOID(oid string) string {
+	return strings.TrimPrefix(strings.TrimSpace(oid), ".")
+}
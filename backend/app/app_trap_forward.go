@@ -0,0 +1,585 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	// trapForwardingMetadataKey è la chiave di app_metadata con la configurazione dell'inoltro trap.
+	trapForwardingMetadataKey = "trap_forwarding"
+	// defaultTrapQueueSize è la capacità predefinita della coda di inoltro.
+	defaultTrapQueueSize = 256
+	maxTrapQueueSize     = 10000
+	// defaultTrapForwardRetries è il numero predefinito di nuovi tentativi per sink prima del dead-letter.
+	defaultTrapForwardRetries = 3
+	maxTrapForwardRetries     = 10
+	// trapSinkTimeout limita ogni singolo invio verso un sink.
+	trapSinkTimeout = 10 * time.Second
+	// syslogFacilityLocal0 è la facility syslog predefinita (local0).
+	syslogFacilityLocal0 = 16
+)
+
+// SyslogSinkConfig configura l'inoltro delle trap verso un server syslog (RFC5424).
+type SyslogSinkConfig struct {
+	Enabled  bool   `json:"enabled"`
+	Address  string `json:"address"`  // host:porta
+	Protocol string `json:"protocol"` // udp (predefinito) o tcp
+	Facility int    `json:"facility"` // 0-23 (0 = kern), predefinita local0 (16)
+}
+
+// WebhookSinkConfig configura l'inoltro delle trap come JSON verso un endpoint HTTP.
+type WebhookSinkConfig struct {
+	Enabled bool   `json:"enabled"`
+	URL     string `json:"url"`
+}
+
+// TrapForwardingConfig raccoglie i sink di inoltro, abilitabili in modo indipendente.
+type TrapForwardingConfig struct {
+	Syslog     SyslogSinkConfig  `json:"syslog"`
+	Webhook    WebhookSinkConfig `json:"webhook"`
+	QueueSize  int               `json:"queueSize"`
+	MaxRetries int               `json:"maxRetries"`
+}
+
+// TrapForwardEvent è la trap inoltrata ai sink, con l'host di origine e la severità stimata.
+type TrapForwardEvent struct {
+	Source    string       `json:"source"`
+	Timestamp string       `json:"timestamp"`
+	Severity  string       `json:"severity"`
+	Trap      *DecodedTrap `json:"trap"`
+}
+
+// TrapSinkStats riporta l'esito degli inoltri verso un sink.
+type TrapSinkStats struct {
+	Enabled     bool   `json:"enabled"`
+	Forwarded   uint64 `json:"forwarded"`
+	Retries     uint64 `json:"retries"`
+	DeadLetters uint64 `json:"deadLetters"`
+	LastError   string `json:"lastError,omitempty"`
+}
+
+// TrapReceiverStats riassume lo stato della coda di inoltro e dei sink.
+type TrapReceiverStats struct {
+	Received      uint64                   `json:"received"`
+	QueueLength   int                      `json:"queueLength"`
+	QueueCapacity int                      `json:"queueCapacity"`
+	Dropped       uint64                   `json:"dropped"`
+	Sinks         map[string]TrapSinkStats `json:"sinks"`
+}
+
+// trapSink è una destinazione di inoltro.
+type trapSink interface {
+	name() string
+	send(ctx context.Context, event TrapForwardEvent) error
+}
+
+// trapForwarder inoltra le trap ai sink da una coda limitata: quando è piena scarta la trap più vecchia,
+// così chi riceve le trap non resta mai bloccato. Un solo worker consuma la coda.
+type trapForwarder struct {
+	mu         sync.Mutex
+	queue      []TrapForwardEvent
+	capacity   int
+	maxRetries int
+	sinks      []trapSink
+	received   uint64
+	dropped    uint64
+	sinkStats  map[string]*TrapSinkStats
+	notify     chan struct{}
+	backoff    func(attempt int) time.Duration
+}
+
+func newTrapForwarder(capacity, maxRetries int, sinks []trapSink) *trapForwarder {
+	f := &trapForwarder{
+		capacity:   capacity,
+		maxRetries: maxRetries,
+		sinks:      sinks,
+		sinkStats:  make(map[string]*TrapSinkStats),
+		notify:     make(chan struct{}, 1),
+		backoff:    trapForwardBackoff,
+	}
+	for _, sink := range sinks {
+		f.sinkStats[sink.name()] = &TrapSinkStats{Enabled: true}
+	}
+	return f
+}
+
+// trapForwardBackoff raddoppia l'attesa tra i tentativi partendo da 500ms, fino a 10s.
+func trapForwardBackoff(attempt int) time.Duration {
+	delay := 500 * time.Millisecond << uint(attempt)
+	if delay <= 0 || delay > 10*time.Second {
+		delay = 10 * time.Second
+	}
+	return delay
+}
+
+// enqueue accoda l'evento senza bloccare, scartando il più vecchio se la coda è piena.
+func (f *trapForwarder) enqueue(event TrapForwardEvent) {
+	f.mu.Lock()
+	f.received++
+	if len(f.queue) >= f.capacity {
+		f.queue = f.queue[1:]
+		f.dropped++
+	}
+	f.queue = append(f.queue, event)
+	f.mu.Unlock()
+
+	select {
+	case f.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (f *trapForwarder) dequeue() (TrapForwardEvent, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.queue) == 0 {
+		return TrapForwardEvent{}, false
+	}
+	event := f.queue[0]
+	f.queue = f.queue[1:]
+	return event, true
+}
+
+// run consuma la coda fino all'annullamento del contesto.
+func (f *trapForwarder) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-f.notify:
+		}
+		for {
+			event, ok := f.dequeue()
+			if !ok {
+				break
+			}
+			f.deliver(ctx, event)
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}
+}
+
+// deliver invia l'evento a ogni sink, ritentando con backoff; esauriti i tentativi lo conta come dead-letter.
+func (f *trapForwarder) deliver(ctx context.Context, event TrapForwardEvent) {
+	for _, sink := range f.sinks {
+		var err error
+		for attempt := 0; attempt <= f.maxRetries; attempt++ {
+			if attempt > 0 {
+				f.updateSink(sink.name(), func(stats *TrapSinkStats) { stats.Retries++ })
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(f.backoff(attempt - 1)):
+				}
+			}
+
+			sendCtx, cancel := context.WithTimeout(ctx, trapSinkTimeout)
+			err = sink.send(sendCtx, event)
+			cancel()
+			if err == nil {
+				break
+			}
+		}
+
+		if err != nil {
+			f.updateSink(sink.name(), func(stats *TrapSinkStats) {
+				stats.DeadLetters++
+				stats.LastError = err.Error()
+			})
+			continue
+		}
+		f.updateSink(sink.name(), func(stats *TrapSinkStats) { stats.Forwarded++ })
+	}
+}
+
+func (f *trapForwarder) updateSink(name string, update func(stats *TrapSinkStats)) {
+	f.mu.Lock()
+	update(f.sinkStats[name])
+	f.mu.Unlock()
+}
+
+func (f *trapForwarder) stats() TrapReceiverStats {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stats := TrapReceiverStats{
+		Received:      f.received,
+		QueueLength:   len(f.queue),
+		QueueCapacity: f.capacity,
+		Dropped:       f.dropped,
+		Sinks:         make(map[string]TrapSinkStats, len(f.sinkStats)),
+	}
+	for name, sinkStats := range f.sinkStats {
+		stats.Sinks[name] = *sinkStats
+	}
+	return stats
+}
+
+// syslogSink invia le trap come messaggi RFC5424 su UDP o TCP (framing a conteggio di ottetti, RFC6587).
+type syslogSink struct {
+	config   SyslogSinkConfig
+	hostname string
+}
+
+func (s *syslogSink) name() string { return "syslog" }
+
+func (s *syslogSink) send(ctx context.Context, event TrapForwardEvent) error {
+	protocol := s.config.Protocol
+	if protocol == "" {
+		protocol = "udp"
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, protocol, s.config.Address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	message := formatSyslogMessage(s.config.Facility, s.hostname, event)
+	if protocol == "tcp" {
+		message = fmt.Sprintf("%d %s", len(message), message)
+	}
+	_, err = conn.Write([]byte(message))
+	return err
+}
+
+// syslogSeverities associa le severità stimate ai livelli syslog.
+var syslogSeverities = map[string]int{
+	"critical": 2,
+	"error":    3,
+	"warning":  4,
+	"notice":   5,
+	"info":     6,
+}
+
+// formatSyslogMessage compone il messaggio RFC5424: <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID SD MSG.
+func formatSyslogMessage(facility int, hostname string, event TrapForwardEvent) string {
+	severity, ok := syslogSeverities[event.Severity]
+	if !ok {
+		severity = syslogSeverities["notice"]
+	}
+	if hostname == "" {
+		hostname = "-"
+	}
+
+	timestamp := event.Timestamp
+	if timestamp == "" {
+		timestamp = time.Now().Format(time.RFC3339)
+	}
+
+	var msg strings.Builder
+	if event.Trap != nil {
+		name := event.Trap.TrapName
+		if name == "" {
+			name = event.Trap.TrapOID
+		}
+		fmt.Fprintf(&msg, "%s from %s (%s)", name, event.Source, event.Trap.TrapOID)
+		for _, varbind := range event.Trap.Varbinds {
+			if varbind.IsTrapOID {
+				continue
+			}
+			value := varbind.DisplayValue
+			if value == "" {
+				value = varbind.Value
+			}
+			label := varbind.Name
+			if label == "" {
+				label = varbind.OID
+			}
+			fmt.Fprintf(&msg, " %s=%q", label, value)
+		}
+	}
+
+	return fmt.Sprintf("<%d>1 %s %s mib-to-the-future - TRAP - %s", facility*8+severity, timestamp, hostname, msg.String())
+}
+
+// webhookSink invia l'evento JSON con una POST; le risposte non 2xx sono considerate errori.
+type webhookSink struct {
+	config WebhookSinkConfig
+	client *http.Client
+}
+
+func (w *webhookSink) name() string { return "webhook" }
+
+func (w *webhookSink) send(ctx context.Context, event TrapForwardEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, w.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded %s", response.Status)
+	}
+	return nil
+}
+
+// trapSeverityKeywords associa le parole del nome della notifica alle severità, in ordine di priorità:
+// una trap che segnala sia un guasto sia un ripristino è classificata come guasto. Le parole che
+// terminano con "*" sono prefissi.
+var trapSeverityKeywords = []struct {
+	severity string
+	words    []string
+}{
+	{"critical", []string{"critical", "fatal", "emergenc*"}},
+	{"error", []string{"down", "fail*", "fault*", "error*", "alarm*", "lost"}},
+	{"info", []string{"clear*", "restor*", "resolv*", "up"}},
+	{"warning", []string{"warn*", "threshold*", "exceed*", "high"}},
+}
+
+// trapSeverity stima la severità di una trap dal nome della notifica. Il nome viene diviso in parole
+// (camelCase e separatori), così "linkUp" contiene "up" ma "backupStarted" no.
+func trapSeverity(trap *DecodedTrap) string {
+	if trap == nil {
+		return "notice"
+	}
+	tokens := trapNameTokens(trap.TrapName)
+
+	for _, group := range trapSeverityKeywords {
+		for _, word := range group.words {
+			prefix := strings.TrimSuffix(word, "*")
+			for _, token := range tokens {
+				if token == prefix || (prefix != word && strings.HasPrefix(token, prefix)) {
+					return group.severity
+				}
+			}
+		}
+	}
+	return "notice"
+}
+
+// trapNameTokens divide il nome di una notifica in parole minuscole, spezzando ai cambi da minuscola
+// a maiuscola e ai caratteri non alfabetici.
+func trapNameTokens(name string) []string {
+	tokens := []string{}
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, strings.ToLower(current.String()))
+			current.Reset()
+		}
+	}
+
+	previousLower := false
+	for _, r := range name {
+		switch {
+		case unicode.IsUpper(r):
+			if previousLower {
+				flush()
+			}
+			current.WriteRune(r)
+			previousLower = false
+		case unicode.IsLetter(r):
+			current.WriteRune(r)
+			previousLower = true
+		default:
+			flush()
+			previousLower = false
+		}
+	}
+	flush()
+	return tokens
+}
+
+// defaultTrapForwardingConfig restituisce la configurazione di partenza su cui decodificare quella
+// salvata o importata: i campi assenti mantengono i valori predefiniti, mentre uno zero esplicito resta
+// valido (nessun nuovo tentativo, facility kern).
+func defaultTrapForwardingConfig() TrapForwardingConfig {
+	return TrapForwardingConfig{
+		Syslog:     SyslogSinkConfig{Facility: syslogFacilityLocal0},
+		MaxRetries: defaultTrapForwardRetries,
+	}
+}
+
+// normalizeTrapForwardingConfig applica i valori predefiniti e verifica i sink abilitati.
+func normalizeTrapForwardingConfig(config TrapForwardingConfig) (TrapForwardingConfig, error) {
+	if config.QueueSize <= 0 {
+		config.QueueSize = defaultTrapQueueSize
+	}
+	if config.QueueSize > maxTrapQueueSize {
+		return config, fmt.Errorf("queue size must be at most %d", maxTrapQueueSize)
+	}
+	if config.MaxRetries < 0 || config.MaxRetries > maxTrapForwardRetries {
+		return config, fmt.Errorf("max retries must be between 0 and %d", maxTrapForwardRetries)
+	}
+
+	config.Syslog.Address = strings.TrimSpace(config.Syslog.Address)
+	config.Syslog.Protocol = strings.ToLower(strings.TrimSpace(config.Syslog.Protocol))
+	if config.Syslog.Protocol == "" {
+		config.Syslog.Protocol = "udp"
+	}
+	if config.Syslog.Enabled {
+		if _, _, err := net.SplitHostPort(config.Syslog.Address); err != nil {
+			return config, fmt.Errorf("invalid syslog address %q: expected host:port", config.Syslog.Address)
+		}
+		if config.Syslog.Protocol != "udp" && config.Syslog.Protocol != "tcp" {
+			return config, fmt.Errorf("unsupported syslog protocol %q", config.Syslog.Protocol)
+		}
+		if config.Syslog.Facility < 0 || config.Syslog.Facility > 23 {
+			return config, fmt.Errorf("syslog facility must be between 0 and 23")
+		}
+	}
+
+	config.Webhook.URL = strings.TrimSpace(config.Webhook.URL)
+	if config.Webhook.Enabled {
+		parsed, err := url.Parse(config.Webhook.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return config, fmt.Errorf("invalid webhook URL %q", config.Webhook.URL)
+		}
+	}
+	return config, nil
+}
+
+// GetTrapForwardingConfig restituisce la configurazione di inoltro delle trap salvata.
+func (a *App) GetTrapForwardingConfig() (*TrapForwardingConfig, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	config := defaultTrapForwardingConfig()
+	raw, ok, err := db.GetMetadata(trapForwardingMetadataKey)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if err := json.Unmarshal([]byte(raw), &config); err != nil {
+			return nil, fmt.Errorf("invalid trap forwarding config: %w", err)
+		}
+	}
+
+	normalized, err := normalizeTrapForwardingConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &normalized, nil
+}
+
+// SetTrapForwardingConfig valida e salva la configurazione di inoltro, riavviando l'inoltro con i nuovi sink.
+// Le statistiche ripartono da zero.
+func (a *App) SetTrapForwardingConfig(config TrapForwardingConfig) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+
+	normalized, err := normalizeTrapForwardingConfig(config)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(normalized)
+	if err != nil {
+		return fmt.Errorf("failed to encode trap forwarding config: %w", err)
+	}
+	if err := db.SetMetadata(trapForwardingMetadataKey, string(raw)); err != nil {
+		return err
+	}
+
+	return a.startTrapForwarding(normalized)
+}
+
+// startTrapForwarding sostituisce l'inoltro in corso con uno configurato sui sink abilitati.
+func (a *App) startTrapForwarding(config TrapForwardingConfig) error {
+	var sinks []trapSink
+	if config.Syslog.Enabled {
+		hostname, _ := os.Hostname()
+		sinks = append(sinks, &syslogSink{config: config.Syslog, hostname: hostname})
+	}
+	if config.Webhook.Enabled {
+		sinks = append(sinks, &webhookSink{config: config.Webhook, client: &http.Client{}})
+	}
+
+	a.trapForwardM.Lock()
+	defer a.trapForwardM.Unlock()
+
+	if a.trapForwardCancel != nil {
+		a.trapForwardCancel()
+		a.trapForwardCancel = nil
+	}
+	a.trapForwarder = nil
+	if len(sinks) == 0 {
+		return nil
+	}
+
+	forwarder := newTrapForwarder(config.QueueSize, config.MaxRetries, sinks)
+	ctx, cancel := context.WithCancel(a.backgroundContext())
+	if err := a.goBackground("trap-forwarder", func(context.Context) { forwarder.run(ctx) }); err != nil {
+		cancel()
+		return err
+	}
+	a.trapForwarder = forwarder
+	a.trapForwardCancel = cancel
+	return nil
+}
+
+// loadTrapForwarding avvia all'avvio l'inoltro configurato nella sessione precedente.
+func (a *App) loadTrapForwarding() {
+	config, err := a.GetTrapForwardingConfig()
+	if err == nil {
+		err = a.startTrapForwarding(*config)
+	}
+	if err != nil && a.ctx != nil {
+		runtime.LogWarning(a.ctx, fmt.Sprintf("Trap forwarding not started: %v", err))
+	}
+}
+
+// ForwardTrap accoda una trap decodificata verso i sink abilitati. Non blocca: se la coda è piena
+// viene scartata la trap più vecchia. Senza sink abilitati la trap viene ignorata.
+func (a *App) ForwardTrap(source string, trap DecodedTrap) {
+	a.trapForwardM.Lock()
+	forwarder := a.trapForwarder
+	a.trapForwardM.Unlock()
+	if forwarder == nil {
+		return
+	}
+
+	forwarder.enqueue(TrapForwardEvent{
+		Source:    strings.TrimSpace(source),
+		Timestamp: time.Now().Format(time.RFC3339),
+		Severity:  trapSeverity(&trap),
+		Trap:      &trap,
+	})
+}
+
+// GetTrapReceiverStats restituisce i contatori della coda di inoltro (trap ricevute, scartate)
+// e, per ogni sink, inoltri riusciti, nuovi tentativi e dead-letter.
+func (a *App) GetTrapReceiverStats() TrapReceiverStats {
+	a.trapForwardM.Lock()
+	forwarder := a.trapForwarder
+	a.trapForwardM.Unlock()
+	if forwarder == nil {
+		return TrapReceiverStats{Sinks: map[string]TrapSinkStats{}}
+	}
+	return forwarder.stats()
+}
@@ -0,0 +1,300 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeTrapSink struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+}
+
+func (f *fakeTrapSink) name() string { return "fake" }
+
+func (f *fakeTrapSink) send(ctx context.Context, event TrapForwardEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.calls <= f.failures {
+		return errors.New("sink unavailable")
+	}
+	return nil
+}
+
+func TestTrapForwarderDropsOldestWhenFull(t *testing.T) {
+	forwarder := newTrapForwarder(2, 0, nil)
+	for _, source := range []string{"a", "b", "c"} {
+		forwarder.enqueue(TrapForwardEvent{Source: source})
+	}
+
+	stats := forwarder.stats()
+	if stats.Received != 3 || stats.Dropped != 1 || stats.QueueLength != 2 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	event, _ := forwarder.dequeue()
+	if event.Source != "b" {
+		t.Fatalf("expected oldest trap to be dropped, got first %q", event.Source)
+	}
+}
+
+// stalledTrapSink segnala ogni invio su started e resta bloccato finché release non viene chiuso.
+type stalledTrapSink struct {
+	started   chan string
+	release   chan struct{}
+	mu        sync.Mutex
+	delivered []string
+}
+
+func (s *stalledTrapSink) name() string { return "stalled" }
+
+func (s *stalledTrapSink) send(ctx context.Context, event TrapForwardEvent) error {
+	s.started <- event.Source
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	s.mu.Lock()
+	s.delivered = append(s.delivered, event.Source)
+	s.mu.Unlock()
+	return nil
+}
+
+func TestForwardTrapDoesNotBlockOnStalledSinks(t *testing.T) {
+	sink := &stalledTrapSink{started: make(chan string, 16), release: make(chan struct{})}
+	forwarder := newTrapForwarder(3, 0, []trapSink{sink})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go forwarder.run(ctx)
+
+	app := NewApp()
+	app.trapForwarder = forwarder
+
+	// La prima trap occupa il worker, che resta bloccato sul sink.
+	app.ForwardTrap("t0", DecodedTrap{})
+	select {
+	case <-sink.started:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("first trap was not delivered to the sink")
+	}
+
+	produced := make(chan struct{})
+	go func() {
+		defer close(produced)
+		for i := 1; i <= 9; i++ {
+			app.ForwardTrap(fmt.Sprintf("t%d", i), DecodedTrap{})
+		}
+	}()
+	select {
+	case <-produced:
+	case <-time.After(time.Second):
+		t.Fatalf("ForwardTrap blocked while the sink was stalled")
+	}
+
+	stats := app.GetTrapReceiverStats()
+	if stats.Received != 10 || stats.QueueLength != 3 || stats.Dropped != 6 {
+		t.Fatalf("unexpected stats while stalled: %+v", stats)
+	}
+
+	close(sink.release)
+	deadline := time.After(2 * time.Second)
+	for {
+		sink.mu.Lock()
+		delivered := append([]string(nil), sink.delivered...)
+		sink.mu.Unlock()
+		if len(delivered) == 4 {
+			if strings.Join(delivered, ",") != "t0,t7,t8,t9" {
+				t.Fatalf("expected the oldest queued traps to be dropped, delivered %v", delivered)
+			}
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("queued traps not delivered, got %v", delivered)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestTrapForwarderRetriesAndDeadLetters(t *testing.T) {
+	recovering := &fakeTrapSink{failures: 2}
+	forwarder := newTrapForwarder(10, 2, []trapSink{recovering})
+	forwarder.backoff = func(int) time.Duration { return 0 }
+
+	forwarder.deliver(context.Background(), TrapForwardEvent{Source: "10.0.0.1"})
+	stats := forwarder.stats().Sinks["fake"]
+	if stats.Forwarded != 1 || stats.Retries != 2 || stats.DeadLetters != 0 {
+		t.Fatalf("expected delivery after retries, got %+v", stats)
+	}
+
+	failing := &fakeTrapSink{failures: 100}
+	forwarder = newTrapForwarder(10, 1, []trapSink{failing})
+	forwarder.backoff = func(int) time.Duration { return 0 }
+
+	forwarder.deliver(context.Background(), TrapForwardEvent{Source: "10.0.0.1"})
+	stats = forwarder.stats().Sinks["fake"]
+	if stats.Forwarded != 0 || stats.DeadLetters != 1 || stats.LastError == "" || failing.calls != 2 {
+		t.Fatalf("expected dead letter after retries, got %+v (calls %d)", stats, failing.calls)
+	}
+}
+
+func TestSyslogSinkSendsRFC5424OverUDP(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+
+	sink := &syslogSink{
+		config:   SyslogSinkConfig{Address: listener.LocalAddr().String(), Protocol: "udp", Facility: syslogFacilityLocal0},
+		hostname: "workstation",
+	}
+	event := TrapForwardEvent{
+		Source:    "10.0.0.1",
+		Timestamp: "2024-01-02T03:04:05Z",
+		Severity:  "error",
+		Trap:      &DecodedTrap{TrapOID: "1.3.6.1.6.3.1.1.5.3", TrapName: "linkDown"},
+	}
+	if err := sink.send(context.Background(), event); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	listener.SetReadDeadline(time.Now().Add(2 * time.Second))
+	buffer := make([]byte, 2048)
+	n, _, err := listener.ReadFrom(buffer)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	message := string(buffer[:n])
+	want := "<131>1 2024-01-02T03:04:05Z workstation mib-to-the-future - TRAP - linkDown from 10.0.0.1"
+	if !strings.HasPrefix(message, want) {
+		t.Fatalf("unexpected syslog message %q", message)
+	}
+}
+
+func TestWebhookSinkPostsJSON(t *testing.T) {
+	var received TrapForwardEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink := &webhookSink{config: WebhookSinkConfig{URL: server.URL}, client: server.Client()}
+	event := TrapForwardEvent{Source: "10.0.0.1", Severity: "info", Trap: &DecodedTrap{TrapName: "linkUp"}}
+	if err := sink.send(context.Background(), event); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if received.Source != "10.0.0.1" || received.Trap == nil || received.Trap.TrapName != "linkUp" {
+		t.Fatalf("unexpected webhook payload: %+v", received)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	sink = &webhookSink{config: WebhookSinkConfig{URL: failing.URL}, client: failing.Client()}
+	if err := sink.send(context.Background(), event); err == nil {
+		t.Fatalf("expected error for non-2xx response")
+	}
+}
+
+func TestTrapSeverity(t *testing.T) {
+	tests := map[string]string{
+		"linkDown":              "error",
+		"linkUp":                "info",
+		"coldStart":             "notice",
+		"tempThresholdExceeded": "warning",
+		"psuCriticalFailure":    "critical",
+		"alarmCleared":          "error",
+		"thresholdCleared":      "info",
+		"linkUpFailure":         "error",
+		"backupStarted":         "notice",
+		"ciscoLinkUp":           "info",
+		"bgpPeerDown":           "error",
+	}
+	for name, want := range tests {
+		if got := trapSeverity(&DecodedTrap{TrapName: name}); got != want {
+			t.Errorf("trapSeverity(%s) = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestTrapForwardingConfigRoundTrip(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+
+	invalid := []TrapForwardingConfig{
+		{Syslog: SyslogSinkConfig{Enabled: true, Address: "no-port"}},
+		{Syslog: SyslogSinkConfig{Enabled: true, Address: "127.0.0.1:514", Protocol: "sctp"}},
+		{Webhook: WebhookSinkConfig{Enabled: true, URL: "ftp://example.com"}},
+		{QueueSize: maxTrapQueueSize + 1},
+	}
+	for _, config := range invalid {
+		if err := app.SetTrapForwardingConfig(config); err == nil {
+			t.Fatalf("expected validation error for %+v", config)
+		}
+	}
+
+	config := TrapForwardingConfig{
+		Webhook:    WebhookSinkConfig{Enabled: true, URL: "http://127.0.0.1:1/traps"},
+		MaxRetries: 1,
+	}
+	if err := app.SetTrapForwardingConfig(config); err != nil {
+		t.Fatalf("SetTrapForwardingConfig: %v", err)
+	}
+	t.Cleanup(func() { app.SetTrapForwardingConfig(TrapForwardingConfig{}) })
+
+	stored, err := app.GetTrapForwardingConfig()
+	if err != nil {
+		t.Fatalf("GetTrapForwardingConfig: %v", err)
+	}
+	if !stored.Webhook.Enabled || stored.QueueSize != defaultTrapQueueSize || stored.Syslog.Protocol != "udp" {
+		t.Fatalf("unexpected stored config: %+v", stored)
+	}
+
+	app.ForwardTrap("10.0.0.1", DecodedTrap{TrapName: "linkDown"})
+	stats := app.GetTrapReceiverStats()
+	if stats.Received != 1 || !stats.Sinks["webhook"].Enabled {
+		t.Fatalf("unexpected receiver stats: %+v", stats)
+	}
+}
+
+func TestTrapForwardingConfigKeepsKernFacility(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+
+	defaults, err := app.GetTrapForwardingConfig()
+	if err != nil {
+		t.Fatalf("GetTrapForwardingConfig: %v", err)
+	}
+	if defaults.Syslog.Facility != syslogFacilityLocal0 {
+		t.Fatalf("expected default facility local0, got %d", defaults.Syslog.Facility)
+	}
+
+	config := TrapForwardingConfig{Syslog: SyslogSinkConfig{Address: "127.0.0.1:514", Facility: 0}}
+	if err := app.SetTrapForwardingConfig(config); err != nil {
+		t.Fatalf("SetTrapForwardingConfig: %v", err)
+	}
+
+	stored, err := app.GetTrapForwardingConfig()
+	if err != nil {
+		t.Fatalf("GetTrapForwardingConfig: %v", err)
+	}
+	if stored.Syslog.Facility != 0 {
+		t.Fatalf("expected kern facility to be kept, got %d", stored.Syslog.Facility)
+	}
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// TrapVarbindTemplate è un varbind previsto da una notifica, usato per precompilare il form di invio.
+type TrapVarbindTemplate struct {
+	OID    string `json:"oid"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Syntax string `json:"syntax,omitempty"`
+	// NeedsInstance indica che l'oggetto è una colonna: l'OID va completato con l'indice della riga.
+	NeedsInstance bool `json:"needsInstance"`
+}
+
+// GetTrapVarbindTemplate restituisce gli oggetti dichiarati nella clausola OBJECTS della notifica,
+// con il tipo da usare per l'invio. Gli scalar riportano già l'istanza `.0`.
+func (a *App) GetTrapVarbindTemplate(trapOID string) ([]TrapVarbindTemplate, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+	if err := validateOIDInput(trapOID); err != nil {
+		return nil, err
+	}
+
+	objects, err := db.GetNotificationObjects(normalizeTrapOID(trapOID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notification objects: %v", err)
+	}
+
+	templates := make([]TrapVarbindTemplate, 0, len(objects))
+	for _, object := range objects {
+		template := TrapVarbindTemplate{OID: object, Name: object, Type: "octetstring"}
+		if node, err := db.GetNode(object); err == nil && node != nil {
+			template.Name = node.Name
+			template.Syntax = node.Syntax
+			template.Type = setValueType(node)
+			if strings.EqualFold(node.Type, "scalar") {
+				template.OID = appendInstanceSuffix(object)
+			} else {
+				template.NeedsInstance = strings.EqualFold(node.Type, "column")
+			}
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// SendTestTrap invia una TRAP SNMPv2c verso un ricevitore (porta 162 se non indicata), utile per
+// verificare un NMS. La TRAP non prevede conferma: lo stato "sent" indica solo l'avvenuto invio.
+func (a *App) SendTestTrap(config snmp.Config, trapOID string, varbinds []snmp.SetRequest) (*snmp.TrapResult, error) {
+	return a.sendTestNotification(config, trapOID, varbinds, false)
+}
+
+// SendTestInform invia una INFORM SNMPv2c e attende la conferma del ricevitore:
+// lo stato "acknowledged" indica la consegna, un errore la mancata conferma.
+func (a *App) SendTestInform(config snmp.Config, trapOID string, varbinds []snmp.SetRequest) (*snmp.TrapResult, error) {
+	return a.sendTestNotification(config, trapOID, varbinds, true)
+}
+
+func (a *App) sendTestNotification(config snmp.Config, trapOID string, varbinds []snmp.SetRequest, asInform bool) (*snmp.TrapResult, error) {
+	if err := validateOIDInput(trapOID); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(config.Host) == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+	if config.Port <= 0 {
+		config.Port = snmp.DefaultTrapPort
+	}
+
+	client, err := a.newSNMPClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SNMP client: %v", err)
+	}
+
+	result, err := client.SendTrap(trapOID, varbinds, asInform)
+	if err != nil {
+		return result, fmt.Errorf("failed to send notification: %v", err)
+	}
+
+	if a.ctx != nil {
+		runtime.LogInfo(a.ctx, fmt.Sprintf("Sent test notification %s to %s:%d (%s)", result.TrapOID, config.Host, config.Port, result.Status))
+	}
+	return result, nil
+}
+
+// setValueType restituisce il tipo accettato da Client.Set corrispondente alla sintassi del nodo.
+func setValueType(node *mib.Node) string {
+	switch netSNMPSetType(node) {
+	case "i":
+		return "integer"
+	case "u":
+		return "gauge32"
+	case "c":
+		return "counter32"
+	case "C":
+		return "counter64"
+	case "t":
+		return "timeticks"
+	case "a":
+		return "ipaddress"
+	case "o":
+		return "objectidentifier"
+	case "b":
+		return "bits"
+	default:
+		return "octetstring"
+	}
+}
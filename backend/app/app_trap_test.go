@@ -0,0 +1,110 @@
+package app
+
+import (
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+)
+
+func TestDecodeTrapLabelsVarbinds(t *testing.T) {
+	app := setupTestAppWithNodes(
+		t,
+		&mib.Node{OID: "1.3.6.1.2.1.1.3", Name: "sysUpTime", Type: "scalar"},
+		&mib.Node{OID: "1.3.6.1.6.3.1.1.4.1", Name: "snmpTrapOID", Type: "scalar"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.1", Name: "ifIndex", Type: "column"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.2", Name: "ifDescr", Type: "column"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.8", Name: "ifOperStatus", Type: "column"},
+	)
+	// SaveNode non gestisce gli oggetti di notifica: si passa da SaveNodes come fa il parser.
+	moduleID, err := app.mibDB.GetModuleID("TEST-MIB")
+	if err != nil {
+		t.Fatalf("GetModuleID() error = %v", err)
+	}
+	if err := app.mibDB.SaveNodes([]*mib.Node{{
+		OID:                 "1.3.6.1.6.3.1.1.5.3",
+		Name:                "linkDown",
+		Type:                "notification",
+		NotificationObjects: []string{"1.3.6.1.2.1.2.2.1.1", "1.3.6.1.2.1.2.2.1.8"},
+	}}, moduleID); err != nil {
+		t.Fatalf("SaveNodes() error = %v", err)
+	}
+
+	trap, err := app.DecodeTrap([]snmp.Result{
+		{OID: ".1.3.6.1.2.1.1.3.0", Type: "TimeTicks", Value: "12345"},
+		{OID: ".1.3.6.1.6.3.1.1.4.1.0", Type: "ObjectIdentifier", Value: ".1.3.6.1.6.3.1.1.5.3"},
+		{OID: ".1.3.6.1.2.1.2.2.1.1.7", Type: "Integer", Value: "7"},
+		{OID: ".1.3.6.1.2.1.2.2.1.8.7", Type: "Integer", Value: "2"},
+		{OID: ".1.3.6.1.2.1.2.2.1.2.7", Type: "OctetString", Value: "eth0"},
+	})
+	if err != nil {
+		t.Fatalf("DecodeTrap() error = %v", err)
+	}
+
+	if trap.TrapOID != "1.3.6.1.6.3.1.1.5.3" || trap.TrapName != "linkDown" || trap.Module != "TEST-MIB" {
+		t.Fatalf("unexpected trap identity: %+v", trap)
+	}
+	if len(trap.Varbinds) != 5 {
+		t.Fatalf("expected 5 varbinds, got %d", len(trap.Varbinds))
+	}
+
+	trapOIDVarbind := trap.Varbinds[1]
+	if !trapOIDVarbind.IsTrapOID || trapOIDVarbind.DisplayValue != "linkDown" || trapOIDVarbind.Name != "snmpTrapOID" {
+		t.Fatalf("unexpected snmpTrapOID varbind: %+v", trapOIDVarbind)
+	}
+
+	wantPayload := []bool{false, false, true, true, false}
+	for i, varbind := range trap.Varbinds {
+		if varbind.IsNotificationObject != wantPayload[i] {
+			t.Fatalf("varbind %s IsNotificationObject = %v, want %v", varbind.OID, varbind.IsNotificationObject, wantPayload[i])
+		}
+		if i != 1 && varbind.IsTrapOID {
+			t.Fatalf("varbind %s unexpectedly marked as snmpTrapOID", varbind.OID)
+		}
+	}
+	if trap.Varbinds[2].Name != "ifIndex[7]" {
+		t.Fatalf("expected resolved varbind name, got %q", trap.Varbinds[2].Name)
+	}
+}
+
+func TestDecodeTrapRequiresTrapOID(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+
+	if _, err := app.DecodeTrap([]snmp.Result{{OID: ".1.3.6.1.2.1.1.3.0", Type: "TimeTicks", Value: "1"}}); err == nil {
+		t.Fatalf("expected error when snmpTrapOID is missing")
+	}
+}
+
+func TestGetTrapVarbindTemplate(t *testing.T) {
+	app := setupTestAppWithNodes(
+		t,
+		&mib.Node{OID: "1.3.6.1.2.1.1.5", Name: "sysName", Type: "scalar", Syntax: "DisplayString"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.8", Name: "ifOperStatus", Type: "column", Syntax: "INTEGER { up(1), down(2) }"},
+	)
+	moduleID, err := app.mibDB.GetModuleID("TEST-MIB")
+	if err != nil {
+		t.Fatalf("GetModuleID() error = %v", err)
+	}
+	if err := app.mibDB.SaveNodes([]*mib.Node{{
+		OID:                 "1.3.6.1.4.1.9999.0.1",
+		Name:                "testEvent",
+		Type:                "notification",
+		NotificationObjects: []string{"1.3.6.1.2.1.1.5", "1.3.6.1.2.1.2.2.1.8"},
+	}}, moduleID); err != nil {
+		t.Fatalf("SaveNodes() error = %v", err)
+	}
+
+	templates, err := app.GetTrapVarbindTemplate("1.3.6.1.4.1.9999.0.1")
+	if err != nil {
+		t.Fatalf("GetTrapVarbindTemplate() error = %v", err)
+	}
+	if len(templates) != 2 {
+		t.Fatalf("expected 2 templates, got %+v", templates)
+	}
+	if templates[0].OID != "1.3.6.1.2.1.1.5.0" || templates[0].Type != "octetstring" || templates[0].NeedsInstance {
+		t.Fatalf("unexpected scalar template: %+v", templates[0])
+	}
+	if templates[1].OID != "1.3.6.1.2.1.2.2.1.8" || templates[1].Type != "integer" || !templates[1].NeedsInstance {
+		t.Fatalf("unexpected column template: %+v", templates[1])
+	}
+}
@@ -0,0 +1,140 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"mib-to-the-future/backend/mib"
+)
+
+// treePreferencesMetadataKey è la chiave di app_metadata con le preferenze dell'albero MIB.
+const treePreferencesMetadataKey = "tree_preferences"
+
+// TreePreferences raccoglie i rami nascosti e i preferiti dell'albero MIB. Entrambe le liste
+// contengono OID; l'ordine di Pinned è l'ordine in cui i preferiti compaiono in cima.
+type TreePreferences struct {
+	HiddenRoots []string `json:"hiddenRoots"`
+	Pinned      []string `json:"pinned"`
+}
+
+// GetTreePreferences restituisce le preferenze dell'albero salvate (liste vuote se mai impostate).
+func (a *App) GetTreePreferences() (*TreePreferences, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	preferences := TreePreferences{}
+	raw, ok, err := db.GetMetadata(treePreferencesMetadataKey)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if err := json.Unmarshal([]byte(raw), &preferences); err != nil {
+			return nil, fmt.Errorf("invalid tree preferences: %w", err)
+		}
+	}
+
+	normalized, err := normalizeTreePreferences(preferences)
+	if err != nil {
+		return nil, err
+	}
+	return &normalized, nil
+}
+
+// SetTreePreferences valida e salva i rami nascosti e i preferiti dell'albero.
+func (a *App) SetTreePreferences(preferences TreePreferences) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+
+	normalized, err := normalizeTreePreferences(preferences)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(normalized)
+	if err != nil {
+		return fmt.Errorf("failed to encode tree preferences: %w", err)
+	}
+	return db.SetMetadata(treePreferencesMetadataKey, string(raw))
+}
+
+// normalizeTreePreferences valida gli OID, li riporta alla forma senza punto iniziale e rimuove i duplicati.
+func normalizeTreePreferences(preferences TreePreferences) (TreePreferences, error) {
+	normalize := func(oids []string) ([]string, error) {
+		seen := make(map[string]struct{}, len(oids))
+		normalized := []string{}
+		for _, oid := range oids {
+			if err := validateOIDInput(oid); err != nil {
+				return nil, err
+			}
+			key := normalizeOIDKey(oid)
+			if _, duplicate := seen[key]; duplicate {
+				continue
+			}
+			seen[key] = struct{}{}
+			normalized = append(normalized, key)
+		}
+		return normalized, nil
+	}
+
+	hidden, err := normalize(preferences.HiddenRoots)
+	if err != nil {
+		return preferences, err
+	}
+	pinned, err := normalize(preferences.Pinned)
+	if err != nil {
+		return preferences, err
+	}
+	return TreePreferences{HiddenRoots: hidden, Pinned: pinned}, nil
+}
+
+// applyTreePreferences rimuove dall'albero i rami nascosti e porta i preferiti in cima ai rispettivi
+// fratelli, impostando Pinned come indicazione d'ordine per il frontend. I bookmark non sono toccati.
+func applyTreePreferences(nodes []*mib.Node, preferences *TreePreferences) []*mib.Node {
+	if preferences == nil || (len(preferences.HiddenRoots) == 0 && len(preferences.Pinned) == 0) {
+		return nodes
+	}
+
+	hidden := make(map[string]struct{}, len(preferences.HiddenRoots))
+	for _, oid := range preferences.HiddenRoots {
+		hidden[oid] = struct{}{}
+	}
+	pinned := make(map[string]int, len(preferences.Pinned))
+	for i, oid := range preferences.Pinned {
+		pinned[oid] = i + 1
+	}
+	return applyTreePreferenceMaps(nodes, hidden, pinned)
+}
+
+func applyTreePreferenceMaps(nodes []*mib.Node, hidden map[string]struct{}, pinned map[string]int) []*mib.Node {
+	filtered := make([]*mib.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if strings.HasPrefix(node.Type, "bookmark") {
+			filtered = append(filtered, node)
+			continue
+		}
+
+		oid := normalizeOIDKey(node.OID)
+		if _, isHidden := hidden[oid]; isHidden {
+			continue
+		}
+		node.Pinned = pinned[oid]
+		node.Children = applyTreePreferenceMaps(node.Children, hidden, pinned)
+		filtered = append(filtered, node)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		left, right := filtered[i].Pinned, filtered[j].Pinned
+		if left == 0 || right == 0 {
+			return left != 0 && right == 0
+		}
+		return left < right
+	})
+	return filtered
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+)
+
+func TestGetMIBTreeAppliesTreePreferences(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "0", Name: "ccitt", Type: "node"},
+		&mib.Node{OID: "1", Name: "iso", Type: "node"},
+		&mib.Node{OID: "2", Name: "joint-iso-ccitt", Type: "node"},
+		&mib.Node{OID: "1.0", Name: "std", Type: "node", ParentOID: "1"},
+		&mib.Node{OID: "1.3", Name: "org", Type: "node", ParentOID: "1"},
+		&mib.Node{OID: "1.3.6", Name: "dod", Type: "node", ParentOID: "1.3"},
+		&mib.Node{OID: "1.3.6.1", Name: "internet", Type: "node", ParentOID: "1.3.6"},
+		&mib.Node{OID: "1.3.6.1.1", Name: "directory", Type: "node", ParentOID: "1.3.6.1"},
+		&mib.Node{OID: "1.3.6.1.2", Name: "mgmt", Type: "node", ParentOID: "1.3.6.1"},
+		&mib.Node{OID: "1.3.6.1.4", Name: "private", Type: "node", ParentOID: "1.3.6.1"},
+	)
+
+	if err := app.SetTreePreferences(TreePreferences{HiddenRoots: []string{"0", ".2", "1.0"}, Pinned: []string{"1.3.6.1.4", "1.3.6.1.2", "1.3.6.1.4"}}); err != nil {
+		t.Fatalf("SetTreePreferences() error = %v", err)
+	}
+	preferences, err := app.GetTreePreferences()
+	if err != nil {
+		t.Fatalf("GetTreePreferences() error = %v", err)
+	}
+	if len(preferences.HiddenRoots) != 3 || preferences.HiddenRoots[1] != "2" || len(preferences.Pinned) != 2 {
+		t.Fatalf("unexpected normalized preferences: %+v", preferences)
+	}
+
+	tree, err := app.GetMIBTree()
+	if err != nil {
+		t.Fatalf("GetMIBTree() error = %v", err)
+	}
+	if len(tree) != 2 || tree[0].OID != "bookmarks" || tree[1].OID != "1" {
+		t.Fatalf("expected bookmarks and iso only at the top level, got %+v", tree)
+	}
+	iso := tree[1]
+	if len(iso.Children) != 1 || iso.Children[0].OID != "1.3" {
+		t.Fatalf("expected 1.0 to be hidden under iso, got %+v", iso.Children)
+	}
+
+	internet := iso.Children[0].Children[0].Children[0]
+	var order []string
+	for _, child := range internet.Children {
+		order = append(order, child.Name)
+	}
+	if len(order) != 3 || order[0] != "private" || order[1] != "mgmt" || order[2] != "directory" {
+		t.Fatalf("expected pinned nodes first, got %v", order)
+	}
+	if internet.Children[0].Pinned != 1 || internet.Children[1].Pinned != 2 || internet.Children[2].Pinned != 0 {
+		t.Fatalf("unexpected pinned hints: %+v", internet.Children)
+	}
+
+	// I rami nascosti restano raggiungibili tramite ricerca.
+	found, err := app.SearchMIBNodes("ccitt")
+	if err != nil {
+		t.Fatalf("SearchMIBNodes() error = %v", err)
+	}
+	if len(found) == 0 {
+		t.Fatalf("expected hidden branch to be found by search")
+	}
+
+	if err := app.SetTreePreferences(TreePreferences{HiddenRoots: []string{"not-an-oid"}}); err == nil {
+		t.Fatalf("expected invalid OID to be rejected")
+	}
+}
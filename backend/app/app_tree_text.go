@@ -0,0 +1,145 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"mib-to-the-future/backend/mib"
+)
+
+// treeTextTypeLabels associa i tipi gosnmp attesi alle etichette usate da snmptranslate -Tp.
+var treeTextTypeLabels = map[string]string{
+	"Integer":          "Integer32",
+	"Gauge32":          "Gauge",
+	"Counter32":        "Counter",
+	"Counter64":        "Counter64",
+	"TimeTicks":        "TimeTicks",
+	"IPAddress":        "IpAddr",
+	"ObjectIdentifier": "ObjID",
+	"OctetString":      "String",
+	"Opaque":           "Opaque",
+}
+
+// ExportTreeText restituisce il sottoalbero di rootOID nel formato indentato di snmptranslate -Tp,
+// con nome e sub-identificatore di ogni nodo e, per scalar e colonne, accesso e tipo.
+// Con rootOID vuoto viene esportato l'intero albero.
+func (a *App) ExportTreeText(rootOID string) (string, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return "", a.mibNotInitializedErr()
+	}
+
+	tree, err := db.GetTree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get MIB tree: %v", err)
+	}
+
+	roots := tree
+	if strings.TrimSpace(rootOID) != "" {
+		if err := validateOIDInput(rootOID); err != nil {
+			return "", err
+		}
+		root := findTreeNode(tree, normalizeOIDKey(rootOID))
+		if root == nil {
+			return "", fmt.Errorf("OID %s not found in MIB tree", rootOID)
+		}
+		roots = []*mib.Node{root}
+	}
+
+	var builder strings.Builder
+	for i, root := range roots {
+		writeTreeTextNode(&builder, root, "", i == len(roots)-1)
+	}
+	return builder.String(), nil
+}
+
+// findTreeNode cerca nell'albero il nodo con l'OID indicato, scendendo solo nei rami che lo contengono.
+func findTreeNode(nodes []*mib.Node, oid string) *mib.Node {
+	for _, node := range nodes {
+		nodeOID := normalizeOIDKey(node.OID)
+		if nodeOID == oid {
+			return node
+		}
+		if isOIDWithinSubtree(nodeOID, oid) {
+			if found := findTreeNode(node.Children, oid); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// writeTreeTextNode scrive il nodo e, ricorsivamente, i suoi figli. I figli sono indentati di tre
+// colonne; la barra verticale prosegue finché il nodo ha fratelli successivi.
+func writeTreeTextNode(builder *strings.Builder, node *mib.Node, indent string, last bool) {
+	builder.WriteString(indent)
+	builder.WriteString(treeTextLabel(node))
+	builder.WriteByte('\n')
+
+	if len(node.Children) == 0 {
+		return
+	}
+
+	childIndent := indent + "|  "
+	if last {
+		childIndent = indent + "   "
+	}
+	builder.WriteString(childIndent)
+	builder.WriteString("|\n")
+	for i, child := range node.Children {
+		writeTreeTextNode(builder, child, childIndent, i == len(node.Children)-1)
+	}
+}
+
+// treeTextLabel produce la riga del nodo: "+--name(arc)" oppure, per scalar e colonne,
+// "+-- -R-- String    name(arc)".
+func treeTextLabel(node *mib.Node) string {
+	segments := splitSegments(node.OID)
+	arc := ""
+	if len(segments) > 0 {
+		arc = segments[len(segments)-1]
+	}
+	name := fmt.Sprintf("%s(%s)", node.Name, arc)
+
+	switch strings.ToLower(node.Type) {
+	case "scalar", "column":
+		return fmt.Sprintf("+-- %s %-9s %s", treeTextAccess(node.Access), treeTextType(node.Syntax), name)
+	default:
+		return "+--" + name
+	}
+}
+
+// treeTextAccess restituisce il codice di accesso di snmptranslate (-R--, -RW-, -RC-, ...).
+func treeTextAccess(access string) string {
+	switch strings.ToLower(strings.TrimSpace(access)) {
+	case "read-only":
+		return "-R--"
+	case "read-write":
+		return "-RW-"
+	case "read-create":
+		return "-RC-"
+	case "write-only":
+		return "--W-"
+	case "accessible-for-notify":
+		return "---N"
+	default:
+		return "----"
+	}
+}
+
+// treeTextType restituisce l'etichetta del tipo di snmptranslate per la sintassi del nodo.
+func treeTextType(syntax string) string {
+	base := syntaxBaseName(syntax)
+	if base == "bits" {
+		return "BitString"
+	}
+	expected, ok := syntaxAgentTypes[base]
+	if !ok {
+		return ""
+	}
+	if expected[0] == "Integer" && parseEnumMapping(syntax) != nil {
+		return "EnumVal"
+	}
+	return treeTextTypeLabels[expected[0]]
+}
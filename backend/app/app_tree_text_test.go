@@ -0,0 +1,52 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"mib-to-the-future/backend/mib"
+)
+
+func TestExportTreeText(t *testing.T) {
+	app := setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.1", Name: "system", Type: "node"},
+		&mib.Node{OID: "1.3.6.1.2.1.1.1", Name: "sysDescr", Type: "scalar", Syntax: "DisplayString", Access: "read-only", ParentOID: "1.3.6.1.2.1.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.1.10", Name: "sysLater", Type: "scalar", Syntax: "INTEGER {up(1), down(2)}", Access: "read-write", ParentOID: "1.3.6.1.2.1.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.1.9", Name: "sysORTable", Type: "table", ParentOID: "1.3.6.1.2.1.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.1.9.1", Name: "sysOREntry", Type: "node", ParentOID: "1.3.6.1.2.1.1.9"},
+		&mib.Node{OID: "1.3.6.1.2.1.1.9.1.3", Name: "sysORDescr", Type: "column", Syntax: "DisplayString", Access: "read-only", ParentOID: "1.3.6.1.2.1.1.9.1"},
+	)
+
+	text, err := app.ExportTreeText("1.3.6.1.2.1.1")
+	if err != nil {
+		t.Fatalf("ExportTreeText error: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"+--system(1)",
+		"   |",
+		"   +-- -R-- String    sysDescr(1)",
+		"   +--sysORTable(9)",
+		"   |  |",
+		"   |  +--sysOREntry(1)",
+		"   |     |",
+		"   |     +-- -R-- String    sysORDescr(3)",
+		"   +-- -RW- EnumVal   sysLater(10)",
+		"",
+	}, "\n")
+	if text != want {
+		t.Fatalf("unexpected tree text:\n%s\nwant:\n%s", text, want)
+	}
+
+	full, err := app.ExportTreeText("")
+	if err != nil {
+		t.Fatalf("ExportTreeText full error: %v", err)
+	}
+	if !strings.HasPrefix(full, "+--system(1)") {
+		t.Fatalf("unexpected full export:\n%s", full)
+	}
+
+	if _, err := app.ExportTreeText("1.3.6.1.4.1.9"); err == nil {
+		t.Fatalf("expected unknown root to be rejected")
+	}
+}
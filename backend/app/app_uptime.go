@@ -0,0 +1,136 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// sysUpTimeOID è l'OID di SNMPv2-MIB::sysUpTime.
+const sysUpTimeOID = "1.3.6.1.2.1.1.3"
+
+// timeTicksWrap è il valore a cui un TimeTicks (32 bit) torna a zero, circa 497 giorni.
+const timeTicksWrap = int64(1) << 32
+
+// HostRebootEvent è il payload dell'evento `host:rebooted`.
+type HostRebootEvent struct {
+	Host              string `json:"host"`
+	PreviousUptime    int64  `json:"previousUptime"`
+	CurrentUptime     int64  `json:"currentUptime"`
+	EstimatedRebootAt string `json:"estimatedRebootAt"`
+}
+
+// uptimeSample memorizza un valore di sysUpTime e l'istante in cui è stato osservato.
+type uptimeSample struct {
+	ticks      int64
+	observedAt time.Time
+}
+
+// UptimeTracker tiene traccia dell'ultimo sysUpTime osservato per ciascun host e rileva i riavvii.
+type UptimeTracker struct {
+	mu      sync.Mutex
+	samples map[string]uptimeSample
+}
+
+// NewUptimeTracker crea un tracker vuoto.
+func NewUptimeTracker() *UptimeTracker {
+	return &UptimeTracker{samples: make(map[string]uptimeSample)}
+}
+
+// Known indica se il tracker dispone già di un'osservazione per l'host.
+func (t *UptimeTracker) Known(host string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.samples[host]
+	return ok
+}
+
+// Seed registra un'osservazione precedente (es. caricata dal database) se l'host non è già noto.
+func (t *UptimeTracker) Seed(host string, ticks int64, observedAt time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.samples[host]; !ok {
+		t.samples[host] = uptimeSample{ticks: ticks, observedAt: observedAt}
+	}
+}
+
+// Observe registra un nuovo sysUpTime e indica se l'host risulta riavviato rispetto all'osservazione
+// precedente. Un valore minore del precedente è considerato riavvio, salvo che il tempo trascorso
+// giustifichi il wrap a 32 bit del contatore. Restituisce anche il valore precedente e l'istante stimato di avvio.
+func (t *UptimeTracker) Observe(host string, ticks int64, observedAt time.Time) (bool, int64, time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	previous, known := t.samples[host]
+	t.samples[host] = uptimeSample{ticks: ticks, observedAt: observedAt}
+
+	bootTime := observedAt.Add(-time.Duration(ticks) * 10 * time.Millisecond)
+	if !known || ticks >= previous.ticks {
+		return false, previous.ticks, bootTime
+	}
+
+	elapsedTicks := observedAt.Sub(previous.observedAt).Milliseconds() / 10
+	if previous.ticks+elapsedTicks >= timeTicksWrap {
+		return false, previous.ticks, bootTime
+	}
+
+	return true, previous.ticks, bootTime
+}
+
+// observeUptime aggiorna il tracker quando il risultato riguarda sysUpTime, persistendo l'osservazione
+// in host_configs ed emettendo `host:rebooted` quando il valore torna indietro.
+func (a *App) observeUptime(host string, result *snmp.Result) {
+	db, release := a.acquireMIBDB()
+	defer release()
+
+	host = strings.TrimSpace(host)
+	if host == "" || result == nil || a.uptimeTracker == nil {
+		return
+	}
+
+	oid := normalizeOIDKey(result.OID)
+	if oid != sysUpTimeOID && oid != sysUpTimeOID+".0" {
+		return
+	}
+
+	ticks, err := strconv.ParseInt(strings.TrimSpace(result.Value), 10, 64)
+	if err != nil || ticks < 0 {
+		return
+	}
+
+	now := time.Now()
+
+	if !a.uptimeTracker.Known(host) && db != nil {
+		if previous, observedAt, ok, err := db.GetHostUptime(host); err == nil && ok {
+			a.uptimeTracker.Seed(host, previous, observedAt)
+		}
+	}
+
+	rebooted, previous, bootTime := a.uptimeTracker.Observe(host, ticks, now)
+
+	if db != nil {
+		if err := db.UpdateHostUptime(host, ticks, now); err != nil && a.ctx != nil {
+			runtime.LogError(a.ctx, fmt.Sprintf("Failed to persist uptime for %s: %v", host, err))
+		}
+	}
+
+	if !rebooted || a.ctx == nil {
+		return
+	}
+
+	event := HostRebootEvent{
+		Host:              host,
+		PreviousUptime:    previous,
+		CurrentUptime:     ticks,
+		EstimatedRebootAt: bootTime.Format(time.RFC3339),
+	}
+	runtime.LogWarning(a.ctx, fmt.Sprintf("Host %s rebooted (sysUpTime %d -> %d), estimated reboot at %s",
+		host, previous, ticks, event.EstimatedRebootAt))
+	runtime.EventsEmit(a.ctx, "host:rebooted", event)
+}
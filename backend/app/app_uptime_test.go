@@ -0,0 +1,40 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUptimeTrackerDetectsReboot(t *testing.T) {
+	tracker := NewUptimeTracker()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if rebooted, _, _ := tracker.Observe("10.0.0.1", 500000, start); rebooted {
+		t.Fatalf("first observation should not report a reboot")
+	}
+	if rebooted, _, _ := tracker.Observe("10.0.0.1", 506000, start.Add(time.Minute)); rebooted {
+		t.Fatalf("increasing uptime should not report a reboot")
+	}
+
+	observed := start.Add(2 * time.Minute)
+	rebooted, previous, bootTime := tracker.Observe("10.0.0.1", 3000, observed)
+	if !rebooted {
+		t.Fatalf("decreasing uptime should report a reboot")
+	}
+	if previous != 506000 {
+		t.Fatalf("previous uptime = %d, want 506000", previous)
+	}
+	if want := observed.Add(-30 * time.Second); !bootTime.Equal(want) {
+		t.Fatalf("boot time = %s, want %s", bootTime, want)
+	}
+}
+
+func TestUptimeTrackerIgnoresCounterWrap(t *testing.T) {
+	tracker := NewUptimeTracker()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tracker.Seed("10.0.0.2", timeTicksWrap-1000, start)
+	if rebooted, _, _ := tracker.Observe("10.0.0.2", 500, start.Add(20*time.Second)); rebooted {
+		t.Fatalf("32-bit wrap should not be reported as a reboot")
+	}
+}
@@ -0,0 +1,184 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	// defaultWalkProfileRepetitions è il max-repetitions dei profili bulk che non lo specificano.
+	defaultWalkProfileRepetitions = 50
+	// walkProfilesSettingKey è la chiave dei profili di WALK in ExportSettings.
+	walkProfilesSettingKey = "walk_profiles"
+)
+
+// ListWalkProfiles restituisce i profili di WALK salvati, ordinati per nome.
+func (a *App) ListWalkProfiles() ([]mib.WalkProfile, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	profiles, err := db.ListWalkProfiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list walk profiles: %w", err)
+	}
+	return profiles, nil
+}
+
+// SaveWalkProfile crea o aggiorna un profilo di WALK e restituisce la versione persistita.
+func (a *App) SaveWalkProfile(profile mib.WalkProfile) (*mib.WalkProfile, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return nil, a.mibNotInitializedErr()
+	}
+
+	saved, err := db.SaveWalkProfile(profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save walk profile: %w", err)
+	}
+
+	runtime.LogInfo(a.ctx, fmt.Sprintf("Saved walk profile: %s (%s)", saved.Name, saved.RootOID))
+	return saved, nil
+}
+
+// DeleteWalkProfile elimina un profilo di WALK.
+func (a *App) DeleteWalkProfile(profileID int64) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+
+	if err := db.DeleteWalkProfile(profileID); err != nil {
+		return fmt.Errorf("failed to delete walk profile: %w", err)
+	}
+
+	runtime.LogInfo(a.ctx, fmt.Sprintf("Deleted walk profile: %d", profileID))
+	return nil
+}
+
+// importWalkProfiles salva i profili importati: quelli con lo stesso nome di un profilo esistente
+// lo aggiornano, gli altri vengono creati. Gli ID di provenienza sono ignorati.
+func (a *App) importWalkProfiles(profiles []mib.WalkProfile) error {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return a.mibNotInitializedErr()
+	}
+
+	existing, err := db.ListWalkProfiles()
+	if err != nil {
+		return fmt.Errorf("failed to list walk profiles: %w", err)
+	}
+	idsByName := make(map[string]int64, len(existing))
+	for _, profile := range existing {
+		idsByName[strings.ToLower(profile.Name)] = profile.ID
+	}
+
+	for _, profile := range profiles {
+		profile.ID = idsByName[strings.ToLower(profile.Name)]
+		saved, err := db.SaveWalkProfile(profile)
+		if err != nil {
+			return fmt.Errorf("failed to import walk profile %s: %w", profile.Name, err)
+		}
+		idsByName[strings.ToLower(saved.Name)] = saved.ID
+	}
+	return nil
+}
+
+// RunWalkProfile avvia come walk in streaming un profilo di WALK sull'host indicato, usando le
+// credenziali salvate. I varbind arrivano con gli eventi walk:results e walk:state come per
+// StartWalkStream, già filtrati e limitati secondo il profilo.
+// Parametri:
+//   - profileID: l'ID del profilo da eseguire.
+//   - hostAddress: l'indirizzo di un host presente tra quelli salvati.
+//
+// Ritorna l'ID del walk da usare con PauseWalk, ResumeWalk, CancelWalk e GetWalkStreamState.
+func (a *App) RunWalkProfile(profileID int64, hostAddress string) (string, error) {
+	db, release := a.acquireMIBDB()
+	defer release()
+	if db == nil {
+		return "", a.mibNotInitializedErr()
+	}
+
+	profile, err := db.GetWalkProfile(profileID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load walk profile: %w", err)
+	}
+	if profile == nil {
+		return "", fmt.Errorf("walk profile %d not found", profileID)
+	}
+
+	address := strings.TrimSpace(hostAddress)
+	if address == "" {
+		return "", fmt.Errorf("address is required")
+	}
+
+	host, err := db.GetHost(address)
+	if err != nil {
+		return "", fmt.Errorf("failed to load host config: %w", err)
+	}
+	if host == nil {
+		return "", fmt.Errorf("host %s non trovato tra quelli salvati", address)
+	}
+
+	config := hostConfigToSNMP(host)
+	config.MaxDurationMs = profile.MaxDurationMs
+
+	options := walkStreamOptions{
+		filters:    parseWalkFilters(profile.Filter),
+		maxResults: profile.MaxResults,
+	}
+	if profile.UseBulk && !strings.EqualFold(config.Version, "v1") {
+		options.bulkRepetitions = uint8(profile.MaxRepetitions)
+		if options.bulkRepetitions == 0 {
+			options.bulkRepetitions = defaultWalkProfileRepetitions
+		}
+	}
+
+	walkID, err := a.startWalkStream(config, profile.RootOID, options)
+	if err != nil {
+		return "", err
+	}
+
+	if a.ctx != nil {
+		runtime.LogInfo(a.ctx, fmt.Sprintf("Walk profile %s started on %s (%s)", profile.Name, address, walkID))
+	}
+	return walkID, nil
+}
+
+// parseWalkFilters divide il filtro del profilo in termini separati da virgola, in minuscolo.
+func parseWalkFilters(filter string) []string {
+	terms := []string{}
+	for _, part := range strings.Split(filter, ",") {
+		term := strings.ToLower(strings.TrimSpace(part))
+		if term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// matchesWalkFilters verifica che OID o nome risolto contengano almeno uno dei termini del filtro.
+func matchesWalkFilters(result snmp.Result, terms []string) bool {
+	if len(terms) == 0 {
+		return true
+	}
+
+	oid := strings.ToLower(normalizeOIDKey(result.OID))
+	name := strings.ToLower(result.ResolvedName)
+	for _, term := range terms {
+		if strings.Contains(oid, term) || strings.Contains(name, term) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestRunWalkProfileStreamsWithProfileLimits(t *testing.T) {
+	app := setupTestAppWithNodes(t)
+	if err := app.mibDB.EnsureHostConfigSchema(); err != nil {
+		t.Fatalf("EnsureHostConfigSchema error: %v", err)
+	}
+
+	values := map[string]gosnmp.SnmpPDU{}
+	for _, oid := range []string{"1.3.6.1.2.1.2.2.1.1.1", "1.3.6.1.2.1.2.2.1.1.2", "1.3.6.1.2.1.2.2.1.2.1", "1.3.6.1.2.1.2.2.1.2.2", "1.3.6.1.2.1.2.2.1.2.3"} {
+		values[oid] = gosnmp.SnmpPDU{Type: gosnmp.Integer, Value: 1}
+	}
+	agent := startTestAgent(t, values)
+	config := agent.config()
+	if _, err := app.SaveHost(mib.HostConfig{Address: config.Host, Port: config.Port, Community: config.Community, Version: config.Version}); err != nil {
+		t.Fatalf("SaveHost error: %v", err)
+	}
+
+	for _, useBulk := range []bool{false, true} {
+		profile, err := app.mibDB.SaveWalkProfile(mib.WalkProfile{
+			Name:           map[bool]string{false: "getnext", true: "bulk"}[useBulk],
+			RootOID:        "1.3.6.1.2.1.2.2",
+			UseBulk:        useBulk,
+			MaxRepetitions: 2,
+			Filter:         "2.2.1.2.",
+			MaxResults:     2,
+			MaxDurationMs:  5000,
+		})
+		if err != nil {
+			t.Fatalf("SaveWalkProfile error: %v", err)
+		}
+
+		walkID, err := app.RunWalkProfile(profile.ID, config.Host)
+		if err != nil {
+			t.Fatalf("RunWalkProfile(bulk=%v) error: %v", useBulk, err)
+		}
+
+		state := waitWalkStreamDone(t, app, walkID)
+		// Le prime due righe non passano il filtro, il limite ferma il walk al secondo varbind utile.
+		if state.Status != walkStatusCompleted || state.Count != 4 || state.LastOID != "1.3.6.1.2.1.2.2.1.2.2" {
+			t.Fatalf("unexpected walk state (bulk=%v): %+v", useBulk, state)
+		}
+	}
+}
+
+func waitWalkStreamDone(t *testing.T, app *App, walkID string) *WalkStreamState {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		state, err := app.GetWalkStreamState(walkID)
+		if err != nil {
+			t.Fatalf("GetWalkStreamState error: %v", err)
+		}
+		if state.Status != walkStatusRunning {
+			return state
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("walk %s did not finish", walkID)
+	return nil
+}
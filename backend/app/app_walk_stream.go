@@ -0,0 +1,400 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	// walkStreamBatchSize è il numero di varbind raccolti prima di emettere un evento walk:results.
+	walkStreamBatchSize = 50
+	walkResultsEvent    = "walk:results"
+	walkStateEvent      = "walk:state"
+)
+
+// Stati di un walk in streaming.
+const (
+	walkStatusRunning   = "running"
+	walkStatusPaused    = "paused"
+	walkStatusCompleted = "completed"
+	walkStatusCancelled = "cancelled"
+	walkStatusFailed    = "failed"
+)
+
+// walkStream è lo stato di un walk GETNEXT eseguito in background. lastOID è l'ultimo OID ricevuto:
+// alla ripresa il walk prosegue con un GETNEXT da lì. resume è non nil solo mentre il walk è in pausa
+// e viene chiuso da ResumeWalk.
+type walkStream struct {
+	config    snmp.Config
+	options   walkStreamOptions
+	root      string
+	lastOID   string
+	count     int
+	status    string
+	err       string
+	timedOut  bool
+	resume    chan struct{}
+	cancel    context.CancelFunc
+	startedAt time.Time
+}
+
+// walkStreamOptions sono le opzioni dei walk avviati da un profilo. Con bulkRepetitions maggiore di
+// zero il walk usa GETBULK; filters scarta i varbind che non corrispondono (vedi matchesWalkFilters)
+// e maxResults conclude il walk dopo quel numero di varbind notificati.
+type walkStreamOptions struct {
+	bulkRepetitions uint8
+	filters         []string
+	maxResults      int
+}
+
+// WalkStreamState descrive l'avanzamento di un walk in streaming; è anche il payload dell'evento walk:state.
+type WalkStreamState struct {
+	WalkID    string `json:"walkId"`
+	Host      string `json:"host"`
+	RootOID   string `json:"rootOid"`
+	LastOID   string `json:"lastOid"`
+	Count     int    `json:"count"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	StartedAt string `json:"startedAt"`
+	// TimedOut indica che il walk è stato interrotto allo scadere di MaxDurationMs.
+	TimedOut bool `json:"timedOut,omitempty"`
+}
+
+// WalkStreamBatch è il payload dell'evento walk:results con i varbind ricevuti dall'ultimo evento.
+type WalkStreamBatch struct {
+	WalkID  string        `json:"walkId"`
+	Results []snmp.Result `json:"results"`
+}
+
+// StartWalkStream avvia in background un walk GETNEXT del sottoalbero oid. I varbind vengono
+// notificati a blocchi con l'evento walk:results e i cambi di stato con walk:state.
+// Ritorna l'ID da usare con PauseWalk, ResumeWalk, CancelWalk e GetWalkStreamState.
+// Con config.MaxDurationMs il walk si conclude allo scadere del tempo, pause comprese, con TimedOut impostato.
+func (a *App) StartWalkStream(config snmp.Config, oid string) (string, error) {
+	return a.startWalkStream(config, oid, walkStreamOptions{})
+}
+
+func (a *App) startWalkStream(config snmp.Config, oid string, options walkStreamOptions) (string, error) {
+	if err := validateOIDInput(oid); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(config.Host) == "" {
+		return "", fmt.Errorf("host is required")
+	}
+
+	client, err := a.newSNMPClient(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to create SNMP client: %v", err)
+	}
+	a.persistHostUsage(config)
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if maxDuration := config.MaxDuration(); maxDuration > 0 {
+		ctx, cancel = context.WithTimeout(a.backgroundContext(), maxDuration)
+	} else {
+		ctx, cancel = context.WithCancel(a.backgroundContext())
+	}
+	client.SetContext(ctx)
+
+	root := normalizeOIDKey(oid)
+	a.walkStreamM.Lock()
+	if a.walkStreams == nil {
+		a.walkStreams = make(map[string]*walkStream)
+	}
+	a.walkStreamSeq++
+	walkID := fmt.Sprintf("walk-%d", a.walkStreamSeq)
+	a.walkStreams[walkID] = &walkStream{
+		config:    config,
+		options:   options,
+		root:      root,
+		lastOID:   root,
+		status:    walkStatusRunning,
+		cancel:    cancel,
+		startedAt: time.Now(),
+	}
+	a.walkStreamM.Unlock()
+
+	next := client.GetNext
+	if options.bulkRepetitions > 0 {
+		next = bulkWalkCursor(client, options.bulkRepetitions)
+	}
+	if err := a.goBackground("walk-stream", func(context.Context) { a.runWalkStream(ctx, walkID, next) }); err != nil {
+		cancel()
+		a.walkStreamM.Lock()
+		delete(a.walkStreams, walkID)
+		a.walkStreamM.Unlock()
+		return "", err
+	}
+	return walkID, nil
+}
+
+// PauseWalk sospende un walk in corso: la richiesta già inviata viene completata, poi il walk
+// attende ResumeWalk conservando l'ultimo OID ricevuto.
+func (a *App) PauseWalk(walkID string) (*WalkStreamState, error) {
+	a.walkStreamM.Lock()
+	defer a.walkStreamM.Unlock()
+
+	stream, ok := a.walkStreams[walkID]
+	if !ok {
+		return nil, fmt.Errorf("walk %s not found", walkID)
+	}
+	switch stream.status {
+	case walkStatusPaused:
+		return stream.state(walkID), nil
+	case walkStatusRunning:
+		stream.status = walkStatusPaused
+		stream.resume = make(chan struct{})
+	default:
+		return nil, fmt.Errorf("walk %s is already %s", walkID, stream.status)
+	}
+
+	state := stream.state(walkID)
+	a.emitWalkState(*state)
+	return state, nil
+}
+
+// ResumeWalk riprende un walk in pausa con un GETNEXT dall'ultimo OID ricevuto.
+func (a *App) ResumeWalk(walkID string) (*WalkStreamState, error) {
+	a.walkStreamM.Lock()
+	defer a.walkStreamM.Unlock()
+
+	stream, ok := a.walkStreams[walkID]
+	if !ok {
+		return nil, fmt.Errorf("walk %s not found", walkID)
+	}
+	switch stream.status {
+	case walkStatusRunning:
+		return stream.state(walkID), nil
+	case walkStatusPaused:
+		stream.status = walkStatusRunning
+		close(stream.resume)
+		stream.resume = nil
+	default:
+		return nil, fmt.Errorf("walk %s is already %s", walkID, stream.status)
+	}
+
+	state := stream.state(walkID)
+	a.emitWalkState(*state)
+	return state, nil
+}
+
+// CancelWalk interrompe un walk in corso o in pausa. I varbind già notificati restano validi.
+func (a *App) CancelWalk(walkID string) error {
+	a.walkStreamM.Lock()
+	stream, ok := a.walkStreams[walkID]
+	a.walkStreamM.Unlock()
+	if !ok {
+		return fmt.Errorf("walk %s not found", walkID)
+	}
+	stream.cancel()
+	return nil
+}
+
+// GetWalkStreamState restituisce lo stato di un walk in streaming.
+func (a *App) GetWalkStreamState(walkID string) (*WalkStreamState, error) {
+	a.walkStreamM.Lock()
+	defer a.walkStreamM.Unlock()
+
+	stream, ok := a.walkStreams[walkID]
+	if !ok {
+		return nil, fmt.Errorf("walk %s not found", walkID)
+	}
+	return stream.state(walkID), nil
+}
+
+// CloseWalkStream annulla il walk se ancora attivo e ne elimina lo stato.
+func (a *App) CloseWalkStream(walkID string) {
+	a.walkStreamM.Lock()
+	stream, ok := a.walkStreams[walkID]
+	delete(a.walkStreams, walkID)
+	a.walkStreamM.Unlock()
+	if ok {
+		stream.cancel()
+	}
+}
+
+// runWalkStream esegue il ciclo GETNEXT del walk walkID, controllando pausa e annullamento tra una
+// richiesta e l'altra.
+func (a *App) runWalkStream(ctx context.Context, walkID string, next func(oid string) (*snmp.Result, error)) {
+	batch := make([]snmp.Result, 0, walkStreamBatchSize)
+	flush := func() {
+		if len(batch) > 0 {
+			a.emitWalkResults(WalkStreamBatch{WalkID: walkID, Results: batch})
+			batch = make([]snmp.Result, 0, walkStreamBatchSize)
+		}
+	}
+
+	a.walkStreamM.Lock()
+	var options walkStreamOptions
+	if stream, ok := a.walkStreams[walkID]; ok {
+		options = stream.options
+	}
+	a.walkStreamM.Unlock()
+
+	status, errMessage, timedOut := walkStatusCompleted, "", false
+	emitted := 0
+	for {
+		cursor, host, resume, ok := a.walkStreamCursor(walkID)
+		if !ok {
+			return
+		}
+		if resume != nil {
+			flush()
+			select {
+			case <-resume:
+				continue
+			case <-ctx.Done():
+			}
+		}
+		if ctx.Err() != nil {
+			status, timedOut = walkStreamInterrupted(ctx, nil)
+			break
+		}
+
+		result, err := next(cursor)
+		if ctx.Err() != nil || errors.Is(err, context.DeadlineExceeded) {
+			status, timedOut = walkStreamInterrupted(ctx, err)
+			break
+		}
+		if err != nil {
+			status, errMessage = walkStatusFailed, fmt.Sprintf("SNMP GETNEXT failed: %v", err)
+			break
+		}
+		if !a.advanceWalkStream(walkID, result) {
+			break
+		}
+
+		a.enrichResult(host, result)
+		if !matchesWalkFilters(*result, options.filters) {
+			continue
+		}
+		batch = append(batch, *result)
+		emitted++
+		if len(batch) >= walkStreamBatchSize {
+			flush()
+		}
+		if options.maxResults > 0 && emitted >= options.maxResults {
+			break
+		}
+	}
+	flush()
+
+	a.walkStreamM.Lock()
+	stream, ok := a.walkStreams[walkID]
+	if !ok {
+		a.walkStreamM.Unlock()
+		return
+	}
+	stream.status = status
+	stream.err = errMessage
+	stream.timedOut = timedOut
+	stream.resume = nil
+	stream.cancel()
+	state := stream.state(walkID)
+	a.walkStreamM.Unlock()
+
+	a.emitWalkState(*state)
+}
+
+// walkStreamInterrupted distingue un walk annullato da uno concluso per il limite di durata,
+// che conserva lo stato completed con i risultati raccolti fino a quel momento. gosnmp può
+// restituire la scadenza del contesto (err) prima che ctx.Err() sia impostato.
+func walkStreamInterrupted(ctx context.Context, err error) (string, bool) {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) || errors.Is(err, context.DeadlineExceeded) {
+		return walkStatusCompleted, true
+	}
+	return walkStatusCancelled, false
+}
+
+// walkStreamCursor restituisce l'OID da cui proseguire e, se il walk è in pausa, il canale di ripresa.
+func (a *App) walkStreamCursor(walkID string) (string, string, chan struct{}, bool) {
+	a.walkStreamM.Lock()
+	defer a.walkStreamM.Unlock()
+
+	stream, ok := a.walkStreams[walkID]
+	if !ok {
+		return "", "", nil, false
+	}
+	return stream.lastOID, stream.config.Host, stream.resume, true
+}
+
+// advanceWalkStream registra il risultato di un GETNEXT. Ritorna false quando il walk è concluso:
+// endOfMibView, OID fuori dal sottoalbero o che non avanza.
+func (a *App) advanceWalkStream(walkID string, result *snmp.Result) bool {
+	a.walkStreamM.Lock()
+	defer a.walkStreamM.Unlock()
+
+	stream, ok := a.walkStreams[walkID]
+	if !ok || result == nil || strings.EqualFold(result.Type, "EndOfMibView") {
+		return false
+	}
+
+	next := normalizeOIDKey(result.OID)
+	if !isOIDWithinSubtree(stream.root, next) || mib.CompareOIDs(next, stream.lastOID) <= 0 {
+		return false
+	}
+
+	stream.lastOID = next
+	stream.count++
+	return true
+}
+
+// bulkWalkCursor restituisce una funzione GETNEXT equivalente che legge il sottoalbero a blocchi di
+// GETBULK: i varbind di ogni risposta vengono restituiti uno alla volta finché la richiesta riparte
+// dall'ultimo OID consegnato, altrimenti (ad esempio dopo una pausa) il blocco viene riletto.
+func bulkWalkCursor(client *snmp.Client, repetitions uint8) func(oid string) (*snmp.Result, error) {
+	var pending []snmp.Result
+	delivered := ""
+	return func(oid string) (*snmp.Result, error) {
+		if len(pending) == 0 || normalizeOIDKey(oid) != delivered {
+			results, err := client.GetBulk(oid, repetitions)
+			if err != nil {
+				return nil, err
+			}
+			if len(results) == 0 {
+				return &snmp.Result{OID: oid, Type: "EndOfMibView"}, nil
+			}
+			pending = results
+		}
+		result := pending[0]
+		pending = pending[1:]
+		delivered = normalizeOIDKey(result.OID)
+		return &result, nil
+	}
+}
+
+func (s *walkStream) state(walkID string) *WalkStreamState {
+	return &WalkStreamState{
+		WalkID:    walkID,
+		Host:      s.config.Host,
+		RootOID:   s.root,
+		LastOID:   s.lastOID,
+		Count:     s.count,
+		Status:    s.status,
+		Error:     s.err,
+		StartedAt: s.startedAt.Format(time.RFC3339),
+		TimedOut:  s.timedOut,
+	}
+}
+
+func (a *App) emitWalkResults(batch WalkStreamBatch) {
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, walkResultsEvent, batch)
+	}
+}
+
+func (a *App) emitWalkState(state WalkStreamState) {
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, walkStateEvent, state)
+	}
+}
@@ -0,0 +1,161 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// registerTestWalkStream registra un walk sul sottoalbero root senza avviarlo.
+func registerTestWalkStream(app *App, walkID, root string) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	app.walkStreams = map[string]*walkStream{
+		walkID: {config: snmp.Config{Host: "127.0.0.1"}, root: root, lastOID: root, status: walkStatusRunning, cancel: cancel, startedAt: time.Now()},
+	}
+	return ctx
+}
+
+func TestWalkStreamPauseResumeContinuesFromLastOID(t *testing.T) {
+	app := NewApp()
+	ctx := registerTestWalkStream(app, "walk-1", "1.3.6.1.2.1.2")
+
+	var (
+		requestedM sync.Mutex
+		requested  []string
+	)
+	paused := make(chan struct{})
+	next := func(oid string) (*snmp.Result, error) {
+		requestedM.Lock()
+		requested = append(requested, oid)
+		calls := len(requested)
+		requestedM.Unlock()
+
+		if calls == 2 {
+			if _, err := app.PauseWalk("walk-1"); err != nil {
+				t.Errorf("PauseWalk error: %v", err)
+			}
+			close(paused)
+		}
+		if calls > 4 {
+			return &snmp.Result{OID: "1.3.6.1.2.1.3.1", Type: "Integer"}, nil
+		}
+		return &snmp.Result{OID: fmt.Sprintf("1.3.6.1.2.1.2.%d", calls), Type: "Integer"}, nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		app.runWalkStream(ctx, "walk-1", next)
+		close(done)
+	}()
+
+	<-paused
+	time.Sleep(50 * time.Millisecond)
+	state, err := app.GetWalkStreamState("walk-1")
+	if err != nil {
+		t.Fatalf("GetWalkStreamState error: %v", err)
+	}
+	if state.Status != walkStatusPaused || state.LastOID != "1.3.6.1.2.1.2.2" || state.Count != 2 {
+		t.Fatalf("unexpected paused state: %+v", state)
+	}
+	requestedM.Lock()
+	if len(requested) != 2 {
+		t.Fatalf("expected no requests while paused, got %v", requested)
+	}
+	requestedM.Unlock()
+
+	if _, err := app.ResumeWalk("walk-1"); err != nil {
+		t.Fatalf("ResumeWalk error: %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("walk did not complete after resume")
+	}
+
+	state, _ = app.GetWalkStreamState("walk-1")
+	if state.Status != walkStatusCompleted || state.Count != 4 || state.LastOID != "1.3.6.1.2.1.2.4" {
+		t.Fatalf("unexpected final state: %+v", state)
+	}
+	if requested[2] != "1.3.6.1.2.1.2.2" {
+		t.Fatalf("expected resume to continue from the last OID, got %v", requested)
+	}
+	if _, err := app.PauseWalk("walk-1"); err == nil {
+		t.Fatalf("expected pausing a completed walk to fail")
+	}
+}
+
+func TestWalkStreamCancelWhilePaused(t *testing.T) {
+	app := NewApp()
+	ctx := registerTestWalkStream(app, "walk-1", "1.3.6.1.2.1.2")
+	if _, err := app.PauseWalk("walk-1"); err != nil {
+		t.Fatalf("PauseWalk error: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		app.runWalkStream(ctx, "walk-1", func(oid string) (*snmp.Result, error) {
+			t.Errorf("unexpected request for %s while paused", oid)
+			return nil, nil
+		})
+		close(done)
+	}()
+
+	if err := app.CancelWalk("walk-1"); err != nil {
+		t.Fatalf("CancelWalk error: %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("walk did not stop after cancellation")
+	}
+
+	state, _ := app.GetWalkStreamState("walk-1")
+	if state.Status != walkStatusCancelled {
+		t.Fatalf("expected cancelled walk, got %+v", state)
+	}
+}
+
+func TestWalkStreamStopsAtMaxDuration(t *testing.T) {
+	values := map[string]gosnmp.SnmpPDU{}
+	for i := 1; i <= 20; i++ {
+		values[fmt.Sprintf("1.3.6.1.2.1.1.9.1.2.%d", i)] = gosnmp.SnmpPDU{Type: gosnmp.Integer, Value: i}
+	}
+	agent := startTestAgent(t, values)
+	agent.setDelay(40 * time.Millisecond)
+	app := NewApp()
+
+	config := agent.config()
+	config.MaxDurationMs = 200
+	walkID, err := app.StartWalkStream(config, "1.3.6.1.2.1.1.9")
+	if err != nil {
+		t.Fatalf("StartWalkStream error: %v", err)
+	}
+	t.Cleanup(func() { app.CloseWalkStream(walkID) })
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		state, err := app.GetWalkStreamState(walkID)
+		if err != nil {
+			t.Fatalf("GetWalkStreamState error: %v", err)
+		}
+		if state.Status != walkStatusRunning {
+			if state.Status != walkStatusCompleted || !state.TimedOut {
+				t.Fatalf("expected completed walk with timedOut, got %+v", state)
+			}
+			if state.Count == 0 || state.Count >= len(values) {
+				t.Fatalf("expected partial walk, got %d results", state.Count)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("walk did not stop at max duration: %+v", state)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
@@ -0,0 +1,207 @@
+package app
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+)
+
+const (
+	// walkSummaryTopPayloads è il numero di valori più voluminosi riportati da SummarizeWalkResults.
+	walkSummaryTopPayloads = 10
+	// unresolvedWalkGroup raccoglie i varbind senza nodo MIB corrispondente.
+	unresolvedWalkGroup = "unresolved"
+)
+
+// WalkTypeStats conta i varbind di un tipo SNMP; per i tipi numerici riporta anche minimo, massimo e media.
+type WalkTypeStats struct {
+	Type    string  `json:"type"`
+	Count   int     `json:"count"`
+	Numeric bool    `json:"numeric"`
+	Min     float64 `json:"min,omitempty"`
+	Max     float64 `json:"max,omitempty"`
+	Avg     float64 `json:"avg,omitempty"`
+
+	sum     float64
+	samples int
+}
+
+// WalkGroupCount conta i varbind che appartengono a una tabella o a un gruppo di scalar.
+type WalkGroupCount struct {
+	OID   string `json:"oid"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// WalkPayload descrive una delle OCTET STRING più voluminose del walk; Size è espresso in byte.
+type WalkPayload struct {
+	OID          string `json:"oid"`
+	ResolvedName string `json:"resolvedName"`
+	Type         string `json:"type"`
+	Size         int    `json:"size"`
+}
+
+// WalkSummary è il riepilogo statistico di un walk mostrato nella scheda delle statistiche.
+type WalkSummary struct {
+	Total           int              `json:"total"`
+	Types           []WalkTypeStats  `json:"types"`
+	Groups          []WalkGroupCount `json:"groups"`
+	LargestPayloads []WalkPayload    `json:"largestPayloads"`
+}
+
+// SummarizeWalkResults calcola in un solo passaggio la distribuzione per tipo SNMP (con min/max/media dei
+// valori numerici), il numero di varbind per tabella o gruppo MIB e i valori più voluminosi.
+// Il nodo MIB viene risolto una sola volta per ogni sequenza di varbind dello stesso oggetto, così un walk
+// ordinato richiede una ricerca per colonna e non per istanza.
+func (a *App) SummarizeWalkResults(results []snmp.Result) *WalkSummary {
+	summary := &WalkSummary{
+		Total:           len(results),
+		Types:           []WalkTypeStats{},
+		Groups:          []WalkGroupCount{},
+		LargestPayloads: []WalkPayload{},
+	}
+
+	typeIndex := make(map[string]int)
+	groupIndex := make(map[string]int)
+	lastObject := ""
+	lastGroup := -1
+
+	for i := range results {
+		result := &results[i]
+
+		position, ok := typeIndex[result.Type]
+		if !ok {
+			position = len(summary.Types)
+			typeIndex[result.Type] = position
+			summary.Types = append(summary.Types, WalkTypeStats{Type: result.Type, Numeric: isNumericSNMPType(result.Type)})
+		}
+		stats := &summary.Types[position]
+		stats.Count++
+
+		raw := result.RawValue
+		if raw == "" {
+			raw = result.Value
+		}
+		if stats.Numeric {
+			if value, err := strconv.ParseFloat(raw, 64); err == nil {
+				stats.observe(value)
+			}
+		}
+
+		oid := normalizeOIDKey(result.OID)
+		if lastGroup < 0 || !isOIDWithinSubtree(lastObject, oid) {
+			var group WalkGroupCount
+			lastObject, group = a.walkGroupFor(oid)
+			if lastObject == "" {
+				lastObject = oid
+			}
+			index, ok := groupIndex[group.OID]
+			if !ok {
+				index = len(summary.Groups)
+				groupIndex[group.OID] = index
+				summary.Groups = append(summary.Groups, group)
+			}
+			lastGroup = index
+		}
+		summary.Groups[lastGroup].Count++
+
+		summary.LargestPayloads = insertLargestPayload(summary.LargestPayloads, result, payloadSize(result.Type, raw))
+	}
+
+	for i := range summary.Types {
+		if summary.Types[i].samples > 0 {
+			summary.Types[i].Avg = summary.Types[i].sum / float64(summary.Types[i].samples)
+		}
+	}
+	sort.SliceStable(summary.Types, func(i, j int) bool { return summary.Types[i].Count > summary.Types[j].Count })
+	sort.SliceStable(summary.Groups, func(i, j int) bool { return summary.Groups[i].Count > summary.Groups[j].Count })
+	return summary
+}
+
+func (s *WalkTypeStats) observe(value float64) {
+	if s.samples == 0 || value < s.Min {
+		s.Min = value
+	}
+	if s.samples == 0 || value > s.Max {
+		s.Max = value
+	}
+	s.sum += value
+	s.samples++
+}
+
+// walkGroupFor risolve l'oggetto MIB di un OID e la tabella o il gruppo a cui appartiene.
+// Il primo valore è l'OID dell'oggetto, usato per riconoscere i varbind successivi della stessa colonna;
+// è vuoto se l'OID risolve solo a un nodo intermedio, sotto cui possono trovarsi oggetti di gruppi diversi.
+func (a *App) walkGroupFor(oid string) (string, WalkGroupCount) {
+	node := a.lookupNodeForOID(oid)
+	if node == nil {
+		return "", WalkGroupCount{OID: unresolvedWalkGroup, Name: unresolvedWalkGroup}
+	}
+
+	group := node
+	object := ""
+	switch strings.ToLower(node.Type) {
+	case "column":
+		// colonna -> entry -> tabella
+		object = normalizeOIDKey(node.OID)
+		if entry := a.parentNode(node); entry != nil {
+			group = entry
+			if table := a.parentNode(entry); table != nil {
+				group = table
+			}
+		}
+	case "scalar":
+		object = normalizeOIDKey(node.OID)
+		if parent := a.parentNode(node); parent != nil {
+			group = parent
+		}
+	}
+	return object, WalkGroupCount{OID: normalizeOIDKey(group.OID), Name: group.Name}
+}
+
+func (a *App) parentNode(node *mib.Node) *mib.Node {
+	if node == nil || strings.TrimSpace(node.ParentOID) == "" {
+		return nil
+	}
+	return a.lookupNodeForOID(node.ParentOID)
+}
+
+// isNumericSNMPType indica se il tipo riportato dall'agent ha un valore numerico.
+func isNumericSNMPType(valueType string) bool {
+	switch valueType {
+	case "Integer", "Counter32", "Gauge32", "Counter64", "TimeTicks", "Uinteger32":
+		return true
+	}
+	return false
+}
+
+// payloadSize restituisce la dimensione in byte di un valore OCTET STRING (0 per gli altri tipi).
+// I byte sono formattati in esadecimale con prefisso 0x.
+func payloadSize(valueType, raw string) int {
+	if valueType != "OctetString" && valueType != "Opaque" {
+		return 0
+	}
+	if strings.HasPrefix(raw, "0x") {
+		return (len(raw) - 2) / 2
+	}
+	return len(raw)
+}
+
+// insertLargestPayload mantiene in ordine decrescente i walkSummaryTopPayloads valori più voluminosi.
+func insertLargestPayload(top []WalkPayload, result *snmp.Result, size int) []WalkPayload {
+	if size == 0 || (len(top) == walkSummaryTopPayloads && size <= top[len(top)-1].Size) {
+		return top
+	}
+
+	position := sort.Search(len(top), func(i int) bool { return top[i].Size < size })
+	entry := WalkPayload{OID: result.OID, ResolvedName: result.ResolvedName, Type: result.Type, Size: size}
+	if len(top) < walkSummaryTopPayloads {
+		top = append(top, WalkPayload{})
+	}
+	copy(top[position+1:], top[position:len(top)-1])
+	top[position] = entry
+	return top
+}
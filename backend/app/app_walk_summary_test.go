@@ -0,0 +1,112 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+)
+
+func setupWalkSummaryApp(t *testing.T) *App {
+	t.Helper()
+	return setupTestAppWithNodes(t,
+		&mib.Node{OID: "1.3.6.1.2.1.1", Name: "system", Type: "node"},
+		&mib.Node{OID: "1.3.6.1.2.1.1.1", Name: "sysDescr", Type: "scalar", ParentOID: "1.3.6.1.2.1.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.1.3", Name: "sysUpTime", Type: "scalar", ParentOID: "1.3.6.1.2.1.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2", Name: "ifTable", Type: "table", ParentOID: "1.3.6.1.2.1.2"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1", Name: "ifEntry", Type: "node", ParentOID: "1.3.6.1.2.1.2.2"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.2", Name: "ifDescr", Type: "column", ParentOID: "1.3.6.1.2.1.2.2.1"},
+		&mib.Node{OID: "1.3.6.1.2.1.2.2.1.10", Name: "ifInOctets", Type: "column", ParentOID: "1.3.6.1.2.1.2.2.1"},
+	)
+}
+
+func TestSummarizeWalkResults(t *testing.T) {
+	app := setupWalkSummaryApp(t)
+
+	summary := app.SummarizeWalkResults([]snmp.Result{
+		{OID: ".1.3.6.1.2.1.1.1.0", Type: "OctetString", Value: "0x4c696e7578"},
+		{OID: ".1.3.6.1.2.1.1.3.0", Type: "TimeTicks", Value: "12345"},
+		{OID: ".1.3.6.1.2.1.2.2.1.2.1", Type: "OctetString", Value: "0x6c6f"},
+		{OID: ".1.3.6.1.2.1.2.2.1.2.2", Type: "OctetString", Value: "0x65746830"},
+		{OID: ".1.3.6.1.2.1.2.2.1.10.1", Type: "Counter32", Value: "100"},
+		{OID: ".1.3.6.1.2.1.2.2.1.10.2", Type: "Counter32", Value: "300"},
+		{OID: ".1.3.6.1.4.1.9.9.1.0", Type: "Integer", Value: "7"},
+	})
+
+	if summary.Total != 7 {
+		t.Fatalf("expected 7 results, got %d", summary.Total)
+	}
+
+	types := map[string]WalkTypeStats{}
+	for _, stats := range summary.Types {
+		types[stats.Type] = stats
+	}
+	if types["OctetString"].Count != 3 || types["OctetString"].Numeric {
+		t.Fatalf("unexpected OctetString stats: %+v", types["OctetString"])
+	}
+	if counter := types["Counter32"]; counter.Count != 2 || counter.Min != 100 || counter.Max != 300 || counter.Avg != 200 {
+		t.Fatalf("unexpected Counter32 stats: %+v", counter)
+	}
+	if summary.Types[0].Type != "OctetString" {
+		t.Fatalf("expected types sorted by count, got %+v", summary.Types)
+	}
+
+	groups := map[string]int{}
+	for _, group := range summary.Groups {
+		groups[group.Name] = group.Count
+	}
+	if groups["ifTable"] != 4 || groups["system"] != 2 || groups[unresolvedWalkGroup] != 1 {
+		t.Fatalf("unexpected groups: %+v", summary.Groups)
+	}
+
+	if len(summary.LargestPayloads) == 0 || summary.LargestPayloads[0].OID != ".1.3.6.1.2.1.1.1.0" || summary.LargestPayloads[0].Size != 5 {
+		t.Fatalf("unexpected largest payloads: %+v", summary.LargestPayloads)
+	}
+}
+
+func TestSummarizeWalkResultsUnknownColumn(t *testing.T) {
+	app := setupWalkSummaryApp(t)
+
+	// ifSpeed non è nel database e risolve a ifEntry: ifInOctets deve comunque essere risolto alla sua tabella.
+	summary := app.SummarizeWalkResults([]snmp.Result{
+		{OID: ".1.3.6.1.2.1.2.2.1.5.1", Type: "Gauge32", Value: "1000"},
+		{OID: ".1.3.6.1.2.1.2.2.1.10.1", Type: "Counter32", Value: "100"},
+		{OID: ".1.3.6.1.2.1.2.2.1.10.2", Type: "Counter32", Value: "300"},
+	})
+
+	groups := map[string]int{}
+	for _, group := range summary.Groups {
+		groups[group.Name] = group.Count
+	}
+	if groups["ifEntry"] != 1 || groups["ifTable"] != 2 {
+		t.Fatalf("unexpected groups: %+v", summary.Groups)
+	}
+}
+
+func TestSummarizeWalkResultsLargeWalk(t *testing.T) {
+	app := setupWalkSummaryApp(t)
+
+	const rows = 50000
+	results := make([]snmp.Result, 0, 2*rows)
+	for i := 1; i <= rows; i++ {
+		results = append(results, snmp.Result{OID: fmt.Sprintf("1.3.6.1.2.1.2.2.1.2.%d", i), Type: "OctetString", Value: fmt.Sprintf("0x%04x", i)})
+	}
+	for i := 1; i <= rows; i++ {
+		results = append(results, snmp.Result{OID: fmt.Sprintf("1.3.6.1.2.1.2.2.1.10.%d", i), Type: "Counter32", Value: fmt.Sprint(i)})
+	}
+
+	start := time.Now()
+	summary := app.SummarizeWalkResults(results)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("summary of %d results took %v", len(results), elapsed)
+	}
+
+	if len(summary.Groups) != 1 || summary.Groups[0].Count != 2*rows {
+		t.Fatalf("unexpected groups: %+v", summary.Groups)
+	}
+	if len(summary.LargestPayloads) != walkSummaryTopPayloads {
+		t.Fatalf("expected %d largest payloads, got %d", walkSummaryTopPayloads, len(summary.LargestPayloads))
+	}
+}
@@ -0,0 +1,150 @@
+package app
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"mib-to-the-future/backend/mib"
+	"mib-to-the-future/backend/snmp"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// testAgent è un agent SNMPv2c minimale che risponde alle GET con i valori configurati
+// (noSuchInstance per gli OID sconosciuti) e alle GETNEXT con il valore successivo in ordine di OID
+// (endOfMibView oltre l'ultimo), applica le SET e registra gli OID richiesti. Con setDelay simula
+// un agent lento.
+type testAgent struct {
+	conn      net.PacketConn
+	values    map[string]gosnmp.SnmpPDU
+	mu        sync.Mutex
+	requested []string
+	delay     time.Duration
+}
+
+func startTestAgent(t *testing.T, values map[string]gosnmp.SnmpPDU) *testAgent {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start test agent: %v", err)
+	}
+	agent := &testAgent{conn: conn, values: values}
+	t.Cleanup(func() { conn.Close() })
+	go agent.serve()
+	return agent
+}
+
+// config restituisce una configurazione SNMP che punta all'agent.
+func (ta *testAgent) config() snmp.Config {
+	_, port, _ := net.SplitHostPort(ta.conn.LocalAddr().String())
+	portNumber, _ := strconv.Atoi(port)
+	return snmp.Config{Host: "127.0.0.1", Port: portNumber, Community: "public", Version: "v2c"}
+}
+
+// setDelay fa attendere all'agent delay prima di ogni risposta.
+func (ta *testAgent) setDelay(delay time.Duration) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.delay = delay
+}
+
+// value restituisce il valore corrente di oid, comprese le modifiche ricevute con SET.
+func (ta *testAgent) value(oid string) (gosnmp.SnmpPDU, bool) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	value, ok := ta.values[oid]
+	return value, ok
+}
+
+func (ta *testAgent) requestedOIDs() []string {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	return append([]string(nil), ta.requested...)
+}
+
+func (ta *testAgent) serve() {
+	decoder := &gosnmp.GoSNMP{Version: gosnmp.Version2c, Logger: gosnmp.NewLogger(nil)}
+	buffer := make([]byte, 65535)
+	for {
+		n, addr, err := ta.conn.ReadFrom(buffer)
+		if err != nil {
+			return
+		}
+		request, err := decoder.SnmpDecodePacket(buffer[:n])
+		if err != nil {
+			continue
+		}
+
+		response := *request
+		response.PDUType = gosnmp.GetResponse
+		response.Variables = make([]gosnmp.SnmpPDU, 0, len(request.Variables))
+		for _, variable := range request.Variables {
+			oid := strings.TrimPrefix(variable.Name, ".")
+			ta.mu.Lock()
+			ta.requested = append(ta.requested, oid)
+			ta.mu.Unlock()
+
+			if request.PDUType == gosnmp.GetNextRequest {
+				response.Variables = append(response.Variables, ta.next(oid, variable.Name))
+				continue
+			}
+			if request.PDUType == gosnmp.GetBulkRequest {
+				// Solo varbind ripetuti: max-repetitions successori di oid.
+				cursor := variable.Name
+				for i := uint32(0); i < request.MaxRepetitions; i++ {
+					next := ta.next(strings.TrimPrefix(cursor, "."), cursor)
+					response.Variables = append(response.Variables, next)
+					if next.Type == gosnmp.EndOfMibView {
+						break
+					}
+					cursor = next.Name
+				}
+				continue
+			}
+			if request.PDUType == gosnmp.SetRequest {
+				ta.mu.Lock()
+				ta.values[oid] = variable
+				ta.mu.Unlock()
+				response.Variables = append(response.Variables, variable)
+				continue
+			}
+			if value, ok := ta.values[oid]; ok {
+				value.Name = variable.Name
+				response.Variables = append(response.Variables, value)
+				continue
+			}
+			response.Variables = append(response.Variables, gosnmp.SnmpPDU{Name: variable.Name, Type: gosnmp.NoSuchInstance})
+		}
+
+		data, err := response.MarshalMsg()
+		if err != nil {
+			continue
+		}
+		ta.mu.Lock()
+		delay := ta.delay
+		ta.mu.Unlock()
+		time.Sleep(delay)
+		ta.conn.WriteTo(data, addr)
+	}
+}
+
+// next restituisce il varbind con l'OID immediatamente successivo a oid.
+func (ta *testAgent) next(oid, requestedName string) gosnmp.SnmpPDU {
+	best := ""
+	for candidate := range ta.values {
+		if mib.CompareOIDs(candidate, oid) > 0 && (best == "" || mib.CompareOIDs(candidate, best) < 0) {
+			best = candidate
+		}
+	}
+	if best == "" {
+		return gosnmp.SnmpPDU{Name: requestedName, Type: gosnmp.EndOfMibView}
+	}
+	value := ta.values[best]
+	value.Name = "." + best
+	return value
+}
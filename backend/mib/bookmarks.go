@@ -32,7 +32,7 @@ func (d *Database) AddBookmark(oid string, folderID *int64) error {
 	if d == nil || d.db == nil {
 		return fmt.Errorf("database not initialized")
 	}
-	trimmed := strings.TrimSpace(oid)
+	trimmed := d.normalizeBookmarkOID(oid)
 	if trimmed == "" {
 		return fmt.Errorf("oid is required")
 	}
@@ -62,6 +62,23 @@ func (d *Database) AddBookmark(oid string, folderID *int64) error {
 	return nil
 }
 
+// normalizeBookmarkOID rimuove i punti iniziali e l'istanza `.0` degli scalar, così che
+// `sysName` e `sysName.0` puntino allo stesso bookmark. Le istanze delle colonne restano invariate.
+func (d *Database) normalizeBookmarkOID(oid string) string {
+	trimmed := strings.TrimLeft(strings.TrimSpace(oid), ".")
+	if trimmed == "" || !strings.HasSuffix(trimmed, ".0") {
+		return trimmed
+	}
+
+	base := strings.TrimSuffix(trimmed, ".0")
+	var nodeType string
+	err := d.db.QueryRow(`SELECT type FROM mib_nodes WHERE oid = ? OR oid = ? LIMIT 1`, base, "."+base).Scan(&nodeType)
+	if err == nil && nodeType == "scalar" {
+		return base
+	}
+	return trimmed
+}
+
 // MoveBookmark sposta un bookmark esistente in una nuova cartella (o nella root).
 func (d *Database) MoveBookmark(oid string, folderID *int64) error {
 	return d.AddBookmark(oid, folderID)
@@ -72,7 +89,7 @@ func (d *Database) RemoveBookmark(oid string) error {
 	if d == nil || d.db == nil {
 		return fmt.Errorf("database not initialized")
 	}
-	trimmed := strings.TrimSpace(oid)
+	trimmed := d.normalizeBookmarkOID(oid)
 	if trimmed == "" {
 		return fmt.Errorf("oid is required")
 	}
@@ -246,6 +263,220 @@ func (d *Database) MoveBookmarkFolder(id int64, parentID *int64) error {
 	return nil
 }
 
+// DuplicateBookmarkFolder crea nella cartella di destinazione una nuova cartella con il nome indicato
+// a partire da una cartella esistente, lasciando intatta l'originale. Si copiano solo i bookmark diretti,
+// non le sotto-cartelle. Ogni OID può appartenere a una sola cartella (oid è la chiave primaria di
+// bookmarks), quindi un bookmark già presente fa fallire la copia e in quel caso non viene creato nulla.
+func (d *Database) DuplicateBookmarkFolder(id int64, newName string, parentID *int64) (*BookmarkFolder, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	if id <= 0 {
+		return nil, fmt.Errorf("folder id is required")
+	}
+
+	trimmed := strings.TrimSpace(newName)
+	if trimmed == "" {
+		return nil, fmt.Errorf("folder name is required")
+	}
+	if err := d.ensureFolderExists(id); err != nil {
+		return nil, err
+	}
+
+	var parent interface{}
+	if parentID != nil {
+		if err := d.ensureFolderExists(*parentID); err != nil {
+			return nil, err
+		}
+		parent = *parentID
+	}
+	if err := d.ensureFolderNameUnique(trimmed, parentID); err != nil {
+		return nil, err
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var bookmarked string
+	err = tx.QueryRow(`SELECT oid FROM bookmarks WHERE folder_id = ? ORDER BY oid LIMIT 1`, id).Scan(&bookmarked)
+	switch {
+	case err == nil:
+		return nil, fmt.Errorf("cannot copy bookmark %s: each OID can be bookmarked in only one folder", bookmarked)
+	case err != sql.ErrNoRows:
+		return nil, fmt.Errorf("failed to load folder bookmarks: %w", err)
+	}
+
+	result, err := tx.Exec(`INSERT INTO bookmark_folders (name, parent_folder_id) VALUES (?, ?)`, trimmed, parent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bookmark folder: %w", err)
+	}
+	folderID, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve new folder id: %w", err)
+	}
+
+	folder := &BookmarkFolder{ID: folderID, Name: trimmed, ParentID: parentID}
+	if err := tx.QueryRow(`SELECT created_at FROM bookmark_folders WHERE id = ?`, folderID).Scan(&folder.CreatedAt); err != nil {
+		return nil, fmt.Errorf("failed to fetch folder metadata: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit folder duplication: %w", err)
+	}
+	return folder, nil
+}
+
+// BookmarkMergeResult riassume l'esito di MergeBookmarkFolders.
+type BookmarkMergeResult struct {
+	BookmarksMoved int `json:"bookmarksMoved"`
+	FoldersMoved   int `json:"foldersMoved"`
+	// SkippedDuplicates conta le sotto-cartelle già presenti con lo stesso nome nella destinazione,
+	// che vengono unite a quelle esistenti invece di essere spostate.
+	SkippedDuplicates int `json:"skippedDuplicates"`
+}
+
+// MergeBookmarkFolders sposta bookmark e sotto-cartelle della cartella sorgente nella destinazione
+// (nil per la root) ed elimina la sorgente, tutto in una transazione. Le sotto-cartelle con un nome
+// già presente nella destinazione vengono unite ricorsivamente.
+func (d *Database) MergeBookmarkFolders(sourceID int64, destinationID *int64) (*BookmarkMergeResult, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	if sourceID <= 0 {
+		return nil, fmt.Errorf("folder id is required")
+	}
+	if err := d.ensureFolderExists(sourceID); err != nil {
+		return nil, err
+	}
+	if destinationID != nil {
+		if *destinationID == sourceID {
+			return nil, fmt.Errorf("cannot merge a folder into itself")
+		}
+		if err := d.ensureFolderExists(*destinationID); err != nil {
+			return nil, err
+		}
+		if err := d.ensureNotDescendant(sourceID, *destinationID); err != nil {
+			return nil, fmt.Errorf("cannot merge a folder into its own subtree")
+		}
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &BookmarkMergeResult{}
+	if err := mergeBookmarkFolderTx(tx, sourceID, destinationID, result); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit folder merge: %w", err)
+	}
+	return result, nil
+}
+
+func mergeBookmarkFolderTx(tx *sql.Tx, sourceID int64, destinationID *int64, result *BookmarkMergeResult) error {
+	var destination interface{}
+	if destinationID != nil {
+		destination = *destinationID
+	}
+
+	moved, err := tx.Exec(`UPDATE bookmarks SET folder_id = ? WHERE folder_id = ?`, destination, sourceID)
+	if err != nil {
+		return fmt.Errorf("failed to move bookmarks: %w", err)
+	}
+	affected, _ := moved.RowsAffected()
+	result.BookmarksMoved += int(affected)
+
+	type childFolder struct {
+		id   int64
+		name string
+	}
+	rows, err := tx.Query(`SELECT id, name FROM bookmark_folders WHERE parent_folder_id = ? ORDER BY id`, sourceID)
+	if err != nil {
+		return fmt.Errorf("failed to load sub-folders: %w", err)
+	}
+	var children []childFolder
+	for rows.Next() {
+		var child childFolder
+		if err := rows.Scan(&child.id, &child.name); err != nil {
+			rows.Close()
+			return err
+		}
+		children = append(children, child)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		var existingID int64
+		err := tx.QueryRow(`SELECT id FROM bookmark_folders WHERE name = ? AND parent_folder_id IS ? AND id != ?`,
+			child.name, destination, child.id).Scan(&existingID)
+		switch {
+		case err == nil:
+			result.SkippedDuplicates++
+			if err := mergeBookmarkFolderTx(tx, child.id, &existingID, result); err != nil {
+				return err
+			}
+		case err == sql.ErrNoRows:
+			if _, err := tx.Exec(`UPDATE bookmark_folders SET parent_folder_id = ? WHERE id = ?`, destination, child.id); err != nil {
+				return fmt.Errorf("failed to move bookmark folder: %w", err)
+			}
+			result.FoldersMoved++
+		default:
+			return fmt.Errorf("failed to check folder name uniqueness: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM bookmark_folders WHERE id = ?`, sourceID); err != nil {
+		return fmt.Errorf("failed to delete merged bookmark folder: %w", err)
+	}
+	return nil
+}
+
+// GetFolderPath restituisce i nomi delle cartelle dalla root fino alla cartella indicata (inclusa).
+func (d *Database) GetFolderPath(id int64) ([]string, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	if err := d.ensureFolderExists(id); err != nil {
+		return nil, err
+	}
+
+	// La profondità limita la risalita nel caso di catene corrotte (i cicli sono già impediti da MoveBookmarkFolder).
+	rows, err := d.db.Query(`
+		WITH RECURSIVE ancestors(id, name, parent_folder_id, depth) AS (
+			SELECT id, name, parent_folder_id, 0 FROM bookmark_folders WHERE id = ?
+			UNION ALL
+			SELECT bf.id, bf.name, bf.parent_folder_id, a.depth + 1 FROM bookmark_folders bf
+			INNER JOIN ancestors a ON bf.id = a.parent_folder_id
+			WHERE a.depth < 64
+		)
+		SELECT name FROM ancestors ORDER BY depth DESC
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve bookmark folder path: %w", err)
+	}
+	defer rows.Close()
+
+	path := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		path = append(path, name)
+	}
+	return path, rows.Err()
+}
+
 // GetBookmarkHierarchy ricostruisce l'albero delle cartelle e dei bookmark.
 func (d *Database) GetBookmarkHierarchy() (*BookmarkFolder, error) {
 	if d == nil || d.db == nil {
@@ -363,6 +594,117 @@ func (d *Database) GetBookmarkHierarchy() (*BookmarkFolder, error) {
 	return root, nil
 }
 
+// OrphanedBookmark è un bookmark il cui OID non corrisponde più ad alcun nodo MIB caricato.
+type OrphanedBookmark struct {
+	OID        string    `json:"oid"`
+	FolderID   *int64    `json:"folderId,omitempty"`
+	FolderName string    `json:"folderName"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// FindOrphanedBookmarks restituisce i bookmark senza un nodo in mib_nodes, tipicamente rimasti dopo
+// l'eliminazione di un modulo. Le istanze (es. ifDescr.3) non sono orfane finché esiste la colonna
+// o lo scalar di cui fanno parte.
+func (d *Database) FindOrphanedBookmarks() ([]OrphanedBookmark, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	rows, err := d.db.Query(`
+		SELECT b.oid, b.folder_id, COALESCE(f.name, ''), b.created_at
+		FROM bookmarks b
+		LEFT JOIN bookmark_folders f ON f.id = b.folder_id
+		WHERE NOT EXISTS (SELECT 1 FROM mib_nodes n WHERE n.oid = b.oid)
+		AND NOT EXISTS (SELECT 1 FROM mib_nodes n WHERE n.oid = '.' || b.oid)
+		ORDER BY b.oid
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query orphaned bookmarks: %w", err)
+	}
+
+	candidates := []OrphanedBookmark{}
+	for rows.Next() {
+		var (
+			bookmark OrphanedBookmark
+			folderID sql.NullInt64
+		)
+		if err := rows.Scan(&bookmark.OID, &folderID, &bookmark.FolderName, &bookmark.CreatedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan orphaned bookmark: %w", err)
+		}
+		if folderID.Valid {
+			id := folderID.Int64
+			bookmark.FolderID = &id
+		}
+		candidates = append(candidates, bookmark)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate orphaned bookmarks: %w", err)
+	}
+
+	orphaned := make([]OrphanedBookmark, 0, len(candidates))
+	for _, bookmark := range candidates {
+		isInstance, err := d.isObjectInstance(bookmark.OID)
+		if err != nil {
+			return nil, err
+		}
+		if !isInstance {
+			orphaned = append(orphaned, bookmark)
+		}
+	}
+	return orphaned, nil
+}
+
+// RemoveOrphanedBookmarks elimina i bookmark individuati da FindOrphanedBookmarks e ne restituisce il numero.
+func (d *Database) RemoveOrphanedBookmarks() (int, error) {
+	orphaned, err := d.FindOrphanedBookmarks()
+	if err != nil {
+		return 0, err
+	}
+	if len(orphaned) == 0 {
+		return 0, nil
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, bookmark := range orphaned {
+		if _, err := tx.Exec(`DELETE FROM bookmarks WHERE oid = ?`, bookmark.OID); err != nil {
+			return 0, fmt.Errorf("failed to remove orphaned bookmark %s: %w", bookmark.OID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit orphaned bookmark removal: %w", err)
+	}
+	return len(orphaned), nil
+}
+
+// isObjectInstance indica se l'OID è un'istanza di una colonna o di uno scalar presenti in mib_nodes.
+func (d *Database) isObjectInstance(oid string) (bool, error) {
+	arcs := strings.Split(oid, ".")
+	if len(arcs) < 2 {
+		return false, nil
+	}
+
+	prefixes := make([]interface{}, 0, 2*(len(arcs)-1))
+	for i := len(arcs) - 1; i > 0; i-- {
+		prefix := strings.Join(arcs[:i], ".")
+		prefixes = append(prefixes, prefix, "."+prefix)
+	}
+
+	query := `SELECT COUNT(1) FROM mib_nodes WHERE type IN ('column', 'scalar') AND oid IN (?` +
+		strings.Repeat(", ?", len(prefixes)-1) + `)`
+	var count int
+	if err := d.db.QueryRow(query, prefixes...).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to resolve bookmark %s: %w", oid, err)
+	}
+	return count > 0, nil
+}
+
 // ensureFolderExists verifica che una cartella esista.
 func (d *Database) ensureFolderExists(id int64) error {
 	var exists int
@@ -2,6 +2,9 @@ package mib
 
 import (
 	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -73,6 +76,35 @@ func TestMoveBookmarkFolderPreventsCycles(t *testing.T) {
 	}
 }
 
+func TestGetFolderPath(t *testing.T) {
+	db := newTestDB(t)
+
+	network, err := db.CreateBookmarkFolder("Network", nil)
+	if err != nil {
+		t.Fatalf("CreateBookmarkFolder network error: %v", err)
+	}
+	switches, err := db.CreateBookmarkFolder("Switches", &network.ID)
+	if err != nil {
+		t.Fatalf("CreateBookmarkFolder switches error: %v", err)
+	}
+	core, err := db.CreateBookmarkFolder("Core", &switches.ID)
+	if err != nil {
+		t.Fatalf("CreateBookmarkFolder core error: %v", err)
+	}
+
+	path, err := db.GetFolderPath(core.ID)
+	if err != nil {
+		t.Fatalf("GetFolderPath error: %v", err)
+	}
+	if !reflect.DeepEqual(path, []string{"Network", "Switches", "Core"}) {
+		t.Fatalf("unexpected path: %v", path)
+	}
+
+	if _, err := db.GetFolderPath(9999); err == nil {
+		t.Fatalf("expected error for unknown folder")
+	}
+}
+
 func TestAddBookmarkWithFolder(t *testing.T) {
 	db := newTestDB(t)
 
@@ -168,3 +200,291 @@ func TestGetBookmarkHierarchy(t *testing.T) {
 		t.Fatalf("expected bookmark 1.3.6.1 in child folder")
 	}
 }
+
+func TestAddBookmarkNormalizesScalarInstance(t *testing.T) {
+	db := newTestDB(t)
+
+	moduleID, err := db.SaveModule("TEST-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule error: %v", err)
+	}
+	nodes := []*Node{
+		{OID: "1.3.6.1.2.1.1.5", Name: "sysName", Type: "scalar", ParentOID: "1.3.6.1.2.1.1"},
+		{OID: "1.3.6.1.2.1.2.2.1.2", Name: "ifDescr", Type: "column", ParentOID: "1.3.6.1.2.1.2.2.1"},
+	}
+	for _, node := range nodes {
+		if err := db.SaveNode(node, moduleID); err != nil {
+			t.Fatalf("SaveNode error: %v", err)
+		}
+	}
+
+	for _, oid := range []string{"1.3.6.1.2.1.1.5.0", "1.3.6.1.2.1.1.5", ".1.3.6.1.2.1.1.5.0", "1.3.6.1.2.1.2.2.1.2.0"} {
+		if err := db.AddBookmark(oid, nil); err != nil {
+			t.Fatalf("AddBookmark(%s) error: %v", oid, err)
+		}
+	}
+
+	rows, err := db.db.Query(`SELECT oid FROM bookmarks ORDER BY oid`)
+	if err != nil {
+		t.Fatalf("query bookmarks: %v", err)
+	}
+	defer rows.Close()
+
+	var got []string
+	for rows.Next() {
+		var oid string
+		if err := rows.Scan(&oid); err != nil {
+			t.Fatalf("scan bookmark: %v", err)
+		}
+		got = append(got, oid)
+	}
+
+	want := []string{"1.3.6.1.2.1.1.5", "1.3.6.1.2.1.2.2.1.2.0"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("bookmarks = %v, want %v", got, want)
+	}
+}
+
+func TestDedupeBookmarks(t *testing.T) {
+	db := newTestDB(t)
+
+	moduleID, err := db.SaveModule("TEST-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule error: %v", err)
+	}
+	if err := db.SaveNode(&Node{OID: "1.3.6.1.2.1.1.5", Name: "sysName", Type: "scalar"}, moduleID); err != nil {
+		t.Fatalf("SaveNode error: %v", err)
+	}
+
+	for _, oid := range []string{"1.3.6.1.2.1.1.5", "1.3.6.1.2.1.1.5.0", ".1.3.6.1.2.1.1.3"} {
+		if _, err := db.db.Exec(`INSERT INTO bookmarks (oid) VALUES (?)`, oid); err != nil {
+			t.Fatalf("insert bookmark: %v", err)
+		}
+	}
+
+	if err := db.dedupeBookmarks(); err != nil {
+		t.Fatalf("dedupeBookmarks error: %v", err)
+	}
+
+	var count int
+	if err := db.db.QueryRow(`SELECT COUNT(1) FROM bookmarks WHERE oid IN ('1.3.6.1.2.1.1.5', '1.3.6.1.2.1.1.3')`).Scan(&count); err != nil {
+		t.Fatalf("count bookmarks: %v", err)
+	}
+	var total int
+	if err := db.db.QueryRow(`SELECT COUNT(1) FROM bookmarks`).Scan(&total); err != nil {
+		t.Fatalf("count bookmarks: %v", err)
+	}
+	if count != 2 || total != 2 {
+		t.Fatalf("expected 2 normalized bookmarks, got %d of %d", count, total)
+	}
+}
+
+func TestDedupeBookmarksKeepsNormalizedVariant(t *testing.T) {
+	db := newTestDB(t)
+
+	folder, err := db.CreateBookmarkFolder("Routers", nil)
+	if err != nil {
+		t.Fatalf("CreateBookmarkFolder error: %v", err)
+	}
+
+	// Varianti puntate di OID già salvati in forma normalizzata altrove, o solo puntate: resta la prima salvata.
+	for _, bookmark := range []struct {
+		oid    string
+		folder interface{}
+	}{
+		{".1.3.6.1.2.1.1.3", nil},
+		{"..1.3.6.1.2.1.1.3", nil},
+		{"1.3.6.1.2.1.1.3", folder.ID},
+		{".1.3.6.1.2.1.1.5", folder.ID},
+		{"..1.3.6.1.2.1.1.7", nil},
+		{".1.3.6.1.2.1.1.7", folder.ID},
+	} {
+		if _, err := db.db.Exec(`INSERT INTO bookmarks (oid, folder_id) VALUES (?, ?)`, bookmark.oid, bookmark.folder); err != nil {
+			t.Fatalf("insert bookmark: %v", err)
+		}
+	}
+
+	if err := db.dedupeBookmarks(); err != nil {
+		t.Fatalf("dedupeBookmarks error: %v", err)
+	}
+
+	rows, err := db.db.Query(`SELECT oid, COALESCE(folder_id, 0) FROM bookmarks ORDER BY COALESCE(folder_id, 0), oid`)
+	if err != nil {
+		t.Fatalf("query bookmarks: %v", err)
+	}
+	defer rows.Close()
+	var got []string
+	for rows.Next() {
+		var oid string
+		var folderID int64
+		if err := rows.Scan(&oid, &folderID); err != nil {
+			t.Fatalf("scan bookmark: %v", err)
+		}
+		got = append(got, fmt.Sprintf("%d:%s", folderID, oid))
+	}
+	want := []string{
+		"0:1.3.6.1.2.1.1.7",
+		fmt.Sprintf("%d:1.3.6.1.2.1.1.3", folder.ID),
+		fmt.Sprintf("%d:1.3.6.1.2.1.1.5", folder.ID),
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected bookmarks after dedupe: %v, want %v", got, want)
+	}
+}
+
+func TestDuplicateBookmarkFolder(t *testing.T) {
+	db := newTestDB(t)
+
+	template, err := db.CreateBookmarkFolder("Template", nil)
+	if err != nil {
+		t.Fatalf("CreateBookmarkFolder template error: %v", err)
+	}
+	destination, err := db.CreateBookmarkFolder("Devices", nil)
+	if err != nil {
+		t.Fatalf("CreateBookmarkFolder destination error: %v", err)
+	}
+	if _, err := db.CreateBookmarkFolder("Nested", &template.ID); err != nil {
+		t.Fatalf("CreateBookmarkFolder nested error: %v", err)
+	}
+
+	copied, err := db.DuplicateBookmarkFolder(template.ID, "Router A", &destination.ID)
+	if err != nil {
+		t.Fatalf("DuplicateBookmarkFolder error: %v", err)
+	}
+	if copied.Name != "Router A" || copied.ParentID == nil || *copied.ParentID != destination.ID {
+		t.Fatalf("unexpected duplicated folder: %+v", copied)
+	}
+	if _, err := db.GetFolderPath(template.ID); err != nil {
+		t.Fatalf("expected original folder to be intact: %v", err)
+	}
+
+	var children int
+	if err := db.db.QueryRow(`SELECT COUNT(1) FROM bookmark_folders WHERE parent_folder_id = ?`, copied.ID).Scan(&children); err != nil {
+		t.Fatalf("count children: %v", err)
+	}
+	if children != 0 {
+		t.Fatalf("expected sub-folders not to be copied, got %d", children)
+	}
+
+	if _, err := db.DuplicateBookmarkFolder(template.ID, "Router A", &destination.ID); err == nil {
+		t.Fatalf("expected duplicate name to be rejected")
+	}
+
+	if err := db.AddBookmark("1.3.6.1.2", &template.ID); err != nil {
+		t.Fatalf("AddBookmark error: %v", err)
+	}
+	if _, err := db.DuplicateBookmarkFolder(template.ID, "Router B", nil); err == nil || !strings.Contains(err.Error(), "1.3.6.1.2") {
+		t.Fatalf("expected the already bookmarked OID to be rejected, got %v", err)
+	}
+	if err := db.ensureFolderNameUnique("Router B", nil); err != nil {
+		t.Fatalf("expected no folder to be created on failure: %v", err)
+	}
+}
+
+func TestMergeBookmarkFolders(t *testing.T) {
+	db := newTestDB(t)
+
+	source, err := db.CreateBookmarkFolder("Old", nil)
+	if err != nil {
+		t.Fatalf("CreateBookmarkFolder source error: %v", err)
+	}
+	destination, err := db.CreateBookmarkFolder("New", nil)
+	if err != nil {
+		t.Fatalf("CreateBookmarkFolder destination error: %v", err)
+	}
+	sharedSource, err := db.CreateBookmarkFolder("Interfaces", &source.ID)
+	if err != nil {
+		t.Fatalf("CreateBookmarkFolder shared source error: %v", err)
+	}
+	sharedDestination, err := db.CreateBookmarkFolder("Interfaces", &destination.ID)
+	if err != nil {
+		t.Fatalf("CreateBookmarkFolder shared destination error: %v", err)
+	}
+	if _, err := db.CreateBookmarkFolder("System", &source.ID); err != nil {
+		t.Fatalf("CreateBookmarkFolder system error: %v", err)
+	}
+
+	if err := db.AddBookmark("1.3.6.1.2.1.1.5", &source.ID); err != nil {
+		t.Fatalf("AddBookmark error: %v", err)
+	}
+	if err := db.AddBookmark("1.3.6.1.2.1.2.2.1.2", &sharedSource.ID); err != nil {
+		t.Fatalf("AddBookmark error: %v", err)
+	}
+
+	if _, err := db.MergeBookmarkFolders(destination.ID, &sharedDestination.ID); err == nil {
+		t.Fatalf("expected merge into own subtree to fail")
+	}
+
+	result, err := db.MergeBookmarkFolders(source.ID, &destination.ID)
+	if err != nil {
+		t.Fatalf("MergeBookmarkFolders error: %v", err)
+	}
+	if result.BookmarksMoved != 2 || result.FoldersMoved != 1 || result.SkippedDuplicates != 1 {
+		t.Fatalf("unexpected merge result: %+v", result)
+	}
+
+	if err := db.ensureFolderExists(source.ID); err == nil {
+		t.Fatalf("expected source folder to be deleted")
+	}
+	var folderID int64
+	if err := db.db.QueryRow(`SELECT folder_id FROM bookmarks WHERE oid = ?`, "1.3.6.1.2.1.2.2.1.2").Scan(&folderID); err != nil {
+		t.Fatalf("load bookmark: %v", err)
+	}
+	if folderID != sharedDestination.ID {
+		t.Fatalf("expected bookmark in merged sub-folder %d, got %d", sharedDestination.ID, folderID)
+	}
+	if err := db.db.QueryRow(`SELECT folder_id FROM bookmarks WHERE oid = ?`, "1.3.6.1.2.1.1.5").Scan(&folderID); err != nil {
+		t.Fatalf("load bookmark: %v", err)
+	}
+	if folderID != destination.ID {
+		t.Fatalf("expected bookmark in destination %d, got %d", destination.ID, folderID)
+	}
+}
+
+func TestFindOrphanedBookmarks(t *testing.T) {
+	db := newTestDB(t)
+
+	moduleID, err := db.SaveModule("TEST-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule error: %v", err)
+	}
+	for _, node := range []*Node{
+		{OID: "1.3.6.1.2.1.1.5", Name: "sysName", Type: "scalar"},
+		{OID: "1.3.6.1.2.1.2.2.1.2", Name: "ifDescr", Type: "column"},
+	} {
+		if err := db.SaveNode(node, moduleID); err != nil {
+			t.Fatalf("SaveNode error: %v", err)
+		}
+	}
+
+	folder, err := db.CreateBookmarkFolder("Legacy", nil)
+	if err != nil {
+		t.Fatalf("CreateBookmarkFolder error: %v", err)
+	}
+	for _, oid := range []string{"1.3.6.1.2.1.1.5", "1.3.6.1.2.1.2.2.1.2.3"} {
+		if err := db.AddBookmark(oid, nil); err != nil {
+			t.Fatalf("AddBookmark error: %v", err)
+		}
+	}
+	if err := db.AddBookmark("1.3.6.1.4.1.9999.1.1", &folder.ID); err != nil {
+		t.Fatalf("AddBookmark error: %v", err)
+	}
+
+	orphaned, err := db.FindOrphanedBookmarks()
+	if err != nil {
+		t.Fatalf("FindOrphanedBookmarks error: %v", err)
+	}
+	if len(orphaned) != 1 || orphaned[0].OID != "1.3.6.1.4.1.9999.1.1" || orphaned[0].FolderName != "Legacy" ||
+		orphaned[0].FolderID == nil || *orphaned[0].FolderID != folder.ID {
+		t.Fatalf("unexpected orphaned bookmarks: %+v", orphaned)
+	}
+
+	removed, err := db.RemoveOrphanedBookmarks()
+	if err != nil || removed != 1 {
+		t.Fatalf("RemoveOrphanedBookmarks = %d, %v", removed, err)
+	}
+	bookmarks, err := db.GetBookmarks()
+	if err != nil || len(bookmarks) != 2 {
+		t.Fatalf("expected 2 remaining bookmarks, got %v (%v)", bookmarks, err)
+	}
+}
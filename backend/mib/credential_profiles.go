@@ -0,0 +1,220 @@
+package mib
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// CredentialProfile raccoglie versione e credenziali SNMP condivise da più host, così da poterle
+// ruotare in un solo punto.
+type CredentialProfile struct {
+	Name             string `json:"name"`
+	Version          string `json:"version"`
+	Community        string `json:"community"`
+	WriteCommunity   string `json:"writeCommunity"`
+	ContextName      string `json:"contextName,omitempty"`
+	SecurityLevel    string `json:"securityLevel,omitempty"`
+	SecurityUsername string `json:"securityUsername,omitempty"`
+	AuthProtocol     string `json:"authProtocol,omitempty"`
+	AuthPassword     string `json:"authPassword,omitempty"`
+	PrivProtocol     string `json:"privProtocol,omitempty"`
+	PrivPassword     string `json:"privPassword,omitempty"`
+	UpdatedAt        string `json:"updatedAt"`
+}
+
+// ensureCredentialProfilesSchema crea la tabella dei profili di credenziali.
+func (d *Database) ensureCredentialProfilesSchema() error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	if _, err := d.db.Exec(`CREATE TABLE IF NOT EXISTS credential_profiles (
+		name TEXT PRIMARY KEY,
+		version TEXT NOT NULL DEFAULT 'v2c',
+		community TEXT NOT NULL DEFAULT '',
+		write_community TEXT NOT NULL DEFAULT '',
+		context_name TEXT NOT NULL DEFAULT '',
+		security_level TEXT NOT NULL DEFAULT '',
+		security_username TEXT NOT NULL DEFAULT '',
+		auth_protocol TEXT NOT NULL DEFAULT '',
+		auth_password TEXT NOT NULL DEFAULT '',
+		priv_protocol TEXT NOT NULL DEFAULT '',
+		priv_password TEXT NOT NULL DEFAULT '',
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return fmt.Errorf("failed to ensure credential_profiles table: %w", err)
+	}
+	return nil
+}
+
+// SaveCredentialProfile crea o aggiorna un profilo, validando le credenziali come SaveHost.
+// Gli host che lo referenziano usano le nuove credenziali dalla lettura successiva.
+func (d *Database) SaveCredentialProfile(profile CredentialProfile) (*CredentialProfile, error) {
+	name := strings.TrimSpace(profile.Name)
+	if name == "" {
+		return nil, fmt.Errorf("profile name is required")
+	}
+
+	credentials, err := normalizeHostCredentials(HostConfig{
+		Version:          profile.Version,
+		Community:        profile.Community,
+		WriteCommunity:   profile.WriteCommunity,
+		ContextName:      profile.ContextName,
+		SecurityLevel:    profile.SecurityLevel,
+		SecurityUsername: profile.SecurityUsername,
+		AuthProtocol:     profile.AuthProtocol,
+		AuthPassword:     profile.AuthPassword,
+		PrivProtocol:     profile.PrivProtocol,
+		PrivPassword:     profile.PrivPassword,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := d.db.Exec(`
+		INSERT INTO credential_profiles (
+			name, version, community, write_community, context_name, security_level, security_username,
+			auth_protocol, auth_password, priv_protocol, priv_password, updated_at
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(name) DO UPDATE SET
+			version = excluded.version,
+			community = excluded.community,
+			write_community = excluded.write_community,
+			context_name = excluded.context_name,
+			security_level = excluded.security_level,
+			security_username = excluded.security_username,
+			auth_protocol = excluded.auth_protocol,
+			auth_password = excluded.auth_password,
+			priv_protocol = excluded.priv_protocol,
+			priv_password = excluded.priv_password,
+			updated_at = CURRENT_TIMESTAMP
+	`, name, credentials.Version, credentials.Community, credentials.WriteCommunity,
+		credentials.ContextName, credentials.SecurityLevel, credentials.SecurityUsername,
+		credentials.AuthProtocol, credentials.AuthPassword, credentials.PrivProtocol, credentials.PrivPassword); err != nil {
+		return nil, fmt.Errorf("failed to persist credential profile: %w", err)
+	}
+
+	return d.GetCredentialProfile(name)
+}
+
+// GetCredentialProfile restituisce il profilo con il nome indicato, o nil se non esiste.
+func (d *Database) GetCredentialProfile(name string) (*CredentialProfile, error) {
+	profile, err := scanCredentialProfile(d.db.QueryRow(`
+		SELECT name, version, community, write_community, context_name, security_level, security_username,
+		       auth_protocol, auth_password, priv_protocol, priv_password, updated_at
+		FROM credential_profiles
+		WHERE name = ?
+	`, strings.TrimSpace(name)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load credential profile: %w", err)
+	}
+	return profile, nil
+}
+
+// ListCredentialProfiles restituisce tutti i profili in ordine alfabetico.
+func (d *Database) ListCredentialProfiles() ([]CredentialProfile, error) {
+	rows, err := d.db.Query(`
+		SELECT name, version, community, write_community, context_name, security_level, security_username,
+		       auth_protocol, auth_password, priv_protocol, priv_password, updated_at
+		FROM credential_profiles
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list credential profiles: %w", err)
+	}
+	defer rows.Close()
+
+	profiles := []CredentialProfile{}
+	for rows.Next() {
+		profile, err := scanCredentialProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan credential profile: %w", err)
+		}
+		profiles = append(profiles, *profile)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed during credential profile iteration: %w", err)
+	}
+	return profiles, nil
+}
+
+// DeleteCredentialProfile rimuove un profilo. Un profilo ancora usato da qualche host non può essere rimosso.
+func (d *Database) DeleteCredentialProfile(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("profile name is required")
+	}
+
+	var users int
+	if err := d.db.QueryRow(`SELECT COUNT(*) FROM host_configs WHERE credential_profile = ?`, name).Scan(&users); err != nil {
+		return fmt.Errorf("failed to check credential profile usage: %w", err)
+	}
+	if users > 0 {
+		return fmt.Errorf("credential profile %s is used by %d host(s)", name, users)
+	}
+
+	// La condizione ripete il controllo nella stessa istruzione, così un host salvato nel frattempo
+	// non resta con un riferimento a un profilo eliminato.
+	result, err := d.db.Exec(`
+		DELETE FROM credential_profiles
+		WHERE name = ? AND NOT EXISTS (SELECT 1 FROM host_configs WHERE credential_profile = ?)
+	`, name, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete credential profile: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		if profile, err := d.GetCredentialProfile(name); err == nil && profile != nil {
+			return fmt.Errorf("credential profile %s is used by one or more hosts", name)
+		}
+	}
+	return nil
+}
+
+// applyCredentialProfile sostituisce versione e credenziali dell'host con quelle del profilo referenziato.
+// Un riferimento a un profilo inesistente è un errore: l'host resterebbe senza credenziali.
+func (d *Database) applyCredentialProfile(host *HostConfig) error {
+	if host == nil || strings.TrimSpace(host.CredentialProfile) == "" {
+		return nil
+	}
+
+	profile, err := d.GetCredentialProfile(host.CredentialProfile)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		return fmt.Errorf("host %s references missing credential profile %s", host.Address, host.CredentialProfile)
+	}
+
+	host.Version = profile.Version
+	host.Community = profile.Community
+	host.WriteCommunity = profile.WriteCommunity
+	host.ContextName = profile.ContextName
+	host.SecurityLevel = profile.SecurityLevel
+	host.SecurityUsername = profile.SecurityUsername
+	host.AuthProtocol = profile.AuthProtocol
+	host.AuthPassword = profile.AuthPassword
+	host.PrivProtocol = profile.PrivProtocol
+	host.PrivPassword = profile.PrivPassword
+	return nil
+}
+
+func scanCredentialProfile(row rowScanner) (*CredentialProfile, error) {
+	profile := &CredentialProfile{}
+	if err := row.Scan(
+		&profile.Name, &profile.Version, &profile.Community, &profile.WriteCommunity,
+		&profile.ContextName, &profile.SecurityLevel, &profile.SecurityUsername,
+		&profile.AuthProtocol, &profile.AuthPassword, &profile.PrivProtocol, &profile.PrivPassword,
+		&profile.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if parsed, err := parseTimestamp(profile.UpdatedAt); err == nil && parsed != "" {
+		profile.UpdatedAt = parsed
+	}
+	return profile, nil
+}
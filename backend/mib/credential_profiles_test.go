@@ -0,0 +1,123 @@
+package mib
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCredentialProfilesResolvedOnHosts(t *testing.T) {
+	db := setupTestDB(t)
+	if err := db.EnsureHostConfigSchema(); err != nil {
+		t.Fatalf("EnsureHostConfigSchema failed: %v", err)
+	}
+
+	profile := CredentialProfile{
+		Name:             "core-v3",
+		Version:          "v3",
+		SecurityLevel:    "authPriv",
+		SecurityUsername: "monitor",
+		AuthProtocol:     "sha",
+		AuthPassword:     "auth-secret",
+		PrivProtocol:     "aes",
+		PrivPassword:     "priv-secret",
+	}
+	saved, err := db.SaveCredentialProfile(profile)
+	if err != nil {
+		t.Fatalf("SaveCredentialProfile() error = %v", err)
+	}
+	if saved.AuthProtocol != "SHA" || saved.PrivProtocol != "AES" {
+		t.Fatalf("expected normalized protocols, got %+v", saved)
+	}
+
+	for _, address := range []string{"10.0.0.1", "10.0.0.2"} {
+		if _, err := db.SaveHost(HostConfig{Address: address, CredentialProfile: "core-v3", Community: "ignored"}); err != nil {
+			t.Fatalf("SaveHost(%s) error = %v", address, err)
+		}
+	}
+	if _, err := db.SaveHost(HostConfig{Address: "10.0.0.3", Community: "private", Version: "v2c"}); err != nil {
+		t.Fatalf("SaveHost() error = %v", err)
+	}
+
+	host, err := db.GetHost("10.0.0.1")
+	if err != nil {
+		t.Fatalf("GetHost() error = %v", err)
+	}
+	if host.Version != "v3" || host.SecurityUsername != "monitor" || host.AuthPassword != "auth-secret" || host.Community != "" {
+		t.Fatalf("expected credentials from profile, got %+v", host)
+	}
+
+	// Rotazione delle password: tutti gli host del profilo vedono le nuove credenziali.
+	profile.AuthPassword = "rotated"
+	if _, err := db.SaveCredentialProfile(profile); err != nil {
+		t.Fatalf("SaveCredentialProfile() update error = %v", err)
+	}
+	hosts, err := db.ListHosts(0)
+	if err != nil {
+		t.Fatalf("ListHosts() error = %v", err)
+	}
+	for _, host := range hosts {
+		switch host.Address {
+		case "10.0.0.1", "10.0.0.2":
+			if host.AuthPassword != "rotated" || host.CredentialProfile != "core-v3" {
+				t.Fatalf("expected rotated credentials for %s, got %+v", host.Address, host)
+			}
+		case "10.0.0.3":
+			if host.Community != "private" || host.CredentialProfile != "" {
+				t.Fatalf("unexpected standalone host: %+v", host)
+			}
+		}
+	}
+
+	if _, err := db.SaveHost(HostConfig{Address: "10.0.0.4", CredentialProfile: "missing"}); err == nil {
+		t.Fatalf("expected error for unknown credential profile")
+	}
+	if err := db.DeleteCredentialProfile("core-v3"); err == nil {
+		t.Fatalf("expected deleting a profile in use to fail")
+	}
+
+	for _, address := range []string{"10.0.0.1", "10.0.0.2"} {
+		if err := db.DeleteHost(address); err != nil {
+			t.Fatalf("DeleteHost(%s) error = %v", address, err)
+		}
+	}
+	if err := db.DeleteCredentialProfile("core-v3"); err != nil {
+		t.Fatalf("DeleteCredentialProfile() error = %v", err)
+	}
+	profiles, err := db.ListCredentialProfiles()
+	if err != nil || len(profiles) != 0 {
+		t.Fatalf("expected no profiles left, got %+v (err %v)", profiles, err)
+	}
+}
+
+func TestMissingCredentialProfileIsReported(t *testing.T) {
+	db := setupTestDB(t)
+	if err := db.EnsureHostConfigSchema(); err != nil {
+		t.Fatalf("EnsureHostConfigSchema failed: %v", err)
+	}
+
+	if _, err := db.SaveHost(HostConfig{Address: "10.0.0.1", Community: "public"}); err != nil {
+		t.Fatalf("SaveHost() error = %v", err)
+	}
+	// Riferimento rimasto da un profilo non più presente (es. database copiato a mano).
+	if _, err := db.db.Exec(`UPDATE host_configs SET credential_profile = 'gone' WHERE address = '10.0.0.1'`); err != nil {
+		t.Fatalf("update host: %v", err)
+	}
+
+	if _, err := db.GetHost("10.0.0.1"); err == nil || !strings.Contains(err.Error(), "gone") {
+		t.Fatalf("expected GetHost to name the missing profile, got %v", err)
+	}
+	if _, err := db.ListHosts(0); err == nil || !strings.Contains(err.Error(), "gone") {
+		t.Fatalf("expected ListHosts to name the missing profile, got %v", err)
+	}
+}
+
+func TestSaveCredentialProfileValidates(t *testing.T) {
+	db := setupTestDB(t)
+
+	if _, err := db.SaveCredentialProfile(CredentialProfile{Version: "v2c"}); err == nil {
+		t.Fatalf("expected error for missing name")
+	}
+	if _, err := db.SaveCredentialProfile(CredentialProfile{Name: "bad", Version: "v3", SecurityLevel: "authNoPriv", SecurityUsername: "u"}); err == nil {
+		t.Fatalf("expected error for missing auth protocol")
+	}
+}
@@ -1,6 +1,7 @@
 package mib
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -8,10 +9,29 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
-	_ "modernc.org/sqlite"
+	"modernc.org/sqlite"
 )
 
+// sqliteBusyTimeoutMs è l'attesa massima di una connessione quando il file è bloccato da un'altra,
+// ad esempio mentre il database viene riaperto e migrato con letture ancora in corso.
+const sqliteBusyTimeoutMs = 5000
+
+// oidCollation è la collation SQLite che ordina gli OID per componenti numeriche (1.2 prima di 1.10),
+// come CompareOIDs: `ORDER BY n.oid COLLATE OID`.
+const oidCollation = "OID"
+
+func init() {
+	sqlite.MustRegisterCollationUtf8(oidCollation, CompareOIDs)
+
+	// busy_timeout vale per la singola connessione: l'hook lo imposta su tutte quelle del pool.
+	sqlite.RegisterConnectionHook(func(conn sqlite.ExecQuerierContext, _ string) error {
+		_, err := conn.ExecContext(context.Background(), fmt.Sprintf("PRAGMA busy_timeout = %d", sqliteBusyTimeoutMs), nil)
+		return err
+	})
+}
+
 // Node rappresenta un nodo MIB
 type Node struct {
 	ID          int64   `json:"id"`
@@ -25,6 +45,24 @@ type Node struct {
 	Description string  `json:"description"`
 	Module      string  `json:"module"` // Nome modulo MIB (es. SNMPv2-MIB)
 	Children    []*Node `json:"children,omitempty"`
+
+	// RawDescription è la descrizione come scritta nel MIB, con spaziatura e paragrafi originali
+	// (tabelle, diagrammi ASCII). È letta solo da GetNode e resta vuota se coincide con Description.
+	RawDescription string `json:"rawDescription,omitempty"`
+
+	// NotificationObjects elenca gli OID della clausola OBJECTS per i nodi di tipo notification.
+	NotificationObjects []string `json:"notificationObjects,omitempty"`
+	// Index descrive la clausola INDEX (o quella della riga estesa con AUGMENTS) per i nodi di tipo row.
+	Index []IndexComponent `json:"index,omitempty"`
+	// ImplementedOn elenca gli host su cui il nodo ha risposto; valorizzato solo su richiesta.
+	ImplementedOn []string `json:"implementedOn,omitempty"`
+	// Pinned è la posizione (da 1) del nodo tra i preferiti fissati in cima all'albero; 0 se non fissato.
+	Pinned int `json:"pinned,omitempty"`
+	// ResolvedBaseType, TypeChain e TypeChainIncomplete descrivono la risalita delle textual convention
+	// della sintassi; valorizzati solo nei dettagli del nodo.
+	ResolvedBaseType    string   `json:"resolvedBaseType,omitempty"`
+	TypeChain           []string `json:"typeChain,omitempty"`
+	TypeChainIncomplete bool     `json:"typeChainIncomplete,omitempty"`
 }
 
 // ModuleStats rappresenta conteggi aggregati per un modulo MIB.
@@ -49,6 +87,7 @@ type ModuleSummary struct {
 	TypeCount      int      `json:"typeCount"`
 	SkippedNodes   int      `json:"skippedNodes"`
 	MissingImports []string `json:"missingImports"`
+	IdentityOID    string   `json:"identityOid,omitempty"` // OID della MODULE-IDENTITY, vuoto per i moduli SMIv1
 }
 
 func decodeMissingImports(raw string) []string {
@@ -148,7 +187,9 @@ func (d *Database) initSchema() error {
 		column_count INTEGER NOT NULL DEFAULT 0,
 		type_count INTEGER NOT NULL DEFAULT 0,
 		skipped_nodes INTEGER NOT NULL DEFAULT 0,
-		missing_imports TEXT NOT NULL DEFAULT ''
+		missing_imports TEXT NOT NULL DEFAULT '',
+		last_revision TEXT NOT NULL DEFAULT '',
+		identity_oid TEXT NOT NULL DEFAULT ''
 	);
 
 	CREATE TABLE IF NOT EXISTS mib_nodes (
@@ -167,6 +208,7 @@ func (d *Database) initSchema() error {
 
 	CREATE INDEX IF NOT EXISTS idx_oid ON mib_nodes(oid);
 	CREATE INDEX IF NOT EXISTS idx_name ON mib_nodes(name);
+	CREATE INDEX IF NOT EXISTS idx_name_nocase ON mib_nodes(name COLLATE NOCASE, oid, type, module_id);
 	CREATE INDEX IF NOT EXISTS idx_parent_oid ON mib_nodes(parent_oid);
 	CREATE INDEX IF NOT EXISTS idx_module_id ON mib_nodes(module_id);
 
@@ -210,10 +252,46 @@ func (d *Database) initSchema() error {
 		return err
 	}
 
+	if err := d.ensureWalkProfileSchema(); err != nil {
+		return err
+	}
+
+	if err := d.ensureNotificationObjectSchema(); err != nil {
+		return err
+	}
+
+	if err := d.ensureRowIndexSchema(); err != nil {
+		return err
+	}
+
+	if err := d.ensurePollGroupSchema(); err != nil {
+		return err
+	}
+
+	if err := d.ensureHostOIDHitsSchema(); err != nil {
+		return err
+	}
+
+	if err := d.ensureHostModuleFiltersSchema(); err != nil {
+		return err
+	}
+
+	if err := d.ensureCredentialProfilesSchema(); err != nil {
+		return err
+	}
+
+	if err := d.ensureTypesSchema(); err != nil {
+		return err
+	}
+
+	if err := d.ensureOIDWatchSchema(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-// ensureModuleExtendedSchema aggiunge le colonne di metadati ai moduli se mancanti.
+// ensureModuleExtendedSchema aggiunge le colonne di metadati ai moduli e ai nodi se mancanti.
 func (d *Database) ensureModuleExtendedSchema() error {
 	if d == nil || d.db == nil {
 		return fmt.Errorf("database not initialized")
@@ -251,6 +329,18 @@ func (d *Database) ensureModuleExtendedSchema() error {
 			query: `ALTER TABLE mib_modules ADD COLUMN missing_imports TEXT NOT NULL DEFAULT ''`,
 			err:   "failed to add missing_imports column to mib_modules",
 		},
+		{
+			query: `ALTER TABLE mib_modules ADD COLUMN last_revision TEXT NOT NULL DEFAULT ''`,
+			err:   "failed to add last_revision column to mib_modules",
+		},
+		{
+			query: `ALTER TABLE mib_modules ADD COLUMN identity_oid TEXT NOT NULL DEFAULT ''`,
+			err:   "failed to add identity_oid column to mib_modules",
+		},
+		{
+			query: `ALTER TABLE mib_nodes ADD COLUMN raw_description TEXT NOT NULL DEFAULT ''`,
+			err:   "failed to add raw_description column to mib_nodes",
+		},
 	}
 
 	for _, stmt := range alterStatements {
@@ -314,6 +404,52 @@ func (d *Database) ensureBookmarkSchema() error {
 		return fmt.Errorf("failed to ensure bookmarks folder index: %w", err)
 	}
 
+	return d.dedupeBookmarks()
+}
+
+// dedupeBookmarks normalizza i bookmark salvati prima dell'introduzione di normalizeBookmarkOID:
+// rimuove i punti iniziali e l'istanza `.0` degli scalar. I duplicati che ne derivano vengono eliminati
+// prima di normalizzare, così l'aggiornamento non viola la chiave primaria oid; tra le varianti di un OID
+// resta quella già normalizzata o, in mancanza, la prima salvata.
+func (d *Database) dedupeBookmarks() error {
+	statements := []struct {
+		query string
+		err   string
+	}{
+		{
+			query: `DELETE FROM bookmarks
+				WHERE oid LIKE '.%' AND EXISTS (
+					SELECT 1 FROM bookmarks other
+					WHERE ltrim(other.oid, '.') = ltrim(bookmarks.oid, '.')
+					AND (other.oid NOT LIKE '.%' OR other.rowid < bookmarks.rowid)
+				)`,
+			err: "failed to remove dotted duplicate bookmarks",
+		},
+		{
+			query: `UPDATE bookmarks SET oid = ltrim(oid, '.') WHERE oid LIKE '.%'`,
+			err:   "failed to normalize dotted bookmarks",
+		},
+		{
+			query: `DELETE FROM bookmarks
+				WHERE oid LIKE '%.0'
+				AND substr(oid, 1, length(oid) - 2) IN (SELECT ltrim(oid, '.') FROM mib_nodes WHERE type = 'scalar')
+				AND substr(oid, 1, length(oid) - 2) IN (SELECT oid FROM bookmarks)`,
+			err: "failed to remove scalar instance duplicate bookmarks",
+		},
+		{
+			query: `UPDATE bookmarks SET oid = substr(oid, 1, length(oid) - 2)
+				WHERE oid LIKE '%.0'
+				AND substr(oid, 1, length(oid) - 2) IN (SELECT ltrim(oid, '.') FROM mib_nodes WHERE type = 'scalar')`,
+			err: "failed to normalize scalar instance bookmarks",
+		},
+	}
+
+	for _, stmt := range statements {
+		if _, err := d.db.Exec(stmt.query); err != nil {
+			return fmt.Errorf("%s: %w", stmt.err, err)
+		}
+	}
+
 	return nil
 }
 
@@ -335,6 +471,12 @@ func (d *Database) EnsureHostConfigSchema() error {
 		{"auth_password", "TEXT NOT NULL DEFAULT ''"},
 		{"priv_protocol", "TEXT NOT NULL DEFAULT ''"},
 		{"priv_password", "TEXT NOT NULL DEFAULT ''"},
+		{"last_uptime_ticks", "INTEGER NOT NULL DEFAULT 0"},
+		{"last_uptime_at", "TEXT NOT NULL DEFAULT ''"},
+		{"source_port", "INTEGER NOT NULL DEFAULT 0"},
+		{"credential_profile", "TEXT NOT NULL DEFAULT ''"},
+		{"serialize_requests", "INTEGER NOT NULL DEFAULT 0"},
+		{"ip_version", "TEXT NOT NULL DEFAULT 'ipv4'"},
 	}
 
 	for _, col := range columns {
@@ -350,6 +492,11 @@ func (d *Database) EnsureHostConfigSchema() error {
 		return fmt.Errorf("failed to backfill write community column: %w", err)
 	}
 
+	// I nomi host non contengono ':', quindi gli host già salvati con i due punti sono IPv6.
+	if _, err := d.db.Exec("UPDATE host_configs SET ip_version = 'ipv6' WHERE instr(address, ':') > 0"); err != nil {
+		return fmt.Errorf("failed to backfill ip version column: %w", err)
+	}
+
 	return nil
 }
 
@@ -368,10 +515,15 @@ func (d *Database) Close() error {
 	return d.db.Close()
 }
 
+// Path restituisce il percorso del file SQLite.
+func (d *Database) Path() string {
+	return d.path
+}
+
 // SaveModule salva informazioni sul modulo MIB
 func (d *Database) SaveModule(name, filePath string) (int64, error) {
 	_, err := d.db.Exec(
-		"INSERT INTO mib_modules (name, file_path) VALUES (?, ?) ON CONFLICT(name) DO UPDATE SET file_path = excluded.file_path",
+		"INSERT INTO mib_modules (name, file_path) VALUES (?, ?) ON CONFLICT(name) DO UPDATE SET file_path = excluded.file_path, loaded_at = CURRENT_TIMESTAMP",
 		name, filePath,
 	)
 	if err != nil {
@@ -407,8 +559,8 @@ func (d *Database) SaveNode(node *Node, moduleID int64) error {
 	}
 
 	_, err := d.db.Exec(`
-		INSERT INTO mib_nodes (oid, name, parent_oid, type, syntax, access, status, description, module_id)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO mib_nodes (oid, name, parent_oid, type, syntax, access, status, description, raw_description, module_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(oid) DO UPDATE SET
 			name = excluded.name,
 			parent_oid = excluded.parent_oid,
@@ -417,8 +569,9 @@ func (d *Database) SaveNode(node *Node, moduleID int64) error {
 			access = excluded.access,
 			status = excluded.status,
 			description = excluded.description,
+			raw_description = excluded.raw_description,
 			module_id = excluded.module_id
-	`, node.OID, node.Name, parentOID, node.Type, node.Syntax, node.Access, node.Status, node.Description, moduleID)
+	`, node.OID, node.Name, parentOID, node.Type, node.Syntax, node.Access, node.Status, node.Description, node.RawDescription, moduleID)
 
 	return err
 }
@@ -432,8 +585,8 @@ func (d *Database) SaveNodes(nodes []*Node, moduleID int64) error {
 	defer tx.Rollback()
 
 	stmt, err := tx.Prepare(`
-		INSERT INTO mib_nodes (oid, name, parent_oid, type, syntax, access, status, description, module_id)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO mib_nodes (oid, name, parent_oid, type, syntax, access, status, description, raw_description, module_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(oid) DO UPDATE SET
 			name = CASE WHEN excluded.name <> '' THEN excluded.name ELSE name END,
 			parent_oid = CASE WHEN excluded.parent_oid <> '' THEN excluded.parent_oid ELSE parent_oid END,
@@ -442,6 +595,7 @@ func (d *Database) SaveNodes(nodes []*Node, moduleID int64) error {
 			access = CASE WHEN excluded.access <> '' THEN excluded.access ELSE access END,
 			status = CASE WHEN excluded.status <> '' THEN excluded.status ELSE status END,
 			description = CASE WHEN excluded.description <> '' THEN excluded.description ELSE description END,
+			raw_description = CASE WHEN excluded.description <> '' THEN excluded.raw_description ELSE raw_description END,
 			module_id = excluded.module_id
 	`)
 	if err != nil {
@@ -481,22 +635,31 @@ func (d *Database) SaveNodes(nodes []*Node, moduleID int64) error {
 
 		_, err = stmt.Exec(
 			node.OID, node.Name, parentOID, node.Type,
-			node.Syntax, node.Access, node.Status, node.Description, targetModuleID,
+			node.Syntax, node.Access, node.Status, node.Description, node.RawDescription, targetModuleID,
 		)
 		if err != nil {
 			return err
 		}
+
+		if node.Type == "notification" && len(node.NotificationObjects) > 0 {
+			if err := saveNotificationObjects(tx, node.OID, node.NotificationObjects); err != nil {
+				return err
+			}
+		}
+		if node.Type == "row" && len(node.Index) > 0 {
+			if err := saveRowIndex(tx, node.OID, node.Index); err != nil {
+				return err
+			}
+		}
 	}
 
 	return tx.Commit()
 }
 
-// GetNode recupera un nodo per OID
-func (d *Database) GetNode(oid string) (*Node, error) {
-	if oid == "" {
-		return nil, fmt.Errorf("oid is empty")
-	}
-
+// nodeOIDVariants genera le forme equivalenti di un OID (con/senza punto iniziale, senza istanza `.0`)
+// nell'ordine in cui GetNode le prova.
+func nodeOIDVariants(oid string) []string {
+	oid = strings.TrimSpace(oid)
 	variants := []string{}
 	seen := make(map[string]struct{})
 
@@ -541,6 +704,20 @@ func (d *Database) GetNode(oid string) (*Node, error) {
 		}
 	}
 
+	return variants
+}
+
+// GetNode recupera un nodo per OID
+func (d *Database) GetNode(oid string) (*Node, error) {
+	if oid == "" {
+		return nil, fmt.Errorf("oid is empty")
+	}
+	if err := ValidateOID(oid); err != nil {
+		return nil, err
+	}
+
+	variants := nodeOIDVariants(oid)
+
 	var lastErr error
 
 	for _, candidate := range variants {
@@ -548,13 +725,15 @@ func (d *Database) GetNode(oid string) (*Node, error) {
 		var parentOID, syntax, access, status, description, moduleName sql.NullString
 
 		err := d.db.QueryRow(`
-		SELECT n.id, n.oid, n.name, n.parent_oid, n.type, n.syntax, n.access, n.status, n.description, m.name
+		SELECT n.id, n.oid, n.name, n.parent_oid, n.type, n.syntax, n.access, n.status, n.description, m.name,
+		       COALESCE(n.raw_description, '')
 		FROM mib_nodes n
 		LEFT JOIN mib_modules m ON n.module_id = m.id
 		WHERE n.oid = ?
 	`, candidate).Scan(
 			&node.ID, &node.OID, &node.Name, &parentOID, &node.Type,
 			&syntax, &access, &status, &description, &moduleName,
+			&node.RawDescription,
 		)
 
 		if err != nil {
@@ -647,7 +826,8 @@ func (d *Database) GetNodeAncestors(oid string) ([]*Node, error) {
 	visited := make(map[string]struct{})
 	current := node
 
-	for current != nil {
+	// Oltre al controllo sui cicli, la risalita è limitata a maxAncestorDepth livelli.
+	for current != nil && len(ancestors) < maxAncestorDepth {
 		canonical := strings.TrimPrefix(current.OID, ".")
 		if canonical == "" {
 			canonical = current.OID
@@ -725,6 +905,117 @@ func (d *Database) GetChildren(parentOID string) ([]*Node, error) {
 	return nodes, rows.Err()
 }
 
+// childrenBulkChunk limita i parametri di ogni query di GetChildrenBulk.
+const childrenBulkChunk = 500
+
+// GetChildrenBulk restituisce i figli di più nodi padre, indicizzati per OID del padre.
+// Ogni padre richiesto compare nella mappa, con una slice vuota se non ha figli; i figli sono in ordine numerico.
+func (d *Database) GetChildrenBulk(parentOIDs []string) (map[string][]*Node, error) {
+	children := make(map[string][]*Node, len(parentOIDs))
+	parents := make([]interface{}, 0, len(parentOIDs))
+	for _, oid := range parentOIDs {
+		if _, seen := children[oid]; seen {
+			continue
+		}
+		children[oid] = []*Node{}
+		parents = append(parents, oid)
+	}
+
+	for start := 0; start < len(parents); start += childrenBulkChunk {
+		end := start + childrenBulkChunk
+		if end > len(parents) {
+			end = len(parents)
+		}
+		chunk := parents[start:end]
+
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(chunk)), ",")
+		rows, err := d.db.Query(`
+			SELECT `+nodeColumns+`
+			FROM mib_nodes n
+			LEFT JOIN mib_modules m ON n.module_id = m.id
+			WHERE n.parent_oid IN (`+placeholders+`)
+		`, chunk...)
+		if err != nil {
+			return nil, err
+		}
+		nodes, err := scanNodeRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			children[node.ParentOID] = append(children[node.ParentOID], node)
+		}
+	}
+
+	for _, nodes := range children {
+		sortTreeNodes(nodes)
+	}
+	return children, nil
+}
+
+// GetNodesByType restituisce tutti i nodi del tipo indicato (es. "table"), ordinati per OID.
+func (d *Database) GetNodesByType(nodeType string) ([]*Node, error) {
+	rows, err := d.db.Query(`
+		SELECT `+nodeColumns+`
+		FROM mib_nodes n
+		LEFT JOIN mib_modules m ON n.module_id = m.id
+		WHERE n.type = ?
+	`, strings.TrimSpace(nodeType))
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := scanNodeRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	sortTreeNodes(nodes)
+	return nodes, nil
+}
+
+// GetWritableTables restituisce le tabelle con almeno una colonna discendente in read-write.
+func (d *Database) GetWritableTables() ([]*Node, error) {
+	rows, err := d.db.Query(`
+		SELECT ` + nodeColumns + `
+		FROM mib_nodes n
+		LEFT JOIN mib_modules m ON n.module_id = m.id
+		WHERE n.type = 'table' AND EXISTS (
+			SELECT 1 FROM mib_nodes c
+			WHERE c.type = 'column' AND c.oid LIKE n.oid || '.%' AND c.access = 'read-write'
+		)
+	`)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := scanNodeRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	sortTreeNodes(nodes)
+	return nodes, nil
+}
+
+// GetModuleScalars restituisce gli scalar leggibili (read-only o read-write) del modulo indicato, ordinati per OID.
+func (d *Database) GetModuleScalars(moduleName string) ([]*Node, error) {
+	rows, err := d.db.Query(`
+		SELECT `+nodeColumns+`
+		FROM mib_nodes n
+		INNER JOIN mib_modules m ON n.module_id = m.id
+		WHERE m.name = ? AND n.type = 'scalar' AND n.access IN ('read-only', 'read-write')
+	`, strings.TrimSpace(moduleName))
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := scanNodeRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	sortTreeNodes(nodes)
+	return nodes, nil
+}
+
 // GetTree costruisce l'albero MIB completo
 func (d *Database) GetTree() ([]*Node, error) {
 	// Prendi tutti i nodi
@@ -871,14 +1162,20 @@ func (d *Database) getRootNodes() ([]*Node, error) {
 
 // SearchNodes cerca nodi per nome o OID
 func (d *Database) SearchNodes(query string) ([]*Node, error) {
+	// Il confronto sull'OID ha senso solo per prefissi numerici; per il resto si cerca solo sul nome.
+	oidPattern := ""
+	if IsOIDPrefix(query) {
+		oidPattern = "%" + strings.TrimSpace(query) + "%"
+	}
+
 	rows, err := d.db.Query(`
 		SELECT n.id, n.oid, n.name, n.parent_oid, n.type, n.syntax, n.access, n.status, n.description, m.name
 		FROM mib_nodes n
 		LEFT JOIN mib_modules m ON n.module_id = m.id
-		WHERE name LIKE ? OR oid LIKE ?
+		WHERE name LIKE ? OR (? != '' AND oid LIKE ?)
 		ORDER BY oid
 		LIMIT 100
-	`, "%"+query+"%", "%"+query+"%")
+	`, "%"+query+"%", oidPattern, oidPattern)
 	if err != nil {
 		return nil, err
 	}
@@ -922,10 +1219,46 @@ func (d *Database) SearchNodes(query string) ([]*Node, error) {
 	return nodes, rows.Err()
 }
 
+// SearchNodesBySyntax cerca i nodi la cui sintassi contiene il pattern indicato (es. "Counter64", "TruthValue").
+// Se moduleName non è vuoto la ricerca viene limitata ai nodi di quel modulo.
+func (d *Database) SearchNodesBySyntax(pattern string, moduleName string) ([]*Node, error) {
+	trimmed := strings.TrimSpace(pattern)
+	if trimmed == "" {
+		return nil, fmt.Errorf("syntax pattern is required")
+	}
+
+	query := `SELECT ` + nodeColumns + `
+		FROM mib_nodes n
+		LEFT JOIN mib_modules m ON n.module_id = m.id
+		WHERE n.syntax LIKE ? ESCAPE '\'
+	`
+	args := []interface{}{likeContainsPattern(trimmed)}
+
+	if module := strings.TrimSpace(moduleName); module != "" {
+		query += " AND m.name = ?"
+		args = append(args, module)
+	}
+
+	query += " ORDER BY n.oid COLLATE " + oidCollation + " LIMIT 1000"
+
+	rows, err := d.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanNodeRows(rows)
+}
+
+// likeContainsPattern costruisce il pattern LIKE che cerca text come sottostringa, con `%`, `_` e `\`
+// trattati come caratteri letterali. Va usato insieme a `ESCAPE '\'`.
+func likeContainsPattern(text string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(text)
+	return "%" + escaped + "%"
+}
+
 // ListModules elenca tutti i moduli MIB caricati con le relative statistiche.
 func (d *Database) ListModules() ([]ModuleSummary, error) {
 	rows, err := d.db.Query(`
-		SELECT name, file_path, node_count, scalar_count, table_count, column_count, type_count, skipped_nodes, missing_imports
+		SELECT name, file_path, node_count, scalar_count, table_count, column_count, type_count, skipped_nodes, missing_imports, identity_oid
 		FROM mib_modules
 		ORDER BY name
 	`)
@@ -948,6 +1281,50 @@ func (d *Database) ListModules() ([]ModuleSummary, error) {
 			&summary.TypeCount,
 			&summary.SkippedNodes,
 			&missingRaw,
+			&summary.IdentityOID,
+		); err != nil {
+			return nil, err
+		}
+		summary.MissingImports = decodeMissingImports(missingRaw)
+		modules = append(modules, summary)
+	}
+
+	return modules, rows.Err()
+}
+
+// GetRecentlyLoadedModules restituisce i moduli caricati (o ricaricati) nelle ultime `hours` ore,
+// dal più recente al meno recente.
+func (d *Database) GetRecentlyLoadedModules(hours int) ([]ModuleSummary, error) {
+	if hours <= 0 {
+		return nil, fmt.Errorf("hours must be greater than zero")
+	}
+
+	rows, err := d.db.Query(`
+		SELECT name, file_path, node_count, scalar_count, table_count, column_count, type_count, skipped_nodes, missing_imports, identity_oid
+		FROM mib_modules
+		WHERE datetime(loaded_at) >= datetime('now', ?)
+		ORDER BY datetime(loaded_at) DESC, name
+	`, fmt.Sprintf("-%d hours", hours))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	modules := []ModuleSummary{}
+	for rows.Next() {
+		var summary ModuleSummary
+		var missingRaw string
+		if err := rows.Scan(
+			&summary.Name,
+			&summary.FilePath,
+			&summary.NodeCount,
+			&summary.ScalarCount,
+			&summary.TableCount,
+			&summary.ColumnCount,
+			&summary.TypeCount,
+			&summary.SkippedNodes,
+			&missingRaw,
+			&summary.IdentityOID,
 		); err != nil {
 			return nil, err
 		}
@@ -958,6 +1335,24 @@ func (d *Database) ListModules() ([]ModuleSummary, error) {
 	return modules, rows.Err()
 }
 
+// GetModuleLoadDate restituisce l'istante di caricamento (loaded_at) di un modulo.
+func (d *Database) GetModuleLoadDate(name string) (time.Time, error) {
+	var raw string
+	err := d.db.QueryRow(`SELECT loaded_at FROM mib_modules WHERE name = ?`, strings.TrimSpace(name)).Scan(&raw)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, fmt.Errorf("module %s not found", name)
+		}
+		return time.Time{}, err
+	}
+
+	formatted, err := parseTimestamp(raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, formatted)
+}
+
 // UpdateModuleMetadata aggiorna le informazioni sulle dipendenze mancanti di un modulo.
 func (d *Database) UpdateModuleMetadata(name string, skippedNodes int, missingImports []string) error {
 	if _, err := d.db.Exec(
@@ -971,6 +1366,35 @@ func (d *Database) UpdateModuleMetadata(name string, skippedNodes int, missingIm
 	return nil
 }
 
+// UpdateModuleRevision salva la data (YYYY-MM-DD) della revisione più recente dichiarata dal modulo.
+func (d *Database) UpdateModuleRevision(name, revision string) error {
+	if _, err := d.db.Exec(`UPDATE mib_modules SET last_revision = ? WHERE name = ?`, revision, name); err != nil {
+		return fmt.Errorf("failed to update revision for module %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetModuleRevision restituisce la revisione più recente del modulo, o una stringa vuota se non è nota.
+func (d *Database) GetModuleRevision(name string) (string, error) {
+	var revision string
+	err := d.db.QueryRow(`SELECT last_revision FROM mib_modules WHERE name = ?`, strings.TrimSpace(name)).Scan(&revision)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("module %s not found", name)
+		}
+		return "", err
+	}
+	return revision, nil
+}
+
+// UpdateModuleIdentity salva l'OID della MODULE-IDENTITY del modulo, radice del suo albero.
+func (d *Database) UpdateModuleIdentity(name, identityOID string) error {
+	if _, err := d.db.Exec(`UPDATE mib_modules SET identity_oid = ? WHERE name = ?`, normalizeOID(identityOID), name); err != nil {
+		return fmt.Errorf("failed to update identity for module %s: %w", name, err)
+	}
+	return nil
+}
+
 // UpdateModuleStats salva le statistiche calcolate per un modulo.
 func (d *Database) UpdateModuleStats(name string, stats ModuleStats) error {
 	_, err := d.db.Exec(
@@ -994,10 +1418,40 @@ func (d *Database) UpdateModuleStats(name string, stats ModuleStats) error {
 	return nil
 }
 
+// RecomputeModuleStats ricalcola i conteggi dei nodi di un modulo direttamente da mib_nodes
+// e aggiorna la riga del modulo. type_count non è ricavabile dal database e resta invariato.
+func (d *Database) RecomputeModuleStats(moduleName string) error {
+	name := strings.TrimSpace(moduleName)
+	if name == "" {
+		return fmt.Errorf("module name is required")
+	}
+
+	result, err := d.db.Exec(`
+		UPDATE mib_modules SET
+			node_count = (SELECT COUNT(*) FROM mib_nodes n WHERE n.module_id = mib_modules.id),
+			scalar_count = (SELECT COUNT(*) FROM mib_nodes n WHERE n.module_id = mib_modules.id AND n.type = 'scalar'),
+			table_count = (SELECT COUNT(*) FROM mib_nodes n WHERE n.module_id = mib_modules.id AND n.type = 'table'),
+			column_count = (SELECT COUNT(*) FROM mib_nodes n WHERE n.module_id = mib_modules.id AND n.type = 'column')
+		WHERE name = ?
+	`, name)
+	if err != nil {
+		return fmt.Errorf("failed to recompute stats for module %s: %w", name, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to recompute stats for module %s: %w", name, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("module %s not found", name)
+	}
+	return nil
+}
+
 // GetModuleSummary recupera i metadati di un singolo modulo.
 func (d *Database) GetModuleSummary(name string) (*ModuleSummary, error) {
 	row := d.db.QueryRow(`
-		SELECT name, file_path, node_count, scalar_count, table_count, column_count, type_count, skipped_nodes, missing_imports
+		SELECT name, file_path, node_count, scalar_count, table_count, column_count, type_count, skipped_nodes, missing_imports, identity_oid
 		FROM mib_modules
 		WHERE name = ?
 	`, name)
@@ -1014,6 +1468,7 @@ func (d *Database) GetModuleSummary(name string) (*ModuleSummary, error) {
 		&summary.TypeCount,
 		&summary.SkippedNodes,
 		&missingRaw,
+		&summary.IdentityOID,
 	); err != nil {
 		return nil, err
 	}
@@ -1023,7 +1478,15 @@ func (d *Database) GetModuleSummary(name string) (*ModuleSummary, error) {
 }
 
 // GetModuleTree restituisce l'albero dei nodi appartenenti a un modulo specifico.
+// Se il modulo dichiara una MODULE-IDENTITY, la radice che la contiene è la prima dell'elenco
+// e le eventuali definizioni esterne a quel ramo la seguono in ordine di OID.
 func (d *Database) GetModuleTree(name string) ([]*Node, error) {
+	var identityOID string
+	err := d.db.QueryRow(`SELECT identity_oid FROM mib_modules WHERE name = ?`, name).Scan(&identityOID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
 	rows, err := d.db.Query(`
 		SELECT n.id, n.oid, n.name, n.parent_oid, n.type, n.syntax, n.access, n.status, n.description, m.name
 		FROM mib_nodes n
@@ -1101,12 +1564,42 @@ func (d *Database) GetModuleTree(name string) ([]*Node, error) {
 		}
 	}
 
-	return roots, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sortTreeNodes(roots)
+	anchor, stray := SplitModuleTree(roots, identityOID)
+	if anchor == nil {
+		return roots, nil
+	}
+	return append([]*Node{anchor}, stray...), nil
+}
+
+// SplitModuleTree separa le radici di un modulo in quella ancorata alla MODULE-IDENTITY (la radice
+// che coincide con identityOID o lo contiene) e le definizioni esterne a quel ramo. Senza
+// MODULE-IDENTITY, o se nessuna radice la contiene, anchor è nil e stray contiene tutte le radici.
+func SplitModuleTree(roots []*Node, identityOID string) (anchor *Node, stray []*Node) {
+	identityOID = normalizeOID(identityOID)
+	stray = make([]*Node, 0, len(roots))
+	for _, root := range roots {
+		oid := normalizeOID(root.OID)
+		if anchor == nil && identityOID != "" && oid != "" &&
+			(identityOID == oid || strings.HasPrefix(identityOID, oid+".")) {
+			anchor = root
+			continue
+		}
+		stray = append(stray, root)
+	}
+	return anchor, stray
 }
 
 // DeleteModule elimina un modulo e tutti i suoi nodi
 func (d *Database) DeleteModule(name string) error {
-	_, err := d.db.Exec("DELETE FROM mib_modules WHERE name = ?", name)
+	if _, err := d.db.Exec("DELETE FROM mib_modules WHERE name = ?", name); err != nil {
+		return err
+	}
+	_, err := d.db.Exec("DELETE FROM mib_types WHERE module = ?", name)
 	return err
 }
 
@@ -1164,6 +1657,71 @@ func (d *Database) GetStats() (map[string]int, error) {
 	return stats, nil
 }
 
+// GetModuleNodeTypeSummary restituisce la distribuzione dei nodi per tipo all'interno di un modulo.
+// I nodi senza tipo vengono conteggiati come "unknown".
+func (d *Database) GetModuleNodeTypeSummary(moduleName string) (map[string]int, error) {
+	name := strings.TrimSpace(moduleName)
+	if name == "" {
+		return nil, fmt.Errorf("module name is required")
+	}
+
+	exists, err := d.ModuleExists(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("module %s not found", name)
+	}
+
+	return d.queryNodeTypeSummary(`
+		SELECT COALESCE(NULLIF(n.type, ''), 'unknown'), COUNT(*)
+		FROM mib_nodes n
+		JOIN mib_modules m ON n.module_id = m.id
+		WHERE m.name = ?
+		GROUP BY 1
+	`, name)
+}
+
+// GetNodeTypeSummary restituisce la distribuzione dei nodi per tipo su tutti i moduli caricati.
+func (d *Database) GetNodeTypeSummary() (map[string]int, error) {
+	return d.queryNodeTypeSummary(`
+		SELECT COALESCE(NULLIF(type, ''), 'unknown'), COUNT(*)
+		FROM mib_nodes
+		GROUP BY 1
+	`)
+}
+
+// GetModuleNodeCounts restituisce il numero di nodi di ciascun modulo caricato con un'unica query raggruppata.
+// I moduli senza nodi compaiono con conteggio zero.
+func (d *Database) GetModuleNodeCounts() (map[string]int, error) {
+	return d.queryNodeTypeSummary(`
+		SELECT m.name, COUNT(n.id)
+		FROM mib_modules m
+		LEFT JOIN mib_nodes n ON n.module_id = m.id
+		GROUP BY m.id
+	`)
+}
+
+func (d *Database) queryNodeTypeSummary(query string, args ...interface{}) (map[string]int, error) {
+	rows, err := d.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summary := make(map[string]int)
+	for rows.Next() {
+		var nodeType string
+		var count int
+		if err := rows.Scan(&nodeType, &count); err != nil {
+			return nil, err
+		}
+		summary[nodeType] += count
+	}
+
+	return summary, rows.Err()
+}
+
 // GetBookmarks recupera tutti gli OID dei bookmark
 func (d *Database) GetBookmarks() ([]string, error) {
 	rows, err := d.db.Query("SELECT oid FROM bookmarks ORDER BY created_at DESC")
@@ -0,0 +1,65 @@
+package mib
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DatabaseInfo riassume lo stato dell'archivio SQLite per la scheda diagnostica delle impostazioni.
+// SizeBytes comprende il file WAL, se presente. WalkSessionCount conta i profili di WALK salvati,
+// l'unica forma persistita dei walk. SchemaVersion è il PRAGMA user_version del database.
+type DatabaseInfo struct {
+	Path             string `json:"path"`
+	SizeBytes        int64  `json:"sizeBytes"`
+	ModuleCount      int    `json:"moduleCount"`
+	NodeCount        int    `json:"nodeCount"`
+	BookmarkCount    int    `json:"bookmarkCount"`
+	HostCount        int    `json:"hostCount"`
+	WalkSessionCount int    `json:"walkSessionCount"`
+	SchemaVersion    int    `json:"schemaVersion"`
+	IsWALMode        bool   `json:"isWalMode"`
+}
+
+// GetDatabaseInfo restituisce percorso, dimensione su disco e contenuto del database.
+// I conteggi sono letti con un'unica query di subselect invece di una query per tabella.
+func (d *Database) GetDatabaseInfo() (DatabaseInfo, error) {
+	info := DatabaseInfo{}
+	if d == nil || d.db == nil {
+		return info, fmt.Errorf("database not initialized")
+	}
+	info.Path = d.path
+
+	if err := d.db.QueryRow(`SELECT
+		(SELECT COUNT(*) FROM mib_modules),
+		(SELECT COUNT(*) FROM mib_nodes),
+		(SELECT COUNT(*) FROM bookmarks),
+		(SELECT COUNT(*) FROM host_configs),
+		(SELECT COUNT(*) FROM walk_profiles)`,
+	).Scan(&info.ModuleCount, &info.NodeCount, &info.BookmarkCount, &info.HostCount, &info.WalkSessionCount); err != nil {
+		return info, fmt.Errorf("failed to count database records: %w", err)
+	}
+
+	if err := d.db.QueryRow(`PRAGMA user_version`).Scan(&info.SchemaVersion); err != nil {
+		return info, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	var journalMode string
+	if err := d.db.QueryRow(`PRAGMA journal_mode`).Scan(&journalMode); err != nil {
+		return info, fmt.Errorf("failed to read journal mode: %w", err)
+	}
+	info.IsWALMode = strings.EqualFold(journalMode, "wal")
+
+	for _, path := range []string{d.path, d.path + "-wal"} {
+		stat, err := os.Stat(path)
+		if err != nil {
+			if os.IsNotExist(err) && path != d.path {
+				continue
+			}
+			return info, fmt.Errorf("failed to stat %q: %w", path, err)
+		}
+		info.SizeBytes += stat.Size()
+	}
+
+	return info, nil
+}
@@ -2,7 +2,9 @@ package mib
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 // findNodeByOID cerca ricorsivamente un nodo in base al suo OID.
@@ -181,6 +183,44 @@ func TestGetTreeOrdersChildrenNumerically(t *testing.T) {
 	}
 }
 
+func TestGetChildrenBulk(t *testing.T) {
+	db := newTestDB(t)
+	moduleID, _ := db.SaveModule("TEST-MIB", "")
+
+	nodes := []*Node{
+		{OID: "1.3.6.1.2.1.1", Name: "system", ParentOID: "1.3.6.1.2.1"},
+		{OID: "1.3.6.1.2.1.1.10", Name: "sysTen", ParentOID: "1.3.6.1.2.1.1"},
+		{OID: "1.3.6.1.2.1.1.2", Name: "sysObjectID", ParentOID: "1.3.6.1.2.1.1"},
+		{OID: "1.3.6.1.2.1.2", Name: "interfaces", ParentOID: "1.3.6.1.2.1"},
+		{OID: "1.3.6.1.2.1.2.1", Name: "ifNumber", ParentOID: "1.3.6.1.2.1.2"},
+	}
+	if err := db.SaveNodes(nodes, moduleID); err != nil {
+		t.Fatalf("SaveNodes() error = %v", err)
+	}
+
+	children, err := db.GetChildrenBulk([]string{"1.3.6.1.2.1.1", "1.3.6.1.2.1.2", "1.3.6.1.2.1.1", "1.3.6.1.2.1.2.1"})
+	if err != nil {
+		t.Fatalf("GetChildrenBulk() error = %v", err)
+	}
+	if len(children) != 3 {
+		t.Fatalf("GetChildrenBulk() returned %d parents, want 3", len(children))
+	}
+
+	var names []string
+	for _, node := range children["1.3.6.1.2.1.1"] {
+		names = append(names, node.Name)
+	}
+	if want := []string{"sysObjectID", "sysTen"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("children of system = %v, want %v", names, want)
+	}
+	if got := children["1.3.6.1.2.1.2"]; len(got) != 1 || got[0].Name != "ifNumber" {
+		t.Fatalf("children of interfaces = %v", got)
+	}
+	if got, ok := children["1.3.6.1.2.1.2.1"]; !ok || len(got) != 0 {
+		t.Fatalf("leaf parent should map to an empty slice, got %v (present=%v)", got, ok)
+	}
+}
+
 func TestGetNodeVariantsAndAncestors(t *testing.T) {
 	db := newTestDB(t)
 	moduleID, _ := db.SaveModule("TEST-MIB", "")
@@ -371,3 +411,499 @@ func TestModuleSummaryAndTree(t *testing.T) {
 		t.Error("module filtering failed, found nodes from other modules")
 	}
 }
+
+func TestGetModuleTreeAnchorsAtModuleIdentity(t *testing.T) {
+	db := newTestDB(t)
+
+	modID, _ := db.SaveModule("TEST-MIB", "")
+	nodes := []*Node{
+		{OID: "1.3.6.1.2.1.99", Name: "testMIB", Type: "node"},
+		{OID: "1.3.6.1.2.1.99.1", Name: "testObjects", ParentOID: "1.3.6.1.2.1.99", Type: "node"},
+		{OID: "1.3.6.1.2.1.10.99", Name: "testTransmission", Type: "node"},
+	}
+	if err := db.SaveNodes(nodes, modID); err != nil {
+		t.Fatalf("SaveNodes() error = %v", err)
+	}
+
+	// Senza MODULE-IDENTITY (SMIv1) l'ordine resta quello per OID.
+	tree, err := db.GetModuleTree("TEST-MIB")
+	if err != nil {
+		t.Fatalf("GetModuleTree() error = %v", err)
+	}
+	if len(tree) != 2 || tree[0].Name != "testTransmission" {
+		t.Fatalf("unexpected SMIv1 roots: %+v", tree)
+	}
+
+	if err := db.UpdateModuleIdentity("TEST-MIB", ".1.3.6.1.2.1.99"); err != nil {
+		t.Fatalf("UpdateModuleIdentity() error = %v", err)
+	}
+	summary, err := db.GetModuleSummary("TEST-MIB")
+	if err != nil {
+		t.Fatalf("GetModuleSummary() error = %v", err)
+	}
+	if summary.IdentityOID != "1.3.6.1.2.1.99" {
+		t.Errorf("summary.IdentityOID = %q, want 1.3.6.1.2.1.99", summary.IdentityOID)
+	}
+
+	tree, err = db.GetModuleTree("TEST-MIB")
+	if err != nil {
+		t.Fatalf("GetModuleTree() error = %v", err)
+	}
+	if len(tree) != 2 || tree[0].Name != "testMIB" || tree[1].Name != "testTransmission" {
+		t.Fatalf("expected identity root first, got %+v", tree)
+	}
+
+	anchor, stray := SplitModuleTree(tree, summary.IdentityOID)
+	if anchor == nil || anchor.Name != "testMIB" {
+		t.Fatalf("anchor = %+v, want testMIB", anchor)
+	}
+	if len(stray) != 1 || stray[0].Name != "testTransmission" {
+		t.Fatalf("stray = %+v, want [testTransmission]", stray)
+	}
+}
+
+func TestRecomputeModuleStats(t *testing.T) {
+	db := newTestDB(t)
+
+	modID, _ := db.SaveModule("TEST-MIB", "")
+	nodes := []*Node{
+		{OID: "1.3.6.1.2.1.2", Name: "interfaces", Type: "node"},
+		{OID: "1.3.6.1.2.1.2.1", Name: "ifNumber", ParentOID: "1.3.6.1.2.1.2", Type: "scalar"},
+		{OID: "1.3.6.1.2.1.2.2", Name: "ifTable", ParentOID: "1.3.6.1.2.1.2", Type: "table"},
+		{OID: "1.3.6.1.2.1.2.2.1", Name: "ifEntry", ParentOID: "1.3.6.1.2.1.2.2", Type: "row"},
+		{OID: "1.3.6.1.2.1.2.2.1.1", Name: "ifIndex", ParentOID: "1.3.6.1.2.1.2.2.1", Type: "column"},
+		{OID: "1.3.6.1.2.1.2.2.1.2", Name: "ifDescr", ParentOID: "1.3.6.1.2.1.2.2.1", Type: "column"},
+	}
+	if err := db.SaveNodes(nodes, modID); err != nil {
+		t.Fatalf("SaveNodes() error = %v", err)
+	}
+
+	// Statistiche volutamente errate, come dopo un import parziale.
+	if err := db.UpdateModuleStats("TEST-MIB", ModuleStats{NodeCount: 99, ScalarCount: 7, TypeCount: 4}); err != nil {
+		t.Fatalf("UpdateModuleStats() error = %v", err)
+	}
+
+	if err := db.RecomputeModuleStats("TEST-MIB"); err != nil {
+		t.Fatalf("RecomputeModuleStats() error = %v", err)
+	}
+
+	summary, err := db.GetModuleSummary("TEST-MIB")
+	if err != nil {
+		t.Fatalf("GetModuleSummary() error = %v", err)
+	}
+	if summary.NodeCount != 6 || summary.ScalarCount != 1 || summary.TableCount != 1 || summary.ColumnCount != 2 {
+		t.Errorf("unexpected recomputed stats: %+v", summary)
+	}
+	if summary.TypeCount != 4 {
+		t.Errorf("summary.TypeCount = %d, want it preserved as 4", summary.TypeCount)
+	}
+
+	if err := db.RecomputeModuleStats("MISSING-MIB"); err == nil {
+		t.Error("expected error for unknown module")
+	}
+}
+
+func TestSearchNodesBySyntax(t *testing.T) {
+	db := newTestDB(t)
+
+	ifMibID, err := db.SaveModule("IF-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule IF-MIB failed: %v", err)
+	}
+	snmpMibID, err := db.SaveModule("SNMPv2-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule SNMPv2-MIB failed: %v", err)
+	}
+
+	nodes := []struct {
+		node     *Node
+		moduleID int64
+	}{
+		{&Node{OID: "1.3.6.1.2.1.31.1.1.1.6", Name: "ifHCInOctets", Type: "column", Syntax: "Counter64"}, ifMibID},
+		{&Node{OID: "1.3.6.1.2.1.31.1.1.1.14", Name: "ifLinkUpDownTrapEnable", Type: "column", Syntax: "INTEGER {enabled(1), disabled(2)}"}, ifMibID},
+		{&Node{OID: "1.3.6.1.2.1.11.30", Name: "snmpEnableAuthenTraps", Type: "scalar", Syntax: "INTEGER {enabled(1), disabled(2)}"}, snmpMibID},
+		{&Node{OID: "1.3.6.1.2.1.2.2.1.7", Name: "ifAdminStatus", Type: "column", Syntax: "INTEGER {up(1), down(2)}"}, ifMibID},
+	}
+	for _, entry := range nodes {
+		if err := db.SaveNode(entry.node, entry.moduleID); err != nil {
+			t.Fatalf("SaveNode %s failed: %v", entry.node.Name, err)
+		}
+	}
+
+	results, err := db.SearchNodesBySyntax("counter64", "")
+	if err != nil {
+		t.Fatalf("SearchNodesBySyntax failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "ifHCInOctets" {
+		t.Fatalf("expected only ifHCInOctets, got %v", results)
+	}
+
+	results, err = db.SearchNodesBySyntax("enabled(1)", "SNMPv2-MIB")
+	if err != nil {
+		t.Fatalf("SearchNodesBySyntax with module failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "snmpEnableAuthenTraps" {
+		t.Fatalf("expected only snmpEnableAuthenTraps, got %v", results)
+	}
+
+	// Ordine per componenti numeriche: 2.2.1.7 precede 11.30, che precede 31.1.1.1.14.
+	results, err = db.SearchNodesBySyntax("INTEGER", "")
+	if err != nil {
+		t.Fatalf("SearchNodesBySyntax failed: %v", err)
+	}
+	var names []string
+	for _, node := range results {
+		names = append(names, node.Name)
+	}
+	if strings.Join(names, ",") != "ifAdminStatus,snmpEnableAuthenTraps,ifLinkUpDownTrapEnable" {
+		t.Fatalf("expected results in OID order, got %v", names)
+	}
+
+	// I caratteri jolly di LIKE sono cercati letteralmente.
+	for _, pattern := range []string{"%", "_"} {
+		results, err := db.SearchNodesBySyntax(pattern, "")
+		if err != nil || len(results) != 0 {
+			t.Fatalf("expected no match for %q, got %v (err %v)", pattern, results, err)
+		}
+	}
+
+	if _, err := db.SearchNodesBySyntax("  ", ""); err == nil {
+		t.Fatalf("expected empty pattern to fail")
+	}
+}
+
+func TestGetModuleScalars(t *testing.T) {
+	db := newTestDB(t)
+
+	snmpMibID, err := db.SaveModule("SNMPv2-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule SNMPv2-MIB failed: %v", err)
+	}
+	ifMibID, err := db.SaveModule("IF-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule IF-MIB failed: %v", err)
+	}
+
+	nodes := []struct {
+		node     *Node
+		moduleID int64
+	}{
+		{&Node{OID: "1.3.6.1.2.1.1.10", Name: "sysFake", Type: "scalar", Access: "read-only"}, snmpMibID},
+		{&Node{OID: "1.3.6.1.2.1.1.5", Name: "sysName", Type: "scalar", Access: "read-write"}, snmpMibID},
+		{&Node{OID: "1.3.6.1.2.1.1.1", Name: "sysDescr", Type: "scalar", Access: "read-only"}, snmpMibID},
+		{&Node{OID: "1.3.6.1.2.1.1.9", Name: "sysORTable", Type: "table", Access: "not-accessible"}, snmpMibID},
+		{&Node{OID: "1.3.6.1.6.3.1.1.4.1", Name: "snmpTrapOID", Type: "scalar", Access: "accessible-for-notify"}, snmpMibID},
+		{&Node{OID: "1.3.6.1.2.1.2.1", Name: "ifNumber", Type: "scalar", Access: "read-only"}, ifMibID},
+	}
+	for _, entry := range nodes {
+		if err := db.SaveNode(entry.node, entry.moduleID); err != nil {
+			t.Fatalf("SaveNode %s failed: %v", entry.node.Name, err)
+		}
+	}
+
+	scalars, err := db.GetModuleScalars("SNMPv2-MIB")
+	if err != nil {
+		t.Fatalf("GetModuleScalars failed: %v", err)
+	}
+	var names []string
+	for _, node := range scalars {
+		names = append(names, node.Name)
+	}
+	if want := []string{"sysDescr", "sysName", "sysFake"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("GetModuleScalars = %v, want %v", names, want)
+	}
+}
+
+func TestNodeTypeSummary(t *testing.T) {
+	db := newTestDB(t)
+
+	ifMibID, err := db.SaveModule("IF-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule IF-MIB failed: %v", err)
+	}
+	otherID, err := db.SaveModule("OTHER-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule OTHER-MIB failed: %v", err)
+	}
+
+	nodes := []struct {
+		node     *Node
+		moduleID int64
+	}{
+		{&Node{OID: "1.3.6.1.2.1.2.2", Name: "ifTable", Type: "table"}, ifMibID},
+		{&Node{OID: "1.3.6.1.2.1.2.2.1", Name: "ifEntry", Type: "row"}, ifMibID},
+		{&Node{OID: "1.3.6.1.2.1.2.2.1.1", Name: "ifIndex", Type: "column"}, ifMibID},
+		{&Node{OID: "1.3.6.1.2.1.2.2.1.2", Name: "ifDescr", Type: "column"}, ifMibID},
+		{&Node{OID: "1.3.6.1.4.1.9999.1", Name: "mystery", Type: ""}, otherID},
+	}
+	for _, entry := range nodes {
+		if err := db.SaveNode(entry.node, entry.moduleID); err != nil {
+			t.Fatalf("SaveNode %s failed: %v", entry.node.Name, err)
+		}
+	}
+
+	summary, err := db.GetModuleNodeTypeSummary("IF-MIB")
+	if err != nil {
+		t.Fatalf("GetModuleNodeTypeSummary failed: %v", err)
+	}
+	if summary["table"] != 1 || summary["row"] != 1 || summary["column"] != 2 || len(summary) != 3 {
+		t.Fatalf("unexpected module summary: %v", summary)
+	}
+
+	overall, err := db.GetNodeTypeSummary()
+	if err != nil {
+		t.Fatalf("GetNodeTypeSummary failed: %v", err)
+	}
+	if overall["column"] != 2 || overall["unknown"] != 1 {
+		t.Fatalf("unexpected overall summary: %v", overall)
+	}
+
+	if _, err := db.GetModuleNodeTypeSummary("MISSING-MIB"); err == nil {
+		t.Fatalf("expected error for unknown module")
+	}
+
+	if _, err := db.SaveModule("EMPTY-MIB", ""); err != nil {
+		t.Fatalf("SaveModule EMPTY-MIB failed: %v", err)
+	}
+	counts, err := db.GetModuleNodeCounts()
+	if err != nil {
+		t.Fatalf("GetModuleNodeCounts failed: %v", err)
+	}
+	if want := map[string]int{"IF-MIB": 4, "OTHER-MIB": 1, "EMPTY-MIB": 0}; !reflect.DeepEqual(counts, want) {
+		t.Fatalf("GetModuleNodeCounts = %v, want %v", counts, want)
+	}
+}
+
+func TestGetRecentlyLoadedModules(t *testing.T) {
+	db := newTestDB(t)
+
+	if _, err := db.SaveModule("FRESH-MIB", "/tmp/fresh.mib"); err != nil {
+		t.Fatalf("SaveModule FRESH-MIB failed: %v", err)
+	}
+	if _, err := db.SaveModule("OLD-MIB", "/tmp/old.mib"); err != nil {
+		t.Fatalf("SaveModule OLD-MIB failed: %v", err)
+	}
+	if _, err := db.db.Exec(`UPDATE mib_modules SET loaded_at = datetime('now', '-48 hours') WHERE name = 'OLD-MIB'`); err != nil {
+		t.Fatalf("failed to age module: %v", err)
+	}
+
+	recent, err := db.GetRecentlyLoadedModules(24)
+	if err != nil {
+		t.Fatalf("GetRecentlyLoadedModules failed: %v", err)
+	}
+	if len(recent) != 1 || recent[0].Name != "FRESH-MIB" {
+		t.Fatalf("expected only FRESH-MIB, got %+v", recent)
+	}
+
+	all, err := db.GetRecentlyLoadedModules(72)
+	if err != nil {
+		t.Fatalf("GetRecentlyLoadedModules failed: %v", err)
+	}
+	if len(all) != 2 || all[0].Name != "FRESH-MIB" {
+		t.Fatalf("expected both modules newest first, got %+v", all)
+	}
+
+	if _, err := db.GetRecentlyLoadedModules(0); err == nil {
+		t.Fatalf("expected error for non-positive hours")
+	}
+
+	loadedAt, err := db.GetModuleLoadDate("OLD-MIB")
+	if err != nil {
+		t.Fatalf("GetModuleLoadDate failed: %v", err)
+	}
+	if age := time.Since(loadedAt); age < 47*time.Hour || age > 49*time.Hour {
+		t.Fatalf("unexpected load date %v (age %v)", loadedAt, age)
+	}
+
+	// Ricaricare un modulo aggiorna la data di caricamento.
+	if _, err := db.SaveModule("OLD-MIB", "/tmp/old.mib"); err != nil {
+		t.Fatalf("SaveModule reload failed: %v", err)
+	}
+	reloadedAt, err := db.GetModuleLoadDate("OLD-MIB")
+	if err != nil {
+		t.Fatalf("GetModuleLoadDate failed: %v", err)
+	}
+	if !reloadedAt.After(loadedAt) {
+		t.Fatalf("expected reload to refresh loaded_at, got %v", reloadedAt)
+	}
+
+	if _, err := db.GetModuleLoadDate("MISSING-MIB"); err == nil {
+		t.Fatalf("expected error for unknown module")
+	}
+}
+
+func TestSaveNodesStoresNotificationObjects(t *testing.T) {
+	db := newTestDB(t)
+
+	moduleID, err := db.SaveModule("IF-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule failed: %v", err)
+	}
+
+	linkDown := &Node{
+		OID:                 "1.3.6.1.6.3.1.1.5.3",
+		Name:                "linkDown",
+		Type:                "notification",
+		NotificationObjects: []string{"1.3.6.1.2.1.2.2.1.1", "1.3.6.1.2.1.2.2.1.7", "1.3.6.1.2.1.2.2.1.8"},
+	}
+	if err := db.SaveNodes([]*Node{linkDown}, moduleID); err != nil {
+		t.Fatalf("SaveNodes failed: %v", err)
+	}
+
+	objects, err := db.GetNotificationObjects(".1.3.6.1.6.3.1.1.5.3")
+	if err != nil {
+		t.Fatalf("GetNotificationObjects failed: %v", err)
+	}
+	if !reflect.DeepEqual(objects, linkDown.NotificationObjects) {
+		t.Fatalf("unexpected notification objects: %v", objects)
+	}
+
+	// Un nuovo caricamento sostituisce l'elenco invece di accodarlo.
+	linkDown.NotificationObjects = []string{"1.3.6.1.2.1.2.2.1.1"}
+	if err := db.SaveNodes([]*Node{linkDown}, moduleID); err != nil {
+		t.Fatalf("SaveNodes reload failed: %v", err)
+	}
+	objects, err = db.GetNotificationObjects("1.3.6.1.6.3.1.1.5.3")
+	if err != nil {
+		t.Fatalf("GetNotificationObjects failed: %v", err)
+	}
+	if len(objects) != 1 {
+		t.Fatalf("expected notification objects to be replaced, got %v", objects)
+	}
+
+	if err := db.DeleteModule("IF-MIB"); err != nil {
+		t.Fatalf("DeleteModule failed: %v", err)
+	}
+	objects, err = db.GetNotificationObjects("1.3.6.1.6.3.1.1.5.3")
+	if err != nil || len(objects) != 0 {
+		t.Fatalf("expected notification objects to be removed with the module, got %v (err=%v)", objects, err)
+	}
+}
+
+func TestModuleRevision(t *testing.T) {
+	db := newTestDB(t)
+
+	if _, err := db.SaveModule("IF-MIB", ""); err != nil {
+		t.Fatalf("SaveModule() error = %v", err)
+	}
+	if revision, err := db.GetModuleRevision("IF-MIB"); err != nil || revision != "" {
+		t.Fatalf("expected empty revision before update, got %q (err %v)", revision, err)
+	}
+
+	if err := db.UpdateModuleRevision("IF-MIB", "2000-06-14"); err != nil {
+		t.Fatalf("UpdateModuleRevision() error = %v", err)
+	}
+	if revision, err := db.GetModuleRevision("IF-MIB"); err != nil || revision != "2000-06-14" {
+		t.Fatalf("expected revision 2000-06-14, got %q (err %v)", revision, err)
+	}
+
+	if _, err := db.GetModuleRevision("MISSING-MIB"); err == nil {
+		t.Fatalf("expected error for unknown module")
+	}
+}
+
+func TestSaveNodesStoresRowIndex(t *testing.T) {
+	db := newTestDB(t)
+
+	moduleID, err := db.SaveModule("BRIDGE-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule failed: %v", err)
+	}
+
+	entry := &Node{
+		OID:  "1.3.6.1.2.1.17.4.3.1",
+		Name: "dot1dTpFdbEntry",
+		Type: "row",
+		Index: []IndexComponent{
+			{OID: "1.3.6.1.2.1.17.4.3.1.1", Name: "dot1dTpFdbAddress", Syntax: "MacAddress", Kind: IndexKindString, FixedLength: 6},
+		},
+	}
+	if err := db.SaveNodes([]*Node{entry}, moduleID); err != nil {
+		t.Fatalf("SaveNodes failed: %v", err)
+	}
+
+	index, err := db.GetRowIndex(".1.3.6.1.2.1.17.4.3.1")
+	if err != nil {
+		t.Fatalf("GetRowIndex failed: %v", err)
+	}
+	if !reflect.DeepEqual(index, entry.Index) {
+		t.Fatalf("unexpected row index: %+v", index)
+	}
+
+	index, err = db.GetRowIndex("1.3.6.1.2.1.17.4.3")
+	if err != nil || len(index) != 0 {
+		t.Fatalf("expected empty index for a non-row node, got %+v (err %v)", index, err)
+	}
+}
+
+func TestGetDatabaseInfo(t *testing.T) {
+	db := newTestDB(t)
+
+	moduleID, err := db.SaveModule("IF-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule failed: %v", err)
+	}
+	if err := db.SaveNodes([]*Node{
+		{OID: "1.3.6.1.2.1.2", Name: "interfaces", Type: "node"},
+		{OID: "1.3.6.1.2.1.2.1", Name: "ifNumber", Type: "scalar", ParentOID: "1.3.6.1.2.1.2"},
+	}, moduleID); err != nil {
+		t.Fatalf("SaveNodes failed: %v", err)
+	}
+	if _, err := db.SaveWalkProfile(WalkProfile{Name: "Interfaces", RootOID: "1.3.6.1.2.1.2"}); err != nil {
+		t.Fatalf("SaveWalkProfile failed: %v", err)
+	}
+
+	info, err := db.GetDatabaseInfo()
+	if err != nil {
+		t.Fatalf("GetDatabaseInfo failed: %v", err)
+	}
+	if info.Path != db.path || info.SizeBytes <= 0 {
+		t.Fatalf("unexpected path or size: %+v", info)
+	}
+	if info.ModuleCount != 1 || info.NodeCount != 2 || info.WalkSessionCount != 1 || info.BookmarkCount != 0 || info.HostCount != 0 {
+		t.Fatalf("unexpected counts: %+v", info)
+	}
+}
+
+func TestOIDWatches(t *testing.T) {
+	db := newTestDB(t)
+
+	watch, err := db.SaveOIDWatch(OIDWatch{Host: "10.0.0.1", OID: ".1.3.6.1.2.1.1.5.0", IntervalSeconds: 30, CompareMode: "any-change"})
+	if err != nil {
+		t.Fatalf("SaveOIDWatch() error = %v", err)
+	}
+	if watch.ID == 0 || watch.OID != "1.3.6.1.2.1.1.5.0" || watch.LastCheckedAt != "" {
+		t.Fatalf("unexpected saved watch: %+v", watch)
+	}
+
+	checkedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := db.UpdateOIDWatchValue(watch.ID, "router", "router", checkedAt); err != nil {
+		t.Fatalf("UpdateOIDWatchValue() error = %v", err)
+	}
+
+	// Una nuova sorveglianza dello stesso OID aggiorna la modalità e conserva l'ultimo valore.
+	updated, err := db.SaveOIDWatch(OIDWatch{Host: "10.0.0.1", OID: "1.3.6.1.2.1.1.5.0", IntervalSeconds: 60, CompareMode: "numeric-increase"})
+	if err != nil {
+		t.Fatalf("SaveOIDWatch() update error = %v", err)
+	}
+	if updated.ID != watch.ID || updated.IntervalSeconds != 60 || updated.CompareMode != "numeric-increase" {
+		t.Fatalf("unexpected updated watch: %+v", updated)
+	}
+	if updated.LastValue != "router" || updated.LastCheckedAt != checkedAt.Format(time.RFC3339) {
+		t.Fatalf("expected last value to be kept, got %+v", updated)
+	}
+
+	if _, err := db.SaveOIDWatch(OIDWatch{Host: "10.0.0.1", OID: "1.3.6.1.2.1.1.5.0"}); err == nil {
+		t.Fatalf("expected a watch without interval to be rejected")
+	}
+
+	if err := db.DeleteOIDWatch(watch.ID); err != nil {
+		t.Fatalf("DeleteOIDWatch() error = %v", err)
+	}
+	if err := db.DeleteOIDWatch(watch.ID); err == nil {
+		t.Fatalf("expected deleting a missing watch to fail")
+	}
+	if watches, err := db.ListOIDWatches(); err != nil || len(watches) != 0 {
+		t.Fatalf("ListOIDWatches() = %v, %v; want empty", watches, err)
+	}
+}
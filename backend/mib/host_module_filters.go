@@ -0,0 +1,76 @@
+package mib
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ensureHostModuleFiltersSchema crea la tabella dei moduli nascosti nella vista MIB di ciascun host.
+func (d *Database) ensureHostModuleFiltersSchema() error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	if _, err := d.db.Exec(`CREATE TABLE IF NOT EXISTS host_module_filters (
+		host TEXT NOT NULL,
+		module TEXT NOT NULL,
+		PRIMARY KEY (host, module)
+	)`); err != nil {
+		return fmt.Errorf("failed to ensure host_module_filters table: %w", err)
+	}
+	return nil
+}
+
+// SetHostModuleHidden nasconde (hidden true) o rende di nuovo visibile un modulo nella vista MIB dell'host.
+func (d *Database) SetHostModuleHidden(host, module string, hidden bool) error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	host = strings.TrimSpace(host)
+	module = strings.TrimSpace(module)
+	if host == "" {
+		return fmt.Errorf("host is required")
+	}
+	if module == "" {
+		return fmt.Errorf("module name is required")
+	}
+
+	if hidden {
+		if _, err := d.db.Exec(`INSERT OR IGNORE INTO host_module_filters (host, module) VALUES (?, ?)`, host, module); err != nil {
+			return fmt.Errorf("failed to hide module %s for %s: %w", module, host, err)
+		}
+		return nil
+	}
+
+	if _, err := d.db.Exec(`DELETE FROM host_module_filters WHERE host = ? AND module = ?`, host, module); err != nil {
+		return fmt.Errorf("failed to show module %s for %s: %w", module, host, err)
+	}
+	return nil
+}
+
+// GetHostHiddenModules restituisce i moduli nascosti per l'host, in ordine alfabetico.
+func (d *Database) GetHostHiddenModules(host string) ([]string, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	rows, err := d.db.Query(`SELECT module FROM host_module_filters WHERE host = ? ORDER BY module`, strings.TrimSpace(host))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load hidden modules: %w", err)
+	}
+	defer rows.Close()
+
+	modules := []string{}
+	for rows.Next() {
+		var module string
+		if err := rows.Scan(&module); err != nil {
+			return nil, fmt.Errorf("failed to scan hidden module: %w", err)
+		}
+		modules = append(modules, module)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed during hidden module iteration: %w", err)
+	}
+	return modules, nil
+}
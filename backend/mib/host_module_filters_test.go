@@ -0,0 +1,39 @@
+package mib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHostModuleFilters(t *testing.T) {
+	db := newTestDB(t)
+
+	for _, module := range []string{"JUNIPER-MIB", "ARISTA-MIB", "JUNIPER-MIB"} {
+		if err := db.SetHostModuleHidden("10.0.0.1", module, true); err != nil {
+			t.Fatalf("SetHostModuleHidden() error = %v", err)
+		}
+	}
+	if err := db.SetHostModuleHidden("10.0.0.2", "CISCO-MIB", true); err != nil {
+		t.Fatalf("SetHostModuleHidden() error = %v", err)
+	}
+
+	modules, err := db.GetHostHiddenModules("10.0.0.1")
+	if err != nil {
+		t.Fatalf("GetHostHiddenModules() error = %v", err)
+	}
+	if want := []string{"ARISTA-MIB", "JUNIPER-MIB"}; !reflect.DeepEqual(modules, want) {
+		t.Fatalf("GetHostHiddenModules() = %v, want %v", modules, want)
+	}
+
+	if err := db.SetHostModuleHidden("10.0.0.1", "ARISTA-MIB", false); err != nil {
+		t.Fatalf("SetHostModuleHidden() error = %v", err)
+	}
+	modules, _ = db.GetHostHiddenModules("10.0.0.1")
+	if want := []string{"JUNIPER-MIB"}; !reflect.DeepEqual(modules, want) {
+		t.Fatalf("GetHostHiddenModules() after show = %v, want %v", modules, want)
+	}
+
+	if err := db.SetHostModuleHidden("", "JUNIPER-MIB", true); err == nil {
+		t.Fatalf("expected empty host to be rejected")
+	}
+}
@@ -0,0 +1,131 @@
+package mib
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MaxHostOIDHits è il numero massimo di OID ricordati per host; oltre, vengono scartati i meno recenti.
+const MaxHostOIDHits = 10000
+
+// ensureHostOIDHitsSchema crea la tabella degli OID che hanno risposto su ciascun host.
+func (d *Database) ensureHostOIDHitsSchema() error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	statements := []struct {
+		query string
+		err   string
+	}{
+		{
+			query: `CREATE TABLE IF NOT EXISTS host_oid_hits (
+				host TEXT NOT NULL,
+				oid TEXT NOT NULL,
+				hits INTEGER NOT NULL DEFAULT 1,
+				last_seen DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (host, oid)
+			)`,
+			err: "failed to ensure host_oid_hits table",
+		},
+		{
+			query: `CREATE INDEX IF NOT EXISTS idx_host_oid_hits_recent ON host_oid_hits(host, last_seen DESC)`,
+			err:   "failed to ensure host_oid_hits index",
+		},
+	}
+
+	for _, stmt := range statements {
+		if _, err := d.db.Exec(stmt.query); err != nil {
+			return fmt.Errorf("%s: %w", stmt.err, err)
+		}
+	}
+	return nil
+}
+
+// RecordHostOIDHits registra gli OID base che hanno risposto sull'host indicato e pota la tabella
+// mantenendo al massimo MaxHostOIDHits OID per host.
+func (d *Database) RecordHostOIDHits(host string, oids []string) error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	host = strings.TrimSpace(host)
+	if host == "" || len(oids) == 0 {
+		return nil
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, oid := range oids {
+		oid = strings.TrimPrefix(strings.TrimSpace(oid), ".")
+		if oid == "" {
+			continue
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO host_oid_hits (host, oid) VALUES (?, ?)
+			ON CONFLICT(host, oid) DO UPDATE SET hits = hits + 1, last_seen = CURRENT_TIMESTAMP
+		`, host, oid); err != nil {
+			return fmt.Errorf("failed to record OID hit %s on %s: %w", oid, host, err)
+		}
+	}
+
+	if err := pruneHostOIDHits(tx, host, MaxHostOIDHits); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func pruneHostOIDHits(tx *sql.Tx, host string, limit int) error {
+	var count int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM host_oid_hits WHERE host = ?`, host).Scan(&count); err != nil {
+		return fmt.Errorf("failed to count OID hits for %s: %w", host, err)
+	}
+	if count <= limit {
+		return nil
+	}
+
+	if _, err := tx.Exec(`
+		DELETE FROM host_oid_hits
+		WHERE host = ? AND oid NOT IN (
+			SELECT oid FROM host_oid_hits WHERE host = ? ORDER BY last_seen DESC, hits DESC, oid LIMIT ?
+		)
+	`, host, host, limit); err != nil {
+		return fmt.Errorf("failed to prune OID hits for %s: %w", host, err)
+	}
+	return nil
+}
+
+// GetHostOIDHits restituisce gli OID base che hanno risposto sull'host, in ordine numerico.
+func (d *Database) GetHostOIDHits(host string) ([]string, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	rows, err := d.db.Query(`SELECT oid FROM host_oid_hits WHERE host = ?`, strings.TrimSpace(host))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OID hits: %w", err)
+	}
+	defer rows.Close()
+
+	oids := []string{}
+	for rows.Next() {
+		var oid string
+		if err := rows.Scan(&oid); err != nil {
+			return nil, fmt.Errorf("failed to scan OID hit: %w", err)
+		}
+		oids = append(oids, oid)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed during OID hit iteration: %w", err)
+	}
+
+	sort.Slice(oids, func(i, j int) bool { return CompareOIDs(oids[i], oids[j]) < 0 })
+	return oids, nil
+}
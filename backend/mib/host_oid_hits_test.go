@@ -0,0 +1,72 @@
+package mib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRecordHostOIDHits(t *testing.T) {
+	db := newTestDB(t)
+
+	if err := db.RecordHostOIDHits("10.0.0.1", []string{"1.3.6.1.2.1.1.5", ".1.3.6.1.2.1.1.10", "1.3.6.1.2.1.1.5"}); err != nil {
+		t.Fatalf("RecordHostOIDHits() error = %v", err)
+	}
+	if err := db.RecordHostOIDHits("10.0.0.2", []string{"1.3.6.1.2.1.2.2.1.2"}); err != nil {
+		t.Fatalf("RecordHostOIDHits() error = %v", err)
+	}
+
+	oids, err := db.GetHostOIDHits("10.0.0.1")
+	if err != nil {
+		t.Fatalf("GetHostOIDHits() error = %v", err)
+	}
+	if want := []string{"1.3.6.1.2.1.1.5", "1.3.6.1.2.1.1.10"}; !reflect.DeepEqual(oids, want) {
+		t.Fatalf("GetHostOIDHits() = %v, want %v", oids, want)
+	}
+
+	var hits int
+	if err := db.db.QueryRow(`SELECT hits FROM host_oid_hits WHERE host = ? AND oid = ?`, "10.0.0.1", "1.3.6.1.2.1.1.5").Scan(&hits); err != nil {
+		t.Fatalf("query hits: %v", err)
+	}
+	if hits != 2 {
+		t.Fatalf("hits = %d, want 2", hits)
+	}
+
+	oids, err = db.GetHostOIDHits("10.0.0.9")
+	if err != nil {
+		t.Fatalf("GetHostOIDHits() error = %v", err)
+	}
+	if len(oids) != 0 {
+		t.Fatalf("GetHostOIDHits() for unknown host = %v, want empty", oids)
+	}
+}
+
+func TestPruneHostOIDHitsKeepsMostRecent(t *testing.T) {
+	db := newTestDB(t)
+
+	if err := db.RecordHostOIDHits("10.0.0.1", []string{"1.3.6.1.2.1.1.1", "1.3.6.1.2.1.1.2", "1.3.6.1.2.1.1.3"}); err != nil {
+		t.Fatalf("RecordHostOIDHits() error = %v", err)
+	}
+	if _, err := db.db.Exec(`UPDATE host_oid_hits SET last_seen = datetime('now', '-1 day') WHERE oid = ?`, "1.3.6.1.2.1.1.2"); err != nil {
+		t.Fatalf("age hit: %v", err)
+	}
+
+	tx, err := db.db.Begin()
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	if err := pruneHostOIDHits(tx, "10.0.0.1", 2); err != nil {
+		tx.Rollback()
+		t.Fatalf("pruneHostOIDHits() error = %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	oids, err := db.GetHostOIDHits("10.0.0.1")
+	if err != nil {
+		t.Fatalf("GetHostOIDHits() error = %v", err)
+	}
+	if want := []string{"1.3.6.1.2.1.1.1", "1.3.6.1.2.1.1.3"}; !reflect.DeepEqual(oids, want) {
+		t.Fatalf("GetHostOIDHits() after prune = %v, want %v", oids, want)
+	}
+}
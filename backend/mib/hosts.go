@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"mib-to-the-future/backend/snmp"
 )
 
 // HostConfig rappresenta i parametri di connessione per un host SNMP persistito nel database.
@@ -23,121 +25,93 @@ type HostConfig struct {
 	AuthPassword     string `json:"authPassword,omitempty"`
 	PrivProtocol     string `json:"privProtocol,omitempty"`
 	PrivPassword     string `json:"privPassword,omitempty"`
+	// SourcePort è la porta UDP locale fissa da usare verso l'host (0 = porta effimera).
+	SourcePort int `json:"sourcePort,omitempty"`
+	// CredentialProfile è il nome del profilo da cui l'host eredita versione e credenziali; se valorizzato,
+	// GetHost e ListHosts sostituiscono i campi di credenziale con quelli del profilo.
+	CredentialProfile string `json:"credentialProfile,omitempty"`
+	// SerializeRequests limita l'host a una richiesta SNMP in corso alla volta, per agent fragili.
+	SerializeRequests bool `json:"serializeRequests,omitempty"`
+	// IPVersion è "ipv6" se l'indirizzo è un IPv6 letterale, altrimenti "ipv4" (anche per i nomi host);
+	// viene calcolato da SaveHost e ignorato in ingresso.
+	IPVersion string `json:"ipVersion,omitempty"`
+}
+
+// MaskedPassword è il segnaposto che sostituisce community e password SNMPv3 nelle configurazioni esportate.
+const MaskedPassword = "***"
+
+// Masked restituisce una copia della configurazione con le community v1/v2c e le password SNMPv3
+// sostituite da MaskedPassword.
+func (h HostConfig) Masked() HostConfig {
+	if h.Community != "" {
+		h.Community = MaskedPassword
+	}
+	if h.WriteCommunity != "" {
+		h.WriteCommunity = MaskedPassword
+	}
+	if h.AuthPassword != "" {
+		h.AuthPassword = MaskedPassword
+	}
+	if h.PrivPassword != "" {
+		h.PrivPassword = MaskedPassword
+	}
+	return h
 }
 
 // SaveHost salva o aggiorna la configurazione SNMP per un host.
-// L'indirizzo viene utilizzato come chiave primaria e l'ora di ultimo utilizzo viene aggiornata ad ogni salvataggio.
+// L'indirizzo, normalizzato con snmp.NormalizeHost, viene utilizzato come chiave primaria e l'ora di
+// ultimo utilizzo viene aggiornata ad ogni salvataggio.
 func (d *Database) SaveHost(config HostConfig) (*HostConfig, error) {
-	address := strings.TrimSpace(config.Address)
-	if address == "" {
+	if strings.TrimSpace(config.Address) == "" {
 		return nil, fmt.Errorf("address is required")
 	}
+	address, err := snmp.NormalizeHost(config.Address)
+	if err != nil {
+		return nil, err
+	}
 
 	port := config.Port
 	if port <= 0 {
 		port = 161
 	}
 
-	community := strings.TrimSpace(config.Community)
-	version := strings.TrimSpace(config.Version)
-	switch strings.ToLower(version) {
-	case "", "v2c":
-		version = "v2c"
-	case "v1":
-		version = "v1"
-	case "v3":
-		version = "v3"
-	default:
-		return nil, fmt.Errorf("versione SNMP non supportata: %s", config.Version)
-	}
-
-	if community == "" && version != "v3" {
-		community = "public"
-	}
-
-	writeCommunity := strings.TrimSpace(config.WriteCommunity)
-	if version == "v3" {
-		community = strings.TrimSpace(config.Community)
-		writeCommunity = ""
-	} else {
-		if writeCommunity == "" {
-			writeCommunity = community
+	profileName := strings.TrimSpace(config.CredentialProfile)
+	var credentials HostConfig
+	if profileName != "" {
+		// Le credenziali sono quelle del profilo, risolte in lettura: sull'host resta solo il riferimento.
+		profile, err := d.GetCredentialProfile(profileName)
+		if err != nil {
+			return nil, err
 		}
-	}
-
-	contextName := ""
-	securityLevel := ""
-	securityUsername := ""
-	authProtocol := ""
-	authPassword := ""
-	privProtocol := ""
-	privPassword := ""
-
-	if version == "v3" {
+		if profile == nil {
+			return nil, fmt.Errorf("credential profile %s not found", profileName)
+		}
+		credentials.Version = profile.Version
+	} else {
 		var err error
-
-		contextName = strings.TrimSpace(config.ContextName)
-
-		securityLevel, err = normalizeSecurityLevel(config.SecurityLevel)
+		credentials, err = normalizeHostCredentials(config)
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		securityUsername = strings.TrimSpace(config.SecurityUsername)
-		if securityUsername == "" {
-			return nil, fmt.Errorf("username di sicurezza richiesto per SNMPv3")
-		}
-
-		switch securityLevel {
-		case "noAuthNoPriv":
-			// Nessun parametro aggiuntivo richiesto
-		case "authNoPriv":
-			authProtocol, err = normalizeAuthProtocol(config.AuthProtocol)
-			if err != nil {
-				return nil, err
-			}
-			if authProtocol == "" {
-				return nil, fmt.Errorf("protocollo di autenticazione richiesto per SNMPv3 livello authNoPriv")
-			}
-			authPassword = config.AuthPassword
-			if strings.TrimSpace(authPassword) == "" {
-				return nil, fmt.Errorf("password di autenticazione richiesta per SNMPv3 livello authNoPriv")
-			}
-		case "authPriv":
-			authProtocol, err = normalizeAuthProtocol(config.AuthProtocol)
-			if err != nil {
-				return nil, err
-			}
-			if authProtocol == "" {
-				return nil, fmt.Errorf("protocollo di autenticazione richiesto per SNMPv3 livello authPriv")
-			}
-			authPassword = config.AuthPassword
-			if strings.TrimSpace(authPassword) == "" {
-				return nil, fmt.Errorf("password di autenticazione richiesta per SNMPv3 livello authPriv")
-			}
+	sourcePort := config.SourcePort
+	if sourcePort < 0 || sourcePort > 65535 {
+		return nil, fmt.Errorf("porta sorgente non valida: %d", sourcePort)
+	}
 
-			privProtocol, err = normalizePrivProtocol(config.PrivProtocol)
-			if err != nil {
-				return nil, err
-			}
-			if privProtocol == "" {
-				return nil, fmt.Errorf("protocollo di privacy richiesto per SNMPv3 livello authPriv")
-			}
-			privPassword = config.PrivPassword
-			if strings.TrimSpace(privPassword) == "" {
-				return nil, fmt.Errorf("password di privacy richiesta per SNMPv3 livello authPriv")
-			}
-		default:
-			return nil, fmt.Errorf("livello di sicurezza SNMPv3 non valido: %s", securityLevel)
-		}
+	ipVersion := "ipv4"
+	if snmp.IsIPv6(address) {
+		ipVersion = "ipv6"
 	}
 
-	_, err := d.db.Exec(`
+	_, err = d.db.Exec(`
 		INSERT INTO host_configs (
 			address, port, community, write_community, version, last_used_at,
-			context_name, security_level, security_username, auth_protocol, auth_password, priv_protocol, priv_password
+			context_name, security_level, security_username, auth_protocol, auth_password, priv_protocol, priv_password,
+			source_port, credential_profile, serialize_requests, ip_version
 		)
-		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, ?, ?, ?, ?, ?, ?, ?)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(address) DO UPDATE SET
 			port = excluded.port,
 			community = excluded.community,
@@ -150,10 +124,15 @@ func (d *Database) SaveHost(config HostConfig) (*HostConfig, error) {
 			auth_protocol = excluded.auth_protocol,
 			auth_password = excluded.auth_password,
 			priv_protocol = excluded.priv_protocol,
-			priv_password = excluded.priv_password
-	`, address, port, community, writeCommunity, version,
-		contextName, securityLevel, securityUsername,
-		authProtocol, authPassword, privProtocol, privPassword)
+			priv_password = excluded.priv_password,
+			source_port = excluded.source_port,
+			credential_profile = excluded.credential_profile,
+			serialize_requests = excluded.serialize_requests,
+			ip_version = excluded.ip_version
+	`, address, port, credentials.Community, credentials.WriteCommunity, credentials.Version,
+		credentials.ContextName, credentials.SecurityLevel, credentials.SecurityUsername,
+		credentials.AuthProtocol, credentials.AuthPassword, credentials.PrivProtocol, credentials.PrivPassword,
+		sourcePort, profileName, config.SerializeRequests, ipVersion)
 	if err != nil {
 		return nil, fmt.Errorf("failed to persist host config: %w", err)
 	}
@@ -161,6 +140,15 @@ func (d *Database) SaveHost(config HostConfig) (*HostConfig, error) {
 	return d.GetHost(address)
 }
 
+// hostKey restituisce l'indirizzo con cui un host è salvato: la forma normalizzata, se valida, così
+// "[::1]" e "::1" indicano lo stesso host.
+func hostKey(address string) string {
+	if normalized, err := snmp.NormalizeHost(address); err == nil {
+		return normalized
+	}
+	return strings.TrimSpace(address)
+}
+
 // GetHost recupera la configurazione associata a un indirizzo host.
 func (d *Database) GetHost(address string) (*HostConfig, error) {
 	row := d.db.QueryRow(`
@@ -171,16 +159,21 @@ func (d *Database) GetHost(address string) (*HostConfig, error) {
 		       COALESCE(auth_protocol, '') AS auth_protocol,
 		       COALESCE(auth_password, '') AS auth_password,
 		       COALESCE(priv_protocol, '') AS priv_protocol,
-		       COALESCE(priv_password, '') AS priv_password
+		       COALESCE(priv_password, '') AS priv_password,
+		       COALESCE(source_port, 0) AS source_port,
+		       COALESCE(credential_profile, '') AS credential_profile,
+		       COALESCE(serialize_requests, 0) AS serialize_requests,
+		       COALESCE(ip_version, 'ipv4') AS ip_version
 		FROM host_configs
 		WHERE address = ?
-	`, strings.TrimSpace(address))
+	`, hostKey(address))
 
 	host := &HostConfig{}
 	err := row.Scan(
 		&host.Address, &host.Port, &host.Community, &host.WriteCommunity, &host.Version, &host.LastUsedAt, &host.CreatedAt,
 		&host.ContextName, &host.SecurityLevel, &host.SecurityUsername, &host.AuthProtocol, &host.AuthPassword,
-		&host.PrivProtocol, &host.PrivPassword,
+		&host.PrivProtocol, &host.PrivPassword, &host.SourcePort, &host.CredentialProfile, &host.SerializeRequests,
+		&host.IPVersion,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -197,6 +190,9 @@ func (d *Database) GetHost(address string) (*HostConfig, error) {
 	if host.WriteCommunity == "" && host.Community != "" {
 		host.WriteCommunity = host.Community
 	}
+	if err := d.applyCredentialProfile(host); err != nil {
+		return nil, err
+	}
 	return host, nil
 }
 
@@ -211,7 +207,11 @@ func (d *Database) ListHosts(limit int) ([]HostConfig, error) {
 		       COALESCE(auth_protocol, '') AS auth_protocol,
 		       COALESCE(auth_password, '') AS auth_password,
 		       COALESCE(priv_protocol, '') AS priv_protocol,
-		       COALESCE(priv_password, '') AS priv_password
+		       COALESCE(priv_password, '') AS priv_password,
+		       COALESCE(source_port, 0) AS source_port,
+		       COALESCE(credential_profile, '') AS credential_profile,
+		       COALESCE(serialize_requests, 0) AS serialize_requests,
+		       COALESCE(ip_version, 'ipv4') AS ip_version
 		FROM host_configs
 		ORDER BY datetime(last_used_at) DESC, address ASC
 	`
@@ -235,7 +235,8 @@ func (d *Database) ListHosts(limit int) ([]HostConfig, error) {
 		err := rows.Scan(
 			&host.Address, &host.Port, &host.Community, &host.WriteCommunity, &host.Version, &host.LastUsedAt, &host.CreatedAt,
 			&host.ContextName, &host.SecurityLevel, &host.SecurityUsername, &host.AuthProtocol, &host.AuthPassword,
-			&host.PrivProtocol, &host.PrivPassword,
+			&host.PrivProtocol, &host.PrivPassword, &host.SourcePort, &host.CredentialProfile, &host.SerializeRequests,
+			&host.IPVersion,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan host config: %w", err)
@@ -255,6 +256,13 @@ func (d *Database) ListHosts(limit int) ([]HostConfig, error) {
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("failed during host config iteration: %w", err)
 	}
+	rows.Close()
+
+	for i := range hosts {
+		if err := d.applyCredentialProfile(&hosts[i]); err != nil {
+			return nil, err
+		}
+	}
 
 	return hosts, nil
 }
@@ -265,7 +273,7 @@ func (d *Database) TouchHost(address string) error {
 		UPDATE host_configs
 		SET last_used_at = CURRENT_TIMESTAMP
 		WHERE address = ?
-	`, strings.TrimSpace(address))
+	`, hostKey(address))
 	if err != nil {
 		return fmt.Errorf("failed to touch host config: %w", err)
 	}
@@ -288,12 +296,159 @@ func (d *Database) DeleteHost(address string) error {
 		return fmt.Errorf("address is required")
 	}
 
-	if _, err := d.db.Exec(`DELETE FROM host_configs WHERE address = ?`, trimmed); err != nil {
+	if _, err := d.db.Exec(`DELETE FROM host_configs WHERE address = ?`, hostKey(trimmed)); err != nil {
 		return fmt.Errorf("failed to delete host config: %w", err)
 	}
 	return nil
 }
 
+// UpdateHostUptime registra l'ultimo sysUpTime osservato per un host e l'istante dell'osservazione.
+func (d *Database) UpdateHostUptime(address string, ticks int64, observedAt time.Time) error {
+	if _, err := d.db.Exec(`
+		UPDATE host_configs
+		SET last_uptime_ticks = ?, last_uptime_at = ?
+		WHERE address = ?
+	`, ticks, observedAt.UTC().Format(time.RFC3339), hostKey(address)); err != nil {
+		return fmt.Errorf("failed to update host uptime: %w", err)
+	}
+	return nil
+}
+
+// GetHostUptime restituisce l'ultimo sysUpTime registrato per un host.
+// Il valore booleano è false se l'host non esiste o non ha ancora un'osservazione.
+func (d *Database) GetHostUptime(address string) (int64, time.Time, bool, error) {
+	var ticks int64
+	var observed string
+	err := d.db.QueryRow(`
+		SELECT last_uptime_ticks, last_uptime_at
+		FROM host_configs
+		WHERE address = ?
+	`, hostKey(address)).Scan(&ticks, &observed)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, time.Time{}, false, nil
+		}
+		return 0, time.Time{}, false, fmt.Errorf("failed to load host uptime: %w", err)
+	}
+
+	observedAt, err := time.Parse(time.RFC3339, observed)
+	if err != nil {
+		return 0, time.Time{}, false, nil
+	}
+	return ticks, observedAt, true, nil
+}
+
+// normalizeHostCredentials valida versione e credenziali SNMP e le restituisce normalizzate,
+// azzerando i parametri che non si applicano alla versione scelta.
+func normalizeHostCredentials(config HostConfig) (HostConfig, error) {
+	community := strings.TrimSpace(config.Community)
+	version := strings.TrimSpace(config.Version)
+	switch strings.ToLower(version) {
+	case "", "v2c":
+		version = "v2c"
+	case "v1":
+		version = "v1"
+	case "v3":
+		version = "v3"
+	default:
+		return HostConfig{}, fmt.Errorf("versione SNMP non supportata: %s", config.Version)
+	}
+
+	if community == "" && version != "v3" {
+		community = "public"
+	}
+
+	writeCommunity := strings.TrimSpace(config.WriteCommunity)
+	if version == "v3" {
+		community = strings.TrimSpace(config.Community)
+		writeCommunity = ""
+	} else {
+		if writeCommunity == "" {
+			writeCommunity = community
+		}
+	}
+
+	contextName := ""
+	securityLevel := ""
+	securityUsername := ""
+	authProtocol := ""
+	authPassword := ""
+	privProtocol := ""
+	privPassword := ""
+
+	if version == "v3" {
+		var err error
+
+		contextName = strings.TrimSpace(config.ContextName)
+
+		securityLevel, err = normalizeSecurityLevel(config.SecurityLevel)
+		if err != nil {
+			return HostConfig{}, err
+		}
+
+		securityUsername = strings.TrimSpace(config.SecurityUsername)
+		if securityUsername == "" {
+			return HostConfig{}, fmt.Errorf("username di sicurezza richiesto per SNMPv3")
+		}
+
+		switch securityLevel {
+		case "noAuthNoPriv":
+			// Nessun parametro aggiuntivo richiesto
+		case "authNoPriv":
+			authProtocol, err = normalizeAuthProtocol(config.AuthProtocol)
+			if err != nil {
+				return HostConfig{}, err
+			}
+			if authProtocol == "" {
+				return HostConfig{}, fmt.Errorf("protocollo di autenticazione richiesto per SNMPv3 livello authNoPriv")
+			}
+			authPassword = config.AuthPassword
+			if strings.TrimSpace(authPassword) == "" {
+				return HostConfig{}, fmt.Errorf("password di autenticazione richiesta per SNMPv3 livello authNoPriv")
+			}
+		case "authPriv":
+			authProtocol, err = normalizeAuthProtocol(config.AuthProtocol)
+			if err != nil {
+				return HostConfig{}, err
+			}
+			if authProtocol == "" {
+				return HostConfig{}, fmt.Errorf("protocollo di autenticazione richiesto per SNMPv3 livello authPriv")
+			}
+			authPassword = config.AuthPassword
+			if strings.TrimSpace(authPassword) == "" {
+				return HostConfig{}, fmt.Errorf("password di autenticazione richiesta per SNMPv3 livello authPriv")
+			}
+
+			privProtocol, err = normalizePrivProtocol(config.PrivProtocol)
+			if err != nil {
+				return HostConfig{}, err
+			}
+			if privProtocol == "" {
+				return HostConfig{}, fmt.Errorf("protocollo di privacy richiesto per SNMPv3 livello authPriv")
+			}
+			privPassword = config.PrivPassword
+			if strings.TrimSpace(privPassword) == "" {
+				return HostConfig{}, fmt.Errorf("password di privacy richiesta per SNMPv3 livello authPriv")
+			}
+		default:
+			return HostConfig{}, fmt.Errorf("livello di sicurezza SNMPv3 non valido: %s", securityLevel)
+		}
+	}
+
+	return HostConfig{
+		Version:          version,
+		Community:        community,
+		WriteCommunity:   writeCommunity,
+		ContextName:      contextName,
+		SecurityLevel:    securityLevel,
+		SecurityUsername: securityUsername,
+		AuthProtocol:     authProtocol,
+		AuthPassword:     authPassword,
+		PrivProtocol:     privProtocol,
+		PrivPassword:     privPassword,
+	}, nil
+}
+
 func parseTimestamp(ts string) (string, error) {
 	if strings.TrimSpace(ts) == "" {
 		return "", nil
@@ -4,6 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"mib-to-the-future/backend/snmp"
 )
 
 func setupTestDB(t *testing.T) *Database {
@@ -52,6 +55,9 @@ func setupTestDB(t *testing.T) *Database {
 
 func TestSaveAndListHosts(t *testing.T) {
 	db := setupTestDB(t)
+	if err := db.EnsureHostConfigSchema(); err != nil {
+		t.Fatalf("EnsureHostConfigSchema failed: %v", err)
+	}
 
 	// Test saving a new host
 	host1 := HostConfig{
@@ -79,11 +85,13 @@ func TestSaveAndListHosts(t *testing.T) {
 
 	// Test updating an existing host
 	host2 := HostConfig{
-		Address:        "localhost",
-		Port:           1161,
-		Community:      "private",
-		WriteCommunity: "private-write",
-		Version:        "v1",
+		Address:           "localhost",
+		Port:              1161,
+		Community:         "private",
+		WriteCommunity:    "private-write",
+		Version:           "v1",
+		SourcePort:        50161,
+		SerializeRequests: true,
 	}
 	_, err = db.SaveHost(host2)
 	if err != nil {
@@ -119,4 +127,108 @@ func TestSaveAndListHosts(t *testing.T) {
 	if hosts[0].Version != "v1" {
 		t.Errorf("expected version v1, got %s", hosts[0].Version)
 	}
+
+	if hosts[0].SourcePort != 50161 {
+		t.Errorf("expected source port 50161, got %d", hosts[0].SourcePort)
+	}
+
+	if !hosts[0].SerializeRequests {
+		t.Errorf("expected serialize requests flag to be persisted")
+	}
+
+	if _, err := db.SaveHost(HostConfig{Address: "localhost", SourcePort: 70000}); err == nil {
+		t.Errorf("expected invalid source port to be rejected")
+	}
+}
+
+func TestHostUptimeRoundTrip(t *testing.T) {
+	db := setupTestDB(t)
+	if err := db.EnsureHostConfigSchema(); err != nil {
+		t.Fatalf("EnsureHostConfigSchema failed: %v", err)
+	}
+
+	if _, _, ok, err := db.GetHostUptime("192.0.2.10"); err != nil || ok {
+		t.Fatalf("expected no uptime for unknown host, got ok=%v err=%v", ok, err)
+	}
+
+	if _, err := db.SaveHost(HostConfig{Address: "192.0.2.10", Community: "public", Version: "v2c"}); err != nil {
+		t.Fatalf("SaveHost failed: %v", err)
+	}
+
+	observed := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	if err := db.UpdateHostUptime("192.0.2.10", 123456, observed); err != nil {
+		t.Fatalf("UpdateHostUptime failed: %v", err)
+	}
+
+	ticks, at, ok, err := db.GetHostUptime("192.0.2.10")
+	if err != nil || !ok {
+		t.Fatalf("GetHostUptime failed: ok=%v err=%v", ok, err)
+	}
+	if ticks != 123456 || !at.Equal(observed) {
+		t.Fatalf("unexpected uptime: %d at %s", ticks, at)
+	}
+}
+
+func TestSaveHostIPVersion(t *testing.T) {
+	db := setupTestDB(t)
+	if err := db.EnsureHostConfigSchema(); err != nil {
+		t.Fatalf("EnsureHostConfigSchema failed: %v", err)
+	}
+
+	for address, want := range map[string]string{
+		"192.0.2.1":      "ipv4",
+		"router.example": "ipv4",
+		"2001:db8::1":    "ipv6",
+		"[fe80::1%eth0]": "ipv6",
+	} {
+		saved, err := db.SaveHost(HostConfig{Address: address, Community: "public", Version: "v2c"})
+		if err != nil {
+			t.Fatalf("SaveHost(%q): %v", address, err)
+		}
+		if saved.IPVersion != want {
+			t.Errorf("SaveHost(%q): expected ip version %s, got %s", address, want, saved.IPVersion)
+		}
+	}
+
+	hosts, err := db.ListHosts(0)
+	if err != nil {
+		t.Fatalf("ListHosts() error = %v", err)
+	}
+	for _, host := range hosts {
+		if (host.IPVersion == "ipv6") != snmp.IsIPv6(host.Address) {
+			t.Errorf("ListHosts: %s has ip version %s", host.Address, host.IPVersion)
+		}
+	}
+}
+
+func TestSaveHostNormalizesAddress(t *testing.T) {
+	db := setupTestDB(t)
+	if err := db.EnsureHostConfigSchema(); err != nil {
+		t.Fatalf("EnsureHostConfigSchema failed: %v", err)
+	}
+
+	for _, address := range []string{"[2001:db8::1]", "2001:DB8:0::1", " 2001:db8::1 "} {
+		saved, err := db.SaveHost(HostConfig{Address: address, Community: "public", Version: "v2c"})
+		if err != nil {
+			t.Fatalf("SaveHost(%q): %v", address, err)
+		}
+		if saved.Address != "2001:db8::1" {
+			t.Fatalf("SaveHost(%q): expected normalized address, got %q", address, saved.Address)
+		}
+	}
+
+	hosts, err := db.ListHosts(0)
+	if err != nil {
+		t.Fatalf("ListHosts() error = %v", err)
+	}
+	if len(hosts) != 1 {
+		t.Fatalf("expected a single host, got %+v", hosts)
+	}
+	if host, err := db.GetHost("[2001:db8::1]"); err != nil || host == nil {
+		t.Fatalf("GetHost with brackets: %v, %v", host, err)
+	}
+
+	if _, err := db.SaveHost(HostConfig{Address: "[192.0.2.1]", Community: "public", Version: "v2c"}); err == nil {
+		t.Fatalf("expected bracketed IPv4 address to be rejected")
+	}
 }
@@ -0,0 +1,67 @@
+package mib
+
+import "fmt"
+
+// Motivi per cui un nodo può essere escluso durante il caricamento di un MIB.
+const (
+	SkipReasonUnresolvedOID     = "unresolved OID"
+	SkipReasonDuplicateOID      = "duplicate OID"
+	SkipReasonConversionFailure = "conversion failure"
+)
+
+// SkippedNode descrive un nodo escluso durante il parsing e il relativo motivo.
+type SkippedNode struct {
+	Name   string `json:"name"`
+	Module string `json:"module"`
+	OID    string `json:"oid,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// LoadReport riassume l'esito del caricamento di un file MIB.
+type LoadReport struct {
+	FilePath          string         `json:"filePath"`
+	ModuleName        string         `json:"moduleName"`
+	IdentityOID       string         `json:"identityOid,omitempty"` // OID della MODULE-IDENTITY, se dichiarata
+	LoadedModules     []string       `json:"loadedModules"`
+	TotalNodes        int            `json:"totalNodes"`
+	SkippedNodes      []SkippedNode  `json:"skippedNodes"`
+	SkippedByReason   map[string]int `json:"skippedByReason"`
+	SanitizationFixes []string       `json:"sanitizationFixes"`
+	MissingImports    []string       `json:"missingImports"`
+	RepairedLinks     int            `json:"repairedLinks"`
+	ElapsedMs         int64          `json:"elapsedMs"`
+	Error             string         `json:"error,omitempty"`
+}
+
+func newLoadReport(filePath string) *LoadReport {
+	return &LoadReport{
+		FilePath:          filePath,
+		LoadedModules:     []string{},
+		SkippedNodes:      []SkippedNode{},
+		SkippedByReason:   make(map[string]int),
+		SanitizationFixes: []string{},
+		MissingImports:    []string{},
+	}
+}
+
+// recordSkipped registra un nodo escluso nel report in corso, se presente.
+func (p *Parser) recordSkipped(name, module, oid, reason string) {
+	if p.report == nil {
+		return
+	}
+	p.report.SkippedNodes = append(p.report.SkippedNodes, SkippedNode{
+		Name:   name,
+		Module: module,
+		OID:    oid,
+		Reason: reason,
+	})
+	p.report.SkippedByReason[reason]++
+}
+
+// recordFix registra una correzione applicata dalla sanitizzazione nel report in corso, se presente.
+func (p *Parser) recordFix(format string, args ...interface{}) {
+	if p.report == nil {
+		return
+	}
+	p.report.SanitizationFixes = append(p.report.SanitizationFixes, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,38 @@
+package mib
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// GetMetadata legge un valore da app_metadata. Ritorna false se la chiave non esiste.
+func (d *Database) GetMetadata(key string) (string, bool, error) {
+	if d == nil || d.db == nil {
+		return "", false, fmt.Errorf("database not initialized")
+	}
+
+	var value sql.NullString
+	err := d.db.QueryRow(`SELECT value FROM app_metadata WHERE key = ?`, key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read metadata %s: %w", key, err)
+	}
+	return value.String, true, nil
+}
+
+// SetMetadata salva un valore in app_metadata, sostituendo quello esistente.
+func (d *Database) SetMetadata(key, value string) error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	if _, err := d.db.Exec(`
+		INSERT INTO app_metadata (key, value) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value
+	`, key, value); err != nil {
+		return fmt.Errorf("failed to write metadata %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package mib
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// NodeContext raccoglie un nodo insieme al contesto circostante (padre, fratelli, figli e antenati).
+type NodeContext struct {
+	Node         *Node   `json:"node"`
+	Parent       *Node   `json:"parent,omitempty"`
+	Siblings     []*Node `json:"siblings"`
+	Children     []*Node `json:"children"`
+	AncestorPath []*Node `json:"ancestorPath"`
+}
+
+// maxAncestorDepth limita la risalita ricorsiva in presenza di cicli nei dati.
+const maxAncestorDepth = 128
+
+// nodeColumns è la proiezione comune usata dalle query sui nodi con il nome del modulo.
+const nodeColumns = `n.id, n.oid, n.name, n.parent_oid, n.type, n.syntax, n.access, n.status, n.description, m.name`
+
+// GetNodeContext recupera un nodo con padre, fratelli, figli e percorso degli antenati (dalla radice al padre)
+// usando tre query: nodo, antenati (CTE ricorsiva) e figli di padre e nodo insieme.
+func (d *Database) GetNodeContext(oid string) (*NodeContext, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	if strings.TrimSpace(oid) == "" {
+		return nil, fmt.Errorf("oid is empty")
+	}
+	if err := ValidateOID(oid); err != nil {
+		return nil, err
+	}
+
+	node, err := d.findNodeByVariants(nodeOIDVariants(oid))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := &NodeContext{
+		Node:         node,
+		Siblings:     []*Node{},
+		Children:     []*Node{},
+		AncestorPath: []*Node{},
+	}
+
+	if node.ParentOID != "" {
+		rows, err := d.db.Query(`
+			WITH RECURSIVE ancestors(oid, depth) AS (
+				SELECT ?, 1
+				UNION ALL
+				SELECT p.parent_oid, ancestors.depth + 1
+				FROM mib_nodes p
+				JOIN ancestors ON p.oid = ancestors.oid
+				WHERE p.parent_oid IS NOT NULL AND p.parent_oid != '' AND ancestors.depth < ?
+			)
+			SELECT `+nodeColumns+`
+			FROM ancestors
+			JOIN mib_nodes n ON n.oid = ancestors.oid
+			LEFT JOIN mib_modules m ON n.module_id = m.id
+			ORDER BY ancestors.depth DESC
+		`, node.ParentOID, maxAncestorDepth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load ancestors: %w", err)
+		}
+		ancestors, err := scanNodeRows(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ancestors: %w", err)
+		}
+		ctx.AncestorPath = ancestors
+		if len(ancestors) > 0 {
+			ctx.Parent = ancestors[len(ancestors)-1]
+		}
+	}
+
+	rows, err := d.db.Query(`
+		SELECT `+nodeColumns+`
+		FROM mib_nodes n
+		LEFT JOIN mib_modules m ON n.module_id = m.id
+		WHERE n.parent_oid IN (?, ?)
+	`, node.OID, node.ParentOID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load related nodes: %w", err)
+	}
+	related, err := scanNodeRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan related nodes: %w", err)
+	}
+
+	for _, candidate := range related {
+		switch {
+		case candidate.ParentOID == node.OID:
+			ctx.Children = append(ctx.Children, candidate)
+		case node.ParentOID != "" && candidate.OID != node.OID:
+			ctx.Siblings = append(ctx.Siblings, candidate)
+		}
+	}
+	sortTreeNodes(ctx.Children)
+	sortTreeNodes(ctx.Siblings)
+
+	return ctx, nil
+}
+
+// findNodeByVariants cerca un nodo con un'unica query e restituisce la prima variante trovata nell'ordine fornito.
+func (d *Database) findNodeByVariants(variants []string) (*Node, error) {
+	if len(variants) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(variants)), ",")
+	args := make([]interface{}, len(variants))
+	for i, variant := range variants {
+		args[i] = variant
+	}
+
+	rows, err := d.db.Query(`
+		SELECT `+nodeColumns+`
+		FROM mib_nodes n
+		LEFT JOIN mib_modules m ON n.module_id = m.id
+		WHERE n.oid IN (`+placeholders+`)
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := scanNodeRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	byOID := make(map[string]*Node, len(nodes))
+	for _, node := range nodes {
+		byOID[node.OID] = node
+	}
+	for _, variant := range variants {
+		if node, ok := byOID[variant]; ok {
+			return node, nil
+		}
+	}
+
+	return nil, sql.ErrNoRows
+}
+
+// scanNodeRows legge tutte le righe prodotte da una query con proiezione nodeColumns e chiude il cursore.
+func scanNodeRows(rows *sql.Rows) ([]*Node, error) {
+	defer rows.Close()
+
+	var nodes []*Node
+	for rows.Next() {
+		node := &Node{}
+		var parentOID, syntax, access, status, description, moduleName sql.NullString
+
+		err := rows.Scan(
+			&node.ID, &node.OID, &node.Name, &parentOID, &node.Type,
+			&syntax, &access, &status, &description, &moduleName,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if parentOID.Valid {
+			node.ParentOID = parentOID.String
+		}
+		if syntax.Valid {
+			node.Syntax = syntax.String
+		}
+		if access.Valid {
+			node.Access = access.String
+		}
+		if status.Valid {
+			node.Status = status.String
+		}
+		if description.Valid {
+			node.Description = description.String
+		}
+		if moduleName.Valid {
+			node.Module = moduleName.String
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes, rows.Err()
+}
@@ -0,0 +1,58 @@
+package mib
+
+import "testing"
+
+func TestGetNodeContext(t *testing.T) {
+	db := newTestDB(t)
+
+	moduleID, err := db.SaveModule("TEST-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule failed: %v", err)
+	}
+
+	nodes := []*Node{
+		{OID: "1.3.6.1.2.1", Name: "mib-2", Type: "node"},
+		{OID: "1.3.6.1.2.1.1", Name: "system", Type: "node", ParentOID: "1.3.6.1.2.1"},
+		{OID: "1.3.6.1.2.1.2", Name: "interfaces", Type: "node", ParentOID: "1.3.6.1.2.1"},
+		{OID: "1.3.6.1.2.1.1.1", Name: "sysDescr", Type: "scalar", ParentOID: "1.3.6.1.2.1.1"},
+		{OID: "1.3.6.1.2.1.1.5", Name: "sysName", Type: "scalar", ParentOID: "1.3.6.1.2.1.1"},
+		{OID: "1.3.6.1.2.1.1.10", Name: "sysTest", Type: "scalar", ParentOID: "1.3.6.1.2.1.1"},
+	}
+	for _, node := range nodes {
+		if err := db.SaveNode(node, moduleID); err != nil {
+			t.Fatalf("SaveNode %s failed: %v", node.Name, err)
+		}
+	}
+
+	ctx, err := db.GetNodeContext("1.3.6.1.2.1.1")
+	if err != nil {
+		t.Fatalf("GetNodeContext failed: %v", err)
+	}
+	if ctx.Node.Name != "system" || ctx.Parent == nil || ctx.Parent.Name != "mib-2" {
+		t.Fatalf("unexpected node/parent: %+v / %+v", ctx.Node, ctx.Parent)
+	}
+	if len(ctx.Siblings) != 1 || ctx.Siblings[0].Name != "interfaces" {
+		t.Fatalf("unexpected siblings: %v", ctx.Siblings)
+	}
+	if len(ctx.Children) != 3 || ctx.Children[2].Name != "sysTest" {
+		t.Fatalf("expected children ordered numerically, got %v", ctx.Children)
+	}
+	if len(ctx.AncestorPath) != 1 || ctx.AncestorPath[0].Name != "mib-2" {
+		t.Fatalf("unexpected ancestor path: %v", ctx.AncestorPath)
+	}
+
+	leaf, err := db.GetNodeContext(".1.3.6.1.2.1.1.5.0")
+	if err != nil {
+		t.Fatalf("GetNodeContext for instance failed: %v", err)
+	}
+	if leaf.Node.Name != "sysName" || len(leaf.AncestorPath) != 2 || leaf.AncestorPath[0].Name != "mib-2" {
+		t.Fatalf("unexpected leaf context: node=%v path=%v", leaf.Node, leaf.AncestorPath)
+	}
+	if len(leaf.Siblings) != 2 || len(leaf.Children) != 0 {
+		t.Fatalf("unexpected leaf siblings/children: %v / %v", leaf.Siblings, leaf.Children)
+	}
+
+	if _, err := db.GetNodeContext("1.2.3.4"); err == nil {
+		t.Fatalf("expected error for unknown OID")
+	}
+}
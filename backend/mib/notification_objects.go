@@ -0,0 +1,73 @@
+package mib
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// ensureNotificationObjectSchema crea la tabella con gli oggetti (clausola OBJECTS/VARIABLES) delle notifiche.
+func (d *Database) ensureNotificationObjectSchema() error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	if _, err := d.db.Exec(`CREATE TABLE IF NOT EXISTS notification_objects (
+		notification_oid TEXT NOT NULL,
+		object_oid TEXT NOT NULL,
+		position INTEGER NOT NULL,
+		PRIMARY KEY (notification_oid, position),
+		FOREIGN KEY (notification_oid) REFERENCES mib_nodes(oid) ON DELETE CASCADE
+	)`); err != nil {
+		return fmt.Errorf("failed to ensure notification_objects table: %w", err)
+	}
+
+	return nil
+}
+
+// saveNotificationObjects sostituisce, nella transazione indicata, gli oggetti associati a una notifica.
+func saveNotificationObjects(tx *sql.Tx, notificationOID string, objects []string) error {
+	if _, err := tx.Exec(`DELETE FROM notification_objects WHERE notification_oid = ?`, notificationOID); err != nil {
+		return fmt.Errorf("failed to reset notification objects for %s: %w", notificationOID, err)
+	}
+
+	for position, object := range objects {
+		if _, err := tx.Exec(
+			`INSERT INTO notification_objects (notification_oid, object_oid, position) VALUES (?, ?, ?)`,
+			notificationOID, object, position,
+		); err != nil {
+			return fmt.Errorf("failed to save notification object %s for %s: %w", object, notificationOID, err)
+		}
+	}
+
+	return nil
+}
+
+// GetNotificationObjects restituisce, nell'ordine dichiarato nel MIB, gli OID degli oggetti
+// trasportati da una notifica. Ritorna un elenco vuoto se la notifica non ne dichiara.
+func (d *Database) GetNotificationObjects(notificationOID string) ([]string, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	oid := strings.TrimPrefix(strings.TrimSpace(notificationOID), ".")
+	rows, err := d.db.Query(`
+		SELECT object_oid FROM notification_objects
+		WHERE notification_oid = ?
+		ORDER BY position
+	`, oid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	objects := []string{}
+	for rows.Next() {
+		var object string
+		if err := rows.Scan(&object); err != nil {
+			return nil, err
+		}
+		objects = append(objects, object)
+	}
+	return objects, rows.Err()
+}
@@ -0,0 +1,85 @@
+package mib
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxOIDArcs è il numero massimo di componenti ammesso per un OID (RFC 2578).
+const MaxOIDArcs = 128
+
+// ErrInvalidOID è l'errore sentinella restituito (tramite InvalidOIDError) per gli OID malformati.
+var ErrInvalidOID = errors.New("invalid OID")
+
+// InvalidOIDError descrive il motivo per cui un OID è stato rifiutato.
+type InvalidOIDError struct {
+	OID    string
+	Reason string
+}
+
+// Error implementa l'interfaccia error.
+func (e *InvalidOIDError) Error() string {
+	return fmt.Sprintf("invalid OID %q: %s", e.OID, e.Reason)
+}
+
+// Is consente il confronto con errors.Is(err, ErrInvalidOID).
+func (e *InvalidOIDError) Is(target error) bool {
+	return target == ErrInvalidOID
+}
+
+// ValidateOID verifica che l'input sia un OID numerico completo (es. "1.3.6.1" o ".1.3.6.1"):
+// un solo punto iniziale opzionale, componenti decimali non vuote che rientrano in 32 bit.
+func ValidateOID(oid string) error {
+	return validateOIDSyntax(oid, false)
+}
+
+// ValidateOIDPrefix verifica che l'input sia un prefisso numerico parziale, come quelli digitati nella ricerca
+// (es. "1.3.6." oppure ".1.3"). È ammesso il punto finale, non le componenti vuote intermedie.
+func ValidateOIDPrefix(prefix string) error {
+	return validateOIDSyntax(prefix, true)
+}
+
+// IsOIDPrefix indica se l'input può essere interpretato come prefisso di OID.
+func IsOIDPrefix(value string) bool {
+	return ValidateOIDPrefix(value) == nil
+}
+
+func validateOIDSyntax(oid string, allowTrailingDot bool) error {
+	trimmed := strings.TrimSpace(oid)
+	if trimmed == "" {
+		return &InvalidOIDError{OID: oid, Reason: "empty"}
+	}
+
+	body := strings.TrimPrefix(trimmed, ".")
+	if allowTrailingDot {
+		body = strings.TrimSuffix(body, ".")
+	}
+	if body == "" {
+		return &InvalidOIDError{OID: oid, Reason: "no components"}
+	}
+
+	// Il conteggio precede lo split per non allocare migliaia di componenti su input ostili.
+	if strings.Count(body, ".") >= MaxOIDArcs {
+		return &InvalidOIDError{OID: oid, Reason: fmt.Sprintf("more than %d components", MaxOIDArcs)}
+	}
+
+	parts := strings.Split(body, ".")
+
+	for i, part := range parts {
+		if part == "" {
+			return &InvalidOIDError{OID: oid, Reason: fmt.Sprintf("empty component at position %d", i+1)}
+		}
+		for _, r := range part {
+			if r < '0' || r > '9' {
+				return &InvalidOIDError{OID: oid, Reason: fmt.Sprintf("non-numeric component %q", part)}
+			}
+		}
+		if _, err := strconv.ParseUint(part, 10, 32); err != nil {
+			return &InvalidOIDError{OID: oid, Reason: fmt.Sprintf("component %q out of range", part)}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package mib
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateOID(t *testing.T) {
+	valid := []string{"1.3.6.1", ".1.3.6.1.2.1.1.5.0", " 1.3.6 ", "0", "1.3.4294967295"}
+	for _, oid := range valid {
+		if err := ValidateOID(oid); err != nil {
+			t.Errorf("ValidateOID(%q) unexpected error: %v", oid, err)
+		}
+	}
+
+	invalid := []string{"", "..1.3..6", "1.3..6", "1.3.6.", "abc", "1.3.x", "1.3.4294967296", "'; DROP TABLE mib_nodes; --", "1.-3"}
+	for _, oid := range invalid {
+		err := ValidateOID(oid)
+		if err == nil {
+			t.Errorf("ValidateOID(%q) expected error", oid)
+			continue
+		}
+		if !errors.Is(err, ErrInvalidOID) {
+			t.Errorf("ValidateOID(%q) error %v is not ErrInvalidOID", oid, err)
+		}
+	}
+}
+
+func TestValidateOIDPrefix(t *testing.T) {
+	if err := ValidateOIDPrefix("1.3.6."); err != nil {
+		t.Errorf("ValidateOIDPrefix(1.3.6.) unexpected error: %v", err)
+	}
+	if IsOIDPrefix("sysName") || IsOIDPrefix("1..3") {
+		t.Errorf("expected non-numeric and malformed inputs to be rejected")
+	}
+}
+
+func TestValidateOIDArcLimit(t *testing.T) {
+	atLimit := strings.TrimSuffix(strings.Repeat("1.", MaxOIDArcs), ".")
+	if err := ValidateOID(atLimit); err != nil {
+		t.Errorf("ValidateOID with %d arcs unexpected error: %v", MaxOIDArcs, err)
+	}
+
+	for _, arcs := range []int{MaxOIDArcs + 1, 10000} {
+		oid := strings.TrimSuffix(strings.Repeat("1.", arcs), ".")
+		if err := ValidateOID(oid); !errors.Is(err, ErrInvalidOID) {
+			t.Errorf("ValidateOID with %d arcs expected ErrInvalidOID, got %v", arcs, err)
+		}
+	}
+}
+
+func TestGetNodeAncestorsIsBounded(t *testing.T) {
+	db := newTestDB(t)
+
+	moduleID, err := db.SaveModule("DEEP-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule failed: %v", err)
+	}
+
+	// Catena di parent_oid più lunga del limite, costruita con OID brevi e validi.
+	nodes := make([]*Node, 0, maxAncestorDepth+50)
+	for i := 1; i <= maxAncestorDepth+50; i++ {
+		node := &Node{OID: fmt.Sprintf("1.%d", i), Name: fmt.Sprintf("deep%d", i), Type: "node"}
+		if i > 1 {
+			node.ParentOID = fmt.Sprintf("1.%d", i-1)
+		}
+		nodes = append(nodes, node)
+	}
+	if err := db.SaveNodes(nodes, moduleID); err != nil {
+		t.Fatalf("SaveNodes failed: %v", err)
+	}
+
+	ancestors, err := db.GetNodeAncestors(fmt.Sprintf("1.%d", maxAncestorDepth+50))
+	if err != nil {
+		t.Fatalf("GetNodeAncestors failed: %v", err)
+	}
+	if len(ancestors) != maxAncestorDepth {
+		t.Fatalf("expected ancestors to stop at %d, got %d", maxAncestorDepth, len(ancestors))
+	}
+}
+
+func TestGetNodeRejectsMalformedOID(t *testing.T) {
+	db := newTestDB(t)
+
+	if _, err := db.GetNode("..1.3..6"); !errors.Is(err, ErrInvalidOID) {
+		t.Fatalf("GetNode() error = %v, want ErrInvalidOID", err)
+	}
+}
+
+func FuzzValidateOID(f *testing.F) {
+	for _, seed := range []string{"1.3.6.1", ".1.3.6.1.0", "..1.3..6", "", ".", "1.", "abc", "1.3.99999999999999999999", "\x00.1"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		err := ValidateOID(input)
+		if err != nil && !errors.Is(err, ErrInvalidOID) {
+			t.Fatalf("ValidateOID(%q) returned untyped error %v", input, err)
+		}
+		if err == nil && ValidateOIDPrefix(input) != nil {
+			t.Fatalf("valid OID %q rejected as prefix", input)
+		}
+	})
+}
+
+func FuzzNodeOIDVariants(f *testing.F) {
+	for _, seed := range []string{"1.3.6.1.2.1.1.5.0", ".1.3.6.1", "..1.3..6", ".0", "0", " .1.0 "} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		variants := nodeOIDVariants(input)
+		// Ogni input genera al più sei query in GetNode: con/senza punto, con/senza istanza `.0`.
+		if len(variants) > 6 {
+			t.Fatalf("nodeOIDVariants(%q) produced %d variants", input, len(variants))
+		}
+		if ValidateOID(input) != nil {
+			return
+		}
+		for _, variant := range variants {
+			if err := ValidateOID(variant); err != nil {
+				t.Fatalf("variant %q of valid OID %q is invalid: %v", variant, input, err)
+			}
+		}
+	})
+}
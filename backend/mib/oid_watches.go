@@ -0,0 +1,157 @@
+package mib
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OIDWatch è la sorveglianza di un singolo OID su un host. LastValue e LastDisplayValue sono
+// l'ultimo valore osservato, LastCheckedAt è vuoto finché il primo controllo non è avvenuto.
+// Le credenziali non sono duplicate: si usano quelle salvate in host_configs per Host.
+type OIDWatch struct {
+	ID               int64  `json:"id"`
+	Host             string `json:"host"`
+	OID              string `json:"oid"`
+	IntervalSeconds  int    `json:"intervalSeconds"`
+	CompareMode      string `json:"compareMode"`
+	LastValue        string `json:"lastValue"`
+	LastDisplayValue string `json:"lastDisplayValue"`
+	LastCheckedAt    string `json:"lastCheckedAt"`
+	CreatedAt        string `json:"createdAt"`
+}
+
+// ensureOIDWatchSchema crea la tabella delle sorveglianze OID se mancante.
+func (d *Database) ensureOIDWatchSchema() error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	if _, err := d.db.Exec(`CREATE TABLE IF NOT EXISTS oid_watches (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		host TEXT NOT NULL,
+		oid TEXT NOT NULL,
+		interval_seconds INTEGER NOT NULL,
+		compare_mode TEXT NOT NULL,
+		last_value TEXT NOT NULL DEFAULT '',
+		last_display_value TEXT NOT NULL DEFAULT '',
+		last_checked_at TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (host, oid)
+	)`); err != nil {
+		return fmt.Errorf("failed to ensure oid_watches table: %w", err)
+	}
+	return nil
+}
+
+// SaveOIDWatch crea la sorveglianza di watch.OID su watch.Host o, se esiste già, ne aggiorna intervallo
+// e modalità di confronto conservando l'ultimo valore osservato.
+func (d *Database) SaveOIDWatch(watch OIDWatch) (*OIDWatch, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	host := strings.TrimSpace(watch.Host)
+	if host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+	if err := ValidateOID(watch.OID); err != nil {
+		return nil, err
+	}
+	oid := strings.TrimPrefix(strings.TrimSpace(watch.OID), ".")
+	if watch.IntervalSeconds <= 0 {
+		return nil, fmt.Errorf("watch interval must be positive")
+	}
+
+	if _, err := d.db.Exec(`
+		INSERT INTO oid_watches (host, oid, interval_seconds, compare_mode)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(host, oid) DO UPDATE SET
+			interval_seconds = excluded.interval_seconds,
+			compare_mode = excluded.compare_mode
+	`, host, oid, watch.IntervalSeconds, watch.CompareMode); err != nil {
+		return nil, fmt.Errorf("failed to save OID watch: %w", err)
+	}
+
+	saved, err := d.scanOIDWatches(`WHERE host = ? AND oid = ?`, host, oid)
+	if err != nil {
+		return nil, err
+	}
+	if len(saved) == 0 {
+		return nil, fmt.Errorf("failed to load saved OID watch")
+	}
+	return &saved[0], nil
+}
+
+// ListOIDWatches restituisce tutte le sorveglianze ordinate per host e OID.
+func (d *Database) ListOIDWatches() ([]OIDWatch, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	return d.scanOIDWatches(`ORDER BY host COLLATE NOCASE ASC, oid ASC`)
+}
+
+// UpdateOIDWatchValue registra l'ultimo valore osservato da una sorveglianza.
+func (d *Database) UpdateOIDWatchValue(id int64, value, displayValue string, checkedAt time.Time) error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	if _, err := d.db.Exec(`
+		UPDATE oid_watches
+		SET last_value = ?, last_display_value = ?, last_checked_at = ?
+		WHERE id = ?
+	`, value, displayValue, checkedAt.UTC().Format(time.RFC3339), id); err != nil {
+		return fmt.Errorf("failed to update OID watch value: %w", err)
+	}
+	return nil
+}
+
+// DeleteOIDWatch elimina una sorveglianza.
+func (d *Database) DeleteOIDWatch(id int64) error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	result, err := d.db.Exec(`DELETE FROM oid_watches WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete OID watch: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to inspect OID watch deletion: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("OID watch %d not found", id)
+	}
+	return nil
+}
+
+func (d *Database) scanOIDWatches(clause string, args ...interface{}) ([]OIDWatch, error) {
+	rows, err := d.db.Query(`
+		SELECT id, host, oid, interval_seconds, compare_mode, last_value, last_display_value, last_checked_at, created_at
+		FROM oid_watches `+clause, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list OID watches: %w", err)
+	}
+	defer rows.Close()
+
+	watches := []OIDWatch{}
+	for rows.Next() {
+		var watch OIDWatch
+		if err := rows.Scan(
+			&watch.ID, &watch.Host, &watch.OID, &watch.IntervalSeconds, &watch.CompareMode,
+			&watch.LastValue, &watch.LastDisplayValue, &watch.LastCheckedAt, &watch.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan OID watch: %w", err)
+		}
+		if parsed, err := parseTimestamp(watch.CreatedAt); err == nil && parsed != "" {
+			watch.CreatedAt = parsed
+		}
+		watches = append(watches, watch)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed during OID watch iteration: %w", err)
+	}
+	return watches, nil
+}
@@ -0,0 +1,138 @@
+package mib
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// FindOrphanNodes restituisce i nodi il cui parent_oid punta a un nodo inesistente.
+// Nell'albero questi nodi comparirebbero come radici fittizie.
+func (d *Database) FindOrphanNodes() ([]*Node, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	rows, err := d.db.Query(`
+		SELECT ` + nodeColumns + `
+		FROM mib_nodes n
+		LEFT JOIN mib_modules m ON n.module_id = m.id
+		WHERE n.parent_oid IS NOT NULL AND n.parent_oid != ''
+			AND NOT EXISTS (SELECT 1 FROM mib_nodes p WHERE p.oid = n.parent_oid)
+	`)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := scanNodeRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if nodes == nil {
+		nodes = []*Node{}
+	}
+
+	sortTreeNodes(nodes)
+	return nodes, nil
+}
+
+// RepairOrphanNodes ricalcola il parent dei nodi orfani accorciando il loro OID una componente
+// alla volta fino al primo antenato presente; se non ne esiste alcuno il nodo diventa una radice
+// (parent_oid NULL). Sono ricalcolati anche i nodi collegati in precedenza a un antenato lontano o
+// resi radice, così quando il modulo intermedio mancante viene caricato tornano sotto il parent
+// dichiarato. Restituisce il numero di collegamenti modificati.
+func (d *Database) RepairOrphanNodes() (int, error) {
+	if d == nil || d.db == nil {
+		return 0, fmt.Errorf("database not initialized")
+	}
+
+	// Il parent dichiarato di un nodo è il suo OID senza l'ultima componente: i nodi con un
+	// parent_oid diverso sono stati ricollegati da una correzione precedente.
+	rows, err := d.db.Query(`
+		SELECT n.id, n.oid, COALESCE(n.parent_oid, '')
+		FROM mib_nodes n
+		WHERE instr(n.oid, '.') > 0 AND (
+			n.parent_oid IS NULL OR n.parent_oid = ''
+			OR NOT EXISTS (SELECT 1 FROM mib_nodes p WHERE p.oid = n.parent_oid)
+			OR NOT (n.oid LIKE n.parent_oid || '.%' AND instr(substr(n.oid, length(n.parent_oid) + 2), '.') = 0)
+		)
+	`)
+	if err != nil {
+		return 0, err
+	}
+	type candidateNode struct {
+		id        int64
+		oid       string
+		parentOID string
+	}
+	var candidates []candidateNode
+	for rows.Next() {
+		var candidate candidateNode
+		if err := rows.Scan(&candidate.id, &candidate.oid, &candidate.parentOID); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		candidates = append(candidates, candidate)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, err
+	}
+	rows.Close()
+	if len(candidates) == 0 {
+		return 0, nil
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	exists, err := tx.Prepare(`SELECT 1 FROM mib_nodes WHERE oid = ?`)
+	if err != nil {
+		return 0, err
+	}
+	defer exists.Close()
+
+	update, err := tx.Prepare(`UPDATE mib_nodes SET parent_oid = ? WHERE id = ?`)
+	if err != nil {
+		return 0, err
+	}
+	defer update.Close()
+
+	repaired := 0
+	for _, node := range candidates {
+		parent := sql.NullString{}
+		candidate := node.oid
+		for {
+			idx := strings.LastIndex(candidate, ".")
+			if idx <= 0 {
+				break
+			}
+			candidate = candidate[:idx]
+
+			var found int
+			err := exists.QueryRow(candidate).Scan(&found)
+			if err == nil {
+				parent = sql.NullString{String: candidate, Valid: true}
+				break
+			}
+			if err != sql.ErrNoRows {
+				return 0, fmt.Errorf("failed to look up ancestor %s: %w", candidate, err)
+			}
+		}
+
+		if parent.String == node.parentOID {
+			continue
+		}
+		if _, err := update.Exec(parent, node.id); err != nil {
+			return 0, fmt.Errorf("failed to repair parent of %s: %w", node.oid, err)
+		}
+		repaired++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return repaired, nil
+}
@@ -0,0 +1,136 @@
+package mib
+
+import "testing"
+
+func TestFindAndRepairOrphanNodes(t *testing.T) {
+	db := newTestDB(t)
+
+	moduleID, err := db.SaveModule("TEST-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule failed: %v", err)
+	}
+
+	// Albero volutamente rotto: mancano 1.3.6.1.2.1.2 (interfaces) e 1.3.6.1.2.1.2.2.1 (ifEntry),
+	// e un nodo enterprise punta a un parent che non esiste affatto.
+	nodes := []*Node{
+		{OID: "1.3.6.1.2.1", Name: "mib-2", Type: "node"},
+		{OID: "1.3.6.1.2.1.1", Name: "system", ParentOID: "1.3.6.1.2.1", Type: "node"},
+		{OID: "1.3.6.1.2.1.2.2", Name: "ifTable", ParentOID: "1.3.6.1.2.1.2", Type: "table"},
+		{OID: "1.3.6.1.2.1.2.2.1.1", Name: "ifIndex", ParentOID: "1.3.6.1.2.1.2.2.1", Type: "column"},
+		{OID: "1.3.6.1.4.1.9999.1", Name: "vendorRoot", ParentOID: "1.3.6.1.4.1.9999", Type: "node"},
+	}
+	for _, node := range nodes {
+		if err := db.SaveNode(node, moduleID); err != nil {
+			t.Fatalf("SaveNode %s failed: %v", node.Name, err)
+		}
+	}
+
+	orphans, err := db.FindOrphanNodes()
+	if err != nil {
+		t.Fatalf("FindOrphanNodes failed: %v", err)
+	}
+	names := make([]string, len(orphans))
+	for i, orphan := range orphans {
+		names[i] = orphan.Name
+	}
+	if len(names) != 3 || names[0] != "ifTable" || names[1] != "ifIndex" || names[2] != "vendorRoot" {
+		t.Fatalf("unexpected orphans: %v", names)
+	}
+
+	repaired, err := db.RepairOrphanNodes()
+	if err != nil {
+		t.Fatalf("RepairOrphanNodes failed: %v", err)
+	}
+	if repaired != 3 {
+		t.Fatalf("expected 3 repaired links, got %d", repaired)
+	}
+
+	expectedParents := map[string]string{
+		"1.3.6.1.2.1.2.2":     "1.3.6.1.2.1",
+		"1.3.6.1.2.1.2.2.1.1": "1.3.6.1.2.1.2.2",
+		"1.3.6.1.4.1.9999.1":  "",
+	}
+	for oid, want := range expectedParents {
+		node, err := db.GetNode(oid)
+		if err != nil {
+			t.Fatalf("GetNode %s failed: %v", oid, err)
+		}
+		if node.ParentOID != want {
+			t.Fatalf("parent of %s = %q, want %q", oid, node.ParentOID, want)
+		}
+	}
+
+	remaining, err := db.FindOrphanNodes()
+	if err != nil {
+		t.Fatalf("FindOrphanNodes failed: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Fatalf("expected no orphans after repair, got %d", len(remaining))
+	}
+
+	tree, err := db.GetTree()
+	if err != nil {
+		t.Fatalf("GetTree failed: %v", err)
+	}
+	if len(tree) != 2 {
+		t.Fatalf("expected mib-2 and vendorRoot as the only roots, got %d", len(tree))
+	}
+
+	again, err := db.RepairOrphanNodes()
+	if err != nil || again != 0 {
+		t.Fatalf("expected repair to be idempotent, got %d (err=%v)", again, err)
+	}
+}
+
+func TestRepairOrphanNodesRestoresDeclaredParent(t *testing.T) {
+	db := newTestDB(t)
+
+	moduleID, err := db.SaveModule("TEST-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule failed: %v", err)
+	}
+	save := func(nodes ...*Node) {
+		t.Helper()
+		for _, node := range nodes {
+			if err := db.SaveNode(node, moduleID); err != nil {
+				t.Fatalf("SaveNode %s failed: %v", node.Name, err)
+			}
+		}
+	}
+
+	save(
+		&Node{OID: "1.3.6.1.2.1", Name: "mib-2", Type: "node"},
+		&Node{OID: "1.3.6.1.2.1.2.2", Name: "ifTable", ParentOID: "1.3.6.1.2.1.2", Type: "table"},
+		&Node{OID: "1.3.6.1.2.1.2.2.1.1", Name: "ifIndex", ParentOID: "1.3.6.1.2.1.2.2.1", Type: "column"},
+	)
+	if _, err := db.RepairOrphanNodes(); err != nil {
+		t.Fatalf("RepairOrphanNodes failed: %v", err)
+	}
+
+	// Il modulo con i nodi intermedi arriva dopo: i collegamenti corretti prima vanno ricalcolati.
+	save(
+		&Node{OID: "1.3.6.1.2.1.2", Name: "interfaces", ParentOID: "1.3.6.1.2.1", Type: "node"},
+		&Node{OID: "1.3.6.1.2.1.2.2.1", Name: "ifEntry", ParentOID: "1.3.6.1.2.1.2.2", Type: "row"},
+	)
+	repaired, err := db.RepairOrphanNodes()
+	if err != nil {
+		t.Fatalf("RepairOrphanNodes failed: %v", err)
+	}
+	if repaired != 2 {
+		t.Fatalf("expected 2 restored links, got %d", repaired)
+	}
+
+	expectedParents := map[string]string{
+		"1.3.6.1.2.1.2.2":     "1.3.6.1.2.1.2",
+		"1.3.6.1.2.1.2.2.1.1": "1.3.6.1.2.1.2.2.1",
+	}
+	for oid, want := range expectedParents {
+		node, err := db.GetNode(oid)
+		if err != nil {
+			t.Fatalf("GetNode %s failed: %v", oid, err)
+		}
+		if node.ParentOID != want {
+			t.Fatalf("parent of %s = %q, want %q", oid, node.ParentOID, want)
+		}
+	}
+}
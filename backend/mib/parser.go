@@ -2,7 +2,6 @@ package mib
 
 import (
 	"bufio"
-	"bytes"
 	"embed"
 	"fmt"
 	"io/fs"
@@ -14,8 +13,10 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/sleepinggenius2/gosmi"
+	"github.com/sleepinggenius2/gosmi/smi"
 	"github.com/sleepinggenius2/gosmi/types"
 )
 
@@ -24,11 +25,25 @@ type Parser struct {
 	db      *Database
 	debug   bool
 	logger  *log.Logger
+	report  *LoadReport
+
+	repairOrphans bool
 }
 
 var (
-	initOnce sync.Once
-	initErr  error
+	// initMu protegge lo stato di inizializzazione di gosmi. Un tentativo fallito non viene
+	// memorizzato, così il caricamento successivo (o RetryGosmiInit) può riprovare.
+	initMu      sync.Mutex
+	initDone    bool
+	gosmiLoaded bool
+	searchPaths = make(map[string]bool)
+	// platformPaths e customPaths sono l'elenco canonico del search path: gosmi non permette di
+	// rimuovere un singolo percorso, quindi per toglierne uno il path viene ricostruito da queste liste.
+	platformPaths []string
+	customPaths   []string
+
+	// extractStandardMibs è sostituibile nei test per simulare errori di estrazione.
+	extractStandardMibs = extractEmbeddedMibs
 )
 
 //go:embed standard/*
@@ -48,6 +63,11 @@ func (p *Parser) SetDebug(enabled bool) {
 	p.debug = enabled
 }
 
+// SetRepairOrphans abilita la correzione dei parent_oid orfani al termine di ogni caricamento
+func (p *Parser) SetRepairOrphans(enabled bool) {
+	p.repairOrphans = enabled
+}
+
 func (p *Parser) debugLog(format string, args ...interface{}) {
 	if p.debug && p.logger != nil {
 		p.logger.Printf(format, args...)
@@ -66,38 +86,96 @@ func (p *Parser) warnLog(format string, args ...interface{}) {
 	}
 }
 
+// ensureGosmiInit inizializza gosmi alla prima chiamata riuscita; dopo un errore ritenta alla chiamata successiva.
 func ensureGosmiInit(appDataDir string) error {
-	initOnce.Do(func() {
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	if initDone {
+		return nil
+	}
+	return initGosmiLocked(appDataDir)
+}
+
+// RetryGosmiInit ripete l'estrazione dei MIB standard e la configurazione dei path di ricerca,
+// anche se un tentativo precedente è già riuscito. Utile dopo aver corretto i permessi della cartella dati.
+func RetryGosmiInit(appDataDir string) error {
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	initDone = false
+	return initGosmiLocked(appDataDir)
+}
+
+// initGosmiLocked esegue l'inizializzazione; richiede initMu acquisito.
+func initGosmiLocked(appDataDir string) error {
+	if !gosmiLoaded {
 		log.Printf("[MIB-PARSER] Initializing gosmi library...")
 		gosmi.Init()
+		gosmiLoaded = true
+	}
 
-		// Percorso dove estrarremo i MIB standard
-		embeddedMibsPath := filepath.Join(appDataDir, "mibs", "standard")
-		log.Printf("[MIB-PARSER] Standard MIBs will be extracted to: %s", embeddedMibsPath)
+	// Percorso dove estrarremo i MIB standard
+	embeddedMibsPath := filepath.Join(appDataDir, "mibs", "standard")
+	log.Printf("[MIB-PARSER] Standard MIBs will be extracted to: %s", embeddedMibsPath)
 
-		// Estrai i MIB standard se non esistono
-		if err := extractEmbeddedMibs(embeddedMibsPath); err != nil {
-			initErr = fmt.Errorf("failed to extract standard MIBs: %w", err)
-			log.Printf("[MIB-PARSER] ERROR: %v", initErr)
-			return
-		}
+	// Estrai i MIB standard se non esistono
+	if err := extractStandardMibs(embeddedMibsPath); err != nil {
+		err = fmt.Errorf("failed to extract standard MIBs: %w", err)
+		log.Printf("[MIB-PARSER] ERROR: %v", err)
+		return err
+	}
 
-		// Aggiungi directory MIB standard e di sistema al search path (cross-platform)
-		standardPaths := getPlatformMIBPaths(embeddedMibsPath)
+	// Aggiungi directory MIB standard e di sistema al search path (cross-platform)
+	platformPaths = getPlatformMIBPaths(embeddedMibsPath)
+	standardPaths := append(append([]string{}, platformPaths...), customPaths...)
 
-		log.Printf("[MIB-PARSER] Adding %d MIB search paths:", len(standardPaths))
-		for i, path := range standardPaths {
-			if stat, err := os.Stat(path); err == nil && stat.IsDir() {
-				gosmi.AppendPath(path)
-				log.Printf("[MIB-PARSER]   [%d] %s (exists)", i+1, path)
-			} else {
-				log.Printf("[MIB-PARSER]   [%d] %s (skipped: %v)", i+1, path, err)
-			}
+	log.Printf("[MIB-PARSER] Adding %d MIB search paths:", len(standardPaths))
+	for i, path := range standardPaths {
+		if searchPaths[path] {
+			log.Printf("[MIB-PARSER]   [%d] %s (already added)", i+1, path)
+			continue
+		}
+		if stat, err := os.Stat(path); err == nil && stat.IsDir() {
+			gosmi.AppendPath(path)
+			searchPaths[path] = true
+			log.Printf("[MIB-PARSER]   [%d] %s (exists)", i+1, path)
+		} else {
+			log.Printf("[MIB-PARSER]   [%d] %s (skipped: %v)", i+1, path, err)
 		}
+	}
 
-		log.Printf("[MIB-PARSER] Gosmi initialized successfully")
-	})
-	return initErr
+	initDone = true
+	log.Printf("[MIB-PARSER] Gosmi initialized successfully")
+	return nil
+}
+
+// SetCustomSearchPaths imposta le directory MIB aggiuntive scelte dall'utente, cercate dopo quelle
+// di piattaforma. Se gosmi è già inizializzato il search path viene ricostruito subito: una directory
+// rimossa non è più usata dai caricamenti successivi (le directory dei file caricati, aggiunte da
+// LoadMIBFile, vengono riaggiunte al caricamento seguente).
+func SetCustomSearchPaths(paths []string) {
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	customPaths = append([]string{}, paths...)
+	if !initDone {
+		return
+	}
+
+	searchPaths = make(map[string]bool)
+	active := []string{}
+	for _, path := range append(append([]string{}, platformPaths...), customPaths...) {
+		if searchPaths[path] {
+			continue
+		}
+		if stat, err := os.Stat(path); err == nil && stat.IsDir() {
+			active = append(active, path)
+			searchPaths[path] = true
+		}
+	}
+	gosmi.SetPath(strings.Join(active, string(os.PathListSeparator)))
+	log.Printf("[MIB-PARSER] Search path rebuilt with %d directories (%d custom)", len(active), len(customPaths))
 }
 
 // getPlatformMIBPaths restituisce i percorsi di ricerca MIB specifici per la piattaforma
@@ -273,6 +351,16 @@ func (p *Parser) PreloadStandardMIBs(appDataDir string) error {
 		if err := p.db.UpdateModuleMetadata(module.Name, skippedCount, nil); err != nil {
 			p.warnLog("Failed to update metadata for module %s: %v", module.Name, err)
 		}
+		if revision := latestModuleRevision(module); revision != "" {
+			if err := p.db.UpdateModuleRevision(module.Name, revision); err != nil {
+				p.warnLog("Failed to update revision for module %s: %v", module.Name, err)
+			}
+		}
+		if identityOID := moduleIdentityOID(module); identityOID != "" {
+			if err := p.db.UpdateModuleIdentity(module.Name, identityOID); err != nil {
+				p.warnLog("Failed to update identity for module %s: %v", module.Name, err)
+			}
+		}
 
 		p.debugLog("  Saved module %s to database (%d nodes, %d skipped)", module.Name, len(nodes), skippedCount)
 		savedCount++
@@ -402,7 +490,26 @@ func extractEmbeddedMibs(destPath string) error {
 
 // LoadMIBFile carica e parsifica un file MIB partendo dal path locale.
 // Ricava il nome modulo dal filename e lo carica tramite gosmi.
-func (p *Parser) LoadMIBFile(filePath string, appDataDir string) (string, error) {
+// Restituisce sempre un LoadReport (anche in caso di errore) con nodi, esclusioni e correzioni applicate.
+func (p *Parser) LoadMIBFile(filePath string, appDataDir string) (report *LoadReport, err error) {
+	report = newLoadReport(filePath)
+	p.report = report
+	start := time.Now()
+	defer func() {
+		p.report = nil
+		report.ElapsedMs = time.Since(start).Milliseconds()
+		if err != nil {
+			report.Error = err.Error()
+		}
+	}()
+
+	loadedName, err := p.loadMIBFile(filePath, appDataDir)
+	report.ModuleName = loadedName
+	return report, err
+}
+
+// loadMIBFile esegue il caricamento vero e proprio, registrando i dettagli nel report in corso.
+func (p *Parser) loadMIBFile(filePath string, appDataDir string) (string, error) {
 	p.debugLog("=== LoadMIBFile START ===")
 	p.debugLog("File path: %s", filePath)
 	p.debugLog("App data dir: %s", appDataDir)
@@ -469,6 +576,9 @@ func (p *Parser) LoadMIBFile(filePath string, appDataDir string) (string, error)
 		return "", fmt.Errorf("failed to get module object %q: %v", loadedName, err)
 	}
 	p.debugLog("Module object retrieved: %s (organization: %s)", gosmiModule.Name, gosmiModule.Organization)
+	if p.report != nil {
+		p.report.IdentityOID = moduleIdentityOID(gosmiModule)
+	}
 
 	// Salva modulo nel DB
 	p.debugLog("Saving module to database...")
@@ -512,6 +622,9 @@ func (p *Parser) LoadMIBFile(filePath string, appDataDir string) (string, error)
 	if len(missingImports) > 0 {
 		p.warnLog("Module has %d missing dependencies: %v", len(missingImports), missingImports)
 	}
+	if p.report != nil {
+		p.report.MissingImports = missingImports
+	}
 
 	// Parsifica e salva i nodi di TUTTI i moduli caricati (incluse dipendenze)
 	p.debugLog("Parsing all loaded modules...")
@@ -521,6 +634,12 @@ func (p *Parser) LoadMIBFile(filePath string, appDataDir string) (string, error)
 		return "", fmt.Errorf("failed to parse modules: %v", err)
 	}
 	p.debugLog("Parsed %d nodes, skipped %d nodes with unresolved OIDs", len(nodes), skippedCount)
+	if p.report != nil {
+		p.report.TotalNodes = len(nodes)
+		for _, mod := range gosmi.GetLoadedModules() {
+			p.report.LoadedModules = append(p.report.LoadedModules, mod.Name)
+		}
+	}
 
 	// Conta nodi con OID vuoti (dipendenze mancanti)
 	emptyOidCount := 0
@@ -544,6 +663,18 @@ func (p *Parser) LoadMIBFile(filePath string, appDataDir string) (string, error)
 	}
 	p.debugLog("Nodes saved successfully")
 
+	if p.repairOrphans {
+		repaired, err := p.db.RepairOrphanNodes()
+		if err != nil {
+			p.warnLog("Failed to repair orphan nodes: %v", err)
+		} else {
+			p.debugLog("Repaired %d orphan parent links", repaired)
+			if p.report != nil {
+				p.report.RepairedLinks = repaired
+			}
+		}
+	}
+
 	// Calcola statistiche per modulo e aggiorna il database
 	statsByModule := make(map[string]ModuleStats)
 	statsByModule[loadedName] = ModuleStats{}
@@ -566,10 +697,27 @@ func (p *Parser) LoadMIBFile(filePath string, appDataDir string) (string, error)
 		statsByModule[moduleName] = stats
 	}
 
+	var typeDefs []TypeDefinition
 	for _, module := range gosmi.GetLoadedModules() {
 		stats := statsByModule[module.Name]
 		stats.TypeCount = len(module.GetTypes())
 		statsByModule[module.Name] = stats
+		typeDefs = append(typeDefs, moduleTypeDefinitions(module)...)
+		if revision := latestModuleRevision(module); revision != "" {
+			if err := p.db.UpdateModuleRevision(module.Name, revision); err != nil {
+				p.warnLog("Failed to update revision for module %s: %v", module.Name, err)
+			}
+		}
+		if identityOID := moduleIdentityOID(module); identityOID != "" {
+			if err := p.db.UpdateModuleIdentity(module.Name, identityOID); err != nil {
+				p.warnLog("Failed to update identity for module %s: %v", module.Name, err)
+			}
+		}
+	}
+
+	// Aggiorna il catalogo dei tipi usato per risalire le textual convention
+	if err := p.db.SaveTypeDefinitions(typeDefs); err != nil {
+		p.warnLog("Failed to save type definitions: %v", err)
 	}
 
 	for moduleName, stats := range statsByModule {
@@ -587,6 +735,30 @@ func (p *Parser) LoadMIBFile(filePath string, appDataDir string) (string, error)
 	return loadedName, nil
 }
 
+// latestModuleRevision restituisce la data (YYYY-MM-DD) della clausola REVISION più recente del modulo.
+func latestModuleRevision(module gosmi.SmiModule) string {
+	var latest time.Time
+	for _, revision := range module.GetRevisions() {
+		if revision.Date.After(latest) {
+			latest = revision.Date
+		}
+	}
+	if latest.IsZero() {
+		return ""
+	}
+	return latest.Format("2006-01-02")
+}
+
+// moduleIdentityOID restituisce l'OID della MODULE-IDENTITY del modulo, o una stringa vuota per i
+// moduli SMIv1 che non la dichiarano.
+func moduleIdentityOID(module gosmi.SmiModule) string {
+	identity, ok := module.GetIdentityNode()
+	if !ok {
+		return ""
+	}
+	return strings.TrimPrefix(identity.RenderNumeric(), ".")
+}
+
 // parseModuleNodes parsifica i nodi di un singolo modulo
 func (p *Parser) parseModuleNodes(module gosmi.SmiModule) (nodes []*Node, skippedCount int) {
 	var moduleNodes []*Node
@@ -650,6 +822,7 @@ func (p *Parser) parseAllLoadedModules() (nodes []*Node, skippedCount int, err e
 					skippedCount++
 					moduleSkipCount++
 					p.debugLog("      Skipped node %s (empty OID)", smiNode.Name)
+					p.recordSkipped(smiNode.Name, module.Name, "", SkipReasonUnresolvedOID)
 				}
 				continue
 			}
@@ -661,7 +834,10 @@ func (p *Parser) parseAllLoadedModules() (nodes []*Node, skippedCount int, err e
 					moduleNodeCount++
 				} else {
 					p.warnLog("      Failed to convert node %s (OID: %s)", smiNode.Name, oidStr)
+					p.recordSkipped(smiNode.Name, module.Name, oidStr, SkipReasonConversionFailure)
 				}
+			} else {
+				p.recordSkipped(smiNode.Name, module.Name, oidStr, SkipReasonDuplicateOID)
 			}
 		}
 		p.debugLog("    Processed %d nodes from %s (%d skipped)", moduleNodeCount, module.Name, moduleSkipCount)
@@ -710,16 +886,94 @@ func (p *Parser) convertNode(smiNode gosmi.SmiNode) *Node {
 	}
 
 	return &Node{
-		OID:         oidNum,
-		Name:        smiNode.Name,
-		ParentOID:   parentOID,
-		Type:        nodeType,
-		Syntax:      getSyntax(smiNode),
-		Access:      getAccess(smiNode),
-		Status:      getStatus(smiNode),
-		Description: cleanDescription(smiNode.Description),
-		Module:      moduleName,
+		OID:                 oidNum,
+		Name:                smiNode.Name,
+		ParentOID:           parentOID,
+		Type:                nodeType,
+		Syntax:              getSyntax(smiNode),
+		Access:              getAccess(smiNode),
+		Status:              getStatus(smiNode),
+		Description:         cleanDescription(smiNode.Description),
+		RawDescription:      rawDescription(smiNode.Description),
+		Module:              moduleName,
+		NotificationObjects: getNotificationObjects(smiNode),
+		Index:               getRowIndex(smiNode),
+	}
+}
+
+// getRowIndex restituisce i componenti della clausola INDEX di una riga; per le righe definite con
+// AUGMENTS è l'indice della riga estesa. Ritorna nil se un componente non ha un tipo noto.
+func getRowIndex(smiNode gosmi.SmiNode) []IndexComponent {
+	if smiNode.Kind != types.NodeRow {
+		return nil
+	}
+
+	implied := smiNode.GetImplied()
+	if augmented := smiNode.GetAugment(); augmented.Name != "" {
+		implied = augmented.GetImplied()
+	}
+
+	columns := smiNode.GetIndex()
+	index := make([]IndexComponent, 0, len(columns))
+	for i, column := range columns {
+		if column.Type == nil {
+			return nil
+		}
+		component := IndexComponent{
+			OID:     column.RenderNumeric(),
+			Name:    column.Name,
+			Syntax:  getSyntax(column),
+			Kind:    IndexKindInteger,
+			Implied: implied && i == len(columns)-1,
+		}
+		switch column.Type.BaseType {
+		case types.BaseTypeOctetString, types.BaseTypeBits:
+			component.Kind = IndexKindString
+			component.FixedLength = fixedTypeSize(column.SmiType)
+		case types.BaseTypeObjectIdentifier:
+			component.Kind = IndexKindOID
+		}
+		index = append(index, component)
+	}
+	return index
+}
+
+// fixedTypeSize restituisce la dimensione di un tipo con un unico vincolo SIZE (n), risalendo ai tipi
+// padre se il tipo non ne dichiara; 0 per le dimensioni variabili.
+func fixedTypeSize(t *gosmi.SmiType) int {
+	if t == nil {
+		return 0
+	}
+	for raw := t.GetRaw(); raw != nil; raw = smi.GetParentType(raw) {
+		ranges := gosmi.CreateType(raw).Ranges
+		if len(ranges) == 0 {
+			continue
+		}
+		if len(ranges) != 1 || fmt.Sprint(ranges[0].MinValue) != fmt.Sprint(ranges[0].MaxValue) {
+			return 0
+		}
+		var size int
+		if _, err := fmt.Sscan(fmt.Sprint(ranges[0].MinValue), &size); err != nil {
+			return 0
+		}
+		return size
+	}
+	return 0
+}
+
+// getNotificationObjects restituisce gli OID degli oggetti dichiarati da una notifica (clausola OBJECTS)
+func getNotificationObjects(smiNode gosmi.SmiNode) []string {
+	if smiNode.Kind != types.NodeNotification {
+		return nil
+	}
+
+	var objects []string
+	for _, object := range smiNode.GetNotificationObjects() {
+		if oid := object.RenderNumeric(); oid != "" {
+			objects = append(objects, oid)
+		}
 	}
+	return objects
 }
 
 // getNodeType determina il tipo di nodo
@@ -831,6 +1085,16 @@ func cleanDescription(desc string) string {
 	return strings.Join(cleaned, "\n")
 }
 
+// rawDescription restituisce la descrizione originale, con spaziatura e paragrafi, solo quando
+// differisce da quella ripulita: in caso contrario non serve conservarne una seconda copia.
+func rawDescription(desc string) string {
+	raw := strings.Trim(desc, "\r\n")
+	if strings.TrimSpace(raw) == "" || raw == cleanDescription(desc) {
+		return ""
+	}
+	return raw
+}
+
 // LoadStandardMIBs carica i MIB standard comuni passando i **nomi** modulo.
 // Aggiunge anche la cartella ai path di gosmi, così le dipendenze vengono risolte.
 func (p *Parser) LoadStandardMIBs(appDataDir string, mibsDir string) error {
@@ -1152,82 +1416,18 @@ func (p *Parser) ensureSanitizedCopy(originalPath string, appDataDir string) (st
 	}
 	p.debugLog("  File size: %d bytes", len(data))
 
-	// Normalizza line endings (Windows -> Unix)
-	normalized := reCRLF.ReplaceAll(data, []byte("\n"))
-	normalizeCount := (len(data) - len(normalized))
-	if normalizeCount > 0 {
-		p.debugLog("  Normalized %d CRLF sequences to LF", normalizeCount)
-	}
-
-	// Fix specifico per RFC1212-MIB che ha IndexSyntax DOPO il macro END
-	// Questo è un bug noto nel file RFC1212
-	beforeFix := normalized
-	normalized = fixRFC1212Structure(normalized)
-	if !bytes.Equal(beforeFix, normalized) {
-		p.debugLog("  Applied RFC1212 structure fix (moved IndexSyntax before END)")
-	}
-
-	// Applica tutte le sanitizzazioni comuni basate su Net-SNMP rfcmibs.diff
-	sanitized := normalized
-	fixesApplied := 0
-
-	// 1. Fix INTEGER overflow: INTEGER(1..2147483648) -> INTEGER(1..2147483647)
-	if matches := reIntegerOverflow.FindAll(sanitized, -1); len(matches) > 0 {
-		sanitized = reIntegerOverflow.ReplaceAll(sanitized, []byte("INTEGER ($1..2147483647)"))
-		fixesApplied += len(matches)
-		p.debugLog("  Fixed %d INTEGER range overflow(s) (2147483648 -> 2147483647)", len(matches))
-	}
-
-	// 2. Fix lowercase 'size' -> 'SIZE'
-	if matches := reLowercaseSize.FindAll(sanitized, -1); len(matches) > 0 {
-		sanitized = reLowercaseSize.ReplaceAll(sanitized, []byte("(SIZE ("))
-		fixesApplied += len(matches)
-		p.debugLog("  Fixed %d lowercase 'size' keyword(s) -> 'SIZE'", len(matches))
-	}
-
-	// 3. Fix hex literals with leading zeros: '07fffffff'h -> '7fffffff'h
-	if matches := reHexLeadingZero.FindAll(sanitized, -1); len(matches) > 0 {
-		sanitized = reHexLeadingZero.ReplaceAll(sanitized, []byte("'$1'h"))
-		fixesApplied += len(matches)
-		p.debugLog("  Fixed %d hex literal(s) with leading zero", len(matches))
-	}
-
-	// 4. Fix LAST-UPDATED timestamp: "YYYYMMDDHHmmssZ" -> "YYYYMMDDHHmmZ"
-	if matches := reLastUpdatedLong.FindAll(sanitized, -1); len(matches) > 0 {
-		sanitized = reLastUpdatedLong.ReplaceAll(sanitized, []byte(`LAST-UPDATED "$1$2"`))
-		fixesApplied += len(matches)
-		p.debugLog("  Fixed %d LAST-UPDATED timestamp(s) (removed seconds)", len(matches))
-	}
-
-	// 5. Sostituisci "..MAX" con un valore numerico valido
-	// Gestisce sia "..MAX" che "N..MAX" (es: "0..MAX", "1..MAX")
-	maxPatternCount := 0
-	sanitized = reDoubleDotMax.ReplaceAllFunc(sanitized, func(match []byte) []byte {
-		matchStr := string(match)
-		maxPatternCount++
-		// Estrai il numero iniziale se presente (es: "0" in "0..MAX")
-		if idx := strings.Index(matchStr, ".."); idx > 0 {
-			prefix := matchStr[:idx]
-			return []byte(prefix + "..2147483647")
-		}
-		// Se non c'è numero, sostituisci solo MAX
-		return bytes.Replace(match, []byte("MAX"), []byte("2147483647"), 1)
-	})
-
-	if maxPatternCount > 0 {
-		fixesApplied += maxPatternCount
-		p.debugLog("  Replaced %d '..MAX' pattern(s) with numeric value", maxPatternCount)
+	sanitized, changes, err := p.SanitizeMIBContent(data)
+	if err != nil {
+		return "", err
 	}
 
-	// Log riepilogo
-	totalChanges := normalizeCount + fixesApplied
-	if totalChanges == 0 {
+	if len(changes) == 0 {
 		p.debugLog("  No sanitization needed (file is clean)")
-	} else {
-		p.debugLog("  File sanitized: %d total fix(es) applied", fixesApplied)
-		if normalizeCount > 0 {
-			p.debugLog("    - %d line ending normalization(s)", normalizeCount)
-		}
+	}
+	for _, change := range changes {
+		message := sanitizationFixMessage(change)
+		p.debugLog("  %s", message)
+		p.recordFix("%s", message)
 	}
 
 	sanitizedDir := filepath.Join(appDataDir, "mibs", "sanitized")
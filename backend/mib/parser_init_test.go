@@ -0,0 +1,87 @@
+package mib
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sleepinggenius2/gosmi"
+)
+
+func TestEnsureGosmiInitRetriesAfterFailure(t *testing.T) {
+	initMu.Lock()
+	prevDone, prevExtract := initDone, extractStandardMibs
+	initDone = false
+	initMu.Unlock()
+	t.Cleanup(func() {
+		initMu.Lock()
+		initDone, extractStandardMibs = prevDone, prevExtract
+		initMu.Unlock()
+	})
+
+	calls := 0
+	extractStandardMibs = func(destPath string) error {
+		calls++
+		if calls == 1 {
+			return errors.New("permission denied")
+		}
+		return nil
+	}
+
+	dataDir := t.TempDir()
+	if err := ensureGosmiInit(dataDir); err == nil {
+		t.Fatalf("expected first initialization to fail")
+	}
+	if err := ensureGosmiInit(dataDir); err != nil {
+		t.Fatalf("expected second initialization to succeed, got %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("extraction calls = %d, want 2", calls)
+	}
+
+	// Una volta riuscita, l'inizializzazione non viene ripetuta...
+	if err := ensureGosmiInit(dataDir); err != nil {
+		t.Fatalf("ensureGosmiInit() error = %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("extraction calls after success = %d, want 2", calls)
+	}
+
+	// ...a meno di un nuovo tentativo esplicito.
+	if err := RetryGosmiInit(dataDir); err != nil {
+		t.Fatalf("RetryGosmiInit() error = %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("extraction calls after retry = %d, want 3", calls)
+	}
+}
+
+func TestCustomSearchPathsRebuildGosmiPath(t *testing.T) {
+	initMu.Lock()
+	prevDone, prevExtract, prevCustom := initDone, extractStandardMibs, customPaths
+	initDone = false
+	initMu.Unlock()
+	t.Cleanup(func() {
+		SetCustomSearchPaths(prevCustom)
+		initMu.Lock()
+		initDone, extractStandardMibs = prevDone, prevExtract
+		initMu.Unlock()
+	})
+	// La directory dei MIB standard deve esistere: gosmi ignora un search path vuoto.
+	extractStandardMibs = func(destPath string) error { return os.MkdirAll(destPath, 0o755) }
+
+	custom := t.TempDir()
+	SetCustomSearchPaths([]string{custom})
+	if err := ensureGosmiInit(t.TempDir()); err != nil {
+		t.Fatalf("ensureGosmiInit() error = %v", err)
+	}
+	if !strings.Contains(gosmi.GetPath(), custom) {
+		t.Fatalf("expected %s in search path %q", custom, gosmi.GetPath())
+	}
+
+	SetCustomSearchPaths(nil)
+	if strings.Contains(gosmi.GetPath(), custom) {
+		t.Fatalf("expected %s to be removed from search path %q", custom, gosmi.GetPath())
+	}
+}
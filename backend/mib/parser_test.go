@@ -0,0 +1,125 @@
+package mib
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// rfc1212Fixture riproduce la struttura di RFC1212-MIB: la definizione di IndexSyntax si trova dopo
+// l'END della macro OBJECT-TYPE, dove libsmi non la accetta.
+const rfc1212Fixture = `RFC1212-MIB DEFINITIONS ::= BEGIN
+
+    IMPORTS
+        ObjectName
+            FROM RFC1155-SMI
+        DisplayString
+            FROM RFC1158-MIB;
+
+    OBJECT-TYPE MACRO ::=
+    BEGIN
+        TYPE NOTATION ::=
+                          "SYNTAX" type(TYPE ObjectSyntax)
+                          "ACCESS" Access
+                          "STATUS" Status
+                          DescrPart
+                          ReferPart
+                          IndexPart
+                          DefValPart
+        VALUE NOTATION ::= value (VALUE ObjectName)
+
+        Access ::= "read-only"
+                        | "read-write"
+                        | "write-only"
+                        | "not-accessible"
+        Status ::= "mandatory"
+                        | "optional"
+                        | "obsolete"
+                        | "deprecated"
+    END
+
+        IndexSyntax ::=
+            CHOICE {
+                number
+                    INTEGER (0..MAX),
+                string
+                    OCTET STRING,
+                object
+                    OBJECT IDENTIFIER,
+                address
+                    NetworkAddress,
+                ipAddress
+                    IpAddress
+            }
+
+END
+`
+
+const rfc1212IndexSyntax = `        IndexSyntax ::=
+            CHOICE {
+                number
+                    INTEGER (0..MAX),
+                string
+                    OCTET STRING,
+                object
+                    OBJECT IDENTIFIER,
+                address
+                    NetworkAddress,
+                ipAddress
+                    IpAddress
+            }`
+
+func TestFixRFC1212Structure(t *testing.T) {
+	fixed := fixRFC1212Structure([]byte(rfc1212Fixture))
+	content := string(fixed)
+
+	// (1) IndexSyntax precede l'END della macro
+	indexPos := strings.Index(content, "IndexSyntax ::=")
+	macroEndPos := strings.Index(content, "\n    END\n")
+	if indexPos < 0 || macroEndPos < 0 {
+		t.Fatalf("expected both IndexSyntax and END in output:\n%s", content)
+	}
+	if indexPos > macroEndPos {
+		t.Fatalf("expected IndexSyntax before END, got:\n%s", content)
+	}
+
+	// (2) il blocco IndexSyntax è conservato identico
+	if !strings.Contains(content, rfc1212IndexSyntax) {
+		t.Fatalf("IndexSyntax block not preserved:\n%s", content)
+	}
+	if strings.Count(content, "IndexSyntax ::=") != 1 {
+		t.Fatalf("expected IndexSyntax exactly once, got:\n%s", content)
+	}
+
+	// (3) un modulo senza il difetto resta invariato
+	clean := []byte(strings.Replace(rfc1212Fixture, rfc1212IndexSyntax+"\n\n", "", 1))
+	if strings.Contains(string(clean), "IndexSyntax") {
+		t.Fatalf("fixture without IndexSyntax still contains it")
+	}
+	if got := fixRFC1212Structure(clean); !bytes.Equal(got, clean) {
+		t.Fatalf("expected module without the defect to be unchanged, got:\n%s", got)
+	}
+	if got := fixRFC1212Structure(fixed); !bytes.Equal(got, fixed) {
+		t.Fatalf("expected already fixed module to be unchanged, got:\n%s", got)
+	}
+
+	// (4) applicare la correzione due volte dà lo stesso risultato
+	if twice := fixRFC1212Structure(fixRFC1212Structure([]byte(rfc1212Fixture))); !bytes.Equal(twice, fixed) {
+		t.Fatalf("expected fix to be idempotent, got:\n%s", twice)
+	}
+}
+
+func TestRawDescriptionKeepsOriginalLayout(t *testing.T) {
+	desc := "\n            The operational state.\n\n              up(1)   -- ready\n              down(2) -- not ready\n"
+
+	raw := rawDescription(desc)
+	if raw != "            The operational state.\n\n              up(1)   -- ready\n              down(2) -- not ready" {
+		t.Fatalf("rawDescription() = %q", raw)
+	}
+	if cleaned := cleanDescription(desc); cleaned != "The operational state.\nup(1)   -- ready\ndown(2) -- not ready" {
+		t.Fatalf("cleanDescription() = %q", cleaned)
+	}
+	if raw := rawDescription("Already clean."); raw != "" {
+		t.Fatalf("expected no raw copy for a description that needs no cleanup, got %q", raw)
+	}
+}
@@ -0,0 +1,234 @@
+package mib
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// PollGroup è un gruppo nominato di OID da interrogare insieme, distinto dai bookmark di navigazione.
+type PollGroup struct {
+	ID        int64    `json:"id"`
+	Name      string   `json:"name"`
+	OIDs      []string `json:"oids"`
+	CreatedAt string   `json:"createdAt"`
+}
+
+// ensurePollGroupSchema crea le tabelle dei gruppi di polling se mancanti.
+func (d *Database) ensurePollGroupSchema() error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	statements := []struct {
+		query string
+		err   string
+	}{
+		{
+			query: `CREATE TABLE IF NOT EXISTS poll_groups (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL UNIQUE,
+				created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+			)`,
+			err: "failed to ensure poll_groups table",
+		},
+		{
+			query: `CREATE TABLE IF NOT EXISTS poll_group_oids (
+				group_id INTEGER NOT NULL,
+				oid TEXT NOT NULL,
+				position INTEGER NOT NULL,
+				PRIMARY KEY (group_id, position),
+				FOREIGN KEY (group_id) REFERENCES poll_groups(id) ON DELETE CASCADE
+			)`,
+			err: "failed to ensure poll_group_oids table",
+		},
+	}
+
+	for _, stmt := range statements {
+		if _, err := d.db.Exec(stmt.query); err != nil {
+			return fmt.Errorf("%s: %w", stmt.err, err)
+		}
+	}
+	return nil
+}
+
+// SavePollGroup crea il gruppo indicato (se non esiste) e ne sostituisce l'elenco di OID,
+// mantenendo l'ordine fornito e scartando i duplicati.
+func (d *Database) SavePollGroup(name string, oids []string) (*PollGroup, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	trimmed := strings.TrimSpace(name)
+	if trimmed == "" {
+		return nil, fmt.Errorf("poll group name is required")
+	}
+
+	normalized := make([]string, 0, len(oids))
+	seen := make(map[string]struct{}, len(oids))
+	for _, oid := range oids {
+		if err := ValidateOID(oid); err != nil {
+			return nil, err
+		}
+		value := strings.TrimPrefix(strings.TrimSpace(oid), ".")
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		normalized = append(normalized, value)
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`INSERT INTO poll_groups (name) VALUES (?) ON CONFLICT(name) DO NOTHING`, trimmed); err != nil {
+		return nil, fmt.Errorf("failed to save poll group: %w", err)
+	}
+
+	var groupID int64
+	if err := tx.QueryRow(`SELECT id FROM poll_groups WHERE name = ?`, trimmed).Scan(&groupID); err != nil {
+		return nil, fmt.Errorf("failed to resolve poll group id: %w", err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM poll_group_oids WHERE group_id = ?`, groupID); err != nil {
+		return nil, fmt.Errorf("failed to reset poll group OIDs: %w", err)
+	}
+	for position, oid := range normalized {
+		if _, err := tx.Exec(
+			`INSERT INTO poll_group_oids (group_id, oid, position) VALUES (?, ?, ?)`,
+			groupID, oid, position,
+		); err != nil {
+			return nil, fmt.Errorf("failed to save poll group OID %s: %w", oid, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return d.GetPollGroup(trimmed)
+}
+
+// GetPollGroup recupera un gruppo con i suoi OID ordinati. Restituisce nil se non esiste.
+func (d *Database) GetPollGroup(name string) (*PollGroup, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	group := &PollGroup{}
+	err := d.db.QueryRow(`SELECT id, name, created_at FROM poll_groups WHERE name = ?`, strings.TrimSpace(name)).
+		Scan(&group.ID, &group.Name, &group.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load poll group: %w", err)
+	}
+	if parsed, err := parseTimestamp(group.CreatedAt); err == nil && parsed != "" {
+		group.CreatedAt = parsed
+	}
+
+	group.OIDs, err = d.pollGroupOIDs(group.ID)
+	if err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// ListPollGroups restituisce tutti i gruppi ordinati per nome.
+func (d *Database) ListPollGroups() ([]PollGroup, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	rows, err := d.db.Query(`SELECT id, name, created_at FROM poll_groups ORDER BY name COLLATE NOCASE ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list poll groups: %w", err)
+	}
+
+	groups := []PollGroup{}
+	for rows.Next() {
+		var group PollGroup
+		if err := rows.Scan(&group.ID, &group.Name, &group.CreatedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan poll group: %w", err)
+		}
+		if parsed, err := parseTimestamp(group.CreatedAt); err == nil && parsed != "" {
+			group.CreatedAt = parsed
+		}
+		groups = append(groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("failed during poll group iteration: %w", err)
+	}
+	rows.Close()
+
+	for i := range groups {
+		if groups[i].OIDs, err = d.pollGroupOIDs(groups[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return groups, nil
+}
+
+// RenamePollGroup cambia il nome di un gruppo esistente.
+func (d *Database) RenamePollGroup(oldName, newName string) error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	trimmed := strings.TrimSpace(newName)
+	if trimmed == "" {
+		return fmt.Errorf("poll group name is required")
+	}
+
+	result, err := d.db.Exec(`UPDATE poll_groups SET name = ? WHERE name = ?`, trimmed, strings.TrimSpace(oldName))
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE") {
+			return fmt.Errorf("a poll group named %q already exists", trimmed)
+		}
+		return fmt.Errorf("failed to rename poll group: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to inspect poll group rename: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("poll group %q not found", oldName)
+	}
+	return nil
+}
+
+// DeletePollGroup elimina un gruppo e i relativi OID.
+func (d *Database) DeletePollGroup(name string) error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	if _, err := d.db.Exec(`DELETE FROM poll_groups WHERE name = ?`, strings.TrimSpace(name)); err != nil {
+		return fmt.Errorf("failed to delete poll group: %w", err)
+	}
+	return nil
+}
+
+func (d *Database) pollGroupOIDs(groupID int64) ([]string, error) {
+	rows, err := d.db.Query(`SELECT oid FROM poll_group_oids WHERE group_id = ? ORDER BY position`, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load poll group OIDs: %w", err)
+	}
+	defer rows.Close()
+
+	oids := []string{}
+	for rows.Next() {
+		var oid string
+		if err := rows.Scan(&oid); err != nil {
+			return nil, err
+		}
+		oids = append(oids, oid)
+	}
+	return oids, rows.Err()
+}
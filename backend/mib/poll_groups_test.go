@@ -0,0 +1,77 @@
+package mib
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPollGroupCRUD(t *testing.T) {
+	db := newTestDB(t)
+
+	group, err := db.SavePollGroup("  Interfaces  ", []string{".1.3.6.1.2.1.2.2.1.10.1", "1.3.6.1.2.1.2.2.1.16.1", "1.3.6.1.2.1.2.2.1.10.1"})
+	if err != nil {
+		t.Fatalf("SavePollGroup error: %v", err)
+	}
+	if group.Name != "Interfaces" || group.ID == 0 {
+		t.Fatalf("unexpected group: %+v", group)
+	}
+	wantOIDs := []string{"1.3.6.1.2.1.2.2.1.10.1", "1.3.6.1.2.1.2.2.1.16.1"}
+	if !reflect.DeepEqual(group.OIDs, wantOIDs) {
+		t.Fatalf("expected normalized, deduplicated OIDs %v, got %v", wantOIDs, group.OIDs)
+	}
+
+	// Salvare di nuovo lo stesso nome sostituisce l'elenco mantenendo l'ordine indicato.
+	updated, err := db.SavePollGroup("Interfaces", []string{"1.3.6.1.2.1.2.2.1.16.1", "1.3.6.1.2.1.1.3.0"})
+	if err != nil {
+		t.Fatalf("SavePollGroup update error: %v", err)
+	}
+	if updated.ID != group.ID || !reflect.DeepEqual(updated.OIDs, []string{"1.3.6.1.2.1.2.2.1.16.1", "1.3.6.1.2.1.1.3.0"}) {
+		t.Fatalf("unexpected updated group: %+v", updated)
+	}
+
+	if _, err := db.SavePollGroup("Broken", []string{"1..3"}); !errors.Is(err, ErrInvalidOID) {
+		t.Fatalf("expected ErrInvalidOID, got %v", err)
+	}
+	if _, err := db.SavePollGroup(" ", nil); err == nil {
+		t.Fatalf("expected error for empty name")
+	}
+
+	if _, err := db.SavePollGroup("CPU", []string{"1.3.6.1.4.1.2021.11.9.0"}); err != nil {
+		t.Fatalf("SavePollGroup CPU error: %v", err)
+	}
+
+	groups, err := db.ListPollGroups()
+	if err != nil {
+		t.Fatalf("ListPollGroups error: %v", err)
+	}
+	if len(groups) != 2 || groups[0].Name != "CPU" || len(groups[1].OIDs) != 2 {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+
+	if err := db.RenamePollGroup("CPU", "Interfaces"); err == nil {
+		t.Fatalf("expected error when renaming onto an existing group")
+	}
+	if err := db.RenamePollGroup("CPU", "Processor"); err != nil {
+		t.Fatalf("RenamePollGroup error: %v", err)
+	}
+	if err := db.RenamePollGroup("Missing", "Other"); err == nil {
+		t.Fatalf("expected error when renaming a missing group")
+	}
+
+	if err := db.DeletePollGroup("Interfaces"); err != nil {
+		t.Fatalf("DeletePollGroup error: %v", err)
+	}
+	deleted, err := db.GetPollGroup("Interfaces")
+	if err != nil || deleted != nil {
+		t.Fatalf("expected deleted group to be gone, got %+v (err=%v)", deleted, err)
+	}
+
+	var leftover int
+	if err := db.db.QueryRow(`SELECT COUNT(1) FROM poll_group_oids WHERE group_id = ?`, group.ID).Scan(&leftover); err != nil {
+		t.Fatalf("count poll_group_oids: %v", err)
+	}
+	if leftover != 0 {
+		t.Fatalf("expected OIDs to be removed with the group, got %d", leftover)
+	}
+}
@@ -0,0 +1,106 @@
+package mib
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Tipi di componente di un indice di tabella, che determinano quanti sub-identifier dell'istanza
+// vengono consumati (RFC 2578, 7.7).
+const (
+	// IndexKindInteger è un componente intero (INTEGER, Unsigned32, Gauge32, ...): un sub-identifier.
+	IndexKindInteger = "integer"
+	// IndexKindString è un OCTET STRING (o BITS): FixedLength sub-identifier se di dimensione fissa,
+	// altrimenti un sub-identifier di lunghezza seguito dai byte (nessuna lunghezza se IMPLIED).
+	IndexKindString = "string"
+	// IndexKindOID è un OBJECT IDENTIFIER, codificato con lunghezza come le stringhe variabili.
+	IndexKindOID = "oid"
+)
+
+// IndexComponent descrive un oggetto della clausola INDEX di una riga, nell'ordine dichiarato.
+// Implied è vero solo per l'ultimo componente di un indice IMPLIED.
+type IndexComponent struct {
+	OID         string `json:"oid"`
+	Name        string `json:"name"`
+	Syntax      string `json:"syntax,omitempty"`
+	Kind        string `json:"kind"`
+	FixedLength int    `json:"fixedLength,omitempty"`
+	Implied     bool   `json:"implied,omitempty"`
+}
+
+// ensureRowIndexSchema crea la tabella con i componenti della clausola INDEX delle righe.
+func (d *Database) ensureRowIndexSchema() error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	if _, err := d.db.Exec(`CREATE TABLE IF NOT EXISTS row_indexes (
+		row_oid TEXT NOT NULL,
+		position INTEGER NOT NULL,
+		column_oid TEXT NOT NULL,
+		column_name TEXT NOT NULL,
+		syntax TEXT NOT NULL DEFAULT '',
+		kind TEXT NOT NULL,
+		fixed_length INTEGER NOT NULL DEFAULT 0,
+		implied INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (row_oid, position),
+		FOREIGN KEY (row_oid) REFERENCES mib_nodes(oid) ON DELETE CASCADE
+	)`); err != nil {
+		return fmt.Errorf("failed to ensure row_indexes table: %w", err)
+	}
+
+	return nil
+}
+
+// saveRowIndex sostituisce, nella transazione indicata, i componenti dell'indice di una riga.
+func saveRowIndex(tx *sql.Tx, rowOID string, index []IndexComponent) error {
+	if _, err := tx.Exec(`DELETE FROM row_indexes WHERE row_oid = ?`, rowOID); err != nil {
+		return fmt.Errorf("failed to reset index for %s: %w", rowOID, err)
+	}
+
+	for position, component := range index {
+		if _, err := tx.Exec(
+			`INSERT INTO row_indexes (row_oid, position, column_oid, column_name, syntax, kind, fixed_length, implied)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			rowOID, position, component.OID, component.Name, component.Syntax, component.Kind,
+			component.FixedLength, component.Implied,
+		); err != nil {
+			return fmt.Errorf("failed to save index component %s for %s: %w", component.Name, rowOID, err)
+		}
+	}
+
+	return nil
+}
+
+// GetRowIndex restituisce, nell'ordine della clausola INDEX, i componenti dell'indice di una riga.
+// Ritorna un elenco vuoto se l'indice non è noto (moduli caricati prima che venisse registrato).
+func (d *Database) GetRowIndex(rowOID string) ([]IndexComponent, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	oid := strings.TrimPrefix(strings.TrimSpace(rowOID), ".")
+	rows, err := d.db.Query(`
+		SELECT column_oid, column_name, syntax, kind, fixed_length, implied FROM row_indexes
+		WHERE row_oid = ?
+		ORDER BY position
+	`, oid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	index := []IndexComponent{}
+	for rows.Next() {
+		var component IndexComponent
+		if err := rows.Scan(
+			&component.OID, &component.Name, &component.Syntax, &component.Kind,
+			&component.FixedLength, &component.Implied,
+		); err != nil {
+			return nil, err
+		}
+		index = append(index, component)
+	}
+	return index, rows.Err()
+}
@@ -0,0 +1,137 @@
+package mib
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Regole applicate da SanitizeMIBContent, nell'ordine di esecuzione.
+const (
+	SanitizeRuleLineEndings     = "line-endings"
+	SanitizeRuleRFC1212         = "rfc1212-index-syntax"
+	SanitizeRuleIntegerOverflow = "integer-range-overflow"
+	SanitizeRuleLowercaseSize   = "lowercase-size"
+	SanitizeRuleHexLeadingZero  = "hex-leading-zero"
+	SanitizeRuleLastUpdated     = "last-updated-seconds"
+	SanitizeRuleRangeMax        = "range-max"
+)
+
+// maxSanitizationExamples limita gli esempi riportati per ogni regola.
+const maxSanitizationExamples = 3
+
+// sanitizationFixMessages descrive ogni regola nel report di caricamento; %d è il numero di occorrenze.
+var sanitizationFixMessages = map[string]string{
+	SanitizeRuleLineEndings:     "Normalized %d CRLF line ending(s) to LF",
+	SanitizeRuleRFC1212:         "Moved RFC1212 IndexSyntax before END",
+	SanitizeRuleIntegerOverflow: "Fixed %d INTEGER range overflow(s)",
+	SanitizeRuleLowercaseSize:   "Uppercased %d 'size' keyword(s)",
+	SanitizeRuleHexLeadingZero:  "Fixed %d hex literal(s) with leading zero",
+	SanitizeRuleLastUpdated:     "Trimmed seconds from %d LAST-UPDATED timestamp(s)",
+	SanitizeRuleRangeMax:        "Replaced %d '..MAX' range bound(s)",
+}
+
+// SanitizationChange riassume le modifiche di una regola di sanitizzazione: quante occorrenze sono
+// state corrette e alcuni esempi del testo originale.
+type SanitizationChange struct {
+	Rule     string   `json:"rule"`
+	Count    int      `json:"count"`
+	Examples []string `json:"examples,omitempty"`
+}
+
+// SanitizeMIBContent applica al sorgente MIB le correzioni usate prima di ricaricarlo con libsmi
+// (line ending, struttura di RFC1212-MIB e i fix di Net-SNMP rfcmibs.diff) e restituisce il contenuto
+// corretto con l'elenco delle regole applicate. Non legge né scrive file, quindi può essere usata per
+// mostrare un'anteprima delle correzioni prima del caricamento.
+func (p *Parser) SanitizeMIBContent(data []byte) ([]byte, []SanitizationChange, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil, fmt.Errorf("empty MIB content")
+	}
+
+	changes := []SanitizationChange{}
+	record := func(rule string, count int, examples []string) {
+		if count > 0 {
+			changes = append(changes, SanitizationChange{Rule: rule, Count: count, Examples: examples})
+		}
+	}
+
+	// Normalizza line endings (Windows -> Unix)
+	sanitized, count, _ := applySanitizeRegexp(data, reCRLF, func([]byte) []byte { return []byte("\n") })
+	record(SanitizeRuleLineEndings, count, nil)
+
+	// Fix specifico per RFC1212-MIB che ha IndexSyntax DOPO il macro END
+	if fixed := fixRFC1212Structure(sanitized); !bytes.Equal(fixed, sanitized) {
+		sanitized = fixed
+		record(SanitizeRuleRFC1212, 1, nil)
+	}
+
+	regexpRules := []struct {
+		rule    string
+		re      *regexp.Regexp
+		replace func(match []byte) []byte
+	}{
+		// INTEGER(1..2147483648) -> INTEGER(1..2147483647)
+		{SanitizeRuleIntegerOverflow, reIntegerOverflow, func(match []byte) []byte {
+			return reIntegerOverflow.ReplaceAll(match, []byte("INTEGER ($1..2147483647)"))
+		}},
+		// 'size' -> 'SIZE'
+		{SanitizeRuleLowercaseSize, reLowercaseSize, func([]byte) []byte { return []byte("(SIZE (") }},
+		// '07fffffff'h -> '7fffffff'h
+		{SanitizeRuleHexLeadingZero, reHexLeadingZero, func(match []byte) []byte {
+			return reHexLeadingZero.ReplaceAll(match, []byte("'$1'h"))
+		}},
+		// "YYYYMMDDHHmmssZ" -> "YYYYMMDDHHmmZ"
+		{SanitizeRuleLastUpdated, reLastUpdatedLong, func(match []byte) []byte {
+			return reLastUpdatedLong.ReplaceAll(match, []byte(`LAST-UPDATED "$1$2"`))
+		}},
+		// "..MAX" e "N..MAX" -> limite numerico
+		{SanitizeRuleRangeMax, reDoubleDotMax, func(match []byte) []byte {
+			if idx := bytes.Index(match, []byte("..")); idx > 0 {
+				return []byte(string(match[:idx]) + "..2147483647")
+			}
+			return bytes.Replace(match, []byte("MAX"), []byte("2147483647"), 1)
+		}},
+	}
+	for _, rule := range regexpRules {
+		var examples []string
+		sanitized, count, examples = applySanitizeRegexp(sanitized, rule.re, rule.replace)
+		record(rule.rule, count, examples)
+	}
+
+	return sanitized, changes, nil
+}
+
+// applySanitizeRegexp sostituisce le occorrenze di re e restituisce il numero di sostituzioni
+// con i primi esempi distinti del testo originale.
+func applySanitizeRegexp(data []byte, re *regexp.Regexp, replace func(match []byte) []byte) ([]byte, int, []string) {
+	count := 0
+	var examples []string
+	result := re.ReplaceAllFunc(data, func(match []byte) []byte {
+		count++
+		if len(examples) < maxSanitizationExamples {
+			example := strings.Join(strings.Fields(string(match)), " ")
+			duplicate := false
+			for _, existing := range examples {
+				duplicate = duplicate || existing == example
+			}
+			if !duplicate {
+				examples = append(examples, example)
+			}
+		}
+		return replace(match)
+	})
+	return result, count, examples
+}
+
+// sanitizationFixMessage restituisce la descrizione della modifica usata nel report di caricamento.
+func sanitizationFixMessage(change SanitizationChange) string {
+	format, ok := sanitizationFixMessages[change.Rule]
+	if !ok {
+		return fmt.Sprintf("Applied %s (%d)", change.Rule, change.Count)
+	}
+	if strings.Contains(format, "%d") {
+		return fmt.Sprintf(format, change.Count)
+	}
+	return format
+}
@@ -0,0 +1,78 @@
+package mib
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeMIBContent(t *testing.T) {
+	p := &Parser{}
+	source := "TEST-MIB DEFINITIONS ::= BEGIN\r\n" +
+		"testIdentity MODULE-IDENTITY\r\n" +
+		"    LAST-UPDATED \"202401011200" + "00Z\"\r\n" +
+		"TestCounter ::= INTEGER (0..2147483648)\r\n" +
+		"TestString ::= OCTET STRING (size (0..MAX))\r\n" +
+		"TestOther ::= OCTET STRING (size (1..MAX))\r\n" +
+		"testDefault OBJECT-TYPE DEFVAL { '07fffffff'h }\r\n" +
+		"END\r\n"
+
+	sanitized, changes, err := p.SanitizeMIBContent([]byte(source))
+	if err != nil {
+		t.Fatalf("SanitizeMIBContent() error = %v", err)
+	}
+
+	got := string(sanitized)
+	for _, want := range []string{
+		`LAST-UPDATED "202401011200Z"`,
+		"INTEGER (0..2147483647)",
+		"(SIZE (0..2147483647))",
+		"'7fffffff'h",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("sanitized content missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "\r") {
+		t.Fatalf("expected CRLF to be normalized")
+	}
+
+	counts := map[string]int{}
+	for _, change := range changes {
+		counts[change.Rule] = change.Count
+	}
+	want := map[string]int{
+		SanitizeRuleLineEndings:     8,
+		SanitizeRuleIntegerOverflow: 1,
+		SanitizeRuleLowercaseSize:   2,
+		SanitizeRuleHexLeadingZero:  1,
+		SanitizeRuleLastUpdated:     1,
+		SanitizeRuleRangeMax:        2,
+	}
+	if !reflect.DeepEqual(counts, want) {
+		t.Fatalf("changes = %+v, want counts %v", changes, want)
+	}
+
+	for _, change := range changes {
+		if change.Rule == SanitizeRuleRangeMax && !reflect.DeepEqual(change.Examples, []string{"0..MAX", "1..MAX"}) {
+			t.Fatalf("unexpected range examples: %v", change.Examples)
+		}
+	}
+}
+
+func TestSanitizeMIBContentCleanInput(t *testing.T) {
+	p := &Parser{}
+	source := "TEST-MIB DEFINITIONS ::= BEGIN\nEND\n"
+
+	sanitized, changes, err := p.SanitizeMIBContent([]byte(source))
+	if err != nil {
+		t.Fatalf("SanitizeMIBContent() error = %v", err)
+	}
+	if string(sanitized) != source || len(changes) != 0 {
+		t.Fatalf("expected clean input to be unchanged, got %q with %+v", sanitized, changes)
+	}
+
+	if _, _, err := p.SanitizeMIBContent([]byte("  \n")); err == nil {
+		t.Fatalf("expected error for empty content")
+	}
+}
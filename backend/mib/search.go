@@ -0,0 +1,127 @@
+package mib
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Campi su cui può operare la ricerca testuale di SearchNodesAdvanced.
+const (
+	SearchFieldName        = "name"
+	SearchFieldOID         = "oid"
+	SearchFieldDescription = "description"
+)
+
+const (
+	defaultSearchLimit = 100
+	maxSearchLimit     = 1000
+)
+
+// NodeSearchQuery descrive una ricerca avanzata: testo libero su uno o più campi e filtri esatti.
+type NodeSearchQuery struct {
+	Query  string
+	Fields []string
+	Type   string
+	Access string
+	Status string
+	Module string
+	Limit  int
+	Offset int
+}
+
+// SearchNodesAdvanced esegue una ricerca costruendo dinamicamente la clausola WHERE.
+// Ritorna la pagina di nodi richiesta e il numero totale di corrispondenze.
+func (d *Database) SearchNodesAdvanced(q NodeSearchQuery) ([]*Node, int, error) {
+	if d == nil || d.db == nil {
+		return nil, 0, fmt.Errorf("database not initialized")
+	}
+
+	fields := q.Fields
+	if len(fields) == 0 {
+		fields = []string{SearchFieldName, SearchFieldOID}
+	}
+
+	var conditions []string
+	var args []interface{}
+
+	if text := strings.TrimSpace(q.Query); text != "" {
+		var textConditions []string
+		pattern := likeContainsPattern(text)
+		for _, field := range fields {
+			switch strings.ToLower(strings.TrimSpace(field)) {
+			case SearchFieldName:
+				textConditions = append(textConditions, `n.name LIKE ? ESCAPE '\'`)
+				args = append(args, pattern)
+			case SearchFieldOID:
+				// Il confronto sull'OID ha senso solo per prefissi numerici.
+				if IsOIDPrefix(text) {
+					textConditions = append(textConditions, `n.oid LIKE ? ESCAPE '\'`)
+					args = append(args, pattern)
+				}
+			case SearchFieldDescription:
+				textConditions = append(textConditions, `n.description LIKE ? ESCAPE '\'`)
+				args = append(args, pattern)
+			default:
+				return nil, 0, fmt.Errorf("unsupported search field: %s", field)
+			}
+		}
+		if len(textConditions) == 0 {
+			return []*Node{}, 0, nil
+		}
+		conditions = append(conditions, "("+strings.Join(textConditions, " OR ")+")")
+	}
+
+	filters := []struct {
+		column string
+		value  string
+	}{
+		{"n.type", q.Type},
+		{"n.access", q.Access},
+		{"n.status", q.Status},
+		{"m.name", q.Module},
+	}
+	for _, filter := range filters {
+		if value := strings.TrimSpace(filter.value); value != "" {
+			conditions = append(conditions, filter.column+" = ?")
+			args = append(args, value)
+		}
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+	from := ` FROM mib_nodes n LEFT JOIN mib_modules m ON n.module_id = m.id`
+
+	var total int
+	if err := d.db.QueryRow(`SELECT COUNT(1)`+from+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	offset := q.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	rows, err := d.db.Query(`SELECT `+nodeColumns+from+where+` ORDER BY n.oid COLLATE `+oidCollation+` LIMIT ? OFFSET ?`,
+		append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	nodes, err := scanNodeRows(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	if nodes == nil {
+		nodes = []*Node{}
+	}
+
+	return nodes, total, nil
+}
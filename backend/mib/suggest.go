@@ -0,0 +1,203 @@
+package mib
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultSuggestionLimit = 20
+	maxSuggestionLimit     = 200
+)
+
+// OIDSuggestion è un suggerimento di completamento per i campi di inserimento di OID e nomi.
+type OIDSuggestion struct {
+	OID    string `json:"oid"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Module string `json:"module"`
+}
+
+// SuggestOIDs restituisce i suggerimenti per il prefisso digitato.
+// Per i prefissi numerici propone i figli del nodo esistente più profondo che corrisponde al prefisso
+// (filtrati sull'ultima componente parziale, se presente); per i prefissi alfabetici cerca sul nome,
+// mettendo prima le corrispondenze per prefisso e poi quelle per contenuto.
+func (d *Database) SuggestOIDs(prefix string, limit int) ([]OIDSuggestion, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	if limit <= 0 {
+		limit = defaultSuggestionLimit
+	}
+	if limit > maxSuggestionLimit {
+		limit = maxSuggestionLimit
+	}
+
+	trimmed := strings.TrimSpace(prefix)
+	if trimmed == "" {
+		return []OIDSuggestion{}, nil
+	}
+
+	if first := trimmed[0]; first == '.' || (first >= '0' && first <= '9') {
+		if err := ValidateOIDPrefix(trimmed); err != nil {
+			return nil, err
+		}
+		return d.suggestChildren(trimmed, limit)
+	}
+
+	if !isIdentifierPrefix(trimmed) {
+		return []OIDSuggestion{}, nil
+	}
+	return d.suggestByName(trimmed, limit)
+}
+
+// suggestChildren risale il prefisso numerico fino al primo nodo presente nel database e ne restituisce i figli.
+func (d *Database) suggestChildren(prefix string, limit int) ([]OIDSuggestion, error) {
+	base := strings.TrimSuffix(strings.TrimPrefix(prefix, "."), ".")
+	partial := ""
+
+	if !strings.HasSuffix(prefix, ".") {
+		node, err := d.findNodeByVariants(nodeOIDVariants(base))
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+		if node != nil {
+			return d.queryChildSuggestions(node.OID, "", limit)
+		}
+
+		// L'ultima componente è parziale: si filtrano i figli del padre su quella componente.
+		idx := strings.LastIndex(base, ".")
+		if idx < 0 {
+			return []OIDSuggestion{}, nil
+		}
+		partial = base[idx+1:]
+		base = base[:idx]
+	}
+
+	for base != "" {
+		node, err := d.findNodeByVariants(nodeOIDVariants(base))
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+		if node != nil {
+			return d.queryChildSuggestions(node.OID, partial, limit)
+		}
+
+		// Il padre immediato non esiste: il filtro parziale non è più significativo.
+		partial = ""
+		idx := strings.LastIndex(base, ".")
+		if idx < 0 {
+			break
+		}
+		base = base[:idx]
+	}
+
+	return []OIDSuggestion{}, nil
+}
+
+// queryChildSuggestions legge i figli diretti di parentOID (via idx_parent_oid) in ordine numerico.
+func (d *Database) queryChildSuggestions(parentOID, partial string, limit int) ([]OIDSuggestion, error) {
+	query := `
+		SELECT n.oid, n.name, n.type, m.name
+		FROM mib_nodes n
+		LEFT JOIN mib_modules m ON n.module_id = m.id
+		WHERE n.parent_oid = ?
+	`
+	args := []interface{}{parentOID}
+	if partial != "" {
+		query += ` AND n.oid LIKE ?`
+		args = append(args, parentOID+"."+partial+"%")
+	}
+
+	rows, err := d.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	suggestions, err := scanSuggestionRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return CompareOIDs(suggestions[i].OID, suggestions[j].OID) < 0
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+// suggestByName usa l'indice di copertura idx_name_nocase: prima le corrispondenze per prefisso
+// (range scan sull'indice), poi, se serve, quelle che contengono il testo.
+func (d *Database) suggestByName(prefix string, limit int) ([]OIDSuggestion, error) {
+	rows, err := d.db.Query(`
+		SELECT n.oid, n.name, n.type, m.name
+		FROM mib_nodes n
+		LEFT JOIN mib_modules m ON n.module_id = m.id
+		WHERE n.name LIKE ?
+		ORDER BY n.name COLLATE NOCASE, n.oid
+		LIMIT ?
+	`, prefix+"%", limit)
+	if err != nil {
+		return nil, err
+	}
+	suggestions, err := scanSuggestionRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(suggestions) >= limit {
+		return suggestions, nil
+	}
+
+	rows, err = d.db.Query(`
+		SELECT n.oid, n.name, n.type, m.name
+		FROM mib_nodes n
+		LEFT JOIN mib_modules m ON n.module_id = m.id
+		WHERE n.name LIKE ? AND n.name NOT LIKE ?
+		ORDER BY n.name COLLATE NOCASE, n.oid
+		LIMIT ?
+	`, "%"+prefix+"%", prefix+"%", limit-len(suggestions))
+	if err != nil {
+		return nil, err
+	}
+	contains, err := scanSuggestionRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(suggestions, contains...), nil
+}
+
+// scanSuggestionRows legge le righe (oid, name, type, module) e chiude il cursore.
+func scanSuggestionRows(rows *sql.Rows) ([]OIDSuggestion, error) {
+	defer rows.Close()
+
+	suggestions := []OIDSuggestion{}
+	for rows.Next() {
+		var suggestion OIDSuggestion
+		var nodeType, moduleName sql.NullString
+		if err := rows.Scan(&suggestion.OID, &suggestion.Name, &nodeType, &moduleName); err != nil {
+			return nil, err
+		}
+		suggestion.Type = nodeType.String
+		suggestion.Module = moduleName.String
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, rows.Err()
+}
+
+// isIdentifierPrefix accetta solo i caratteri validi in un identificatore ASN.1,
+// così il testo può essere usato nei pattern LIKE senza escape.
+func isIdentifierPrefix(value string) bool {
+	for _, r := range value {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
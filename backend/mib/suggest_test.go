@@ -0,0 +1,145 @@
+package mib
+
+import (
+	"errors"
+	"testing"
+)
+
+func seedSuggestionNodes(t *testing.T, db *Database) {
+	t.Helper()
+
+	moduleID, err := db.SaveModule("IF-MIB", "")
+	if err != nil {
+		t.Fatalf("SaveModule failed: %v", err)
+	}
+
+	nodes := []*Node{
+		{OID: "1.3.6.1.2.1", Name: "mib-2", Type: "node"},
+		{OID: "1.3.6.1.2.1.1", Name: "system", ParentOID: "1.3.6.1.2.1", Type: "node"},
+		{OID: "1.3.6.1.2.1.2", Name: "interfaces", ParentOID: "1.3.6.1.2.1", Type: "node"},
+		{OID: "1.3.6.1.2.1.10", Name: "transmission", ParentOID: "1.3.6.1.2.1", Type: "node"},
+		{OID: "1.3.6.1.2.1.11", Name: "snmp", ParentOID: "1.3.6.1.2.1", Type: "node"},
+		{OID: "1.3.6.1.2.1.2.1", Name: "ifNumber", ParentOID: "1.3.6.1.2.1.2", Type: "scalar"},
+		{OID: "1.3.6.1.2.1.2.2", Name: "ifTable", ParentOID: "1.3.6.1.2.1.2", Type: "table"},
+		{OID: "1.3.6.1.2.1.31.1.1.1.1", Name: "ifName", ParentOID: "1.3.6.1.2.1.31.1.1.1", Type: "column"},
+		{OID: "1.3.6.1.2.1.1.3", Name: "sysUpTime", ParentOID: "1.3.6.1.2.1.1", Type: "scalar"},
+		{OID: "1.3.6.1.2.1.1.9", Name: "sysORTable", ParentOID: "1.3.6.1.2.1.1", Type: "table"},
+		{OID: "1.3.6.1.2.1.11.30", Name: "snmpEnableAuthenTraps", ParentOID: "1.3.6.1.2.1.11", Type: "scalar"},
+		{OID: "1.3.6.1.2.1.11.31", Name: "snmpSilentDrops", ParentOID: "1.3.6.1.2.1.11", Type: "scalar"},
+		{OID: "1.3.6.1.2.1.11.32", Name: "snmpProxyDrops", ParentOID: "1.3.6.1.2.1.11", Type: "scalar"},
+	}
+	for _, node := range nodes {
+		if err := db.SaveNode(node, moduleID); err != nil {
+			t.Fatalf("SaveNode %s failed: %v", node.Name, err)
+		}
+	}
+}
+
+func suggestionOIDs(suggestions []OIDSuggestion) []string {
+	oids := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		oids[i] = suggestion.OID
+	}
+	return oids
+}
+
+func TestSuggestOIDsNumericPrefix(t *testing.T) {
+	db := newTestDB(t)
+	seedSuggestionNodes(t, db)
+
+	cases := []struct {
+		prefix string
+		want   []string
+	}{
+		{"1.3.6.1.2.1", []string{"1.3.6.1.2.1.1", "1.3.6.1.2.1.2", "1.3.6.1.2.1.10", "1.3.6.1.2.1.11"}},
+		{".1.3.6.1.2.1.", []string{"1.3.6.1.2.1.1", "1.3.6.1.2.1.2", "1.3.6.1.2.1.10", "1.3.6.1.2.1.11"}},
+		{"1.3.6.1.2.1.2", []string{"1.3.6.1.2.1.2.1", "1.3.6.1.2.1.2.2"}},
+		// Il nodo esiste: si propongono i suoi figli, non i fratelli che iniziano con "1".
+		{"1.3.6.1.2.1.1", []string{"1.3.6.1.2.1.1.3", "1.3.6.1.2.1.1.9"}},
+		{"1.3.6.1.2.1.1.", []string{"1.3.6.1.2.1.1.3", "1.3.6.1.2.1.1.9"}},
+		// Componente parziale: figli del padre che iniziano con "3".
+		{"1.3.6.1.2.1.11.3", []string{"1.3.6.1.2.1.11.30", "1.3.6.1.2.1.11.31", "1.3.6.1.2.1.11.32"}},
+		{"1.3.6.1.2.1.1.1", []string{}},
+		{"1.3.6.1.2.1.2.9", []string{}},
+		// Nessun padre diretto: si risale al nodo esistente più profondo.
+		{"1.3.6.1.2.1.99.4", []string{"1.3.6.1.2.1.1", "1.3.6.1.2.1.2", "1.3.6.1.2.1.10", "1.3.6.1.2.1.11"}},
+		{"2.5", []string{}},
+	}
+
+	for _, tc := range cases {
+		got, err := db.SuggestOIDs(tc.prefix, 10)
+		if err != nil {
+			t.Fatalf("SuggestOIDs(%q) error: %v", tc.prefix, err)
+		}
+		oids := suggestionOIDs(got)
+		if len(oids) != len(tc.want) {
+			t.Fatalf("SuggestOIDs(%q) = %v, want %v", tc.prefix, oids, tc.want)
+		}
+		for i := range oids {
+			if oids[i] != tc.want[i] {
+				t.Fatalf("SuggestOIDs(%q) = %v, want %v", tc.prefix, oids, tc.want)
+			}
+		}
+	}
+
+	limited, err := db.SuggestOIDs("1.3.6.1.2.1", 2)
+	if err != nil {
+		t.Fatalf("SuggestOIDs limited error: %v", err)
+	}
+	if len(limited) != 2 || limited[1].OID != "1.3.6.1.2.1.2" {
+		t.Fatalf("unexpected limited suggestions: %v", suggestionOIDs(limited))
+	}
+
+	if _, err := db.SuggestOIDs("1..3", 10); !errors.Is(err, ErrInvalidOID) {
+		t.Fatalf("expected ErrInvalidOID for malformed prefix, got %v", err)
+	}
+}
+
+func TestSuggestOIDsByName(t *testing.T) {
+	db := newTestDB(t)
+	seedSuggestionNodes(t, db)
+
+	got, err := db.SuggestOIDs("if", 10)
+	if err != nil {
+		t.Fatalf("SuggestOIDs error: %v", err)
+	}
+
+	names := make([]string, len(got))
+	for i, suggestion := range got {
+		names[i] = suggestion.Name
+	}
+	// Prima le corrispondenze per prefisso, poi quelle per contenuto ("interfaces" non contiene "if").
+	want := []string{"ifName", "ifNumber", "ifTable"}
+	if len(names) != len(want) {
+		t.Fatalf("unexpected suggestions: %v", names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("unexpected suggestions: %v", names)
+		}
+	}
+	if got[0].Module != "IF-MIB" || got[0].Type != "column" || got[0].OID != "1.3.6.1.2.1.31.1.1.1.1" {
+		t.Fatalf("unexpected suggestion details: %+v", got[0])
+	}
+
+	mixed, err := db.SuggestOIDs("Sys", 10)
+	if err != nil {
+		t.Fatalf("SuggestOIDs error: %v", err)
+	}
+	if len(mixed) != 3 || mixed[0].Name != "sysORTable" {
+		t.Fatalf("expected case-insensitive prefix matches first, got %+v", mixed)
+	}
+
+	contains, err := db.SuggestOIDs("Table", 10)
+	if err != nil {
+		t.Fatalf("SuggestOIDs error: %v", err)
+	}
+	if len(contains) != 2 {
+		t.Fatalf("expected substring matches, got %+v", contains)
+	}
+
+	empty, err := db.SuggestOIDs("if%", 10)
+	if err != nil || len(empty) != 0 {
+		t.Fatalf("expected no suggestions for wildcard input, got %v (err=%v)", empty, err)
+	}
+}
@@ -0,0 +1,88 @@
+package mib
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TreeStats raccoglie statistiche strutturali sull'albero MIB (profondità, ampiezza, bilanciamento).
+type TreeStats struct {
+	MaxDepth           int     `json:"maxDepth"`
+	MaxBreadth         int     `json:"maxBreadth"`
+	TotalLeaves        int     `json:"totalLeaves"`
+	TotalInternalNodes int     `json:"totalInternalNodes"`
+	AverageChildCount  float64 `json:"averageChildCount"`
+}
+
+// GetTreeStats calcola le statistiche strutturali dell'albero a partire da tutti i nodi salvati.
+// La profondità parte da 1 per i nodi radice; l'ampiezza è il numero massimo di nodi su uno stesso livello.
+func (d *Database) GetTreeStats() (*TreeStats, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	nodes, err := d.getAllNodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load nodes: %w", err)
+	}
+
+	return computeTreeStats(nodes), nil
+}
+
+// computeTreeStats costruisce la mappa parent→children ed esegue una BFS per livelli.
+func computeTreeStats(nodes []*Node) *TreeStats {
+	stats := &TreeStats{}
+	if len(nodes) == 0 {
+		return stats
+	}
+
+	known := make(map[string]struct{}, len(nodes))
+	for _, node := range nodes {
+		known[strings.TrimPrefix(node.OID, ".")] = struct{}{}
+	}
+
+	children := make(map[string][]string, len(nodes))
+	var level []string
+	for _, node := range nodes {
+		oid := strings.TrimPrefix(node.OID, ".")
+		parent := strings.TrimPrefix(node.ParentOID, ".")
+		if _, ok := known[parent]; parent == "" || parent == oid || !ok {
+			level = append(level, oid)
+			continue
+		}
+		children[parent] = append(children[parent], oid)
+	}
+
+	visited := make(map[string]struct{}, len(nodes))
+	totalChildren := 0
+	for depth := 1; len(level) > 0; depth++ {
+		stats.MaxDepth = depth
+		if len(level) > stats.MaxBreadth {
+			stats.MaxBreadth = len(level)
+		}
+
+		var next []string
+		for _, oid := range level {
+			if _, seen := visited[oid]; seen {
+				continue
+			}
+			visited[oid] = struct{}{}
+
+			kids := children[oid]
+			if len(kids) == 0 {
+				stats.TotalLeaves++
+				continue
+			}
+			stats.TotalInternalNodes++
+			totalChildren += len(kids)
+			next = append(next, kids...)
+		}
+		level = next
+	}
+
+	if stats.TotalInternalNodes > 0 {
+		stats.AverageChildCount = float64(totalChildren) / float64(stats.TotalInternalNodes)
+	}
+
+	return stats
+}
@@ -0,0 +1,29 @@
+package mib
+
+import "testing"
+
+func TestComputeTreeStats(t *testing.T) {
+	nodes := []*Node{
+		{OID: "1.3.6.1.2.1", Name: "mib-2"},
+		{OID: "1.3.6.1.2.1.1", Name: "system", ParentOID: "1.3.6.1.2.1"},
+		{OID: "1.3.6.1.2.1.2", Name: "interfaces", ParentOID: "1.3.6.1.2.1"},
+		{OID: "1.3.6.1.2.1.1.1", Name: "sysDescr", ParentOID: "1.3.6.1.2.1.1"},
+		{OID: "1.3.6.1.2.1.1.5", Name: "sysName", ParentOID: "1.3.6.1.2.1.1"},
+		{OID: "1.3.6.1.2.1.1.6", Name: "sysLocation", ParentOID: "1.3.6.1.2.1.1"},
+	}
+
+	stats := computeTreeStats(nodes)
+
+	if stats.MaxDepth != 3 {
+		t.Errorf("MaxDepth = %d, want 3", stats.MaxDepth)
+	}
+	if stats.MaxBreadth != 3 {
+		t.Errorf("MaxBreadth = %d, want 3", stats.MaxBreadth)
+	}
+	if stats.TotalLeaves != 4 || stats.TotalInternalNodes != 2 {
+		t.Errorf("leaves/internal = %d/%d, want 4/2", stats.TotalLeaves, stats.TotalInternalNodes)
+	}
+	if stats.AverageChildCount != 2.5 {
+		t.Errorf("AverageChildCount = %v, want 2.5", stats.AverageChildCount)
+	}
+}
@@ -0,0 +1,248 @@
+package mib
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/sleepinggenius2/gosmi"
+	"github.com/sleepinggenius2/gosmi/smi"
+)
+
+// maxTypeChainDepth limita la risalita delle textual convention, a protezione da catene cicliche.
+const maxTypeChainDepth = 16
+
+// TypeDefinition descrive un tipo (textual convention o tipo applicativo) definito da un modulo,
+// con il riferimento al tipo da cui deriva.
+type TypeDefinition struct {
+	Module       string `json:"module"`
+	Name         string `json:"name"`
+	ParentModule string `json:"parentModule,omitempty"`
+	ParentName   string `json:"parentName,omitempty"`
+	BaseType     string `json:"baseType,omitempty"`
+	// Enum sono i valori enumerati del tipo nel formato della sintassi, es. "{other(1), ethernetCsmacd(6)}".
+	Enum string `json:"enum,omitempty"`
+}
+
+// TypeChain è la catena di derivazione di un tipo, dal nome usato nella sintassi fino al tipo base SMI.
+type TypeChain struct {
+	Chain      []string `json:"chain"`
+	BaseType   string   `json:"baseType"`
+	Incomplete bool     `json:"incomplete"`
+	// Enum sono i valori enumerati del primo tipo della catena che li dichiara (vuoto se nessuno).
+	Enum string `json:"enum,omitempty"`
+}
+
+// smiBaseTypes sono i tipi base SMI (e i tipi predefiniti di libsmi) dove la risalita si ferma.
+var smiBaseTypes = map[string]bool{
+	"integer": true, "integer32": true, "unsigned32": true, "integer64": true, "unsigned64": true,
+	"octet string": true, "octetstring": true, "object identifier": true, "objectidentifier": true,
+	"enumeration": true, "bits": true, "float32": true, "float64": true, "float128": true, "pointer": true,
+	"counter": true, "counter32": true, "counter64": true, "gauge": true, "gauge32": true,
+	"timeticks": true, "ipaddress": true, "networkaddress": true, "opaque": true,
+}
+
+// ensureTypesSchema crea il catalogo dei tipi definiti dai moduli caricati.
+func (d *Database) ensureTypesSchema() error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	statements := []struct {
+		query string
+		err   string
+	}{
+		{
+			query: `CREATE TABLE IF NOT EXISTS mib_types (
+				module TEXT NOT NULL,
+				name TEXT NOT NULL,
+				parent_module TEXT NOT NULL DEFAULT '',
+				parent_name TEXT NOT NULL DEFAULT '',
+				base_type TEXT NOT NULL DEFAULT '',
+				enum_values TEXT NOT NULL DEFAULT '',
+				PRIMARY KEY (module, name)
+			)`,
+			err: "failed to ensure mib_types table",
+		},
+		{
+			query: `CREATE INDEX IF NOT EXISTS idx_mib_types_name ON mib_types(name)`,
+			err:   "failed to ensure mib_types index",
+		},
+	}
+
+	for _, stmt := range statements {
+		if _, err := d.db.Exec(stmt.query); err != nil {
+			return fmt.Errorf("%s: %w", stmt.err, err)
+		}
+	}
+
+	// I cataloghi creati prima dell'introduzione degli enum non hanno la colonna.
+	if _, err := d.db.Exec(`ALTER TABLE mib_types ADD COLUMN enum_values TEXT NOT NULL DEFAULT ''`); err != nil {
+		if !strings.Contains(strings.ToLower(err.Error()), "duplicate column name") {
+			return fmt.Errorf("failed to add enum_values column to mib_types: %w", err)
+		}
+	}
+	return nil
+}
+
+// SaveTypeDefinitions inserisce o aggiorna le definizioni di tipo nel catalogo.
+func (d *Database) SaveTypeDefinitions(defs []TypeDefinition) error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	if len(defs) == 0 {
+		return nil
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO mib_types (module, name, parent_module, parent_name, base_type, enum_values)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(module, name) DO UPDATE SET
+			parent_module = excluded.parent_module,
+			parent_name = excluded.parent_name,
+			base_type = excluded.base_type,
+			enum_values = excluded.enum_values
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, def := range defs {
+		module, name := strings.TrimSpace(def.Module), strings.TrimSpace(def.Name)
+		if module == "" || name == "" {
+			continue
+		}
+		if _, err := stmt.Exec(module, name, strings.TrimSpace(def.ParentModule), strings.TrimSpace(def.ParentName), strings.TrimSpace(def.BaseType), strings.TrimSpace(def.Enum)); err != nil {
+			return fmt.Errorf("failed to save type %s::%s: %w", module, name, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetTypeDefinition cerca un tipo per nome. Se module è indicato cerca solo in quel modulo,
+// altrimenti preferisce la definizione di preferredModule. Ritorna nil se il tipo non è nel catalogo.
+func (d *Database) GetTypeDefinition(module, name, preferredModule string) (*TypeDefinition, error) {
+	var row *sql.Row
+	if strings.TrimSpace(module) != "" {
+		row = d.db.QueryRow(`
+			SELECT module, name, parent_module, parent_name, base_type, enum_values
+			FROM mib_types WHERE module = ? AND name = ?
+		`, module, name)
+	} else {
+		row = d.db.QueryRow(`
+			SELECT module, name, parent_module, parent_name, base_type, enum_values
+			FROM mib_types WHERE name = ?
+			ORDER BY module = ? DESC, module
+			LIMIT 1
+		`, name, preferredModule)
+	}
+
+	var def TypeDefinition
+	if err := row.Scan(&def.Module, &def.Name, &def.ParentModule, &def.ParentName, &def.BaseType, &def.Enum); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load type %s: %w", name, err)
+	}
+	return &def, nil
+}
+
+// ResolveTypeChain risale le textual convention a partire dalla sintassi di un nodo
+// (es. "InterfaceIndexOrZero (0..2147483647)") fino al tipo base SMI.
+// Se un tipo della catena non è nel catalogo, perché il modulo che lo definisce non è caricato,
+// la catena si interrompe e viene marcata come incompleta.
+func (d *Database) ResolveTypeChain(syntax string, nodeModule string) (*TypeChain, error) {
+	name := SyntaxTypeName(syntax)
+	if name == "" {
+		return nil, nil
+	}
+
+	result := &TypeChain{Chain: []string{name}}
+	module := ""
+	seen := make(map[string]bool)
+	for depth := 0; ; depth++ {
+		if smiBaseTypes[strings.ToLower(name)] {
+			result.BaseType = name
+			return result, nil
+		}
+		if depth >= maxTypeChainDepth || seen[module+"::"+name] {
+			result.Incomplete = true
+			return result, nil
+		}
+		seen[module+"::"+name] = true
+
+		def, err := d.GetTypeDefinition(module, name, nodeModule)
+		if err != nil {
+			return nil, err
+		}
+		if def == nil {
+			result.Incomplete = true
+			return result, nil
+		}
+		if result.Enum == "" {
+			result.Enum = def.Enum
+		}
+		if def.ParentName == "" {
+			// Tipo senza padre noto: il tipo base riportato da libsmi è il meglio disponibile.
+			result.BaseType = def.BaseType
+			result.Incomplete = def.BaseType == ""
+			return result, nil
+		}
+
+		module, name = def.ParentModule, def.ParentName
+		result.Chain = append(result.Chain, name)
+	}
+}
+
+// SyntaxTypeName estrae il nome del tipo da una stringa di sintassi, scartando intervalli ed enumerazioni.
+func SyntaxTypeName(syntax string) string {
+	trimmed := strings.TrimSpace(syntax)
+	upper := strings.ToUpper(trimmed)
+	for _, compound := range []string{"OCTET STRING", "OBJECT IDENTIFIER"} {
+		if strings.HasPrefix(upper, compound) {
+			return compound
+		}
+	}
+	if idx := strings.IndexAny(trimmed, " ({"); idx != -1 {
+		trimmed = trimmed[:idx]
+	}
+	return trimmed
+}
+
+// moduleTypeDefinitions raccoglie i tipi definiti da un modulo gosmi con il relativo tipo padre.
+func moduleTypeDefinitions(module gosmi.SmiModule) []TypeDefinition {
+	var defs []TypeDefinition
+	for _, t := range module.GetTypes() {
+		if t.Name == "" {
+			continue
+		}
+		def := TypeDefinition{
+			Module:   module.Name,
+			Name:     t.Name,
+			BaseType: t.BaseType.String(),
+		}
+		if t.Enum != nil && len(t.Enum.Values) > 0 {
+			parts := make([]string, 0, len(t.Enum.Values))
+			for _, value := range t.Enum.Values {
+				parts = append(parts, fmt.Sprintf("%s(%d)", value.Name, value.Value))
+			}
+			def.Enum = fmt.Sprintf("{%s}", strings.Join(parts, ", "))
+		}
+		if parent := smi.GetParentType(t.GetRaw()); parent != nil {
+			def.ParentName = string(parent.Name)
+			if parentModule := smi.GetTypeModule(parent); parentModule != nil {
+				def.ParentModule = string(parentModule.Name)
+			}
+		}
+		defs = append(defs, def)
+	}
+	return defs
+}
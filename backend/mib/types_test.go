@@ -0,0 +1,68 @@
+package mib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveTypeChain(t *testing.T) {
+	db := newTestDB(t)
+
+	defs := []TypeDefinition{
+		{Module: "IF-MIB", Name: "InterfaceIndexOrZero", ParentName: "Integer32", BaseType: "Integer32"},
+		{Module: "ACME-TC", Name: "AcmePortIndex", ParentModule: "IF-MIB", ParentName: "InterfaceIndexOrZero", BaseType: "Integer32"},
+		{Module: "ACME-TC", Name: "AcmeLabel", ParentModule: "VENDOR-TC", ParentName: "VendorString", BaseType: "OctetString"},
+	}
+	if err := db.SaveTypeDefinitions(defs); err != nil {
+		t.Fatalf("SaveTypeDefinitions() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		syntax     string
+		chain      []string
+		base       string
+		incomplete bool
+	}{
+		{name: "textual convention", syntax: "InterfaceIndexOrZero (0..2147483647)", chain: []string{"InterfaceIndexOrZero", "Integer32"}, base: "Integer32"},
+		{name: "nested", syntax: "AcmePortIndex", chain: []string{"AcmePortIndex", "InterfaceIndexOrZero", "Integer32"}, base: "Integer32"},
+		{name: "base type", syntax: "OCTET STRING (SIZE (0..255))", chain: []string{"OCTET STRING"}, base: "OCTET STRING"},
+		{name: "missing parent module", syntax: "AcmeLabel", chain: []string{"AcmeLabel", "VendorString"}, incomplete: true},
+		{name: "unknown type", syntax: "MissingTC", chain: []string{"MissingTC"}, incomplete: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := db.ResolveTypeChain(tt.syntax, "")
+			if err != nil {
+				t.Fatalf("ResolveTypeChain() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Chain, tt.chain) || got.BaseType != tt.base || got.Incomplete != tt.incomplete {
+				t.Fatalf("ResolveTypeChain(%q) = %+v, want chain %v base %q incomplete %v", tt.syntax, got, tt.chain, tt.base, tt.incomplete)
+			}
+		})
+	}
+
+	if chain, err := db.ResolveTypeChain("  ", ""); err != nil || chain != nil {
+		t.Fatalf("ResolveTypeChain(empty) = %v, %v; want nil, nil", chain, err)
+	}
+}
+
+func TestDeleteModuleRemovesTypes(t *testing.T) {
+	db := newTestDB(t)
+
+	if _, err := db.SaveModule("IF-MIB", ""); err != nil {
+		t.Fatalf("SaveModule() error = %v", err)
+	}
+	if err := db.SaveTypeDefinitions([]TypeDefinition{{Module: "IF-MIB", Name: "InterfaceIndex", ParentName: "Integer32"}}); err != nil {
+		t.Fatalf("SaveTypeDefinitions() error = %v", err)
+	}
+	if err := db.DeleteModule("IF-MIB"); err != nil {
+		t.Fatalf("DeleteModule() error = %v", err)
+	}
+
+	def, err := db.GetTypeDefinition("IF-MIB", "InterfaceIndex", "")
+	if err != nil || def != nil {
+		t.Fatalf("GetTypeDefinition() after delete = %v, %v; want nil, nil", def, err)
+	}
+}
@@ -0,0 +1,160 @@
+package mib
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxUISessionBytes è la dimensione massima dello stato UI salvato.
+	MaxUISessionBytes = 256 * 1024
+	// UISessionSchemaVersion identifica il formato dell'involucro salvato in app_metadata.
+	UISessionSchemaVersion = 1
+	// uiSessionVersions è il numero di versioni conservate per il recupero.
+	uiSessionVersions = 3
+	// uiSessionKeyPrefix precede il numero di slot nelle chiavi di app_metadata (1 = più recente).
+	uiSessionKeyPrefix = "ui_session."
+)
+
+// UISession è una versione salvata dello stato dell'interfaccia. State è un JSON definito dal frontend.
+type UISession struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	SavedAt       string          `json:"savedAt"`
+	State         json.RawMessage `json:"state"`
+}
+
+func uiSessionKey(slot int) string {
+	return fmt.Sprintf("%s%d", uiSessionKeyPrefix, slot)
+}
+
+// ValidateUISessionState verifica dimensione e formato JSON dello stato UI.
+func ValidateUISessionState(state string) error {
+	if len(state) > MaxUISessionBytes {
+		return fmt.Errorf("UI session state is %d bytes, limit is %d", len(state), MaxUISessionBytes)
+	}
+	if strings.TrimSpace(state) == "" || !json.Valid([]byte(state)) {
+		return fmt.Errorf("UI session state must be valid JSON")
+	}
+	return nil
+}
+
+// SaveUISession salva lo stato UI come versione più recente, conservando le ultime versioni precedenti.
+// Uno stato identico all'ultimo salvato non crea una nuova versione.
+func (d *Database) SaveUISession(state string) error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	if err := ValidateUISessionState(state); err != nil {
+		return err
+	}
+	// Lo stato viene compattato, così il confronto con l'ultima versione non dipende dalla formattazione.
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(state)); err != nil {
+		return fmt.Errorf("UI session state must be valid JSON")
+	}
+	state = compact.String()
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var latest string
+	err = tx.QueryRow(`SELECT value FROM app_metadata WHERE key = ?`, uiSessionKey(1)).Scan(&latest)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to load UI session: %w", err)
+	}
+	if err == nil {
+		var previous UISession
+		if json.Unmarshal([]byte(latest), &previous) == nil && string(previous.State) == state {
+			return nil
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM app_metadata WHERE key = ?`, uiSessionKey(uiSessionVersions)); err != nil {
+		return fmt.Errorf("failed to rotate UI sessions: %w", err)
+	}
+	for slot := uiSessionVersions - 1; slot >= 1; slot-- {
+		if _, err := tx.Exec(`UPDATE app_metadata SET key = ? WHERE key = ?`, uiSessionKey(slot+1), uiSessionKey(slot)); err != nil {
+			return fmt.Errorf("failed to rotate UI sessions: %w", err)
+		}
+	}
+
+	envelope, err := json.Marshal(UISession{
+		SchemaVersion: UISessionSchemaVersion,
+		SavedAt:       time.Now().Format(time.RFC3339),
+		State:         json.RawMessage(state),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode UI session: %w", err)
+	}
+	if _, err := tx.Exec(`INSERT INTO app_metadata (key, value) VALUES (?, ?)`, uiSessionKey(1), string(envelope)); err != nil {
+		return fmt.Errorf("failed to save UI session: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetUISessions restituisce le versioni salvate leggibili, dalla più recente.
+// Le versioni corrotte o scritte da un formato più recente vengono saltate.
+func (d *Database) GetUISessions() ([]UISession, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	sessions := []UISession{}
+	for slot := 1; slot <= uiSessionVersions; slot++ {
+		var value string
+		err := d.db.QueryRow(`SELECT value FROM app_metadata WHERE key = ?`, uiSessionKey(slot)).Scan(&value)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load UI session: %w", err)
+		}
+
+		var session UISession
+		if err := json.Unmarshal([]byte(value), &session); err != nil || len(session.State) == 0 {
+			continue
+		}
+		if session.SchemaVersion < 1 || session.SchemaVersion > UISessionSchemaVersion {
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// LoadUISession restituisce la versione più recente leggibile dello stato UI, o nil se non ce ne sono.
+func (d *Database) LoadUISession() (*UISession, error) {
+	sessions, err := d.GetUISessions()
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	return &sessions[0], nil
+}
+
+// ClearUISession elimina tutte le versioni salvate dello stato UI.
+func (d *Database) ClearUISession() error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	keys := make([]interface{}, uiSessionVersions)
+	for i := range keys {
+		keys[i] = uiSessionKey(i + 1)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(keys)), ",")
+	if _, err := d.db.Exec(`DELETE FROM app_metadata WHERE key IN (`+placeholders+`)`, keys...); err != nil {
+		return fmt.Errorf("failed to clear UI session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package mib
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUISessionKeepsLastVersions(t *testing.T) {
+	db := newTestDB(t)
+
+	for _, state := range []string{`{"tab":1}`, `{"tab":2}`, `{"tab": 2}`, `{"tab":3}`, `{"tab":4}`} {
+		if err := db.SaveUISession(state); err != nil {
+			t.Fatalf("SaveUISession(%s) error = %v", state, err)
+		}
+	}
+
+	sessions, err := db.GetUISessions()
+	if err != nil {
+		t.Fatalf("GetUISessions() error = %v", err)
+	}
+	var states []string
+	for _, session := range sessions {
+		if session.SchemaVersion != UISessionSchemaVersion {
+			t.Fatalf("SchemaVersion = %d, want %d", session.SchemaVersion, UISessionSchemaVersion)
+		}
+		states = append(states, string(session.State))
+	}
+	if got, want := strings.Join(states, " "), `{"tab":4} {"tab":3} {"tab":2}`; got != want {
+		t.Fatalf("saved versions = %s, want %s", got, want)
+	}
+
+	if err := db.ClearUISession(); err != nil {
+		t.Fatalf("ClearUISession() error = %v", err)
+	}
+	session, err := db.LoadUISession()
+	if err != nil || session != nil {
+		t.Fatalf("LoadUISession() after clear = %v, %v; want nil, nil", session, err)
+	}
+}
+
+func TestUISessionFallsBackToReadableVersion(t *testing.T) {
+	db := newTestDB(t)
+
+	if err := db.SaveUISession(`{"host":"10.0.0.1"}`); err != nil {
+		t.Fatalf("SaveUISession() error = %v", err)
+	}
+	if err := db.SaveUISession(`{"host":"10.0.0.2"}`); err != nil {
+		t.Fatalf("SaveUISession() error = %v", err)
+	}
+	if _, err := db.db.Exec(`UPDATE app_metadata SET value = ? WHERE key = ?`, `{"schemaVersion":99,"state":{}}`, uiSessionKey(1)); err != nil {
+		t.Fatalf("corrupt latest session: %v", err)
+	}
+
+	session, err := db.LoadUISession()
+	if err != nil {
+		t.Fatalf("LoadUISession() error = %v", err)
+	}
+	if session == nil || string(session.State) != `{"host":"10.0.0.1"}` {
+		t.Fatalf("LoadUISession() = %+v, want the previous version", session)
+	}
+}
+
+func TestUISessionValidation(t *testing.T) {
+	db := newTestDB(t)
+
+	if err := db.SaveUISession(`not json`); err == nil {
+		t.Fatalf("expected invalid JSON to be rejected")
+	}
+	large := `{"blob":"` + strings.Repeat("x", MaxUISessionBytes) + `"}`
+	if err := db.SaveUISession(large); err == nil {
+		t.Fatalf("expected oversized state to be rejected")
+	}
+}
@@ -0,0 +1,215 @@
+package mib
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// WalkProfile rappresenta un preset di WALK: OID radice e opzioni di esecuzione.
+type WalkProfile struct {
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	RootOID        string `json:"rootOid"`
+	UseBulk        bool   `json:"useBulk"`
+	MaxRepetitions int    `json:"maxRepetitions"`
+	Filter         string `json:"filter,omitempty"`
+	MaxResults     int    `json:"maxResults"`
+	// MaxDurationMs limita la durata del WALK in millisecondi (0 = nessun limite).
+	MaxDurationMs int    `json:"maxDurationMs"`
+	CreatedAt     string `json:"createdAt"`
+	UpdatedAt     string `json:"updatedAt"`
+}
+
+// ensureWalkProfileSchema crea la tabella dei profili di WALK se mancante.
+func (d *Database) ensureWalkProfileSchema() error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	if _, err := d.db.Exec(`CREATE TABLE IF NOT EXISTS walk_profiles (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		root_oid TEXT NOT NULL,
+		use_bulk INTEGER NOT NULL DEFAULT 0,
+		max_repetitions INTEGER NOT NULL DEFAULT 0,
+		filter TEXT NOT NULL DEFAULT '',
+		max_results INTEGER NOT NULL DEFAULT 0,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return fmt.Errorf("failed to ensure walk_profiles table: %w", err)
+	}
+
+	if _, err := d.db.Exec(`ALTER TABLE walk_profiles ADD COLUMN max_duration_ms INTEGER NOT NULL DEFAULT 0`); err != nil {
+		if !strings.Contains(strings.ToLower(err.Error()), "duplicate column name") {
+			return fmt.Errorf("failed to add max_duration_ms column to walk_profiles: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// NormalizeWalkProfile valida un profilo e ne restituisce una copia con nome, OID radice e filtro ripuliti.
+func NormalizeWalkProfile(profile WalkProfile) (WalkProfile, error) {
+	profile.Name = strings.TrimSpace(profile.Name)
+	if profile.Name == "" {
+		return profile, fmt.Errorf("profile name is required")
+	}
+
+	profile.RootOID = strings.TrimLeft(strings.TrimSpace(profile.RootOID), ".")
+	if profile.RootOID == "" {
+		return profile, fmt.Errorf("root OID is required")
+	}
+
+	if profile.MaxRepetitions < 0 || profile.MaxRepetitions > 255 {
+		return profile, fmt.Errorf("max repetitions must be between 0 and 255")
+	}
+	if profile.MaxResults < 0 {
+		return profile, fmt.Errorf("max results cannot be negative")
+	}
+	if profile.MaxDurationMs < 0 {
+		return profile, fmt.Errorf("max duration cannot be negative")
+	}
+
+	profile.Filter = strings.TrimSpace(profile.Filter)
+	return profile, nil
+}
+
+// SaveWalkProfile crea un nuovo profilo (ID pari a zero) o aggiorna quello esistente.
+func (d *Database) SaveWalkProfile(profile WalkProfile) (*WalkProfile, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	profile, err := NormalizeWalkProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	name, rootOID, maxRepetitions, filter := profile.Name, profile.RootOID, profile.MaxRepetitions, profile.Filter
+
+	id := profile.ID
+	if id == 0 {
+		result, err := d.db.Exec(`
+			INSERT INTO walk_profiles (name, root_oid, use_bulk, max_repetitions, filter, max_results, max_duration_ms)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, name, rootOID, profile.UseBulk, maxRepetitions, filter, profile.MaxResults, profile.MaxDurationMs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create walk profile: %w", err)
+		}
+		id, err = result.LastInsertId()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve new walk profile id: %w", err)
+		}
+	} else {
+		result, err := d.db.Exec(`
+			UPDATE walk_profiles
+			SET name = ?, root_oid = ?, use_bulk = ?, max_repetitions = ?, filter = ?, max_results = ?,
+			    max_duration_ms = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE id = ?
+		`, name, rootOID, profile.UseBulk, maxRepetitions, filter, profile.MaxResults, profile.MaxDurationMs, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update walk profile: %w", err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect walk profile update: %w", err)
+		}
+		if affected == 0 {
+			return nil, fmt.Errorf("walk profile %d not found", id)
+		}
+	}
+
+	return d.GetWalkProfile(id)
+}
+
+// GetWalkProfile recupera un profilo a partire dal suo ID. Restituisce nil se non esiste.
+func (d *Database) GetWalkProfile(id int64) (*WalkProfile, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	row := d.db.QueryRow(`
+		SELECT id, name, root_oid, use_bulk, max_repetitions, filter, max_results, max_duration_ms, created_at, updated_at
+		FROM walk_profiles
+		WHERE id = ?
+	`, id)
+
+	profile, err := scanWalkProfile(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load walk profile: %w", err)
+	}
+	return profile, nil
+}
+
+// ListWalkProfiles restituisce tutti i profili ordinati per nome.
+func (d *Database) ListWalkProfiles() ([]WalkProfile, error) {
+	if d == nil || d.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	rows, err := d.db.Query(`
+		SELECT id, name, root_oid, use_bulk, max_repetitions, filter, max_results, max_duration_ms, created_at, updated_at
+		FROM walk_profiles
+		ORDER BY name COLLATE NOCASE ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list walk profiles: %w", err)
+	}
+	defer rows.Close()
+
+	profiles := []WalkProfile{}
+	for rows.Next() {
+		profile, err := scanWalkProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan walk profile: %w", err)
+		}
+		profiles = append(profiles, *profile)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed during walk profile iteration: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// DeleteWalkProfile elimina un profilo di WALK.
+func (d *Database) DeleteWalkProfile(id int64) error {
+	if d == nil || d.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	if id <= 0 {
+		return fmt.Errorf("profile id is required")
+	}
+
+	if _, err := d.db.Exec(`DELETE FROM walk_profiles WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete walk profile: %w", err)
+	}
+	return nil
+}
+
+// rowScanner astrae sql.Row e sql.Rows per condividere la logica di scansione.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanWalkProfile(scanner rowScanner) (*WalkProfile, error) {
+	profile := &WalkProfile{}
+	if err := scanner.Scan(
+		&profile.ID, &profile.Name, &profile.RootOID, &profile.UseBulk, &profile.MaxRepetitions,
+		&profile.Filter, &profile.MaxResults, &profile.MaxDurationMs, &profile.CreatedAt, &profile.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if parsed, err := parseTimestamp(profile.CreatedAt); err == nil && parsed != "" {
+		profile.CreatedAt = parsed
+	}
+	if parsed, err := parseTimestamp(profile.UpdatedAt); err == nil && parsed != "" {
+		profile.UpdatedAt = parsed
+	}
+	return profile, nil
+}
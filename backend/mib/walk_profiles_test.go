@@ -0,0 +1,56 @@
+package mib
+
+import "testing"
+
+func TestWalkProfileCRUD(t *testing.T) {
+	db := newTestDB(t)
+
+	created, err := db.SaveWalkProfile(WalkProfile{
+		Name:           "System group",
+		RootOID:        ".1.3.6.1.2.1.1",
+		UseBulk:        true,
+		MaxRepetitions: 20,
+		Filter:         "sysName, sysDescr",
+		MaxResults:     50,
+	})
+	if err != nil {
+		t.Fatalf("SaveWalkProfile create error: %v", err)
+	}
+	if created.ID == 0 || created.RootOID != "1.3.6.1.2.1.1" || !created.UseBulk || created.MaxRepetitions != 20 {
+		t.Fatalf("unexpected created profile: %+v", created)
+	}
+
+	created.Name = "System"
+	created.UseBulk = false
+	updated, err := db.SaveWalkProfile(*created)
+	if err != nil {
+		t.Fatalf("SaveWalkProfile update error: %v", err)
+	}
+	if updated.Name != "System" || updated.UseBulk {
+		t.Fatalf("unexpected updated profile: %+v", updated)
+	}
+
+	if _, err := db.SaveWalkProfile(WalkProfile{Name: "System", RootOID: "1.3.6.1.2.1.2"}); err == nil {
+		t.Fatalf("expected duplicate profile name to fail")
+	}
+
+	profiles, err := db.ListWalkProfiles()
+	if err != nil {
+		t.Fatalf("ListWalkProfiles error: %v", err)
+	}
+	if len(profiles) != 1 {
+		t.Fatalf("expected 1 profile, got %d", len(profiles))
+	}
+
+	if err := db.DeleteWalkProfile(created.ID); err != nil {
+		t.Fatalf("DeleteWalkProfile error: %v", err)
+	}
+
+	missing, err := db.GetWalkProfile(created.ID)
+	if err != nil {
+		t.Fatalf("GetWalkProfile error: %v", err)
+	}
+	if missing != nil {
+		t.Fatalf("expected deleted profile to be missing, got %+v", missing)
+	}
+}
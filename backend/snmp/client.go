@@ -1,12 +1,15 @@
 package snmp
 
 import (
+	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"net"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gosnmp/gosnmp"
@@ -26,31 +29,87 @@ type Config struct {
 	AuthPassword     string `json:"authPassword,omitempty"`
 	PrivProtocol     string `json:"privProtocol,omitempty"`
 	PrivPassword     string `json:"privPassword,omitempty"`
+	// AllowVersionFallback abilita un secondo tentativo in SNMPv1 quando un agent non risponde in v2c.
+	AllowVersionFallback bool `json:"allowVersionFallback,omitempty"`
+	// SourcePort fissa la porta UDP locale delle richieste, per i firewall che accettano solo porte note.
+	// 0 (predefinito) lascia scegliere al sistema una porta effimera casuale a ogni richiesta.
+	// Viene applicata tramite gosnmp.LocalAddr come ":porta" (tutte le interfacce locali): con una porta
+	// fissa due richieste contemporanee dello stesso processo non possono condividerla e la seconda fallisce.
+	SourcePort int `json:"sourcePort,omitempty"`
+	// SerializeRequests consente una sola richiesta in corso alla volta verso l'host, anche tra client
+	// diversi, per gli agent che confondono le risposte a richieste ravvicinate.
+	SerializeRequests bool `json:"serializeRequests,omitempty"`
+	// MaxDurationMs limita la durata complessiva di un WALK, in millisecondi: allo scadere il WALK
+	// si interrompe e restituisce i risultati raccolti fino a quel momento. 0 (predefinito) non pone limiti.
+	MaxDurationMs int `json:"maxDurationMs,omitempty"`
+	// TimeoutMs è il timeout di ogni singola richiesta in millisecondi e Retries il numero di
+	// ritrasmissioni dopo un timeout; 0 (predefinito) usa DefaultTimeout e DefaultRetries.
+	TimeoutMs int `json:"timeoutMs,omitempty"`
+	Retries   int `json:"retries,omitempty"`
+}
+
+// MaxDuration restituisce il limite di durata dei WALK configurato (0 se assente).
+func (c Config) MaxDuration() time.Duration {
+	if c.MaxDurationMs <= 0 {
+		return 0
+	}
+	return time.Duration(c.MaxDurationMs) * time.Millisecond
 }
 
 // Result risultato operazione SNMP
 type Result struct {
-	OID          string `json:"oid"`
-	Value        string `json:"value"`
-	Type         string `json:"type"`
-	Status       string `json:"status"`
-	ResponseTime int64  `json:"responseTime"`
-	Timestamp    string `json:"timestamp"`
-	ResolvedName string `json:"resolvedName"`
-	RawValue     string `json:"rawValue,omitempty"`
-	DisplayValue string `json:"displayValue,omitempty"`
-	Syntax       string `json:"syntax,omitempty"`
+	OID          string       `json:"oid"`
+	Value        string       `json:"value"`
+	Type         string       `json:"type"`
+	Status       string       `json:"status"`
+	ResponseTime int64        `json:"responseTime"`
+	Timestamp    string       `json:"timestamp"`
+	ResolvedName string       `json:"resolvedName"`
+	RawValue     string       `json:"rawValue,omitempty"`
+	DisplayValue string       `json:"displayValue,omitempty"`
+	Syntax       string       `json:"syntax,omitempty"`
+	ErrorDetail  *ErrorDetail `json:"errorDetail,omitempty"`
+	Host         string       `json:"host,omitempty"`
+	Version      string       `json:"version,omitempty"`
+	// TypeMismatch segnala che il tipo restituito dall'agent non è compatibile con la sintassi MIB del nodo.
+	TypeMismatch   bool   `json:"typeMismatch,omitempty"`
+	ExpectedSyntax string `json:"expectedSyntax,omitempty"`
+	// AutoCorrectedFrom riporta l'OID richiesto quando la GET è stata ripetuta aggiungendo l'istanza `.0`.
+	AutoCorrectedFrom string `json:"autoCorrectedFrom,omitempty"`
+	// StatusWarning avvisa che l'oggetto MIB è deprecated o obsolete (es. "object deprecated since IF-MIB rev 2000-06-14").
+	StatusWarning string `json:"statusWarning,omitempty"`
 }
 
+const (
+	// DefaultTimeout è il timeout per singola richiesta usato dai client.
+	DefaultTimeout = 5 * time.Second
+	// DefaultRetries è il numero di ritrasmissioni dopo un timeout.
+	DefaultRetries = 2
+	// minBulkRepetitions è il minimo di max-repetitions a cui scende GetBulk dopo risposte tooBig.
+	minBulkRepetitions = 1
+)
+
 // Client client SNMP
 type Client struct {
-	snmp *gosnmp.GoSNMP
-	cfg  Config
+	snmp    *gosnmp.GoSNMP
+	cfg     Config
+	capture *DebugCapture
+	// downshifts conta le riduzioni della richiesta dopo un tooBig nell'ultima operazione.
+	downshifts int
+	// engineSyncFailed segnala che l'operazione in corso è fallita per engine ID o engine time non validi.
+	engineSyncFailed bool
+	// tracker verifica il request-id delle risposte dell'operazione in corso.
+	tracker *requestTracker
+	// lock è il mutex del target trattenuto tra Connect e Close quando SerializeRequests è attivo.
+	lock *sync.Mutex
 }
 
 // NewClient crea nuovo client SNMP
 func NewClient(config Config) (*Client, error) {
-	host := strings.TrimSpace(config.Host)
+	host, err := NormalizeHost(config.Host)
+	if err != nil {
+		return nil, err
+	}
 
 	port := config.Port
 	if port <= 0 {
@@ -60,9 +119,28 @@ func NewClient(config Config) (*Client, error) {
 	client := &gosnmp.GoSNMP{
 		Target:  host,
 		Port:    uint16(port),
-		Timeout: 5 * time.Second,
-		Retries: 2,
+		Timeout: DefaultTimeout,
+		Retries: DefaultRetries,
+	}
+
+	if config.TimeoutMs < 0 {
+		return nil, fmt.Errorf("timeout non valido: %d ms", config.TimeoutMs)
+	}
+	if config.TimeoutMs > 0 {
+		client.Timeout = time.Duration(config.TimeoutMs) * time.Millisecond
+	}
+	if config.Retries < 0 {
+		return nil, fmt.Errorf("numero di ritrasmissioni non valido: %d", config.Retries)
+	}
+	if config.Retries > 0 {
+		client.Retries = config.Retries
+	}
+
+	localAddr, err := localAddrForSourcePort(config.SourcePort)
+	if err != nil {
+		return nil, err
 	}
+	client.LocalAddr = localAddr
 
 	version := strings.ToLower(strings.TrimSpace(config.Version))
 	switch version {
@@ -175,16 +253,162 @@ func NewClient(config Config) (*Client, error) {
 	return &Client{snmp: client, cfg: cfg}, nil
 }
 
-// Connect connette al target
+// NormalizeHost valida l'host di una Config e lo restituisce nella forma attesa da gosnmp. Gli
+// indirizzi IPv6 sono accettati anche tra parentesi quadre ("[::1]") e restituiti senza parentesi e
+// in forma canonica, perché gosnmp aggiunge da sé le parentesi quando compone host e porta; lo zone
+// ("fe80::1%eth0") viene conservato. Gli IPv4 sono restituiti in forma canonica, i nomi host così come sono.
+func NormalizeHost(host string) (string, error) {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		return "", fmt.Errorf("host is required")
+	}
+
+	bracketed := strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]")
+	if bracketed {
+		host = host[1 : len(host)-1]
+	}
+
+	address, zone, hasZone := strings.Cut(host, "%")
+	if ip := net.ParseIP(address); ip != nil {
+		if !strings.Contains(address, ":") {
+			if bracketed || hasZone {
+				return "", fmt.Errorf("invalid host %q", host)
+			}
+			return ip.String(), nil
+		}
+		if !hasZone {
+			return ip.String(), nil
+		}
+		if zone == "" {
+			return "", fmt.Errorf("invalid host %q: empty IPv6 zone", host)
+		}
+		return ip.String() + "%" + zone, nil
+	}
+
+	if bracketed || hasZone || !isValidHostname(host) {
+		return "", fmt.Errorf("invalid host %q", host)
+	}
+	return host, nil
+}
+
+// IsIPv6 indica se host, eventualmente tra parentesi quadre o con zone, è un indirizzo IPv6 letterale.
+func IsIPv6(host string) bool {
+	normalized, err := NormalizeHost(host)
+	if err != nil {
+		return false
+	}
+	address, _, _ := strings.Cut(normalized, "%")
+	return net.ParseIP(address) != nil && strings.Contains(address, ":")
+}
+
+// isValidHostname verifica che host sia composto da etichette DNS di lettere, cifre, trattini e
+// underscore, senza etichette vuote.
+func isValidHostname(host string) bool {
+	if len(host) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(host, "."), ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// localAddrForSourcePort restituisce il LocalAddr di gosnmp per la porta sorgente indicata:
+// vuoto (porta effimera scelta dal sistema) per 0, ":porta" per una porta fissa.
+func localAddrForSourcePort(sourcePort int) (string, error) {
+	if sourcePort == 0 {
+		return "", nil
+	}
+	if sourcePort < 0 || sourcePort > 65535 {
+		return "", fmt.Errorf("porta sorgente non valida: %d", sourcePort)
+	}
+	return ":" + strconv.Itoa(sourcePort), nil
+}
+
+// SetContext imposta il contesto che, se annullato, interrompe le richieste in corso del client.
+func (c *Client) SetContext(ctx context.Context) {
+	if ctx != nil {
+		c.snmp.Context = ctx
+	}
+}
+
+// SetTimeout imposta il timeout delle richieste successive del client; un valore non positivo
+// ripristina DefaultTimeout.
+func (c *Client) SetTimeout(d time.Duration) {
+	if d <= 0 {
+		d = DefaultTimeout
+	}
+	c.snmp.Timeout = d
+}
+
+// SetRetries imposta il numero di ritrasmissioni dopo un timeout per le richieste successive del
+// client; 0 disattiva le ritrasmissioni, un valore negativo ripristina DefaultRetries.
+func (c *Client) SetRetries(n int) {
+	if n < 0 {
+		n = DefaultRetries
+	}
+	c.snmp.Retries = n
+}
+
+// Connect connette al target. Per SNMPv3 riusa engine ID e boots/time già noti per il target;
+// con SerializeRequests attende che le altre operazioni verso lo stesso target siano terminate.
 func (c *Client) Connect() error {
-	return c.snmp.Connect()
+	if c.cfg.SerializeRequests {
+		c.lock = requestLock(c.engineTarget())
+		c.lock.Lock()
+	}
+	c.loadEngineTime()
+	if err := c.snmp.Connect(); err != nil {
+		c.unlock()
+		return err
+	}
+	c.tracker = newRequestTracker()
+	c.snmp.Conn = &trackingConn{Conn: c.snmp.Conn, tracker: c.tracker}
+	if c.capture != nil {
+		c.snmp.Conn = &captureConn{Conn: c.snmp.Conn, capture: c.capture, secrets: c.communitySecrets()}
+	}
+	return nil
+}
+
+// communitySecrets restituisce le community v1/v2c del client, da mascherare nei pacchetti catturati.
+func (c *Client) communitySecrets() [][]byte {
+	var secrets [][]byte
+	for _, community := range []string{c.cfg.Community, c.cfg.WriteCommunity} {
+		if community = strings.TrimSpace(community); community != "" {
+			secrets = append(secrets, []byte(community))
+		}
+	}
+	return secrets
 }
 
-// Close chiude la connessione
+// Close chiude la connessione, aggiorna la cache dei parametri dell'engine SNMPv3 e rilascia il target.
 func (c *Client) Close() error {
+	defer c.unlock()
+	c.saveEngineTime()
 	return c.snmp.Conn.Close()
 }
 
+// unlock rilascia il mutex del target acquisito da Connect, se presente.
+func (c *Client) unlock() {
+	if c.lock != nil {
+		c.lock.Unlock()
+		c.lock = nil
+	}
+}
+
+// operationError classifica l'errore restituito da gosnmp per l'operazione in corso: annota gli errori
+// di sincronizzazione SNMPv3 e distingue i timeout dovuti a risposte con request-id errato.
+func (c *Client) operationError(err error) error {
+	return c.requestIDMismatchError(c.checkEngineSync(err))
+}
+
 // Get esegue SNMP GET
 func (c *Client) Get(oid string) (*Result, error) {
 	start := time.Now()
@@ -196,6 +420,7 @@ func (c *Client) Get(oid string) (*Result, error) {
 	defer c.Close()
 
 	result, err := c.snmp.Get([]string{oid})
+	err = c.operationError(err)
 	if err != nil {
 		return &Result{
 			OID:          oid,
@@ -221,6 +446,147 @@ func (c *Client) Get(oid string) (*Result, error) {
 	}, nil
 }
 
+// GetMany esegue SNMP GET su più OID, raggruppandoli in PDU di al massimo MaxOids varbind.
+// Se l'agent risponde tooBig la PDU viene divisa a metà e ripetuta, fino al singolo varbind.
+// Un noSuchName (SNMPv1) marca come NoSuchObject, con il dettaglio d'errore, il solo varbind
+// indicato dall'error-index e la richiesta viene ripetuta senza di esso; gli altri error-status
+// fanno fallire l'operazione.
+func (c *Client) GetMany(oids []string) ([]Result, error) {
+	start := time.Now()
+	c.downshifts = 0
+
+	err := c.Connect()
+	if err != nil {
+		return nil, fmt.Errorf("connection failed: %v", err)
+	}
+	defer c.Close()
+
+	chunkSize := c.snmp.MaxOids
+	if chunkSize <= 0 {
+		chunkSize = gosnmp.MaxOids
+	}
+
+	results := make([]Result, 0, len(oids))
+	for offset := 0; offset < len(oids); offset += chunkSize {
+		end := offset + chunkSize
+		if end > len(oids) {
+			end = len(oids)
+		}
+
+		variables, err := c.getSplittingTooBig(oids[offset:end])
+		if err != nil {
+			return results, err
+		}
+
+		for _, variable := range variables {
+			result := Result{
+				OID:          variable.pdu.Name,
+				Value:        formatPDUValue(variable.pdu),
+				Type:         variable.pdu.Type.String(),
+				Status:       "success",
+				ResponseTime: time.Since(start).Milliseconds(),
+				Timestamp:    time.Now().Format(time.RFC3339),
+			}
+			if variable.detail != nil {
+				result.Status = "error"
+				result.ErrorDetail = variable.detail
+			}
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+// getVarbind è un varbind di GetMany; detail è valorizzato per i varbind rifiutati dall'agent.
+type getVarbind struct {
+	pdu    gosnmp.SnmpPDU
+	detail *ErrorDetail
+}
+
+// getSplittingTooBig esegue una GET dividendo ricorsivamente a metà i varbind finché l'agent risponde tooBig.
+func (c *Client) getSplittingTooBig(oids []string) ([]getVarbind, error) {
+	packet, err := c.snmp.Get(oids)
+	err = c.operationError(err)
+	if err != nil {
+		return nil, err
+	}
+	switch packet.Error {
+	case gosnmp.NoError:
+		variables := make([]getVarbind, len(packet.Variables))
+		for i, variable := range packet.Variables {
+			variables[i] = getVarbind{pdu: variable}
+		}
+		return variables, nil
+	case gosnmp.TooBig:
+	case gosnmp.NoSuchName:
+		return c.getWithoutMissing(oids, packet)
+	default:
+		return nil, newErrorDetail(packet, nil)
+	}
+	if len(oids) <= 1 {
+		return nil, newErrorDetail(packet, nil)
+	}
+
+	c.downshifts++
+	half := len(oids) / 2
+	first, err := c.getSplittingTooBig(oids[:half])
+	if err != nil {
+		return nil, err
+	}
+	second, err := c.getSplittingTooBig(oids[half:])
+	if err != nil {
+		return nil, err
+	}
+	return append(first, second...), nil
+}
+
+// getWithoutMissing gestisce un noSuchName: in SNMPv1 l'agent rifiuta l'intera PDU per un solo OID
+// inesistente, quindi quel varbind viene marcato e gli altri richiesti di nuovo.
+func (c *Client) getWithoutMissing(oids []string, packet *gosnmp.SnmpPacket) ([]getVarbind, error) {
+	idx := int(packet.ErrorIndex) - 1
+	if idx < 0 || idx >= len(oids) {
+		return nil, newErrorDetail(packet, nil)
+	}
+
+	missing := getVarbind{
+		pdu:    gosnmp.SnmpPDU{Name: oids[idx], Type: gosnmp.NoSuchObject},
+		detail: newErrorDetail(packet, nil),
+	}
+	if idx < len(packet.Variables) {
+		missing.pdu.Name = packet.Variables[idx].Name
+	}
+	if missing.detail.OID == "" {
+		missing.detail.OID = missing.pdu.Name
+	}
+
+	if len(oids) == 1 {
+		return []getVarbind{missing}, nil
+	}
+
+	// Ogni GET restituisce un varbind per OID richiesto, nello stesso ordine: il varbind marcato
+	// torna nella sua posizione.
+	remaining := make([]string, 0, len(oids)-1)
+	remaining = append(remaining, oids[:idx]...)
+	remaining = append(remaining, oids[idx+1:]...)
+	others, err := c.getSplittingTooBig(remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := make([]getVarbind, 0, len(oids))
+	variables = append(variables, others[:idx]...)
+	variables = append(variables, missing)
+	variables = append(variables, others[idx:]...)
+	return variables, nil
+}
+
+// Downshifts restituisce quante volte l'ultima GetMany o GetBulk ha ridotto la richiesta dopo un tooBig
+// (PDU divisa a metà o max-repetitions dimezzato).
+func (c *Client) Downshifts() int {
+	return c.downshifts
+}
+
 // GetNext esegue SNMP GETNEXT
 func (c *Client) GetNext(oid string) (*Result, error) {
 	start := time.Now()
@@ -232,6 +598,7 @@ func (c *Client) GetNext(oid string) (*Result, error) {
 	defer c.Close()
 
 	result, err := c.snmp.GetNext([]string{oid})
+	err = c.operationError(err)
 	if err != nil {
 		return &Result{
 			OID:          oid,
@@ -280,6 +647,7 @@ func (c *Client) Walk(oid string) ([]Result, error) {
 		})
 		return nil
 	})
+	err = c.operationError(err)
 
 	if err != nil {
 		return results, err
@@ -288,8 +656,103 @@ func (c *Client) Walk(oid string) ([]Result, error) {
 	return results, nil
 }
 
-// GetBulk esegue SNMP GETBULK
-func (c *Client) GetBulk(oid string, maxRepetitions uint8) ([]Result, error) {
+// errWalkLimitReached interrompe il WALK di gosnmp quando WalkN ha raccolto abbastanza risultati.
+var errWalkLimitReached = errors.New("walk limit reached")
+
+// WalkN esegue un WALK fermandosi dopo limit risultati (limit <= 0 equivale a Walk).
+// Il secondo valore indica se il WALK è stato troncato.
+func (c *Client) WalkN(oid string, limit int) ([]Result, bool, error) {
+	start := time.Now()
+
+	err := c.Connect()
+	if err != nil {
+		return nil, false, fmt.Errorf("connection failed: %v", err)
+	}
+	defer c.Close()
+
+	results := []Result{}
+	truncated := false
+
+	err = c.snmp.Walk(oid, func(variable gosnmp.SnmpPDU) error {
+		if limit > 0 && len(results) >= limit {
+			truncated = true
+			return errWalkLimitReached
+		}
+		results = append(results, Result{
+			OID:          variable.Name,
+			Value:        formatPDUValue(variable),
+			Type:         variable.Type.String(),
+			Status:       "success",
+			ResponseTime: time.Since(start).Milliseconds(),
+			Timestamp:    time.Now().Format(time.RFC3339),
+		})
+		return nil
+	})
+	err = c.operationError(err)
+
+	if err != nil && !errors.Is(err, errWalkLimitReached) {
+		return results, truncated, err
+	}
+
+	return results, truncated, nil
+}
+
+// WalkWithContext esegue un WALK interrotto dall'annullamento di ctx o, se maxDuration > 0, allo
+// scadere di maxDuration. Il secondo valore indica che il WALK è stato interrotto dal limite di durata:
+// in quel caso i risultati parziali sono restituiti senza errore. La richiesta in corso allo scadere
+// viene attesa fino alla risposta o al timeout della singola richiesta.
+func (c *Client) WalkWithContext(ctx context.Context, oid string, maxDuration time.Duration) ([]Result, bool, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if maxDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, maxDuration)
+		defer cancel()
+	}
+	previous := c.snmp.Context
+	c.SetContext(ctx)
+	defer func() { c.snmp.Context = previous }()
+
+	start := time.Now()
+
+	err := c.Connect()
+	if err != nil {
+		return nil, false, fmt.Errorf("connection failed: %v", err)
+	}
+	defer c.Close()
+
+	results := []Result{}
+
+	err = c.snmp.Walk(oid, func(variable gosnmp.SnmpPDU) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		results = append(results, Result{
+			OID:          variable.Name,
+			Value:        formatPDUValue(variable),
+			Type:         variable.Type.String(),
+			Status:       "success",
+			ResponseTime: time.Since(start).Milliseconds(),
+			Timestamp:    time.Now().Format(time.RFC3339),
+		})
+		return nil
+	})
+	// gosnmp porta la scadenza del contesto sul socket: l'errore può arrivare prima che ctx.Err() sia impostato.
+	if err != nil && (errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded)) {
+		return results, true, nil
+	}
+	err = c.operationError(err)
+
+	if err != nil {
+		return results, false, err
+	}
+
+	return results, false, nil
+}
+
+// BulkWalk esegue un WALK del sottoalbero utilizzando richieste GETBULK (SNMPv2c/v3).
+func (c *Client) BulkWalk(oid string, maxRepetitions uint8) ([]Result, error) {
 	start := time.Now()
 
 	err := c.Connect()
@@ -298,11 +761,61 @@ func (c *Client) GetBulk(oid string, maxRepetitions uint8) ([]Result, error) {
 	}
 	defer c.Close()
 
-	c.snmp.MaxRepetitions = uint32(maxRepetitions)
+	if maxRepetitions > 0 {
+		c.snmp.MaxRepetitions = uint32(maxRepetitions)
+	}
+
+	results := []Result{}
+
+	err = c.snmp.BulkWalk(oid, func(variable gosnmp.SnmpPDU) error {
+		results = append(results, Result{
+			OID:          variable.Name,
+			Value:        formatPDUValue(variable),
+			Type:         variable.Type.String(),
+			Status:       "success",
+			ResponseTime: time.Since(start).Milliseconds(),
+			Timestamp:    time.Now().Format(time.RFC3339),
+		})
+		return nil
+	})
+	err = c.operationError(err)
 
-	result, err := c.snmp.GetBulk([]string{oid}, 0, uint32(maxRepetitions))
 	if err != nil {
-		return nil, err
+		return results, err
+	}
+
+	return results, nil
+}
+
+// GetBulk esegue SNMP GETBULK. Se l'agent risponde tooBig, max-repetitions viene dimezzato
+// e la richiesta ripetuta fino a minBulkRepetitions.
+func (c *Client) GetBulk(oid string, maxRepetitions uint8) ([]Result, error) {
+	start := time.Now()
+	c.downshifts = 0
+
+	err := c.Connect()
+	if err != nil {
+		return nil, fmt.Errorf("connection failed: %v", err)
+	}
+	defer c.Close()
+
+	repetitions := uint32(maxRepetitions)
+	var result *gosnmp.SnmpPacket
+	for {
+		c.snmp.MaxRepetitions = repetitions
+		result, err = c.snmp.GetBulk([]string{oid}, 0, repetitions)
+		err = c.operationError(err)
+		if err != nil {
+			return nil, err
+		}
+		if result.Error != gosnmp.TooBig {
+			break
+		}
+		if repetitions <= minBulkRepetitions {
+			return nil, newErrorDetail(result, nil)
+		}
+		repetitions /= 2
+		c.downshifts++
 	}
 
 	results := []Result{}
@@ -347,26 +860,41 @@ func (c *Client) Set(oid string, valueType string, value interface{}) (*Result,
 	}()
 
 	packet, err := c.snmp.Set([]gosnmp.SnmpPDU{pdu})
+	err = c.operationError(err)
 	if err != nil {
-		return &Result{
+		result := &Result{
 			OID:          oid,
 			Status:       "error",
 			ResponseTime: time.Since(start).Milliseconds(),
 			Timestamp:    time.Now().Format(time.RFC3339),
-		}, err
-	}
-
-	if packet == nil || len(packet.Variables) == 0 {
-		return nil, fmt.Errorf("no data received")
+		}
+		if c.snmp.Version != gosnmp.Version3 && isTimeout(err) {
+			// Molti agent scartano in silenzio le SET con community errata: una GET con la community
+			// di lettura distingue un agent irraggiungibile da una community di scrittura sbagliata.
+			c.snmp.Community = originalCommunity
+			cause := CauseAgentUnreachable
+			if _, getErr := c.snmp.Get([]string{oid}); getErr == nil {
+				cause = CauseWriteCommunityRejected
+			}
+			result.ErrorDetail = timeoutErrorDetail(oid, cause)
+			return result, fmt.Errorf("%s: %w", result.ErrorDetail.Error(), err)
+		}
+		return result, err
 	}
 
-	if packet.Error != gosnmp.NoError {
+	if packet != nil && packet.Error != gosnmp.NoError {
+		detail := newErrorDetail(packet, []gosnmp.SnmpPDU{pdu})
 		return &Result{
 			OID:          oid,
 			Status:       "error",
 			ResponseTime: time.Since(start).Milliseconds(),
 			Timestamp:    time.Now().Format(time.RFC3339),
-		}, fmt.Errorf("SNMP error: %s (index %d)", packet.Error, packet.ErrorIndex)
+			ErrorDetail:  detail,
+		}, detail
+	}
+
+	if packet == nil || len(packet.Variables) == 0 {
+		return nil, fmt.Errorf("no data received")
 	}
 
 	variable := packet.Variables[0]
@@ -435,6 +963,13 @@ func isPrintableASCII(data []byte) bool {
 	return true
 }
 
+// ValidateSetValue verifica, senza contattare l'agent, che value sia convertibile nel tipo valueType
+// accettato da Set.
+func ValidateSetValue(valueType string, value interface{}) error {
+	_, err := buildSetPDU("", valueType, value)
+	return err
+}
+
 func buildSetPDU(oid string, valueType string, raw interface{}) (gosnmp.SnmpPDU, error) {
 	vt := strings.ToLower(strings.TrimSpace(valueType))
 	switch vt {
@@ -733,6 +1268,9 @@ func coerceIPAddress(raw interface{}) (string, error) {
 	return ip4.String(), nil
 }
 
+// maxObjectIdentifierArcs limita le componenti di un valore OBJECT IDENTIFIER (RFC 2578, come mib.MaxOIDArcs).
+const maxObjectIdentifierArcs = 128
+
 func coerceObjectIdentifier(raw interface{}) (string, error) {
 	str, err := coerceString(raw)
 	if err != nil {
@@ -741,6 +1279,9 @@ func coerceObjectIdentifier(raw interface{}) (string, error) {
 	if str == "" {
 		return "", fmt.Errorf("OID cannot be empty")
 	}
+	if strings.Count(str, ".") >= maxObjectIdentifierArcs {
+		return "", fmt.Errorf("invalid OID: more than %d components", maxObjectIdentifierArcs)
+	}
 	parts := strings.Split(str, ".")
 	for _, part := range parts {
 		if part == "" {
@@ -1,7 +1,10 @@
 package snmp
 
 import (
+	"net"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gosnmp/gosnmp"
 )
@@ -105,3 +108,150 @@ func TestNewClient(t *testing.T) {
 		}
 	})
 }
+
+func TestClientTimeoutAndRetries(t *testing.T) {
+	client, err := NewClient(Config{Host: "127.0.0.1"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if client.snmp.Timeout != DefaultTimeout || client.snmp.Retries != DefaultRetries {
+		t.Fatalf("expected defaults, got timeout %v and retries %d", client.snmp.Timeout, client.snmp.Retries)
+	}
+
+	client, err = NewClient(Config{Host: "127.0.0.1", TimeoutMs: 1500, Retries: 4})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if client.snmp.Timeout != 1500*time.Millisecond || client.snmp.Retries != 4 {
+		t.Fatalf("expected configured values, got timeout %v and retries %d", client.snmp.Timeout, client.snmp.Retries)
+	}
+
+	client.SetTimeout(30 * time.Second)
+	client.SetRetries(0)
+	if client.snmp.Timeout != 30*time.Second || client.snmp.Retries != 0 {
+		t.Fatalf("setters not applied, got timeout %v and retries %d", client.snmp.Timeout, client.snmp.Retries)
+	}
+	client.SetTimeout(0)
+	client.SetRetries(-1)
+	if client.snmp.Timeout != DefaultTimeout || client.snmp.Retries != DefaultRetries {
+		t.Fatalf("expected defaults to be restored, got timeout %v and retries %d", client.snmp.Timeout, client.snmp.Retries)
+	}
+
+	if _, err := NewClient(Config{Host: "127.0.0.1", TimeoutMs: -1}); err == nil {
+		t.Fatalf("expected a negative timeout to be rejected")
+	}
+	if _, err := NewClient(Config{Host: "127.0.0.1", Retries: -1}); err == nil {
+		t.Fatalf("expected negative retries to be rejected")
+	}
+}
+
+func TestSourcePort(t *testing.T) {
+	client, err := NewClient(Config{Host: "127.0.0.1"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if client.snmp.LocalAddr != "" {
+		t.Fatalf("expected ephemeral source port by default, got %q", client.snmp.LocalAddr)
+	}
+
+	if _, err := NewClient(Config{Host: "127.0.0.1", SourcePort: 70000}); err == nil {
+		t.Fatalf("expected invalid source port to be rejected")
+	}
+
+	// Si ricava una porta libera e si verifica che il pacchetto arrivi da quella porta.
+	probe, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	sourcePort := probe.LocalAddr().(*net.UDPAddr).Port
+	probe.Close()
+
+	receiver, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer receiver.Close()
+
+	client, err = NewClient(Config{
+		Host:       "127.0.0.1",
+		Port:       receiver.LocalAddr().(*net.UDPAddr).Port,
+		SourcePort: sourcePort,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := client.SendTrap("1.3.6.1.6.3.1.1.5.1", nil, false); err != nil {
+		t.Fatalf("SendTrap: %v", err)
+	}
+
+	receiver.SetReadDeadline(time.Now().Add(2 * time.Second))
+	buffer := make([]byte, 2048)
+	_, addr, err := receiver.ReadFrom(buffer)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if got := addr.(*net.UDPAddr).Port; got != sourcePort {
+		t.Fatalf("expected source port %d, got %d", sourcePort, got)
+	}
+}
+
+func TestNormalizeHost(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+		ipv6  bool
+	}{
+		{" 192.168.1.10 ", "192.168.1.10", false},
+		{"::1", "::1", true},
+		{"[::1]", "::1", true},
+		{"[2001:DB8:0:0:0:0:0:1]", "2001:db8::1", true},
+		{"fe80::1%eth0", "fe80::1%eth0", true},
+		{"[fe80::1%eth0]", "fe80::1%eth0", true},
+		{"switch-01.example.com", "switch-01.example.com", false},
+		{"localhost", "localhost", false},
+	}
+	for _, tc := range cases {
+		got, err := NormalizeHost(tc.input)
+		if err != nil {
+			t.Fatalf("NormalizeHost(%q): %v", tc.input, err)
+		}
+		if got != tc.want {
+			t.Errorf("NormalizeHost(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+		if IsIPv6(tc.input) != tc.ipv6 {
+			t.Errorf("IsIPv6(%q) = %v, want %v", tc.input, !tc.ipv6, tc.ipv6)
+		}
+	}
+
+	for _, input := range []string{"", "   ", "[192.168.1.10]", "[switch]", "[::1", "fe80::1%", "10.0.0.1:161", "bad host", "-router", "a..b"} {
+		if _, err := NormalizeHost(input); err == nil {
+			t.Errorf("NormalizeHost(%q): expected error", input)
+		}
+		if IsIPv6(input) {
+			t.Errorf("IsIPv6(%q): expected false", input)
+		}
+	}
+
+	client, err := NewClient(Config{Host: "[::1]"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if client.snmp.Target != "::1" {
+		t.Fatalf("expected bare IPv6 target, got %q", client.snmp.Target)
+	}
+	if _, err := NewClient(Config{Host: "[::1"}); err == nil {
+		t.Fatalf("expected invalid host to be rejected")
+	}
+}
+
+func TestCoerceObjectIdentifierArcLimit(t *testing.T) {
+	atLimit := strings.TrimSuffix(strings.Repeat("1.", maxObjectIdentifierArcs), ".")
+	if _, err := coerceObjectIdentifier(atLimit); err != nil {
+		t.Fatalf("expected %d arcs to be accepted, got %v", maxObjectIdentifierArcs, err)
+	}
+
+	tooDeep := strings.TrimSuffix(strings.Repeat("1.", maxObjectIdentifierArcs+1), ".")
+	if _, err := coerceObjectIdentifier(tooDeep); err == nil {
+		t.Fatalf("expected error for OID with %d arcs", maxObjectIdentifierArcs+1)
+	}
+}
@@ -0,0 +1,196 @@
+package snmp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maskedSecret sostituisce community e password nei comandi generati con MaskSecrets.
+const maskedSecret = "********"
+
+// CommandOptions completa i parametri necessari a BuildNetSNMPCommand.
+type CommandOptions struct {
+	// SetType è il codice di tipo net-snmp per snmpset (i, u, c, C, s, a, o, t, x, b).
+	SetType string
+	// SetValue è il valore da impostare con snmpset.
+	SetValue string
+	// MaskSecrets sostituisce community e password con un segnaposto.
+	MaskSecrets bool
+}
+
+var netSNMPCommands = map[string]string{
+	"get":      "snmpget",
+	"getnext":  "snmpgetnext",
+	"walk":     "snmpwalk",
+	"bulkwalk": "snmpbulkwalk",
+	"set":      "snmpset",
+}
+
+var netSNMPAuthProtocols = map[string]string{
+	"MD5":    "MD5",
+	"SHA":    "SHA",
+	"SHA224": "SHA-224",
+	"SHA256": "SHA-256",
+	"SHA384": "SHA-384",
+	"SHA512": "SHA-512",
+}
+
+var netSNMPPrivProtocols = map[string]string{
+	"DES":     "DES",
+	"AES":     "AES",
+	"AES192":  "AES-192",
+	"AES192C": "AES-192",
+	"AES256":  "AES-256",
+	"AES256C": "AES-256",
+}
+
+// BuildNetSNMPCommand genera la riga di comando net-snmp equivalente a un'operazione dell'app,
+// con gli stessi timeout e ritrasmissioni usati dal client (TimeoutMs e Retries della Config, se impostati). Operazioni supportate:
+// get, getnext, walk, bulkwalk e set.
+func BuildNetSNMPCommand(config Config, operation string, oid string, opts CommandOptions) (string, error) {
+	op := strings.ToLower(strings.TrimSpace(operation))
+	command, ok := netSNMPCommands[op]
+	if !ok {
+		return "", fmt.Errorf("unsupported operation %q", operation)
+	}
+
+	host, err := NormalizeHost(config.Host)
+	if err != nil {
+		return "", err
+	}
+	target := strings.TrimSpace(oid)
+	if target == "" {
+		return "", fmt.Errorf("OID is required")
+	}
+	if !strings.HasPrefix(target, ".") {
+		target = "." + target
+	}
+
+	version := strings.ToLower(strings.TrimSpace(config.Version))
+	if version == "" {
+		version = "v2c"
+	}
+	if version == "v1" && op == "bulkwalk" {
+		return "", fmt.Errorf("bulkwalk requires SNMP v2c or v3")
+	}
+
+	secret := func(value string) string {
+		if opts.MaskSecrets {
+			return maskedSecret
+		}
+		return shellQuote(value)
+	}
+
+	args := []string{command}
+	switch version {
+	case "v1", "v2c":
+		community := strings.TrimSpace(config.Community)
+		if op == "set" && strings.TrimSpace(config.WriteCommunity) != "" {
+			community = strings.TrimSpace(config.WriteCommunity)
+		}
+		if community == "" {
+			community = "public"
+		}
+		args = append(args, "-"+version, "-c", secret(community))
+	case "v3":
+		level, err := normalizeSecurityLevel(config.SecurityLevel)
+		if err != nil {
+			return "", err
+		}
+		username := strings.TrimSpace(config.SecurityUsername)
+		if username == "" {
+			return "", fmt.Errorf("username di sicurezza richiesto per SNMPv3")
+		}
+		args = append(args, "-v3", "-l", level, "-u", shellQuote(username))
+
+		if level == "authNoPriv" || level == "authPriv" {
+			auth, err := normalizeAuthProtocol(config.AuthProtocol)
+			if err != nil {
+				return "", err
+			}
+			if auth == "" {
+				return "", fmt.Errorf("protocollo di autenticazione richiesto per SNMPv3")
+			}
+			args = append(args, "-a", netSNMPAuthProtocols[auth], "-A", secret(config.AuthPassword))
+		}
+		if level == "authPriv" {
+			priv, err := normalizePrivProtocol(config.PrivProtocol)
+			if err != nil {
+				return "", err
+			}
+			if priv == "" {
+				return "", fmt.Errorf("protocollo di privacy richiesto per SNMPv3")
+			}
+			args = append(args, "-x", netSNMPPrivProtocols[priv], "-X", secret(config.PrivPassword))
+		}
+		if contextName := strings.TrimSpace(config.ContextName); contextName != "" {
+			args = append(args, "-n", shellQuote(contextName))
+		}
+	default:
+		return "", fmt.Errorf("versione SNMP non supportata: %s", config.Version)
+	}
+
+	// Come in NewClient, 0 indica i valori predefiniti; net-snmp accetta timeout frazionari in secondi.
+	if config.TimeoutMs < 0 {
+		return "", fmt.Errorf("timeout non valido: %d ms", config.TimeoutMs)
+	}
+	if config.Retries < 0 {
+		return "", fmt.Errorf("numero di ritrasmissioni non valido: %d", config.Retries)
+	}
+	timeout := DefaultTimeout
+	if config.TimeoutMs > 0 {
+		timeout = time.Duration(config.TimeoutMs) * time.Millisecond
+	}
+	retries := DefaultRetries
+	if config.Retries > 0 {
+		retries = config.Retries
+	}
+	args = append(args,
+		"-t", strconv.FormatFloat(timeout.Seconds(), 'f', -1, 64),
+		"-r", strconv.Itoa(retries),
+	)
+
+	port := config.Port
+	if port <= 0 {
+		port = 161
+	}
+	agent := host
+	ipv6 := IsIPv6(host)
+	if ipv6 {
+		agent = "udp6:[" + host + "]"
+	}
+	if port != 161 || ipv6 {
+		agent += ":" + strconv.Itoa(port)
+	}
+	args = append(args, shellQuote(agent), target)
+
+	if op == "set" {
+		setType := strings.TrimSpace(opts.SetType)
+		if setType == "" {
+			return "", fmt.Errorf("set type is required")
+		}
+		args = append(args, setType, shellQuote(opts.SetValue))
+	}
+
+	return strings.Join(args, " "), nil
+}
+
+// shellQuote racchiude il valore tra apici singoli quando contiene caratteri speciali per la shell.
+func shellQuote(value string) string {
+	if value == "" {
+		return "''"
+	}
+	safe := true
+	for _, r := range value {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.:/@%+=,", r)) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
@@ -0,0 +1,114 @@
+package snmp
+
+import "testing"
+
+func TestBuildNetSNMPCommand(t *testing.T) {
+	v3 := Config{
+		Host:             "10.0.0.1",
+		Port:             1161,
+		Version:          "v3",
+		SecurityLevel:    "authPriv",
+		SecurityUsername: "admin",
+		AuthProtocol:     "sha256",
+		AuthPassword:     "auth pass",
+		PrivProtocol:     "aes256",
+		PrivPassword:     "privpass",
+		ContextName:      "vrf-1",
+	}
+
+	tests := []struct {
+		name      string
+		config    Config
+		operation string
+		oid       string
+		opts      CommandOptions
+		want      string
+	}{
+		{
+			name:      "v2c get",
+			config:    Config{Host: "192.0.2.1", Community: "public"},
+			operation: "get",
+			oid:       "1.3.6.1.2.1.1.5.0",
+			want:      "snmpget -v2c -c public -t 5 -r 2 192.0.2.1 .1.3.6.1.2.1.1.5.0",
+		},
+		{
+			name:      "v1 walk with port and quoted community",
+			config:    Config{Host: "router", Port: 10161, Version: "v1", Community: "my secret"},
+			operation: "walk",
+			oid:       ".1.3.6.1.2.1.2",
+			want:      "snmpwalk -v1 -c 'my secret' -t 5 -r 2 router:10161 .1.3.6.1.2.1.2",
+		},
+		{
+			name:      "v2c set uses write community",
+			config:    Config{Host: "192.0.2.1", Community: "public", WriteCommunity: "private"},
+			operation: "SET",
+			oid:       "1.3.6.1.2.1.1.5.0",
+			opts:      CommandOptions{SetType: "s", SetValue: "new name"},
+			want:      "snmpset -v2c -c private -t 5 -r 2 192.0.2.1 .1.3.6.1.2.1.1.5.0 s 'new name'",
+		},
+		{
+			name:      "v3 bulkwalk with all flags",
+			config:    v3,
+			operation: "bulkwalk",
+			oid:       "1.3.6.1.2.1.2.2",
+			want:      "snmpbulkwalk -v3 -l authPriv -u admin -a SHA-256 -A 'auth pass' -x AES-256 -X privpass -n vrf-1 -t 5 -r 2 10.0.0.1:1161 .1.3.6.1.2.1.2.2",
+		},
+		{
+			name:      "v3 masked",
+			config:    v3,
+			operation: "getnext",
+			oid:       "1.3.6.1.2.1.1",
+			opts:      CommandOptions{MaskSecrets: true},
+			want:      "snmpgetnext -v3 -l authPriv -u admin -a SHA-256 -A ******** -x AES-256 -X ******** -n vrf-1 -t 5 -r 2 10.0.0.1:1161 .1.3.6.1.2.1.1",
+		},
+		{
+			name:      "ipv6 target",
+			config:    Config{Host: "2001:db8::1", Community: "public"},
+			operation: "get",
+			oid:       "1.3.6.1.2.1.1.3.0",
+			want:      "snmpget -v2c -c public -t 5 -r 2 'udp6:[2001:db8::1]:161' .1.3.6.1.2.1.1.3.0",
+		},
+		{
+			name:      "custom timeout and retries",
+			config:    Config{Host: "192.0.2.1", Community: "public", TimeoutMs: 1500, Retries: 4},
+			operation: "get",
+			oid:       "1.3.6.1.2.1.1.3.0",
+			want:      "snmpget -v2c -c public -t 1.5 -r 4 192.0.2.1 .1.3.6.1.2.1.1.3.0",
+		},
+		{
+			name:      "bracketed ipv6 target",
+			config:    Config{Host: "[2001:db8::1]", Port: 1161, Community: "public"},
+			operation: "get",
+			oid:       "1.3.6.1.2.1.1.3.0",
+			want:      "snmpget -v2c -c public -t 5 -r 2 'udp6:[2001:db8::1]:1161' .1.3.6.1.2.1.1.3.0",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := BuildNetSNMPCommand(tt.config, tt.operation, tt.oid, tt.opts)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s:\n got  %s\n want %s", tt.name, got, tt.want)
+		}
+	}
+
+	invalid := []struct {
+		name      string
+		config    Config
+		operation string
+	}{
+		{name: "unknown operation", config: Config{Host: "h"}, operation: "trap"},
+		{name: "invalid host", config: Config{Host: "[192.0.2.1]"}, operation: "get"},
+		{name: "negative timeout", config: Config{Host: "h", TimeoutMs: -1}, operation: "get"},
+		{name: "bulkwalk on v1", config: Config{Host: "h", Version: "v1"}, operation: "bulkwalk"},
+		{name: "set without type", config: Config{Host: "h"}, operation: "set"},
+		{name: "v3 without user", config: Config{Host: "h", Version: "v3"}, operation: "get"},
+	}
+	for _, tt := range invalid {
+		if _, err := BuildNetSNMPCommand(tt.config, tt.operation, "1.3.6.1", CommandOptions{}); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package snmp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultDebugCapturePackets è il numero di pacchetti conservati se non specificato diversamente.
+	DefaultDebugCapturePackets = 50
+	// maxCapturedPacketBytes limita i byte conservati per singolo pacchetto.
+	maxCapturedPacketBytes = 8192
+	// redactedHexByte sostituisce nel dump i byte della community.
+	redactedHexByte = "**"
+	// redactedPacketNotice sostituisce l'intero dump quando la community non può essere individuata.
+	redactedPacketNotice = "[packet redacted: community could not be located]"
+)
+
+// CapturedPacket è un pacchetto SNMP catturato sul filo, reso in esadecimale.
+type CapturedPacket struct {
+	Timestamp string `json:"timestamp"`
+	Direction string `json:"direction"` // request, response
+	Length    int    `json:"length"`
+	Hex       string `json:"hex"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// DebugCapture conserva in un buffer circolare gli ultimi pacchetti scambiati con un agent.
+// È sicuro per l'uso concorrente da più client.
+type DebugCapture struct {
+	mu      sync.Mutex
+	limit   int
+	packets []CapturedPacket
+}
+
+// NewDebugCapture crea un buffer che mantiene al massimo limit pacchetti.
+func NewDebugCapture(limit int) *DebugCapture {
+	if limit <= 0 {
+		limit = DefaultDebugCapturePackets
+	}
+	return &DebugCapture{limit: limit, packets: []CapturedPacket{}}
+}
+
+// Packets restituisce una copia dei pacchetti catturati, dal più vecchio al più recente.
+func (d *DebugCapture) Packets() []CapturedPacket {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	packets := make([]CapturedPacket, len(d.packets))
+	copy(packets, d.packets)
+	return packets
+}
+
+// Clear svuota il buffer.
+func (d *DebugCapture) Clear() {
+	d.mu.Lock()
+	d.packets = []CapturedPacket{}
+	d.mu.Unlock()
+}
+
+func (d *DebugCapture) record(direction string, data []byte, secrets [][]byte) {
+	packet := CapturedPacket{
+		Timestamp: time.Now().Format(time.RFC3339Nano),
+		Direction: direction,
+		Length:    len(data),
+		Hex:       renderPacketHex(data, secrets),
+	}
+	if len(data) > maxCapturedPacketBytes {
+		packet.Truncated = true
+	}
+	d.append(packet)
+}
+
+// append aggiunge pacchetti già resi, scartando i più vecchi oltre il limite.
+func (d *DebugCapture) append(packets ...CapturedPacket) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.packets = append(d.packets, packets...)
+	if len(d.packets) > d.limit {
+		d.packets = append([]CapturedPacket(nil), d.packets[len(d.packets)-d.limit:]...)
+	}
+}
+
+// renderPacketHex produce il dump esadecimale del pacchetto mascherando la community
+// dei messaggi v1/v2c, che viaggia in chiaro sia nelle richieste sia nelle risposte.
+// Se il pacchetto non si decodifica, vengono mascherate le occorrenze delle community note (secrets)
+// del client che l'ha inviato; senza community note il dump è sostituito da redactedPacketNotice,
+// perché i pacchetti malformati potrebbero contenerla in una posizione qualsiasi.
+func renderPacketHex(data []byte, secrets [][]byte) string {
+	redacted := make([]bool, len(data))
+	start, end, located := communityRange(data)
+	if located {
+		for i := start; i < end; i++ {
+			redacted[i] = true
+		}
+	} else if isSNMPv3Message(data) {
+		located = true
+	}
+	for _, secret := range secrets {
+		if len(secret) == 0 {
+			continue
+		}
+		located = true
+		for offset := 0; ; {
+			index := bytes.Index(data[offset:], secret)
+			if index < 0 {
+				break
+			}
+			for i := offset + index; i < offset+index+len(secret); i++ {
+				redacted[i] = true
+			}
+			offset += index + len(secret)
+		}
+	}
+	if !located {
+		return redactedPacketNotice
+	}
+
+	limit := len(data)
+	if limit > maxCapturedPacketBytes {
+		limit = maxCapturedPacketBytes
+	}
+
+	var builder strings.Builder
+	builder.Grow(limit * 3)
+	for i := 0; i < limit; i++ {
+		if i > 0 {
+			builder.WriteByte(' ')
+		}
+		if redacted[i] {
+			builder.WriteString(redactedHexByte)
+			continue
+		}
+		builder.WriteString(hex.EncodeToString(data[i : i+1]))
+	}
+	return builder.String()
+}
+
+// communityRange individua i byte della community in un messaggio SNMPv1/v2c:
+// SEQUENCE { version INTEGER, community OCTET STRING, pdu }.
+func communityRange(data []byte) (int, int, bool) {
+	pos, ok := berSkipHeader(data, 0, 0x30)
+	if !ok {
+		return 0, 0, false
+	}
+
+	versionStart, ok := berSkipHeader(data, pos, 0x02)
+	if !ok {
+		return 0, 0, false
+	}
+	versionLen, _, _ := berLength(data, pos+1)
+	if versionLen != 1 || versionStart >= len(data) || data[versionStart] > 1 {
+		// Solo v1 (0) e v2c (1) trasportano la community; v3 usa USM.
+		return 0, 0, false
+	}
+
+	communityStart, ok := berSkipHeader(data, versionStart+versionLen, 0x04)
+	if !ok {
+		return 0, 0, false
+	}
+	communityLen, _, _ := berLength(data, versionStart+versionLen+1)
+	end := communityStart + communityLen
+	if end > len(data) {
+		return 0, 0, false
+	}
+	return communityStart, end, true
+}
+
+// isSNMPv3Message indica se i dati iniziano come un messaggio SNMPv3, che non contiene una community.
+func isSNMPv3Message(data []byte) bool {
+	pos, ok := berSkipHeader(data, 0, 0x30)
+	if !ok {
+		return false
+	}
+	versionStart, ok := berSkipHeader(data, pos, 0x02)
+	if !ok {
+		return false
+	}
+	versionLen, _, _ := berLength(data, pos+1)
+	return versionLen == 1 && versionStart < len(data) && data[versionStart] == 3
+}
+
+// berSkipHeader verifica il tag in posizione pos e ritorna l'offset del contenuto.
+func berSkipHeader(data []byte, pos int, tag byte) (int, bool) {
+	if pos >= len(data) || data[pos] != tag {
+		return 0, false
+	}
+	_, headerLen, ok := berLength(data, pos+1)
+	if !ok {
+		return 0, false
+	}
+	return pos + 1 + headerLen, true
+}
+
+// berLength decodifica la lunghezza BER in posizione pos, ritornando lunghezza e byte occupati.
+func berLength(data []byte, pos int) (int, int, bool) {
+	if pos >= len(data) {
+		return 0, 0, false
+	}
+	first := data[pos]
+	if first&0x80 == 0 {
+		return int(first), 1, true
+	}
+	count := int(first & 0x7f)
+	if count == 0 || count > 4 || pos+count >= len(data) {
+		return 0, 0, false
+	}
+	length := 0
+	for i := 1; i <= count; i++ {
+		length = length<<8 | int(data[pos+i])
+	}
+	return length, count + 1, true
+}
+
+// captureConn intercetta letture e scritture sulla connessione di gosnmp.
+// Non implementa net.PacketConn, così gosnmp usa Read/Write sul socket già connesso.
+// secrets sono le community del client, mascherate anche nei pacchetti che non si decodificano.
+type captureConn struct {
+	net.Conn
+	capture *DebugCapture
+	secrets [][]byte
+}
+
+func (c *captureConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	if n > 0 {
+		c.capture.record("request", b[:n], c.secrets)
+	}
+	return n, err
+}
+
+func (c *captureConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.capture.record("response", b[:n], c.secrets)
+	}
+	return n, err
+}
+
+// SetDebugCapture abilita la cattura dei pacchetti sul buffer indicato; nil la disabilita.
+func (c *Client) SetDebugCapture(capture *DebugCapture) {
+	c.capture = capture
+}
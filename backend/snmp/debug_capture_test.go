@@ -0,0 +1,103 @@
+package snmp
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestRenderPacketHexRedactsCommunity(t *testing.T) {
+	packet := &gosnmp.SnmpPacket{
+		Version:   gosnmp.Version2c,
+		Community: "s3cr3t",
+		PDUType:   gosnmp.GetRequest,
+		RequestID: 42,
+		Variables: []gosnmp.SnmpPDU{{Name: ".1.3.6.1.2.1.1.5.0", Type: gosnmp.Null}},
+	}
+	data, err := packet.MarshalMsg()
+	if err != nil {
+		t.Fatalf("MarshalMsg() error = %v", err)
+	}
+
+	rendered := renderPacketHex(data, nil)
+	if strings.Contains(strings.ReplaceAll(rendered, " ", ""), "733363723374") {
+		t.Fatalf("community bytes leaked in dump: %s", rendered)
+	}
+	if got := strings.Count(rendered, redactedHexByte); got != len("s3cr3t") {
+		t.Fatalf("expected %d redacted bytes, got %d in %s", len("s3cr3t"), got, rendered)
+	}
+	if !strings.HasPrefix(rendered, "30 ") {
+		t.Fatalf("expected dump to start with the SEQUENCE tag, got %s", rendered)
+	}
+
+	// Un pacchetto malformato senza community note non viene mostrato.
+	malformed := append([]byte{0x30, 0x84}, "s3cr3t"...)
+	if got := renderPacketHex(malformed, nil); got != redactedPacketNotice {
+		t.Fatalf("renderPacketHex(malformed) = %q, want the redaction notice", got)
+	}
+	// Con la community del client viene mascherata ovunque compaia.
+	if got := renderPacketHex(malformed, [][]byte{[]byte("s3cr3t")}); got != "30 84 ** ** ** ** ** **" {
+		t.Fatalf("renderPacketHex(malformed, community) = %q", got)
+	}
+
+	// I messaggi v3 non hanno community e vengono riportati per intero.
+	v3 := []byte{0x30, 0x03, 0x02, 0x01, 0x03}
+	if got := renderPacketHex(v3, nil); got != "30 03 02 01 03" {
+		t.Fatalf("renderPacketHex(v3) = %q", got)
+	}
+}
+
+func TestDebugCaptureIsBounded(t *testing.T) {
+	capture := NewDebugCapture(3)
+	secrets := [][]byte{[]byte("public")}
+	for i := 0; i < 5; i++ {
+		capture.record("request", []byte{byte(i)}, secrets)
+	}
+
+	packets := capture.Packets()
+	if len(packets) != 3 || packets[0].Hex != "02" || packets[2].Hex != "04" {
+		t.Fatalf("unexpected packets: %+v", packets)
+	}
+
+	large := make([]byte, maxCapturedPacketBytes+10)
+	capture.record("response", large, secrets)
+	last := capture.Packets()[2]
+	if !last.Truncated || last.Length != len(large) || len(strings.Fields(last.Hex)) != maxCapturedPacketBytes {
+		t.Fatalf("expected truncated dump of %d bytes, got length=%d truncated=%v", maxCapturedPacketBytes, last.Length, last.Truncated)
+	}
+
+	capture.Clear()
+	if len(capture.Packets()) != 0 {
+		t.Fatalf("expected capture to be empty after Clear")
+	}
+}
+
+func TestCaptureConnRecordsBothDirections(t *testing.T) {
+	local, remote := net.Pipe()
+	defer local.Close()
+	defer remote.Close()
+
+	capture := NewDebugCapture(0)
+	conn := &captureConn{Conn: local, capture: capture, secrets: [][]byte{[]byte("public")}}
+
+	go func() {
+		buf := make([]byte, 8)
+		n, _ := remote.Read(buf)
+		remote.Write(buf[:n])
+	}()
+
+	if _, err := conn.Write([]byte{0xca, 0xfe}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	buf := make([]byte, 8)
+	if _, err := conn.Read(buf); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	packets := capture.Packets()
+	if len(packets) != 2 || packets[0].Direction != "request" || packets[1].Direction != "response" || packets[1].Hex != "ca fe" {
+		t.Fatalf("unexpected packets: %+v", packets)
+	}
+}
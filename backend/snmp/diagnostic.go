@@ -0,0 +1,94 @@
+package snmp
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// DiagnosticResult è il risultato di una GET in modalità raw: oltre al varbind riporta i campi della
+// PDU di risposta e, per SNMPv3, i parametri dell'engine autoritativo.
+type DiagnosticResult struct {
+	Result
+	PDUType     string `json:"pduType"`
+	EngineID    string `json:"engineId,omitempty"`
+	EngineBoots int    `json:"engineBoots,omitempty"`
+	EngineTime  int    `json:"engineTime,omitempty"`
+	RequestID   int    `json:"requestId"`
+	ErrorStatus string `json:"errorStatus"`
+	ErrorIndex  int    `json:"errorIndex"`
+	// RawPDU è il dump esadecimale del pacchetto di risposta, con la community mascherata.
+	RawPDU string `json:"rawPdu"`
+}
+
+// GetDiagnostic esegue una singola GET senza fallback né correzioni e restituisce la risposta
+// dell'agent così com'è, inclusi error-status ed error-index.
+func (c *Client) GetDiagnostic(oid string) (*DiagnosticResult, error) {
+	// I pacchetti vengono catturati in un buffer privato, così richieste concorrenti sullo stesso
+	// buffer dell'host non possono sostituire la risposta; al termine sono copiati anche in quello.
+	shared := c.capture
+	capture := NewDebugCapture(4)
+	c.capture = capture
+	defer func() {
+		c.capture = shared
+		if shared != nil {
+			shared.append(capture.Packets()...)
+		}
+	}()
+
+	start := time.Now()
+	if err := c.Connect(); err != nil {
+		return nil, fmt.Errorf("connection failed: %v", err)
+	}
+	defer c.Close()
+
+	packet, err := c.snmp.Get([]string{oid})
+	err = c.operationError(err)
+	elapsed := time.Since(start).Milliseconds()
+	if err != nil {
+		return &DiagnosticResult{Result: Result{
+			OID:          oid,
+			Status:       "error",
+			ResponseTime: elapsed,
+			Timestamp:    time.Now().Format(time.RFC3339),
+		}}, err
+	}
+
+	diagnostic := &DiagnosticResult{
+		Result: Result{
+			OID:          oid,
+			Status:       "success",
+			ResponseTime: elapsed,
+			Timestamp:    time.Now().Format(time.RFC3339),
+		},
+		PDUType:     packet.PDUType.String(),
+		RequestID:   int(packet.RequestID),
+		ErrorStatus: packet.Error.String(),
+		ErrorIndex:  int(packet.ErrorIndex),
+	}
+	if len(packet.Variables) > 0 {
+		variable := packet.Variables[0]
+		diagnostic.OID = variable.Name
+		diagnostic.Value = formatPDUValue(variable)
+		diagnostic.Type = variable.Type.String()
+	}
+	if packet.Error != gosnmp.NoError {
+		diagnostic.Status = "error"
+	}
+	if usm, ok := packet.SecurityParameters.(*gosnmp.UsmSecurityParameters); ok && usm != nil {
+		diagnostic.EngineID = hex.EncodeToString([]byte(usm.AuthoritativeEngineID))
+		diagnostic.EngineBoots = int(usm.AuthoritativeEngineBoots)
+		diagnostic.EngineTime = int(usm.AuthoritativeEngineTime)
+	}
+
+	packets := capture.Packets()
+	for i := len(packets) - 1; i >= 0; i-- {
+		if packets[i].Direction == "response" {
+			diagnostic.RawPDU = packets[i].Hex
+			break
+		}
+	}
+	return diagnostic, nil
+}
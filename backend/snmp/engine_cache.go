@@ -0,0 +1,142 @@
+package snmp
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// engineCacheMaxAge è l'età oltre la quale boots/time di un engine non vengono più riusati e la
+// discovery SNMPv3 viene ripetuta. Ogni risposta autenticata rinnova la voce.
+const engineCacheMaxAge = 10 * time.Minute
+
+// engineTimeEntry sono i parametri dell'engine autoritativo osservati nell'ultima risposta.
+type engineTimeEntry struct {
+	engineID   string
+	boots      uint32
+	time       uint32
+	observedAt time.Time
+}
+
+// engineTimeCache conserva in memoria, per target (host:porta), l'engine ID e boots/time dell'agent
+// SNMPv3, così che i Client successivi verso lo stesso agent saltino la discovery (RFC 3414 §4).
+type engineTimeCache struct {
+	mu      sync.Mutex
+	entries map[string]engineTimeEntry
+	now     func() time.Time
+}
+
+// engineCache è la cache condivisa da tutti i Client del processo.
+var engineCache = newEngineTimeCache()
+
+func newEngineTimeCache() *engineTimeCache {
+	return &engineTimeCache{entries: make(map[string]engineTimeEntry), now: time.Now}
+}
+
+// load restituisce i parametri dell'engine per il target, con engine time avanzato dei secondi
+// trascorsi dall'osservazione. Le voci più vecchie di engineCacheMaxAge vengono scartate.
+func (c *engineTimeCache) load(target string) (engineTimeEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[target]
+	if !ok {
+		return engineTimeEntry{}, false
+	}
+	elapsed := c.now().Sub(entry.observedAt)
+	if elapsed < 0 || elapsed > engineCacheMaxAge {
+		delete(c.entries, target)
+		return engineTimeEntry{}, false
+	}
+	entry.time += uint32(elapsed / time.Second)
+	return entry, true
+}
+
+// store registra i parametri dell'engine osservati ora per il target.
+func (c *engineTimeCache) store(target, engineID string, boots, engineTime uint32) {
+	if engineID == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[target] = engineTimeEntry{engineID: engineID, boots: boots, time: engineTime, observedAt: c.now()}
+}
+
+// invalidate rimuove la voce del target, forzando una nuova discovery alla richiesta successiva.
+func (c *engineTimeCache) invalidate(target string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, target)
+}
+
+// isEngineSyncError indica se l'errore di gosnmp segnala un engine ID o un engine time non più validi:
+// fuori dalla finestra temporale, engine sconosciuto o autenticazione fallita dopo un riavvio dell'agent.
+func isEngineSyncError(err error) bool {
+	return errors.Is(err, gosnmp.ErrNotInTimeWindow) ||
+		errors.Is(err, gosnmp.ErrUnknownEngineID) ||
+		errors.Is(err, gosnmp.ErrWrongDigest) ||
+		errors.Is(err, gosnmp.ErrDecryption)
+}
+
+// engineTarget è la chiave della cache per il client.
+func (c *Client) engineTarget() string {
+	return net.JoinHostPort(c.snmp.Target, strconv.Itoa(int(c.snmp.Port)))
+}
+
+// usmParameters restituisce i parametri USM del client, o nil se non è un client SNMPv3.
+func (c *Client) usmParameters() *gosnmp.UsmSecurityParameters {
+	if c.snmp.Version != gosnmp.Version3 {
+		return nil
+	}
+	params, _ := c.snmp.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+	return params
+}
+
+// loadEngineTime precarica engine ID e boots/time dalla cache, così gosnmp non ripete la discovery.
+func (c *Client) loadEngineTime() {
+	params := c.usmParameters()
+	if params == nil || params.AuthoritativeEngineID != "" {
+		return
+	}
+	entry, ok := engineCache.load(c.engineTarget())
+	if !ok {
+		return
+	}
+	params.AuthoritativeEngineID = entry.engineID
+	params.AuthoritativeEngineBoots = entry.boots
+	params.AuthoritativeEngineTime = entry.time
+	if c.snmp.ContextEngineID == "" {
+		c.snmp.ContextEngineID = entry.engineID
+	}
+}
+
+// saveEngineTime aggiorna la cache con i parametri dell'ultima risposta, oppure la invalida se
+// l'operazione è fallita per un errore di sincronizzazione con l'engine.
+func (c *Client) saveEngineTime() {
+	params := c.usmParameters()
+	if params == nil {
+		return
+	}
+	target := c.engineTarget()
+	if c.engineSyncFailed {
+		c.engineSyncFailed = false
+		params.AuthoritativeEngineID = ""
+		c.snmp.ContextEngineID = ""
+		engineCache.invalidate(target)
+		return
+	}
+	engineCache.store(target, params.AuthoritativeEngineID, params.AuthoritativeEngineBoots, params.AuthoritativeEngineTime)
+}
+
+// checkEngineSync annota gli errori di sincronizzazione con l'engine, che invalidano la cache alla
+// chiusura dell'operazione, e restituisce l'errore invariato.
+func (c *Client) checkEngineSync(err error) error {
+	if isEngineSyncError(err) {
+		c.engineSyncFailed = true
+	}
+	return err
+}
@@ -0,0 +1,131 @@
+package snmp
+
+import (
+	"net"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+const testEngineID = "\x80\x00\x1f\x88\x80testengine"
+
+// startV3Agent avvia un agent SNMPv3 noAuthNoPriv che risponde alla discovery con un report
+// usmStatsUnknownEngineIDs e alle GET con un intero; restituisce la configurazione e il contatore
+// delle discovery ricevute.
+func startV3Agent(t *testing.T) (Config, *atomic.Int32) {
+	t.Helper()
+
+	discoveries := &atomic.Int32{}
+	config := startFakeAgent(t, func(request *gosnmp.SnmpPacket) *gosnmp.SnmpPacket {
+		usm, _ := request.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+		if usm == nil {
+			return nil
+		}
+
+		response := &gosnmp.SnmpPacket{
+			Version:       gosnmp.Version3,
+			MsgFlags:      gosnmp.NoAuthNoPriv,
+			SecurityModel: gosnmp.UserSecurityModel,
+			SecurityParameters: &gosnmp.UsmSecurityParameters{
+				AuthoritativeEngineID:    testEngineID,
+				AuthoritativeEngineBoots: 3,
+				AuthoritativeEngineTime:  1000,
+				UserName:                 usm.UserName,
+			},
+			MsgID:           request.MsgID,
+			RequestID:       request.RequestID,
+			ContextEngineID: testEngineID,
+			ContextName:     request.ContextName,
+			MsgMaxSize:      65507,
+		}
+		if usm.AuthoritativeEngineID == "" {
+			discoveries.Add(1)
+			response.PDUType = gosnmp.Report
+			response.Variables = []gosnmp.SnmpPDU{{Name: ".1.3.6.1.6.3.15.1.1.4.0", Type: gosnmp.Counter32, Value: uint32(1)}}
+		} else {
+			response.PDUType = gosnmp.GetResponse
+			for _, variable := range request.Variables {
+				response.Variables = append(response.Variables, gosnmp.SnmpPDU{Name: variable.Name, Type: gosnmp.Integer, Value: 1})
+			}
+		}
+		return response
+	})
+
+	config.Community = ""
+	config.Version = "v3"
+	config.SecurityLevel = "noAuthNoPriv"
+	config.SecurityUsername = "monitor"
+	return config, discoveries
+}
+
+func TestV3EngineDiscoveryIsCachedAcrossClients(t *testing.T) {
+	config, discoveries := startV3Agent(t)
+	target := net.JoinHostPort(config.Host, strconv.Itoa(config.Port))
+	t.Cleanup(func() { engineCache.invalidate(target) })
+
+	for i := 0; i < 3; i++ {
+		client, err := NewClient(config)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		client.snmp.Timeout = 500 * time.Millisecond
+		client.snmp.Retries = 0
+		if _, err := client.Get("1.3.6.1.2.1.1.7.0"); err != nil {
+			t.Fatalf("Get #%d: %v", i+1, err)
+		}
+	}
+	if got := discoveries.Load(); got != 1 {
+		t.Fatalf("expected a single discovery, got %d", got)
+	}
+
+	entry, ok := engineCache.load(target)
+	if !ok || entry.engineID != testEngineID || entry.boots != 3 {
+		t.Fatalf("unexpected cache entry: %+v (found %v)", entry, ok)
+	}
+}
+
+func TestEngineTimeCacheAgesEntries(t *testing.T) {
+	cache := newEngineTimeCache()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	cache.store("10.0.0.1:161", testEngineID, 2, 500)
+
+	now = now.Add(90 * time.Second)
+	entry, ok := cache.load("10.0.0.1:161")
+	if !ok || entry.time != 590 || entry.boots != 2 {
+		t.Fatalf("expected engine time advanced to 590, got %+v (found %v)", entry, ok)
+	}
+
+	now = now.Add(engineCacheMaxAge)
+	if _, ok := cache.load("10.0.0.1:161"); ok {
+		t.Fatalf("expected entry older than %v to expire", engineCacheMaxAge)
+	}
+}
+
+func TestEngineSyncErrorInvalidatesCache(t *testing.T) {
+	client, err := NewClient(Config{Host: "10.0.0.2", Version: "v3", SecurityLevel: "noAuthNoPriv", SecurityUsername: "monitor"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	target := client.engineTarget()
+	engineCache.store(target, testEngineID, 1, 100)
+	t.Cleanup(func() { engineCache.invalidate(target) })
+
+	client.loadEngineTime()
+	if client.usmParameters().AuthoritativeEngineID != testEngineID {
+		t.Fatalf("expected cached engine ID to be preloaded")
+	}
+
+	client.checkEngineSync(gosnmp.ErrNotInTimeWindow)
+	client.saveEngineTime()
+	if _, ok := engineCache.load(target); ok {
+		t.Fatalf("expected notInTimeWindow to invalidate the cache entry")
+	}
+	if client.usmParameters().AuthoritativeEngineID != "" {
+		t.Fatalf("expected engine ID to be cleared for a new discovery")
+	}
+}
@@ -0,0 +1,126 @@
+package snmp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// ErrorDetail descrive l'errore riportato dall'agent nella PDU di risposta (error-status/error-index)
+// o, per le SET scadute, la causa probabile della mancata risposta.
+type ErrorDetail struct {
+	Status     string `json:"status"`
+	StatusCode int    `json:"statusCode"`
+	Index      int    `json:"index"`
+	OID        string `json:"oid,omitempty"`
+	// Cause distingue le richieste scadute senza risposta (Status "timeout", StatusCode -1) e riassume
+	// le risposte scartate per request-id errato (Status "requestIdMismatch", StatusCode -2).
+	Cause string `json:"cause,omitempty"`
+}
+
+// Cause riportate in ErrorDetail quando una SET v1/v2c scade senza risposta.
+const (
+	// CauseAgentUnreachable: l'agent non risponde neppure a una GET con la community di lettura.
+	CauseAgentUnreachable = "agentUnreachable"
+	// CauseWriteCommunityRejected: l'agent risponde alle GET, quindi la community di scrittura è probabilmente errata.
+	CauseWriteCommunityRejected = "writeCommunityRejected"
+)
+
+// timeoutErrorDetail costruisce il dettaglio per una richiesta scaduta, con la causa individuata.
+func timeoutErrorDetail(oid, cause string) *ErrorDetail {
+	return &ErrorDetail{Status: "timeout", StatusCode: -1, OID: oid, Cause: cause}
+}
+
+// requestIDMismatchStatus identifica le operazioni scadute perché l'agent ha risposto solo con
+// request-id inattesi o duplicati (StatusCode -2), tipico di agent embedded che confondono richieste
+// ravvicinate.
+const requestIDMismatchStatus = "requestIdMismatch"
+
+// requestIDMismatchErrorDetail costruisce il dettaglio per un'operazione con risposte non abbinabili.
+func requestIDMismatchErrorDetail(mismatched, duplicates int) *ErrorDetail {
+	return &ErrorDetail{
+		Status:     requestIDMismatchStatus,
+		StatusCode: -2,
+		Cause:      fmt.Sprintf("%d unexpected, %d duplicate", mismatched, duplicates),
+	}
+}
+
+// errorStatusNames mappa i codici error-status (RFC 3416) sui nomi usati negli standard.
+var errorStatusNames = map[gosnmp.SNMPError]string{
+	gosnmp.NoError:             "noError",
+	gosnmp.TooBig:              "tooBig",
+	gosnmp.NoSuchName:          "noSuchName",
+	gosnmp.BadValue:            "badValue",
+	gosnmp.ReadOnly:            "readOnly",
+	gosnmp.GenErr:              "genErr",
+	gosnmp.NoAccess:            "noAccess",
+	gosnmp.WrongType:           "wrongType",
+	gosnmp.WrongLength:         "wrongLength",
+	gosnmp.WrongEncoding:       "wrongEncoding",
+	gosnmp.WrongValue:          "wrongValue",
+	gosnmp.NoCreation:          "noCreation",
+	gosnmp.InconsistentValue:   "inconsistentValue",
+	gosnmp.ResourceUnavailable: "resourceUnavailable",
+	gosnmp.CommitFailed:        "commitFailed",
+	gosnmp.UndoFailed:          "undoFailed",
+	gosnmp.AuthorizationError:  "authorizationError",
+	gosnmp.NotWritable:         "notWritable",
+	gosnmp.InconsistentName:    "inconsistentName",
+}
+
+// ErrorStatusName restituisce il nome leggibile di un codice error-status SNMP.
+func ErrorStatusName(status gosnmp.SNMPError) string {
+	if name, ok := errorStatusNames[status]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", status)
+}
+
+// newErrorDetail costruisce il dettaglio dell'errore a partire dalla risposta dell'agent.
+// L'error-index è 1-based: l'OID coinvolto viene cercato prima tra le variabili della risposta
+// e poi tra quelle inviate nella richiesta.
+func newErrorDetail(packet *gosnmp.SnmpPacket, requested []gosnmp.SnmpPDU) *ErrorDetail {
+	if packet == nil || packet.Error == gosnmp.NoError {
+		return nil
+	}
+
+	detail := &ErrorDetail{
+		Status:     ErrorStatusName(packet.Error),
+		StatusCode: int(packet.Error),
+		Index:      int(packet.ErrorIndex),
+	}
+
+	if idx := detail.Index - 1; idx >= 0 {
+		switch {
+		case idx < len(packet.Variables):
+			detail.OID = packet.Variables[idx].Name
+		case idx < len(requested):
+			detail.OID = requested[idx].Name
+		}
+	}
+
+	return detail
+}
+
+// Error restituisce una descrizione testuale del dettaglio d'errore.
+func (d *ErrorDetail) Error() string {
+	switch d.Cause {
+	case CauseWriteCommunityRejected:
+		return fmt.Sprintf("SET timed out on %s but the agent answers GET with the read community: write community likely wrong", d.OID)
+	case CauseAgentUnreachable:
+		return fmt.Sprintf("SET timed out on %s and the agent does not answer GET either: agent unreachable", d.OID)
+	}
+	if d.Status == requestIDMismatchStatus {
+		return fmt.Sprintf("request timed out: the agent only sent responses with a mismatched request-id (%s)", d.Cause)
+	}
+	if d.OID != "" {
+		return fmt.Sprintf("SNMP error: %s (index %d, OID %s)", d.Status, d.Index, d.OID)
+	}
+	return fmt.Sprintf("SNMP error: %s (index %d)", d.Status, d.Index)
+}
+
+// isTimeout indica se l'errore di gosnmp è dovuto alla mancata risposta dell'agent.
+func isTimeout(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "timeout")
+}
@@ -0,0 +1,41 @@
+package snmp
+
+import (
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestNewErrorDetail(t *testing.T) {
+	packet := &gosnmp.SnmpPacket{
+		Error:      gosnmp.NotWritable,
+		ErrorIndex: 1,
+		Variables:  []gosnmp.SnmpPDU{{Name: ".1.3.6.1.2.1.1.5.0", Type: gosnmp.Null}},
+	}
+
+	detail := newErrorDetail(packet, nil)
+	if detail == nil {
+		t.Fatalf("newErrorDetail() = nil, want detail")
+	}
+	if detail.Status != "notWritable" || detail.StatusCode != 17 || detail.Index != 1 {
+		t.Fatalf("unexpected detail: %+v", detail)
+	}
+	if detail.OID != ".1.3.6.1.2.1.1.5.0" {
+		t.Fatalf("detail.OID = %q", detail.OID)
+	}
+
+	if got := newErrorDetail(&gosnmp.SnmpPacket{Error: gosnmp.NoError}, nil); got != nil {
+		t.Fatalf("newErrorDetail() with noError = %+v, want nil", got)
+	}
+}
+
+func TestErrorStatusName(t *testing.T) {
+	for code := gosnmp.NoError; code <= gosnmp.InconsistentName; code++ {
+		if _, ok := errorStatusNames[code]; !ok {
+			t.Errorf("missing name for error-status %d", code)
+		}
+	}
+	if got := ErrorStatusName(gosnmp.SNMPError(99)); got != "unknown(99)" {
+		t.Errorf("ErrorStatusName(99) = %q", got)
+	}
+}
@@ -0,0 +1,59 @@
+package snmp
+
+import (
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// startFakeAgent avvia un agent UDP locale che decodifica ogni pacchetto ricevuto e invia la risposta
+// restituita da handler (nessuna risposta se nil). Ogni richiesta è gestita in una goroutine propria,
+// così un handler lento non blocca le altre. Restituisce una configurazione v2c che punta all'agent.
+func startFakeAgent(t *testing.T, handler func(*gosnmp.SnmpPacket) *gosnmp.SnmpPacket) Config {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		decoder := &gosnmp.GoSNMP{
+			Version:       gosnmp.Version3,
+			SecurityModel: gosnmp.UserSecurityModel,
+			// gosnmp rifiuta di decodificare pacchetti v3 senza un utente configurato nel decoder;
+			// i pacchetti v1 e v2c vengono decodificati secondo la versione indicata nel pacchetto.
+			SecurityParameters: &gosnmp.UsmSecurityParameters{UserName: "monitor"},
+			Logger:             gosnmp.NewLogger(nil),
+		}
+		buffer := make([]byte, 65535)
+		for {
+			n, addr, err := conn.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+			// Il buffer viene riutilizzato dalla lettura successiva mentre l'handler è ancora in esecuzione.
+			request, err := decoder.SnmpDecodePacket(append([]byte(nil), buffer[:n]...))
+			if err != nil {
+				continue
+			}
+
+			go func(request *gosnmp.SnmpPacket, addr net.Addr) {
+				response := handler(request)
+				if response == nil {
+					return
+				}
+				if data, err := response.MarshalMsg(); err == nil {
+					conn.WriteTo(data, addr)
+				}
+			}(request, addr)
+		}
+	}()
+
+	_, port, _ := net.SplitHostPort(conn.LocalAddr().String())
+	portNumber, _ := strconv.Atoi(port)
+	return Config{Host: "127.0.0.1", Port: portNumber, Community: "public", Version: "v2c"}
+}
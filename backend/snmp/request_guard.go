@@ -0,0 +1,174 @@
+package snmp
+
+import (
+	"net"
+	"sync"
+)
+
+// requestLocks serializza le richieste verso i target con SerializeRequests: una sola operazione
+// in corso per host:porta, anche tra Client diversi.
+var requestLocks = struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}{locks: make(map[string]*sync.Mutex)}
+
+// requestLock restituisce il mutex condiviso del target.
+func requestLock(target string) *sync.Mutex {
+	requestLocks.mu.Lock()
+	defer requestLocks.mu.Unlock()
+
+	lock, ok := requestLocks.locks[target]
+	if !ok {
+		lock = &sync.Mutex{}
+		requestLocks.locks[target] = lock
+	}
+	return lock
+}
+
+// requestTracker confronta il request-id delle risposte con quello delle richieste inviate
+// nell'operazione corrente. gosnmp scarta in silenzio le risposte con un request-id inatteso e
+// attende fino al timeout: il tracker permette di riportare la causa reale.
+type requestTracker struct {
+	mu sync.Mutex
+	// sent associa a ogni request-id inviato se ha già ricevuto risposta.
+	sent       map[uint32]bool
+	mismatched int
+	duplicates int
+}
+
+func newRequestTracker() *requestTracker {
+	return &requestTracker{sent: make(map[uint32]bool)}
+}
+
+func (t *requestTracker) recordRequest(data []byte) {
+	id, ok := packetRequestID(data)
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, exists := t.sent[id]; !exists {
+		t.sent[id] = false
+	}
+}
+
+func (t *requestTracker) recordResponse(data []byte) {
+	id, ok := packetRequestID(data)
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	answered, outstanding := t.sent[id]
+	switch {
+	case !outstanding:
+		t.mismatched++
+	case answered:
+		t.duplicates++
+	default:
+		t.sent[id] = true
+	}
+}
+
+// anomalies restituisce le risposte con request-id sconosciuto e quelle duplicate.
+func (t *requestTracker) anomalies() (int, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.mismatched, t.duplicates
+}
+
+// trackingConn passa al tracker i pacchetti scambiati sulla connessione di gosnmp.
+// Come captureConn non implementa net.PacketConn.
+type trackingConn struct {
+	net.Conn
+	tracker *requestTracker
+}
+
+func (c *trackingConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	if n > 0 {
+		c.tracker.recordRequest(b[:n])
+	}
+	return n, err
+}
+
+func (c *trackingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.tracker.recordResponse(b[:n])
+	}
+	return n, err
+}
+
+// requestIDMismatchError sostituisce il timeout di un'operazione quando l'agent ha risposto solo
+// con request-id inattesi o duplicati; negli altri casi restituisce l'errore invariato.
+func (c *Client) requestIDMismatchError(err error) error {
+	if c.tracker == nil || !isTimeout(err) {
+		return err
+	}
+	mismatched, duplicates := c.tracker.anomalies()
+	if mismatched == 0 && duplicates == 0 {
+		return err
+	}
+	return requestIDMismatchErrorDetail(mismatched, duplicates)
+}
+
+// packetRequestID estrae l'identificativo che lega risposta e richiesta: il request-id della PDU
+// per SNMPv1/v2c, il msgID in chiaro di msgGlobalData per SNMPv3.
+func packetRequestID(data []byte) (uint32, bool) {
+	pos, ok := berSkipHeader(data, 0, 0x30)
+	if !ok {
+		return 0, false
+	}
+
+	version, next, ok := berInteger(data, pos)
+	if !ok {
+		return 0, false
+	}
+
+	switch version {
+	case 0, 1:
+		// community OCTET STRING, poi la PDU (tag di contesto costruito 0xA0-0xA8)
+		communityStart, ok := berSkipHeader(data, next, 0x04)
+		if !ok {
+			return 0, false
+		}
+		communityLen, _, _ := berLength(data, next+1)
+		pduPos := communityStart + communityLen
+		if pduPos >= len(data) || data[pduPos]&0xe0 != 0xa0 {
+			return 0, false
+		}
+		pduStart, ok := berSkipHeader(data, pduPos, data[pduPos])
+		if !ok {
+			return 0, false
+		}
+		id, _, ok := berInteger(data, pduStart)
+		return uint32(id), ok
+	case 3:
+		globalStart, ok := berSkipHeader(data, next, 0x30)
+		if !ok {
+			return 0, false
+		}
+		id, _, ok := berInteger(data, globalStart)
+		return uint32(id), ok
+	}
+	return 0, false
+}
+
+// berInteger decodifica l'INTEGER BER in posizione pos, ritornando il valore e l'offset successivo.
+func berInteger(data []byte, pos int) (int64, int, bool) {
+	start, ok := berSkipHeader(data, pos, 0x02)
+	if !ok {
+		return 0, 0, false
+	}
+	length, _, _ := berLength(data, pos+1)
+	end := start + length
+	if length == 0 || length > 8 || end > len(data) {
+		return 0, 0, false
+	}
+	value := int64(int8(data[start]))
+	for _, b := range data[start+1 : end] {
+		value = value<<8 | int64(b)
+	}
+	return value, end, true
+}
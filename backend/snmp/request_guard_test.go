@@ -0,0 +1,127 @@
+package snmp
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// startQuirkyAgent avvia un agent v2c che risponde alle GET dopo delay; con wrongRequestID risponde
+// con un request-id diverso da quello della richiesta. Restituisce la configurazione e il massimo
+// di richieste contemporaneamente in elaborazione.
+func startQuirkyAgent(t *testing.T, wrongRequestID bool, delay time.Duration) (Config, *atomic.Int32) {
+	t.Helper()
+
+	inFlight := &atomic.Int32{}
+	maxInFlight := &atomic.Int32{}
+	config := startFakeAgent(t, func(request *gosnmp.SnmpPacket) *gosnmp.SnmpPacket {
+		if request.PDUType != gosnmp.GetRequest {
+			return nil
+		}
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(delay)
+
+		response := *request
+		response.PDUType = gosnmp.GetResponse
+		if wrongRequestID {
+			response.RequestID++
+		}
+		for i := range response.Variables {
+			response.Variables[i].Type = gosnmp.Integer
+			response.Variables[i].Value = 1
+		}
+		return &response
+	})
+	return config, maxInFlight
+}
+
+func TestMismatchedRequestIDIsReportedDistinctly(t *testing.T) {
+	config, _ := startQuirkyAgent(t, true, 0)
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	client.snmp.Timeout = 200 * time.Millisecond
+	client.snmp.Retries = 0
+
+	_, err = client.Get("1.3.6.1.2.1.1.5.0")
+	var detail *ErrorDetail
+	if !errors.As(err, &detail) {
+		t.Fatalf("expected an ErrorDetail, got %v", err)
+	}
+	if detail.Status != requestIDMismatchStatus || detail.StatusCode != -2 {
+		t.Fatalf("expected requestIdMismatch (-2), got %s (%d)", detail.Status, detail.StatusCode)
+	}
+}
+
+func TestSerializeRequestsAllowsOneInFlightRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		serialize bool
+		wantMax   int32
+	}{
+		{name: "serialized", serialize: true, wantMax: 1},
+		{name: "concurrent", serialize: false, wantMax: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, maxInFlight := startQuirkyAgent(t, false, 100*time.Millisecond)
+			config.SerializeRequests = tt.serialize
+
+			var wg sync.WaitGroup
+			for i := 0; i < 3; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					client, err := NewClient(config)
+					if err != nil {
+						t.Errorf("NewClient: %v", err)
+						return
+					}
+					if _, err := client.Get("1.3.6.1.2.1.1.5.0"); err != nil {
+						t.Errorf("Get: %v", err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if got := maxInFlight.Load(); got != tt.wantMax {
+				t.Fatalf("expected at most %d in-flight request(s), got %d", tt.wantMax, got)
+			}
+		})
+	}
+}
+
+func TestPacketRequestID(t *testing.T) {
+	packet := &gosnmp.SnmpPacket{
+		Version:   gosnmp.Version2c,
+		Community: "public",
+		PDUType:   gosnmp.GetRequest,
+		RequestID: 123456,
+		Variables: []gosnmp.SnmpPDU{{Name: "1.3.6.1.2.1.1.5.0", Type: gosnmp.Null}},
+	}
+	data, err := packet.MarshalMsg()
+	if err != nil {
+		t.Fatalf("MarshalMsg: %v", err)
+	}
+
+	id, ok := packetRequestID(data)
+	if !ok || id != 123456 {
+		t.Fatalf("expected request-id 123456, got %d (ok %v)", id, ok)
+	}
+	if _, ok := packetRequestID([]byte{0x30, 0x01}); ok {
+		t.Fatalf("expected malformed packet to be rejected")
+	}
+}
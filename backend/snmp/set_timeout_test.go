@@ -0,0 +1,70 @@
+package snmp
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// startWriteDroppingAgent avvia un agent v2c che risponde solo alle GET con community "public" e scarta
+// tutte le SET; se answerGets è false scarta anche le GET, come un host irraggiungibile.
+func startWriteDroppingAgent(t *testing.T, answerGets bool) Config {
+	t.Helper()
+
+	config := startFakeAgent(t, func(request *gosnmp.SnmpPacket) *gosnmp.SnmpPacket {
+		if request.PDUType != gosnmp.GetRequest || request.Community != "public" || !answerGets {
+			return nil
+		}
+		response := *request
+		response.PDUType = gosnmp.GetResponse
+		for i := range response.Variables {
+			response.Variables[i].Type = gosnmp.Integer
+			response.Variables[i].Value = 1
+		}
+		return &response
+	})
+	config.WriteCommunity = "wrong"
+	return config
+}
+
+func TestSetTimeoutDistinguishesWriteCommunity(t *testing.T) {
+	tests := []struct {
+		name       string
+		answerGets bool
+		cause      string
+	}{
+		{name: "agent answers reads", answerGets: true, cause: CauseWriteCommunityRejected},
+		{name: "agent silent", answerGets: false, cause: CauseAgentUnreachable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(startWriteDroppingAgent(t, tt.answerGets))
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			client.snmp.Timeout = 100 * time.Millisecond
+			client.snmp.Retries = 0
+
+			result, err := client.Set("1.3.6.1.2.1.1.5.0", "string", "router")
+			if err == nil {
+				t.Fatalf("expected SET to fail")
+			}
+			if result == nil || result.ErrorDetail == nil {
+				t.Fatalf("expected structured error detail, got %+v", result)
+			}
+			detail := result.ErrorDetail
+			if detail.Status != "timeout" || detail.StatusCode != -1 || detail.Cause != tt.cause || detail.OID != "1.3.6.1.2.1.1.5.0" {
+				t.Fatalf("unexpected detail: %+v", detail)
+			}
+			if !isTimeout(errors.Unwrap(err)) {
+				t.Fatalf("expected the original timeout to be wrapped, got %v", err)
+			}
+			if client.snmp.Community != "public" {
+				t.Fatalf("expected read community to be restored, got %q", client.snmp.Community)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package snmp
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// startSizeLimitedAgent avvia un agent v2c che risponde tooBig alle GET con più di maxVarbinds
+// varbind e alle GETBULK con max-repetitions superiore a maxVarbinds.
+func startSizeLimitedAgent(t *testing.T, maxVarbinds int) Config {
+	t.Helper()
+
+	return startFakeAgent(t, func(request *gosnmp.SnmpPacket) *gosnmp.SnmpPacket {
+		response := *request
+		response.PDUType = gosnmp.GetResponse
+		switch request.PDUType {
+		case gosnmp.GetBulkRequest:
+			if int(request.MaxRepetitions) > maxVarbinds {
+				response.Error = gosnmp.TooBig
+				break
+			}
+			response.Variables = nil
+			for i := 1; i <= int(request.MaxRepetitions); i++ {
+				response.Variables = append(response.Variables, gosnmp.SnmpPDU{
+					Name: request.Variables[0].Name + "." + strconv.Itoa(i), Type: gosnmp.Integer, Value: i,
+				})
+			}
+		default:
+			if len(request.Variables) > maxVarbinds {
+				response.Error = gosnmp.TooBig
+				break
+			}
+			for i := range response.Variables {
+				response.Variables[i].Type = gosnmp.Integer
+				response.Variables[i].Value = i
+			}
+		}
+		response.MaxRepetitions = 0
+		response.NonRepeaters = 0
+		return &response
+	})
+}
+
+func TestGetBulkHalvesRepetitionsOnTooBig(t *testing.T) {
+	client, err := NewClient(startSizeLimitedAgent(t, 10))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	results, err := client.GetBulk("1.3.6.1.2.1.2.2.1.2", 40)
+	if err != nil {
+		t.Fatalf("GetBulk: %v", err)
+	}
+	if len(results) != 10 || client.Downshifts() != 2 {
+		t.Fatalf("expected 10 results after 2 downshifts, got %d results and %d downshifts", len(results), client.Downshifts())
+	}
+}
+
+func TestGetBulkFailsWhenTooBigAtFloor(t *testing.T) {
+	client, err := NewClient(startSizeLimitedAgent(t, 0))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, err = client.GetBulk("1.3.6.1.2.1.2.2.1.2", 4)
+	detail, ok := err.(*ErrorDetail)
+	if !ok || detail.Status != "tooBig" {
+		t.Fatalf("expected tooBig error detail, got %v", err)
+	}
+}
+
+func TestGetManySplitsVarbindsOnTooBig(t *testing.T) {
+	client, err := NewClient(startSizeLimitedAgent(t, 3))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	oids := make([]string, 10)
+	for i := range oids {
+		oids[i] = "1.3.6.1.2.1.1." + strconv.Itoa(i+1) + ".0"
+	}
+	results, err := client.GetMany(oids)
+	if err != nil {
+		t.Fatalf("GetMany: %v", err)
+	}
+	if len(results) != len(oids) || client.Downshifts() == 0 {
+		t.Fatalf("expected %d results with downshifts, got %d results and %d downshifts", len(oids), len(results), client.Downshifts())
+	}
+	for i, result := range results {
+		if result.OID != "."+oids[i] {
+			t.Fatalf("unexpected order at %d: %s", i, result.OID)
+		}
+	}
+}
+
+// startErrorStatusAgent avvia un agent v1 che risponde noSuchName, con il relativo error-index, alle
+// GET che contengono missing e genErr a quelle che contengono failing.
+func startErrorStatusAgent(t *testing.T, missing, failing string) Config {
+	t.Helper()
+
+	config := startFakeAgent(t, func(request *gosnmp.SnmpPacket) *gosnmp.SnmpPacket {
+		response := *request
+		response.PDUType = gosnmp.GetResponse
+		for i := range response.Variables {
+			switch response.Variables[i].Name {
+			case "." + missing:
+				response.Error = gosnmp.NoSuchName
+				response.ErrorIndex = uint8(i + 1)
+			case "." + failing:
+				response.Error = gosnmp.GenErr
+				response.ErrorIndex = uint8(i + 1)
+			}
+			if response.Error != gosnmp.NoError {
+				break
+			}
+			response.Variables[i].Type = gosnmp.Integer
+			response.Variables[i].Value = i
+		}
+		return &response
+	})
+	config.Version = "v1"
+	return config
+}
+
+func TestGetManyMarksNoSuchNameVarbind(t *testing.T) {
+	missing := "1.3.6.1.2.1.1.2.0"
+	client, err := NewClient(startErrorStatusAgent(t, missing, ""))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	oids := []string{"1.3.6.1.2.1.1.1.0", missing, "1.3.6.1.2.1.1.3.0"}
+	results, err := client.GetMany(oids)
+	if err != nil {
+		t.Fatalf("GetMany: %v", err)
+	}
+	if len(results) != len(oids) {
+		t.Fatalf("expected %d results, got %d", len(oids), len(results))
+	}
+	for i, result := range results {
+		if result.OID != "."+oids[i] {
+			t.Fatalf("unexpected order at %d: %s", i, result.OID)
+		}
+		if oids[i] == missing {
+			if result.Status != "error" || result.Type != gosnmp.NoSuchObject.String() || result.ErrorDetail == nil {
+				t.Fatalf("expected missing varbind marked as error, got %+v", result)
+			}
+			if result.ErrorDetail.Status != ErrorStatusName(gosnmp.NoSuchName) || result.ErrorDetail.OID != "."+missing {
+				t.Fatalf("unexpected error detail: %+v", result.ErrorDetail)
+			}
+			continue
+		}
+		if result.Status != "success" || result.ErrorDetail != nil {
+			t.Fatalf("expected success for %s, got %+v", oids[i], result)
+		}
+	}
+}
+
+func TestGetManyFailsOnErrorStatus(t *testing.T) {
+	failing := "1.3.6.1.2.1.1.2.0"
+	client, err := NewClient(startErrorStatusAgent(t, "", failing))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, err = client.GetMany([]string{"1.3.6.1.2.1.1.1.0", failing})
+	var detail *ErrorDetail
+	if !errors.As(err, &detail) || detail.Status != ErrorStatusName(gosnmp.GenErr) {
+		t.Fatalf("expected genErr error detail, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package snmp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+const (
+	// SysUpTimeOID è l'OID di sysUpTime.0, primo varbind di ogni notifica SNMPv2.
+	SysUpTimeOID = "1.3.6.1.2.1.1.3.0"
+	// SNMPTrapOID è l'OID di snmpTrapOID.0, secondo varbind di ogni notifica SNMPv2.
+	SNMPTrapOID = "1.3.6.1.6.3.1.1.4.1.0"
+	// DefaultTrapPort è la porta standard dei ricevitori di trap.
+	DefaultTrapPort = 162
+)
+
+// processStart è il riferimento per il sysUpTime riportato nelle notifiche inviate.
+var processStart = time.Now()
+
+// SetRequest è un varbind da inviare, con tipo e valore nel formato accettato da Set.
+type SetRequest struct {
+	OID   string      `json:"oid"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// TrapResult riporta l'esito dell'invio di una notifica. Una TRAP non prevede conferma e
+// risulta "sent"; una INFORM risulta "acknowledged" solo dopo la risposta del ricevitore.
+type TrapResult struct {
+	TrapOID      string `json:"trapOid"`
+	Inform       bool   `json:"inform"`
+	Status       string `json:"status"` // sent, acknowledged, error
+	Error        string `json:"error,omitempty"`
+	Varbinds     int    `json:"varbinds"`
+	ResponseTime int64  `json:"responseTime"`
+	Timestamp    string `json:"timestamp"`
+}
+
+// SendTrap invia una notifica SNMPv2c (TRAP o, con asInform, INFORM) al target del client.
+// sysUpTime.0 e snmpTrapOID.0 vengono anteposti automaticamente; se presenti tra i varbind vengono ignorati.
+func (c *Client) SendTrap(trapOID string, varbinds []SetRequest, asInform bool) (*TrapResult, error) {
+	if c.snmp.Version != gosnmp.Version2c {
+		return nil, fmt.Errorf("trap generation supports SNMPv2c only")
+	}
+
+	trapOID = strings.TrimPrefix(strings.TrimSpace(trapOID), ".")
+	if trapOID == "" {
+		return nil, fmt.Errorf("trap OID is required")
+	}
+
+	pdus := []gosnmp.SnmpPDU{
+		{Name: SysUpTimeOID, Type: gosnmp.TimeTicks, Value: uint32(time.Since(processStart) / (10 * time.Millisecond))},
+		{Name: SNMPTrapOID, Type: gosnmp.ObjectIdentifier, Value: trapOID},
+	}
+	for _, varbind := range varbinds {
+		oid := strings.TrimPrefix(strings.TrimSpace(varbind.OID), ".")
+		if oid == SysUpTimeOID || oid == SNMPTrapOID {
+			continue
+		}
+		pdu, err := buildSetPDU(oid, varbind.Type, varbind.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid varbind %s: %v", oid, err)
+		}
+		pdus = append(pdus, pdu)
+	}
+
+	result := &TrapResult{TrapOID: trapOID, Inform: asInform, Varbinds: len(pdus)}
+	start := time.Now()
+
+	if err := c.Connect(); err != nil {
+		return nil, fmt.Errorf("connection failed: %v", err)
+	}
+	defer c.Close()
+
+	packet, err := c.snmp.SendTrap(gosnmp.SnmpTrap{Variables: pdus, IsInform: asInform})
+	result.ResponseTime = time.Since(start).Milliseconds()
+	result.Timestamp = time.Now().Format(time.RFC3339)
+
+	if err == nil && asInform && packet != nil && packet.Error != gosnmp.NoError {
+		err = fmt.Errorf("receiver returned %s", packet.Error)
+	}
+	if err != nil {
+		result.Status = "error"
+		if asInform {
+			err = fmt.Errorf("inform not acknowledged: %v", err)
+		}
+		result.Error = err.Error()
+		return result, err
+	}
+
+	result.Status = "sent"
+	if asInform {
+		result.Status = "acknowledged"
+	}
+	return result, nil
+}
@@ -0,0 +1,104 @@
+package snmp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// startTrapReceiver avvia un ricevitore UDP che inoltra i pacchetti decodificati sul canale
+// e, se acknowledge è vero, conferma le INFORM.
+func startTrapReceiver(t *testing.T, acknowledge bool) (int, <-chan *gosnmp.SnmpPacket) {
+	t.Helper()
+
+	packets := make(chan *gosnmp.SnmpPacket, 4)
+	config := startFakeAgent(t, func(packet *gosnmp.SnmpPacket) *gosnmp.SnmpPacket {
+		packets <- packet
+		if !acknowledge || packet.PDUType != gosnmp.InformRequest {
+			return nil
+		}
+		response := *packet
+		response.PDUType = gosnmp.GetResponse
+		return &response
+	})
+	return config.Port, packets
+}
+
+func TestSendTrapPrependsStandardVarbinds(t *testing.T) {
+	port, packets := startTrapReceiver(t, false)
+	client, err := NewClient(Config{Host: "127.0.0.1", Port: port, Community: "public", Version: "v2c"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	result, err := client.SendTrap(".1.3.6.1.6.3.1.1.5.3", []SetRequest{
+		{OID: SNMPTrapOID, Type: "objectidentifier", Value: "1.3.6.1.6.3.1.1.5.4"},
+		{OID: "1.3.6.1.2.1.2.2.1.1.7", Type: "integer", Value: 7},
+	}, false)
+	if err != nil {
+		t.Fatalf("SendTrap: %v", err)
+	}
+	if result.Status != "sent" || result.Inform || result.Varbinds != 3 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	select {
+	case packet := <-packets:
+		if packet.PDUType != gosnmp.SNMPv2Trap || len(packet.Variables) != 3 {
+			t.Fatalf("unexpected packet: %v with %d varbinds", packet.PDUType, len(packet.Variables))
+		}
+		names := []string{}
+		for _, variable := range packet.Variables {
+			names = append(names, strings.TrimPrefix(variable.Name, "."))
+		}
+		if names[0] != SysUpTimeOID || names[1] != SNMPTrapOID || names[2] != "1.3.6.1.2.1.2.2.1.1.7" {
+			t.Fatalf("unexpected varbind order: %v", names)
+		}
+		if value := strings.TrimPrefix(packet.Variables[1].Value.(string), "."); value != "1.3.6.1.6.3.1.1.5.3" {
+			t.Fatalf("unexpected snmpTrapOID value %q", value)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("trap not received")
+	}
+}
+
+func TestSendInformReportsAcknowledgement(t *testing.T) {
+	port, _ := startTrapReceiver(t, true)
+	client, err := NewClient(Config{Host: "127.0.0.1", Port: port, Community: "public", Version: "v2c"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	result, err := client.SendTrap("1.3.6.1.6.3.1.1.5.1", nil, true)
+	if err != nil {
+		t.Fatalf("SendTrap inform: %v", err)
+	}
+	if result.Status != "acknowledged" || !result.Inform {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	silentPort, _ := startTrapReceiver(t, false)
+	client, err = NewClient(Config{Host: "127.0.0.1", Port: silentPort, Community: "public", Version: "v2c"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	client.snmp.Timeout = 100 * time.Millisecond
+	client.snmp.Retries = 0
+
+	result, err = client.SendTrap("1.3.6.1.6.3.1.1.5.1", nil, true)
+	if err == nil || result == nil || result.Status != "error" {
+		t.Fatalf("expected unacknowledged inform to fail, got %+v (%v)", result, err)
+	}
+}
+
+func TestSendTrapRequiresV2c(t *testing.T) {
+	client, err := NewClient(Config{Host: "127.0.0.1", Version: "v1"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := client.SendTrap("1.3.6.1.6.3.1.1.5.1", nil, false); err == nil {
+		t.Fatalf("expected v1 trap generation to be rejected")
+	}
+}
@@ -46,7 +46,10 @@ describe('MibManagerDialog.vue', () => {
   })
 
   it('calls LoadMIBFile and emits mib-loaded for each loaded module', async () => {
-    LoadMIBFile.mockResolvedValue(['NEW-MIB', 'SECOND-MIB'])
+    LoadMIBFile.mockResolvedValue([
+      { moduleName: 'NEW-MIB', totalNodes: 10, skippedNodes: [] },
+      { moduleName: 'SECOND-MIB', totalNodes: 4, skippedNodes: [] }
+    ])
     ListMIBModules.mockResolvedValueOnce([
       { name: 'BASE', nodeCount: 3, scalarCount: 1, tableCount: 0, columnCount: 0, typeCount: 2, skippedNodes: 0, missingImports: [] },
       { name: 'IF-MIB', nodeCount: 12, scalarCount: 6, tableCount: 2, columnCount: 4, typeCount: 1, skippedNodes: 0, missingImports: [] }
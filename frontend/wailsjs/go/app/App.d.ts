@@ -1,23 +1,107 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
-import {app} from '../models';
 import {snmp} from '../models';
+import {app} from '../models';
 import {mib} from '../models';
 import {context} from '../models';
 
 export function AddBookmark(arg1:string,arg2:string):Promise<void>;
 
+export function AddMIBRepository(arg1:string):Promise<void>;
+
+export function AddMIBSearchDirectory(arg1:string):Promise<void>;
+
+export function CacheAgentInterfaces(arg1:snmp.Config):Promise<void>;
+
+export function CancelWalk(arg1:string):Promise<void>;
+
+export function ClearSNMPDebugCapture(arg1:string):Promise<void>;
+
+export function ClearUISession():Promise<void>;
+
+export function CloseWalkStepper(arg1:string):Promise<void>;
+
+export function CloseWalkStream(arg1:string):Promise<void>;
+
+export function CommitSet(arg1:string):Promise<snmp.Result>;
+
 export function CreateBookmarkFolder(arg1:string,arg2:string):Promise<app.BookmarkFolderDTO>;
 
+export function DecodeTrap(arg1:Array<snmp.Result>):Promise<app.DecodedTrap>;
+
 export function DeleteBookmarkFolder(arg1:string):Promise<void>;
 
+export function DeleteCredentialProfile(arg1:string):Promise<void>;
+
 export function DeleteHost(arg1:string):Promise<void>;
 
 export function DeleteMIBModule(arg1:string):Promise<void>;
 
+export function DeletePollGroup(arg1:string):Promise<void>;
+
+export function DeleteWalkProfile(arg1:number):Promise<void>;
+
+export function DuplicateBookmarkFolder(arg1:string,arg2:string,arg3:string):Promise<app.BookmarkFolderDTO>;
+
+export function ExportBookmarkSnapshot(arg1:snmp.Config):Promise<string>;
+
+export function ExportHostsToJSON():Promise<boolean>;
+
 export function ExportMIBTree():Promise<string>;
 
-export function FetchTableData(arg1:snmp.Config,arg2:string):Promise<app.TableDataResponse>;
+export function ExportResultsPrometheus(arg1:Array<snmp.Result>):Promise<string>;
+
+export function ExportSettings():Promise<string>;
+
+export function ExportTreeText(arg1:string):Promise<string>;
+
+export function FetchMissingImports(arg1:string):Promise<app.FetchImportsReport>;
+
+export function FetchTableData(arg1:snmp.Config,arg2:string,arg3:string,arg4:string):Promise<app.TableDataResponse>;
+
+export function FetchTableDataMultiHost(arg1:Array<snmp.Config>,arg2:string):Promise<Array<app.HostTableData>>;
+
+export function FetchTableDataTyped(arg1:snmp.Config,arg2:string,arg3:string,arg4:string):Promise<app.TableDataResponse>;
+
+export function FilterResults(arg1:Array<snmp.Result>,arg2:app.ResultPredicate):Promise<Array<snmp.Result>>;
+
+export function FindOrphanMIBNodes():Promise<Array<mib.Node>>;
+
+export function FindOrphanedBookmarks():Promise<Array<app.OrphanedBookmark>>;
+
+export function FormatInetAddressTyped(arg1:number,arg2:string):Promise<string|boolean>;
+
+export function ForwardTrap(arg1:string,arg2:app.DecodedTrap):Promise<void>;
+
+export function GenerateCommand(arg1:snmp.Config,arg2:string,arg3:string):Promise<string>;
+
+export function GenerateMaskedCommand(arg1:snmp.Config,arg2:string,arg3:string):Promise<string>;
+
+export function GetAppSettings():Promise<app.AppSettings>;
+
+export function GetBitsMapping(arg1:string):Promise<Record<string, string>>;
+
+export function GetBookmarkFolderPath(arg1:string):Promise<string>;
+
+export function GetBookmarkFolderPathSegments(arg1:string):Promise<Array<string>>;
+
+export function GetCachedInterfaceName(arg1:string,arg2:string):Promise<string|boolean>;
+
+export function GetColumnValueDistribution(arg1:snmp.Config,arg2:string):Promise<app.ColumnValueDistribution>;
+
+export function GetCredentialProfiles():Promise<Array<mib.CredentialProfile>>;
+
+export function GetDatabaseInfo():Promise<mib.DatabaseInfo>;
+
+export function GetHostHiddenModules(arg1:string):Promise<Array<string>>;
+
+export function GetHostImplementedOIDs(arg1:string):Promise<Array<string>>;
+
+export function GetKeepRawDescriptions():Promise<boolean>;
+
+export function GetKeyboardShortcuts():Promise<Record<string, string>>;
+
+export function GetLastLoadReport():Promise<Array<mib.LoadReport>>;
 
 export function GetMIBModuleDetails(arg1:string):Promise<app.ModuleDetails>;
 
@@ -27,44 +111,214 @@ export function GetMIBNodeAncestors(arg1:string):Promise<Array<mib.Node>>;
 
 export function GetMIBNodeByName(arg1:string):Promise<mib.Node>;
 
+export function GetMIBNodeContext(arg1:string):Promise<mib.NodeContext>;
+
 export function GetMIBStats():Promise<Record<string, number>>;
 
+export function GetMIBTableList():Promise<Array<app.TableSummary>>;
+
 export function GetMIBTree():Promise<Array<mib.Node>>;
 
+export function GetMIBTreeForHost(arg1:string):Promise<Array<mib.Node>>;
+
+export function GetMIBTreeStats():Promise<mib.TreeStats>;
+
+export function GetModuleNodeCounts():Promise<Record<string, number>>;
+
+export function GetModuleNodeTypeSummary(arg1:string):Promise<Record<string, number>>;
+
+export function GetModuleTreeFlat(arg1:string):Promise<Array<app.FlatNode>>;
+
+export function GetOIDModule(arg1:string):Promise<string>;
+
+export function GetOverallNodeTypeSummary():Promise<Record<string, number>>;
+
+export function GetRecentlyLoadedModules(arg1:number):Promise<Array<mib.ModuleSummary>>;
+
+export function GetRepairOrphansOnLoad():Promise<boolean>;
+
+export function GetSNMPAgentEntities(arg1:snmp.Config):Promise<Array<app.PhysicalEntity>>;
+
+export function GetSNMPAgentMIB(arg1:snmp.Config):Promise<Array<app.AgentCapEntry>>;
+
+export function GetSNMPAgentMetrics(arg1:snmp.Config,arg2:Array<string>,arg3:number):Promise<Array<app.MetricResult>>;
+
+export function GetSNMPDebugCapture(arg1:string):Promise<Array<snmp.CapturedPacket>>;
+
+export function GetSNMPWritableTables():Promise<Array<app.TableSummary>>;
+
+export function GetStepperHistory(arg1:string):Promise<Array<snmp.Result>>;
+
+export function GetTableRowCount(arg1:snmp.Config,arg2:string):Promise<number>;
+
+export function GetTrapForwardingConfig():Promise<app.TrapForwardingConfig>;
+
+export function GetTrapReceiverStats():Promise<app.TrapReceiverStats>;
+
+export function GetTrapVarbindTemplate(arg1:string):Promise<Array<app.TrapVarbindTemplate>>;
+
+export function GetTreePreferences():Promise<app.TreePreferences>;
+
+export function GetValueRepresentations(arg1:snmp.Result):Promise<Record<string, string>>;
+
+export function GetWalkStepperState(arg1:string):Promise<app.WalkStepperState>;
+
+export function GetWalkStreamState(arg1:string):Promise<app.WalkStreamState>;
+
 export function Greet(arg1:string):Promise<string>;
 
+export function ImportHostsFromJSON():Promise<number>;
+
+export function ImportSettings(arg1:string):Promise<void>;
+
+export function JumpWalkStepper(arg1:string,arg2:string):Promise<app.WalkStepperState>;
+
 export function ListHosts():Promise<Array<mib.HostConfig>>;
 
 export function ListMIBModules():Promise<Array<mib.ModuleSummary>>;
 
-export function LoadMIBFile():Promise<Array<string>>;
+export function ListMIBRepositories():Promise<Array<string>>;
+
+export function ListMIBSearchDirectories():Promise<Array<string>>;
+
+export function ListOIDWatches():Promise<Array<mib.OIDWatch>>;
+
+export function ListPollGroups():Promise<Array<mib.PollGroup>>;
+
+export function ListWalkProfiles():Promise<Array<mib.WalkProfile>>;
+
+export function LoadAndWalk(arg1:string,arg2:snmp.Config):Promise<app.LoadWalkResult>;
+
+export function LoadMIBFile():Promise<Array<mib.LoadReport>>;
+
+export function LoadUISession():Promise<string>;
+
+export function MergeBookmarkFolders(arg1:string,arg2:string):Promise<app.MergeResult>;
 
 export function MoveBookmark(arg1:string,arg2:string):Promise<void>;
 
 export function MoveBookmarkFolder(arg1:string,arg2:string):Promise<void>;
 
+export function PauseWalk(arg1:string):Promise<app.WalkStreamState>;
+
+export function PollOIDs(arg1:snmp.Config,arg2:Array<string>,arg3:number):Promise<Array<app.PollResult>>;
+
+export function PrepareSet(arg1:snmp.Config,arg2:string,arg3:string,arg4:any):Promise<app.PreparedSet>;
+
+export function RecomputeAllStats():Promise<void>;
+
 export function ReloadMIBDatabase():Promise<void>;
 
 export function RemoveBookmark(arg1:string):Promise<void>;
 
+export function RemoveMIBRepository(arg1:string):Promise<void>;
+
+export function RemoveMIBSearchDirectory(arg1:string):Promise<void>;
+
+export function RemoveOrphanedBookmarks():Promise<number>;
+
 export function RenameBookmarkFolder(arg1:string,arg2:string):Promise<void>;
 
+export function RenamePollGroup(arg1:string,arg2:string):Promise<void>;
+
+export function RepairOrphanMIBNodes():Promise<number>;
+
+export function ResetKeyboardShortcuts():Promise<void>;
+
+export function ResumeWalk(arg1:string):Promise<app.WalkStreamState>;
+
+export function RetryMIBInitialization():Promise<void>;
+
+export function RunPollGroup(arg1:snmp.Config,arg2:string):Promise<Array<snmp.Result>>;
+
+export function RunWalkProfile(arg1:number,arg2:string):Promise<string>;
+
 export function SNMPGet(arg1:snmp.Config,arg2:string):Promise<snmp.Result>;
 
+export function SNMPGetAllScalars(arg1:snmp.Config,arg2:string):Promise<Array<snmp.Result>>;
+
 export function SNMPGetBulk(arg1:snmp.Config,arg2:string,arg3:number):Promise<Array<snmp.Result>>;
 
+export function SNMPGetFanout(arg1:Array<string>,arg2:string,arg3:number):Promise<Array<app.FanoutResult>>;
+
+export function SNMPGetFirst(arg1:snmp.Config,arg2:string):Promise<snmp.Result>;
+
 export function SNMPGetNext(arg1:snmp.Config,arg2:string):Promise<snmp.Result>;
 
+export function SNMPGetWithTimeout(arg1:snmp.Config,arg2:string,arg3:number):Promise<snmp.Result>;
+
 export function SNMPSet(arg1:snmp.Config,arg2:string,arg3:string,arg4:any):Promise<snmp.Result>;
 
+export function SNMPSetConfirmed(arg1:snmp.Config,arg2:string,arg3:string,arg4:any,arg5:boolean):Promise<snmp.Result>;
+
+export function SNMPTableDeleteRow(arg1:snmp.Config,arg2:string,arg3:string):Promise<snmp.Result>;
+
 export function SNMPWalk(arg1:snmp.Config,arg2:string):Promise<Array<snmp.Result>>;
 
+export function SNMPWalkTimed(arg1:snmp.Config,arg2:string):Promise<app.TimedWalkResult>;
+
+export function SaveAppSettings(arg1:app.AppSettings):Promise<void>;
+
 export function SaveCSVFile(arg1:string,arg2:string):Promise<boolean>;
 
+export function SaveCredentialProfile(arg1:mib.CredentialProfile):Promise<mib.CredentialProfile>;
+
 export function SaveHost(arg1:mib.HostConfig):Promise<mib.HostConfig>;
 
+export function SavePollGroup(arg1:string,arg2:Array<string>):Promise<mib.PollGroup>;
+
+export function SaveUISession(arg1:string):Promise<void>;
+
+export function SaveWalkProfile(arg1:mib.WalkProfile):Promise<mib.WalkProfile>;
+
 export function SearchMIBNodes(arg1:string):Promise<Array<mib.Node>>;
 
+export function SearchMIBNodesAdvanced(arg1:app.SearchRequest):Promise<app.SearchResponse>;
+
+export function SearchMIBNodesBySyntax(arg1:string,arg2:string):Promise<Array<mib.Node>>;
+
+export function SendTestInform(arg1:snmp.Config,arg2:string,arg3:Array<snmp.SetRequest>):Promise<snmp.TrapResult>;
+
+export function SendTestTrap(arg1:snmp.Config,arg2:string,arg3:Array<snmp.SetRequest>):Promise<snmp.TrapResult>;
+
+export function SetHostModuleHidden(arg1:string,arg2:string,arg3:boolean):Promise<void>;
+
+export function SetKeepRawDescriptions(arg1:boolean):Promise<void>;
+
+export function SetKeyboardShortcut(arg1:string,arg2:string):Promise<void>;
+
+export function SetRepairOrphansOnLoad(arg1:boolean):Promise<void>;
+
+export function SetSNMPDebugCapture(arg1:boolean):Promise<void>;
+
+export function SetTrapForwardingConfig(arg1:app.TrapForwardingConfig):Promise<void>;
+
+export function SetTreePreferences(arg1:app.TreePreferences):Promise<void>;
+
+export function Shutdown(arg1:context.Context):Promise<void>;
+
+export function StartAPIServer(arg1:number,arg2:string):Promise<string>;
+
+export function StartWalkStepper(arg1:snmp.Config,arg2:string):Promise<string>;
+
+export function StartWalkStream(arg1:snmp.Config,arg2:string):Promise<string>;
+
 export function Startup(arg1:context.Context):Promise<void>;
 
+export function StepNext(arg1:string):Promise<snmp.Result>;
+
+export function StopAPIServer():Promise<void>;
+
+export function SuggestOIDs(arg1:string,arg2:number):Promise<Array<mib.OIDSuggestion>>;
+
+export function SummarizeWalkResults(arg1:Array<snmp.Result>):Promise<app.WalkSummary>;
+
+export function TestSNMPGet(arg1:snmp.Config,arg2:string):Promise<snmp.DiagnosticResult>;
+
 export function TouchHost(arg1:string):Promise<void>;
+
+export function TranslateOIDs(arg1:Array<string>):Promise<Array<app.OIDTranslation>>;
+
+export function UnwatchOID(arg1:number):Promise<void>;
+
+export function WatchOID(arg1:snmp.Config,arg2:string,arg3:number,arg4:string):Promise<mib.OIDWatch>;
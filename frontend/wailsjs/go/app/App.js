@@ -6,14 +6,58 @@ export function AddBookmark(arg1, arg2) {
   return window['go']['app']['App']['AddBookmark'](arg1, arg2);
 }
 
+export function AddMIBRepository(arg1) {
+  return window['go']['app']['App']['AddMIBRepository'](arg1);
+}
+
+export function AddMIBSearchDirectory(arg1) {
+  return window['go']['app']['App']['AddMIBSearchDirectory'](arg1);
+}
+
+export function CacheAgentInterfaces(arg1) {
+  return window['go']['app']['App']['CacheAgentInterfaces'](arg1);
+}
+
+export function CancelWalk(arg1) {
+  return window['go']['app']['App']['CancelWalk'](arg1);
+}
+
+export function ClearSNMPDebugCapture(arg1) {
+  return window['go']['app']['App']['ClearSNMPDebugCapture'](arg1);
+}
+
+export function ClearUISession() {
+  return window['go']['app']['App']['ClearUISession']();
+}
+
+export function CloseWalkStepper(arg1) {
+  return window['go']['app']['App']['CloseWalkStepper'](arg1);
+}
+
+export function CloseWalkStream(arg1) {
+  return window['go']['app']['App']['CloseWalkStream'](arg1);
+}
+
+export function CommitSet(arg1) {
+  return window['go']['app']['App']['CommitSet'](arg1);
+}
+
 export function CreateBookmarkFolder(arg1, arg2) {
   return window['go']['app']['App']['CreateBookmarkFolder'](arg1, arg2);
 }
 
+export function DecodeTrap(arg1) {
+  return window['go']['app']['App']['DecodeTrap'](arg1);
+}
+
 export function DeleteBookmarkFolder(arg1) {
   return window['go']['app']['App']['DeleteBookmarkFolder'](arg1);
 }
 
+export function DeleteCredentialProfile(arg1) {
+  return window['go']['app']['App']['DeleteCredentialProfile'](arg1);
+}
+
 export function DeleteHost(arg1) {
   return window['go']['app']['App']['DeleteHost'](arg1);
 }
@@ -22,12 +66,136 @@ export function DeleteMIBModule(arg1) {
   return window['go']['app']['App']['DeleteMIBModule'](arg1);
 }
 
+export function DeletePollGroup(arg1) {
+  return window['go']['app']['App']['DeletePollGroup'](arg1);
+}
+
+export function DeleteWalkProfile(arg1) {
+  return window['go']['app']['App']['DeleteWalkProfile'](arg1);
+}
+
+export function DuplicateBookmarkFolder(arg1, arg2, arg3) {
+  return window['go']['app']['App']['DuplicateBookmarkFolder'](arg1, arg2, arg3);
+}
+
+export function ExportBookmarkSnapshot(arg1) {
+  return window['go']['app']['App']['ExportBookmarkSnapshot'](arg1);
+}
+
+export function ExportHostsToJSON() {
+  return window['go']['app']['App']['ExportHostsToJSON']();
+}
+
 export function ExportMIBTree() {
   return window['go']['app']['App']['ExportMIBTree']();
 }
 
-export function FetchTableData(arg1, arg2) {
-  return window['go']['app']['App']['FetchTableData'](arg1, arg2);
+export function ExportResultsPrometheus(arg1) {
+  return window['go']['app']['App']['ExportResultsPrometheus'](arg1);
+}
+
+export function ExportSettings() {
+  return window['go']['app']['App']['ExportSettings']();
+}
+
+export function ExportTreeText(arg1) {
+  return window['go']['app']['App']['ExportTreeText'](arg1);
+}
+
+export function FetchMissingImports(arg1) {
+  return window['go']['app']['App']['FetchMissingImports'](arg1);
+}
+
+export function FetchTableData(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['FetchTableData'](arg1, arg2, arg3, arg4);
+}
+
+export function FetchTableDataMultiHost(arg1, arg2) {
+  return window['go']['app']['App']['FetchTableDataMultiHost'](arg1, arg2);
+}
+
+export function FetchTableDataTyped(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['FetchTableDataTyped'](arg1, arg2, arg3, arg4);
+}
+
+export function FilterResults(arg1, arg2) {
+  return window['go']['app']['App']['FilterResults'](arg1, arg2);
+}
+
+export function FindOrphanMIBNodes() {
+  return window['go']['app']['App']['FindOrphanMIBNodes']();
+}
+
+export function FindOrphanedBookmarks() {
+  return window['go']['app']['App']['FindOrphanedBookmarks']();
+}
+
+export function FormatInetAddressTyped(arg1, arg2) {
+  return window['go']['app']['App']['FormatInetAddressTyped'](arg1, arg2);
+}
+
+export function ForwardTrap(arg1, arg2) {
+  return window['go']['app']['App']['ForwardTrap'](arg1, arg2);
+}
+
+export function GenerateCommand(arg1, arg2, arg3) {
+  return window['go']['app']['App']['GenerateCommand'](arg1, arg2, arg3);
+}
+
+export function GenerateMaskedCommand(arg1, arg2, arg3) {
+  return window['go']['app']['App']['GenerateMaskedCommand'](arg1, arg2, arg3);
+}
+
+export function GetAppSettings() {
+  return window['go']['app']['App']['GetAppSettings']();
+}
+
+export function GetBitsMapping(arg1) {
+  return window['go']['app']['App']['GetBitsMapping'](arg1);
+}
+
+export function GetBookmarkFolderPath(arg1) {
+  return window['go']['app']['App']['GetBookmarkFolderPath'](arg1);
+}
+
+export function GetBookmarkFolderPathSegments(arg1) {
+  return window['go']['app']['App']['GetBookmarkFolderPathSegments'](arg1);
+}
+
+export function GetCachedInterfaceName(arg1, arg2) {
+  return window['go']['app']['App']['GetCachedInterfaceName'](arg1, arg2);
+}
+
+export function GetColumnValueDistribution(arg1, arg2) {
+  return window['go']['app']['App']['GetColumnValueDistribution'](arg1, arg2);
+}
+
+export function GetCredentialProfiles() {
+  return window['go']['app']['App']['GetCredentialProfiles']();
+}
+
+export function GetDatabaseInfo() {
+  return window['go']['app']['App']['GetDatabaseInfo']();
+}
+
+export function GetHostHiddenModules(arg1) {
+  return window['go']['app']['App']['GetHostHiddenModules'](arg1);
+}
+
+export function GetHostImplementedOIDs(arg1) {
+  return window['go']['app']['App']['GetHostImplementedOIDs'](arg1);
+}
+
+export function GetKeepRawDescriptions() {
+  return window['go']['app']['App']['GetKeepRawDescriptions']();
+}
+
+export function GetKeyboardShortcuts() {
+  return window['go']['app']['App']['GetKeyboardShortcuts']();
+}
+
+export function GetLastLoadReport() {
+  return window['go']['app']['App']['GetLastLoadReport']();
 }
 
 export function GetMIBModuleDetails(arg1) {
@@ -46,18 +214,130 @@ export function GetMIBNodeByName(arg1) {
   return window['go']['app']['App']['GetMIBNodeByName'](arg1);
 }
 
+export function GetMIBNodeContext(arg1) {
+  return window['go']['app']['App']['GetMIBNodeContext'](arg1);
+}
+
 export function GetMIBStats() {
   return window['go']['app']['App']['GetMIBStats']();
 }
 
+export function GetMIBTableList() {
+  return window['go']['app']['App']['GetMIBTableList']();
+}
+
 export function GetMIBTree() {
   return window['go']['app']['App']['GetMIBTree']();
 }
 
+export function GetMIBTreeForHost(arg1) {
+  return window['go']['app']['App']['GetMIBTreeForHost'](arg1);
+}
+
+export function GetMIBTreeStats() {
+  return window['go']['app']['App']['GetMIBTreeStats']();
+}
+
+export function GetModuleNodeCounts() {
+  return window['go']['app']['App']['GetModuleNodeCounts']();
+}
+
+export function GetModuleNodeTypeSummary(arg1) {
+  return window['go']['app']['App']['GetModuleNodeTypeSummary'](arg1);
+}
+
+export function GetModuleTreeFlat(arg1) {
+  return window['go']['app']['App']['GetModuleTreeFlat'](arg1);
+}
+
+export function GetOIDModule(arg1) {
+  return window['go']['app']['App']['GetOIDModule'](arg1);
+}
+
+export function GetOverallNodeTypeSummary() {
+  return window['go']['app']['App']['GetOverallNodeTypeSummary']();
+}
+
+export function GetRecentlyLoadedModules(arg1) {
+  return window['go']['app']['App']['GetRecentlyLoadedModules'](arg1);
+}
+
+export function GetRepairOrphansOnLoad() {
+  return window['go']['app']['App']['GetRepairOrphansOnLoad']();
+}
+
+export function GetSNMPAgentEntities(arg1) {
+  return window['go']['app']['App']['GetSNMPAgentEntities'](arg1);
+}
+
+export function GetSNMPAgentMIB(arg1) {
+  return window['go']['app']['App']['GetSNMPAgentMIB'](arg1);
+}
+
+export function GetSNMPAgentMetrics(arg1, arg2, arg3) {
+  return window['go']['app']['App']['GetSNMPAgentMetrics'](arg1, arg2, arg3);
+}
+
+export function GetSNMPDebugCapture(arg1) {
+  return window['go']['app']['App']['GetSNMPDebugCapture'](arg1);
+}
+
+export function GetSNMPWritableTables() {
+  return window['go']['app']['App']['GetSNMPWritableTables']();
+}
+
+export function GetStepperHistory(arg1) {
+  return window['go']['app']['App']['GetStepperHistory'](arg1);
+}
+
+export function GetTableRowCount(arg1, arg2) {
+  return window['go']['app']['App']['GetTableRowCount'](arg1, arg2);
+}
+
+export function GetTrapForwardingConfig() {
+  return window['go']['app']['App']['GetTrapForwardingConfig']();
+}
+
+export function GetTrapReceiverStats() {
+  return window['go']['app']['App']['GetTrapReceiverStats']();
+}
+
+export function GetTrapVarbindTemplate(arg1) {
+  return window['go']['app']['App']['GetTrapVarbindTemplate'](arg1);
+}
+
+export function GetTreePreferences() {
+  return window['go']['app']['App']['GetTreePreferences']();
+}
+
+export function GetValueRepresentations(arg1) {
+  return window['go']['app']['App']['GetValueRepresentations'](arg1);
+}
+
+export function GetWalkStepperState(arg1) {
+  return window['go']['app']['App']['GetWalkStepperState'](arg1);
+}
+
+export function GetWalkStreamState(arg1) {
+  return window['go']['app']['App']['GetWalkStreamState'](arg1);
+}
+
 export function Greet(arg1) {
   return window['go']['app']['App']['Greet'](arg1);
 }
 
+export function ImportHostsFromJSON() {
+  return window['go']['app']['App']['ImportHostsFromJSON']();
+}
+
+export function ImportSettings(arg1) {
+  return window['go']['app']['App']['ImportSettings'](arg1);
+}
+
+export function JumpWalkStepper(arg1, arg2) {
+  return window['go']['app']['App']['JumpWalkStepper'](arg1, arg2);
+}
+
 export function ListHosts() {
   return window['go']['app']['App']['ListHosts']();
 }
@@ -66,10 +346,42 @@ export function ListMIBModules() {
   return window['go']['app']['App']['ListMIBModules']();
 }
 
+export function ListMIBRepositories() {
+  return window['go']['app']['App']['ListMIBRepositories']();
+}
+
+export function ListMIBSearchDirectories() {
+  return window['go']['app']['App']['ListMIBSearchDirectories']();
+}
+
+export function ListOIDWatches() {
+  return window['go']['app']['App']['ListOIDWatches']();
+}
+
+export function ListPollGroups() {
+  return window['go']['app']['App']['ListPollGroups']();
+}
+
+export function ListWalkProfiles() {
+  return window['go']['app']['App']['ListWalkProfiles']();
+}
+
+export function LoadAndWalk(arg1, arg2) {
+  return window['go']['app']['App']['LoadAndWalk'](arg1, arg2);
+}
+
 export function LoadMIBFile() {
   return window['go']['app']['App']['LoadMIBFile']();
 }
 
+export function LoadUISession() {
+  return window['go']['app']['App']['LoadUISession']();
+}
+
+export function MergeBookmarkFolders(arg1, arg2) {
+  return window['go']['app']['App']['MergeBookmarkFolders'](arg1, arg2);
+}
+
 export function MoveBookmark(arg1, arg2) {
   return window['go']['app']['App']['MoveBookmark'](arg1, arg2);
 }
@@ -78,6 +390,22 @@ export function MoveBookmarkFolder(arg1, arg2) {
   return window['go']['app']['App']['MoveBookmarkFolder'](arg1, arg2);
 }
 
+export function PauseWalk(arg1) {
+  return window['go']['app']['App']['PauseWalk'](arg1);
+}
+
+export function PollOIDs(arg1, arg2, arg3) {
+  return window['go']['app']['App']['PollOIDs'](arg1, arg2, arg3);
+}
+
+export function PrepareSet(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['PrepareSet'](arg1, arg2, arg3, arg4);
+}
+
+export function RecomputeAllStats() {
+  return window['go']['app']['App']['RecomputeAllStats']();
+}
+
 export function ReloadMIBDatabase() {
   return window['go']['app']['App']['ReloadMIBDatabase']();
 }
@@ -86,46 +414,226 @@ export function RemoveBookmark(arg1) {
   return window['go']['app']['App']['RemoveBookmark'](arg1);
 }
 
+export function RemoveMIBRepository(arg1) {
+  return window['go']['app']['App']['RemoveMIBRepository'](arg1);
+}
+
+export function RemoveMIBSearchDirectory(arg1) {
+  return window['go']['app']['App']['RemoveMIBSearchDirectory'](arg1);
+}
+
+export function RemoveOrphanedBookmarks() {
+  return window['go']['app']['App']['RemoveOrphanedBookmarks']();
+}
+
 export function RenameBookmarkFolder(arg1, arg2) {
   return window['go']['app']['App']['RenameBookmarkFolder'](arg1, arg2);
 }
 
+export function RenamePollGroup(arg1, arg2) {
+  return window['go']['app']['App']['RenamePollGroup'](arg1, arg2);
+}
+
+export function RepairOrphanMIBNodes() {
+  return window['go']['app']['App']['RepairOrphanMIBNodes']();
+}
+
+export function ResetKeyboardShortcuts() {
+  return window['go']['app']['App']['ResetKeyboardShortcuts']();
+}
+
+export function ResumeWalk(arg1) {
+  return window['go']['app']['App']['ResumeWalk'](arg1);
+}
+
+export function RetryMIBInitialization() {
+  return window['go']['app']['App']['RetryMIBInitialization']();
+}
+
+export function RunPollGroup(arg1, arg2) {
+  return window['go']['app']['App']['RunPollGroup'](arg1, arg2);
+}
+
+export function RunWalkProfile(arg1, arg2) {
+  return window['go']['app']['App']['RunWalkProfile'](arg1, arg2);
+}
+
 export function SNMPGet(arg1, arg2) {
   return window['go']['app']['App']['SNMPGet'](arg1, arg2);
 }
 
+export function SNMPGetAllScalars(arg1, arg2) {
+  return window['go']['app']['App']['SNMPGetAllScalars'](arg1, arg2);
+}
+
 export function SNMPGetBulk(arg1, arg2, arg3) {
   return window['go']['app']['App']['SNMPGetBulk'](arg1, arg2, arg3);
 }
 
+export function SNMPGetFanout(arg1, arg2, arg3) {
+  return window['go']['app']['App']['SNMPGetFanout'](arg1, arg2, arg3);
+}
+
+export function SNMPGetFirst(arg1, arg2) {
+  return window['go']['app']['App']['SNMPGetFirst'](arg1, arg2);
+}
+
 export function SNMPGetNext(arg1, arg2) {
   return window['go']['app']['App']['SNMPGetNext'](arg1, arg2);
 }
 
+export function SNMPGetWithTimeout(arg1, arg2, arg3) {
+  return window['go']['app']['App']['SNMPGetWithTimeout'](arg1, arg2, arg3);
+}
+
 export function SNMPSet(arg1, arg2, arg3, arg4) {
   return window['go']['app']['App']['SNMPSet'](arg1, arg2, arg3, arg4);
 }
 
+export function SNMPSetConfirmed(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['app']['App']['SNMPSetConfirmed'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function SNMPTableDeleteRow(arg1, arg2, arg3) {
+  return window['go']['app']['App']['SNMPTableDeleteRow'](arg1, arg2, arg3);
+}
+
 export function SNMPWalk(arg1, arg2) {
   return window['go']['app']['App']['SNMPWalk'](arg1, arg2);
 }
 
+export function SNMPWalkTimed(arg1, arg2) {
+  return window['go']['app']['App']['SNMPWalkTimed'](arg1, arg2);
+}
+
+export function SaveAppSettings(arg1) {
+  return window['go']['app']['App']['SaveAppSettings'](arg1);
+}
+
 export function SaveCSVFile(arg1, arg2) {
   return window['go']['app']['App']['SaveCSVFile'](arg1, arg2);
 }
 
+export function SaveCredentialProfile(arg1) {
+  return window['go']['app']['App']['SaveCredentialProfile'](arg1);
+}
+
 export function SaveHost(arg1) {
   return window['go']['app']['App']['SaveHost'](arg1);
 }
 
+export function SavePollGroup(arg1, arg2) {
+  return window['go']['app']['App']['SavePollGroup'](arg1, arg2);
+}
+
+export function SaveUISession(arg1) {
+  return window['go']['app']['App']['SaveUISession'](arg1);
+}
+
+export function SaveWalkProfile(arg1) {
+  return window['go']['app']['App']['SaveWalkProfile'](arg1);
+}
+
 export function SearchMIBNodes(arg1) {
   return window['go']['app']['App']['SearchMIBNodes'](arg1);
 }
 
+export function SearchMIBNodesAdvanced(arg1) {
+  return window['go']['app']['App']['SearchMIBNodesAdvanced'](arg1);
+}
+
+export function SearchMIBNodesBySyntax(arg1, arg2) {
+  return window['go']['app']['App']['SearchMIBNodesBySyntax'](arg1, arg2);
+}
+
+export function SendTestInform(arg1, arg2, arg3) {
+  return window['go']['app']['App']['SendTestInform'](arg1, arg2, arg3);
+}
+
+export function SendTestTrap(arg1, arg2, arg3) {
+  return window['go']['app']['App']['SendTestTrap'](arg1, arg2, arg3);
+}
+
+export function SetHostModuleHidden(arg1, arg2, arg3) {
+  return window['go']['app']['App']['SetHostModuleHidden'](arg1, arg2, arg3);
+}
+
+export function SetKeepRawDescriptions(arg1) {
+  return window['go']['app']['App']['SetKeepRawDescriptions'](arg1);
+}
+
+export function SetKeyboardShortcut(arg1, arg2) {
+  return window['go']['app']['App']['SetKeyboardShortcut'](arg1, arg2);
+}
+
+export function SetRepairOrphansOnLoad(arg1) {
+  return window['go']['app']['App']['SetRepairOrphansOnLoad'](arg1);
+}
+
+export function SetSNMPDebugCapture(arg1) {
+  return window['go']['app']['App']['SetSNMPDebugCapture'](arg1);
+}
+
+export function SetTrapForwardingConfig(arg1) {
+  return window['go']['app']['App']['SetTrapForwardingConfig'](arg1);
+}
+
+export function SetTreePreferences(arg1) {
+  return window['go']['app']['App']['SetTreePreferences'](arg1);
+}
+
+export function Shutdown(arg1) {
+  return window['go']['app']['App']['Shutdown'](arg1);
+}
+
+export function StartAPIServer(arg1, arg2) {
+  return window['go']['app']['App']['StartAPIServer'](arg1, arg2);
+}
+
+export function StartWalkStepper(arg1, arg2) {
+  return window['go']['app']['App']['StartWalkStepper'](arg1, arg2);
+}
+
+export function StartWalkStream(arg1, arg2) {
+  return window['go']['app']['App']['StartWalkStream'](arg1, arg2);
+}
+
 export function Startup(arg1) {
   return window['go']['app']['App']['Startup'](arg1);
 }
 
+export function StepNext(arg1) {
+  return window['go']['app']['App']['StepNext'](arg1);
+}
+
+export function StopAPIServer() {
+  return window['go']['app']['App']['StopAPIServer']();
+}
+
+export function SuggestOIDs(arg1, arg2) {
+  return window['go']['app']['App']['SuggestOIDs'](arg1, arg2);
+}
+
+export function SummarizeWalkResults(arg1) {
+  return window['go']['app']['App']['SummarizeWalkResults'](arg1);
+}
+
+export function TestSNMPGet(arg1, arg2) {
+  return window['go']['app']['App']['TestSNMPGet'](arg1, arg2);
+}
+
 export function TouchHost(arg1) {
   return window['go']['app']['App']['TouchHost'](arg1);
 }
+
+export function TranslateOIDs(arg1) {
+  return window['go']['app']['App']['TranslateOIDs'](arg1);
+}
+
+export function UnwatchOID(arg1) {
+  return window['go']['app']['App']['UnwatchOID'](arg1);
+}
+
+export function WatchOID(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['WatchOID'](arg1, arg2, arg3, arg4);
+}
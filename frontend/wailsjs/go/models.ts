@@ -1,5 +1,57 @@
 export namespace app {
 	
+	export class AgentCapEntry {
+	    index: number;
+	    oid: string;
+	    resolvedName: string;
+	    description: string;
+	    uptime: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AgentCapEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.index = source["index"];
+	        this.oid = source["oid"];
+	        this.resolvedName = source["resolvedName"];
+	        this.description = source["description"];
+	        this.uptime = source["uptime"];
+	    }
+	}
+	export class AppSettings {
+	    defaultSnmpVersion: string;
+	    defaultCommunity: string;
+	    defaultTimeout: number;
+	    defaultRetries: number;
+	    maxSearchResults: number;
+	    maxWalkResults: number;
+	    treeLazyLoad: boolean;
+	    tableAutoRefreshSec: number;
+	    logLevel: string;
+	    dateTimeFormat: string;
+	    distributionValueMaxLength: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.defaultSnmpVersion = source["defaultSnmpVersion"];
+	        this.defaultCommunity = source["defaultCommunity"];
+	        this.defaultTimeout = source["defaultTimeout"];
+	        this.defaultRetries = source["defaultRetries"];
+	        this.maxSearchResults = source["maxSearchResults"];
+	        this.maxWalkResults = source["maxWalkResults"];
+	        this.treeLazyLoad = source["treeLazyLoad"];
+	        this.tableAutoRefreshSec = source["tableAutoRefreshSec"];
+	        this.logLevel = source["logLevel"];
+	        this.dateTimeFormat = source["dateTimeFormat"];
+	        this.distributionValueMaxLength = source["distributionValueMaxLength"];
+	    }
+	}
 	export class BookmarkFolderDTO {
 	    id: number;
 	    name: string;
@@ -39,22 +91,257 @@ export namespace app {
 		    return a;
 		}
 	}
-	export class ModuleDetails {
+	export class ColumnValueCount {
+	    value: string;
+	    count: number;
+	    truncated?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ColumnValueCount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.value = source["value"];
+	        this.count = source["count"];
+	        this.truncated = source["truncated"];
+	    }
+	}
+	export class ColumnValueDistribution {
+	    columnOid: string;
+	    columnName: string;
+	    total: number;
+	    values: ColumnValueCount[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ColumnValueDistribution(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.columnOid = source["columnOid"];
+	        this.columnName = source["columnName"];
+	        this.total = source["total"];
+	        this.values = this.convertValues(source["values"], ColumnValueCount);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DecodedVarbind {
+	    oid: string;
+	    name: string;
+	    type: string;
+	    value: string;
+	    displayValue: string;
+	    isTrapOid: boolean;
+	    isNotificationObject: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DecodedVarbind(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.oid = source["oid"];
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.value = source["value"];
+	        this.displayValue = source["displayValue"];
+	        this.isTrapOid = source["isTrapOid"];
+	        this.isNotificationObject = source["isNotificationObject"];
+	    }
+	}
+	export class DecodedTrap {
+	    trapOid: string;
+	    trapName: string;
 	    module: string;
-	    tree: mib.Node[];
-	    stats: mib.ModuleStats;
-	    missingImports: string[];
+	    varbinds: DecodedVarbind[];
 	
 	    static createFrom(source: any = {}) {
-	        return new ModuleDetails(source);
+	        return new DecodedTrap(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.trapOid = source["trapOid"];
+	        this.trapName = source["trapName"];
 	        this.module = source["module"];
-	        this.tree = this.convertValues(source["tree"], mib.Node);
-	        this.stats = this.convertValues(source["stats"], mib.ModuleStats);
-	        this.missingImports = source["missingImports"];
+	        this.varbinds = this.convertValues(source["varbinds"], DecodedVarbind);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class DependencyFetchResult {
+	    module: string;
+	    source?: string;
+	    loaded: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DependencyFetchResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.module = source["module"];
+	        this.source = source["source"];
+	        this.loaded = source["loaded"];
+	        this.error = source["error"];
+	    }
+	}
+	export class FanoutResult {
+	    host: string;
+	    result?: snmp.Result;
+	    errorKind?: string;
+	    error?: string;
+	    durationMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FanoutResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.host = source["host"];
+	        this.result = this.convertValues(source["result"], snmp.Result);
+	        this.errorKind = source["errorKind"];
+	        this.error = source["error"];
+	        this.durationMs = source["durationMs"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FetchImportsReport {
+	    module: string;
+	    dependencies: DependencyFetchResult[];
+	    remainingImports: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new FetchImportsReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.module = source["module"];
+	        this.dependencies = this.convertValues(source["dependencies"], DependencyFetchResult);
+	        this.remainingImports = source["remainingImports"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FlatNode {
+	    id: number;
+	    oid: string;
+	    name: string;
+	    parentOid: string;
+	    type: string;
+	    syntax: string;
+	    access: string;
+	    status: string;
+	    description: string;
+	    module: string;
+	    children?: mib.Node[];
+	    rawDescription?: string;
+	    notificationObjects?: string[];
+	    index?: mib.IndexComponent[];
+	    implementedOn?: string[];
+	    pinned?: number;
+	    resolvedBaseType?: string;
+	    typeChain?: string[];
+	    typeChainIncomplete?: boolean;
+	    depth: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FlatNode(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.oid = source["oid"];
+	        this.name = source["name"];
+	        this.parentOid = source["parentOid"];
+	        this.type = source["type"];
+	        this.syntax = source["syntax"];
+	        this.access = source["access"];
+	        this.status = source["status"];
+	        this.description = source["description"];
+	        this.module = source["module"];
+	        this.children = this.convertValues(source["children"], mib.Node);
+	        this.rawDescription = source["rawDescription"];
+	        this.notificationObjects = source["notificationObjects"];
+	        this.index = this.convertValues(source["index"], mib.IndexComponent);
+	        this.implementedOn = source["implementedOn"];
+	        this.pinned = source["pinned"];
+	        this.resolvedBaseType = source["resolvedBaseType"];
+	        this.typeChain = source["typeChain"];
+	        this.typeChainIncomplete = source["typeChainIncomplete"];
+	        this.depth = source["depth"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -104,6 +391,8 @@ export namespace app {
 	    entryOid: string;
 	    columns: TableColumn[];
 	    rows: any[];
+	    typedRows?: any[];
+	    indexColumns?: string[];
 	
 	    static createFrom(source: any = {}) {
 	        return new TableDataResponse(source);
@@ -115,6 +404,967 @@ export namespace app {
 	        this.entryOid = source["entryOid"];
 	        this.columns = this.convertValues(source["columns"], TableColumn);
 	        this.rows = source["rows"];
+	        this.typedRows = source["typedRows"];
+	        this.indexColumns = source["indexColumns"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HostTableData {
+	    host: string;
+	    data?: TableDataResponse;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HostTableData(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.host = source["host"];
+	        this.data = this.convertValues(source["data"], TableDataResponse);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LoadWalkResult {
+	    report: mib.LoadReport;
+	    rootOid: string;
+	    results: snmp.Result[];
+	
+	    static createFrom(source: any = {}) {
+	        return new LoadWalkResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.report = this.convertValues(source["report"], mib.LoadReport);
+	        this.rootOid = source["rootOid"];
+	        this.results = this.convertValues(source["results"], snmp.Result);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MergeResult {
+	    bookmarksMoved: number;
+	    foldersMoved: number;
+	    skippedDuplicates: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MergeResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.bookmarksMoved = source["bookmarksMoved"];
+	        this.foldersMoved = source["foldersMoved"];
+	        this.skippedDuplicates = source["skippedDuplicates"];
+	    }
+	}
+	export class MetricResult {
+	    oid: string;
+	    name: string;
+	    t0: number;
+	    t1: number;
+	    ratePerSec: number;
+	    unit: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MetricResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.oid = source["oid"];
+	        this.name = source["name"];
+	        this.t0 = source["t0"];
+	        this.t1 = source["t1"];
+	        this.ratePerSec = source["ratePerSec"];
+	        this.unit = source["unit"];
+	    }
+	}
+	export class ModuleDetails {
+	    module: string;
+	    tree: mib.Node[];
+	    stats: mib.ModuleStats;
+	    missingImports: string[];
+	    identityOid?: string;
+	    strayNodes?: mib.Node[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ModuleDetails(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.module = source["module"];
+	        this.tree = this.convertValues(source["tree"], mib.Node);
+	        this.stats = this.convertValues(source["stats"], mib.ModuleStats);
+	        this.missingImports = source["missingImports"];
+	        this.identityOid = source["identityOid"];
+	        this.strayNodes = this.convertValues(source["strayNodes"], mib.Node);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class OIDTranslation {
+	    oid: string;
+	    name: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new OIDTranslation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.oid = source["oid"];
+	        this.name = source["name"];
+	    }
+	}
+	export class OrphanedBookmark {
+	    oid: string;
+	    folderKey: string;
+	    folderName: string;
+	    // Go type: time
+	    bookmarkedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new OrphanedBookmark(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.oid = source["oid"];
+	        this.folderKey = source["folderKey"];
+	        this.folderName = source["folderName"];
+	        this.bookmarkedAt = this.convertValues(source["bookmarkedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PhysicalEntity {
+	    index: number;
+	    description: string;
+	    name: string;
+	    class: string;
+	    containedIn: number;
+	    isFRU: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PhysicalEntity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.index = source["index"];
+	        this.description = source["description"];
+	        this.name = source["name"];
+	        this.class = source["class"];
+	        this.containedIn = source["containedIn"];
+	        this.isFRU = source["isFRU"];
+	    }
+	}
+	export class PollResult {
+	    oid: string;
+	    resolvedName?: string;
+	    t0Value: string;
+	    t1Value: string;
+	    delta?: string;
+	    rate: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PollResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.oid = source["oid"];
+	        this.resolvedName = source["resolvedName"];
+	        this.t0Value = source["t0Value"];
+	        this.t1Value = source["t1Value"];
+	        this.delta = source["delta"];
+	        this.rate = source["rate"];
+	    }
+	}
+	export class SetPreview {
+	    host: string;
+	    oid: string;
+	    resolvedName: string;
+	    syntax?: string;
+	    access?: string;
+	    valueType: string;
+	    value: string;
+	    currentValue?: string;
+	    currentValueError?: string;
+	    statusWarning?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SetPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.host = source["host"];
+	        this.oid = source["oid"];
+	        this.resolvedName = source["resolvedName"];
+	        this.syntax = source["syntax"];
+	        this.access = source["access"];
+	        this.valueType = source["valueType"];
+	        this.value = source["value"];
+	        this.currentValue = source["currentValue"];
+	        this.currentValueError = source["currentValueError"];
+	        this.statusWarning = source["statusWarning"];
+	    }
+	}
+	export class PreparedSet {
+	    token: string;
+	    expiresAt: string;
+	    preview: SetPreview;
+	
+	    static createFrom(source: any = {}) {
+	        return new PreparedSet(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.token = source["token"];
+	        this.expiresAt = source["expiresAt"];
+	        this.preview = this.convertValues(source["preview"], SetPreview);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ResultPredicate {
+	    field: string;
+	    operator: string;
+	    value: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResultPredicate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.field = source["field"];
+	        this.operator = source["operator"];
+	        this.value = source["value"];
+	    }
+	}
+	export class SearchRequest {
+	    query: string;
+	    searchIn: string[];
+	    filterType: string;
+	    filterAccess: string;
+	    filterStatus: string;
+	    filterModule: string;
+	    limit: number;
+	    offset: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.query = source["query"];
+	        this.searchIn = source["searchIn"];
+	        this.filterType = source["filterType"];
+	        this.filterAccess = source["filterAccess"];
+	        this.filterStatus = source["filterStatus"];
+	        this.filterModule = source["filterModule"];
+	        this.limit = source["limit"];
+	        this.offset = source["offset"];
+	    }
+	}
+	export class SearchResponse {
+	    nodes: mib.Node[];
+	    total: number;
+	    query: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.nodes = this.convertValues(source["nodes"], mib.Node);
+	        this.total = source["total"];
+	        this.query = source["query"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class SyslogSinkConfig {
+	    enabled: boolean;
+	    address: string;
+	    protocol: string;
+	    facility: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SyslogSinkConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.address = source["address"];
+	        this.protocol = source["protocol"];
+	        this.facility = source["facility"];
+	    }
+	}
+	
+	
+	export class TableSummary {
+	    tableNode?: mib.Node;
+	    rowNode?: mib.Node;
+	    columnCount: number;
+	    module: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TableSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tableNode = this.convertValues(source["tableNode"], mib.Node);
+	        this.rowNode = this.convertValues(source["rowNode"], mib.Node);
+	        this.columnCount = source["columnCount"];
+	        this.module = source["module"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TimedWalkResult {
+	    results: snmp.Result[];
+	    timedOut: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimedWalkResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.results = this.convertValues(source["results"], snmp.Result);
+	        this.timedOut = source["timedOut"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WebhookSinkConfig {
+	    enabled: boolean;
+	    url: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WebhookSinkConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.url = source["url"];
+	    }
+	}
+	export class TrapForwardingConfig {
+	    syslog: SyslogSinkConfig;
+	    webhook: WebhookSinkConfig;
+	    queueSize: number;
+	    maxRetries: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TrapForwardingConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.syslog = this.convertValues(source["syslog"], SyslogSinkConfig);
+	        this.webhook = this.convertValues(source["webhook"], WebhookSinkConfig);
+	        this.queueSize = source["queueSize"];
+	        this.maxRetries = source["maxRetries"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TrapSinkStats {
+	    enabled: boolean;
+	    forwarded: number;
+	    retries: number;
+	    deadLetters: number;
+	    lastError?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TrapSinkStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.forwarded = source["forwarded"];
+	        this.retries = source["retries"];
+	        this.deadLetters = source["deadLetters"];
+	        this.lastError = source["lastError"];
+	    }
+	}
+	export class TrapReceiverStats {
+	    received: number;
+	    queueLength: number;
+	    queueCapacity: number;
+	    dropped: number;
+	    sinks: Record<string, TrapSinkStats>;
+	
+	    static createFrom(source: any = {}) {
+	        return new TrapReceiverStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.received = source["received"];
+	        this.queueLength = source["queueLength"];
+	        this.queueCapacity = source["queueCapacity"];
+	        this.dropped = source["dropped"];
+	        this.sinks = this.convertValues(source["sinks"], TrapSinkStats, true);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TrapVarbindTemplate {
+	    oid: string;
+	    name: string;
+	    type: string;
+	    syntax?: string;
+	    needsInstance: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TrapVarbindTemplate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.oid = source["oid"];
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.syntax = source["syntax"];
+	        this.needsInstance = source["needsInstance"];
+	    }
+	}
+	export class TreePreferences {
+	    hiddenRoots: string[];
+	    pinned: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TreePreferences(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hiddenRoots = source["hiddenRoots"];
+	        this.pinned = source["pinned"];
+	    }
+	}
+	export class WalkGroupCount {
+	    oid: string;
+	    name: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WalkGroupCount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.oid = source["oid"];
+	        this.name = source["name"];
+	        this.count = source["count"];
+	    }
+	}
+	export class WalkPayload {
+	    oid: string;
+	    resolvedName: string;
+	    type: string;
+	    size: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WalkPayload(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.oid = source["oid"];
+	        this.resolvedName = source["resolvedName"];
+	        this.type = source["type"];
+	        this.size = source["size"];
+	    }
+	}
+	export class WalkStepperState {
+	    sessionId: string;
+	    host: string;
+	    cursor: string;
+	    finished: boolean;
+	    steps: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WalkStepperState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.host = source["host"];
+	        this.cursor = source["cursor"];
+	        this.finished = source["finished"];
+	        this.steps = source["steps"];
+	    }
+	}
+	export class WalkStreamState {
+	    walkId: string;
+	    host: string;
+	    rootOid: string;
+	    lastOid: string;
+	    count: number;
+	    status: string;
+	    error?: string;
+	    startedAt: string;
+	    timedOut?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new WalkStreamState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.walkId = source["walkId"];
+	        this.host = source["host"];
+	        this.rootOid = source["rootOid"];
+	        this.lastOid = source["lastOid"];
+	        this.count = source["count"];
+	        this.status = source["status"];
+	        this.error = source["error"];
+	        this.startedAt = source["startedAt"];
+	        this.timedOut = source["timedOut"];
+	    }
+	}
+	export class WalkTypeStats {
+	    type: string;
+	    count: number;
+	    numeric: boolean;
+	    min?: number;
+	    max?: number;
+	    avg?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WalkTypeStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.count = source["count"];
+	        this.numeric = source["numeric"];
+	        this.min = source["min"];
+	        this.max = source["max"];
+	        this.avg = source["avg"];
+	    }
+	}
+	export class WalkSummary {
+	    total: number;
+	    types: WalkTypeStats[];
+	    groups: WalkGroupCount[];
+	    largestPayloads: WalkPayload[];
+	
+	    static createFrom(source: any = {}) {
+	        return new WalkSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.total = source["total"];
+	        this.types = this.convertValues(source["types"], WalkTypeStats);
+	        this.groups = this.convertValues(source["groups"], WalkGroupCount);
+	        this.largestPayloads = this.convertValues(source["largestPayloads"], WalkPayload);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+
+}
+
+export namespace mib {
+	
+	export class CredentialProfile {
+	    name: string;
+	    version: string;
+	    community: string;
+	    writeCommunity: string;
+	    contextName?: string;
+	    securityLevel?: string;
+	    securityUsername?: string;
+	    authProtocol?: string;
+	    authPassword?: string;
+	    privProtocol?: string;
+	    privPassword?: string;
+	    updatedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CredentialProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.version = source["version"];
+	        this.community = source["community"];
+	        this.writeCommunity = source["writeCommunity"];
+	        this.contextName = source["contextName"];
+	        this.securityLevel = source["securityLevel"];
+	        this.securityUsername = source["securityUsername"];
+	        this.authProtocol = source["authProtocol"];
+	        this.authPassword = source["authPassword"];
+	        this.privProtocol = source["privProtocol"];
+	        this.privPassword = source["privPassword"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	export class DatabaseInfo {
+	    path: string;
+	    sizeBytes: number;
+	    moduleCount: number;
+	    nodeCount: number;
+	    bookmarkCount: number;
+	    hostCount: number;
+	    walkSessionCount: number;
+	    schemaVersion: number;
+	    isWalMode: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DatabaseInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.sizeBytes = source["sizeBytes"];
+	        this.moduleCount = source["moduleCount"];
+	        this.nodeCount = source["nodeCount"];
+	        this.bookmarkCount = source["bookmarkCount"];
+	        this.hostCount = source["hostCount"];
+	        this.walkSessionCount = source["walkSessionCount"];
+	        this.schemaVersion = source["schemaVersion"];
+	        this.isWalMode = source["isWalMode"];
+	    }
+	}
+	export class HostConfig {
+	    address: string;
+	    port: number;
+	    community: string;
+	    writeCommunity: string;
+	    version: string;
+	    lastUsedAt: string;
+	    createdAt: string;
+	    contextName?: string;
+	    securityLevel?: string;
+	    securityUsername?: string;
+	    authProtocol?: string;
+	    authPassword?: string;
+	    privProtocol?: string;
+	    privPassword?: string;
+	    sourcePort?: number;
+	    credentialProfile?: string;
+	    serializeRequests?: boolean;
+	    ipVersion?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HostConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.address = source["address"];
+	        this.port = source["port"];
+	        this.community = source["community"];
+	        this.writeCommunity = source["writeCommunity"];
+	        this.version = source["version"];
+	        this.lastUsedAt = source["lastUsedAt"];
+	        this.createdAt = source["createdAt"];
+	        this.contextName = source["contextName"];
+	        this.securityLevel = source["securityLevel"];
+	        this.securityUsername = source["securityUsername"];
+	        this.authProtocol = source["authProtocol"];
+	        this.authPassword = source["authPassword"];
+	        this.privProtocol = source["privProtocol"];
+	        this.privPassword = source["privPassword"];
+	        this.sourcePort = source["sourcePort"];
+	        this.credentialProfile = source["credentialProfile"];
+	        this.serializeRequests = source["serializeRequests"];
+	        this.ipVersion = source["ipVersion"];
+	    }
+	}
+	export class IndexComponent {
+	    oid: string;
+	    name: string;
+	    syntax?: string;
+	    kind: string;
+	    fixedLength?: number;
+	    implied?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new IndexComponent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.oid = source["oid"];
+	        this.name = source["name"];
+	        this.syntax = source["syntax"];
+	        this.kind = source["kind"];
+	        this.fixedLength = source["fixedLength"];
+	        this.implied = source["implied"];
+	    }
+	}
+	export class SkippedNode {
+	    name: string;
+	    module: string;
+	    oid?: string;
+	    reason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SkippedNode(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.module = source["module"];
+	        this.oid = source["oid"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class LoadReport {
+	    filePath: string;
+	    moduleName: string;
+	    identityOid?: string;
+	    loadedModules: string[];
+	    totalNodes: number;
+	    skippedNodes: SkippedNode[];
+	    skippedByReason: Record<string, number>;
+	    sanitizationFixes: string[];
+	    missingImports: string[];
+	    repairedLinks: number;
+	    elapsedMs: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LoadReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filePath = source["filePath"];
+	        this.moduleName = source["moduleName"];
+	        this.identityOid = source["identityOid"];
+	        this.loadedModules = source["loadedModules"];
+	        this.totalNodes = source["totalNodes"];
+	        this.skippedNodes = this.convertValues(source["skippedNodes"], SkippedNode);
+	        this.skippedByReason = source["skippedByReason"];
+	        this.sanitizationFixes = source["sanitizationFixes"];
+	        this.missingImports = source["missingImports"];
+	        this.repairedLinks = source["repairedLinks"];
+	        this.elapsedMs = source["elapsedMs"];
+	        this.error = source["error"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -135,49 +1385,6 @@ export namespace app {
 		    return a;
 		}
 	}
-
-}
-
-export namespace mib {
-	
-	export class HostConfig {
-	    address: string;
-	    port: number;
-	    community: string;
-	    writeCommunity: string;
-	    version: string;
-	    lastUsedAt: string;
-	    createdAt: string;
-	    contextName?: string;
-	    securityLevel?: string;
-	    securityUsername?: string;
-	    authProtocol?: string;
-	    authPassword?: string;
-	    privProtocol?: string;
-	    privPassword?: string;
-	
-	    static createFrom(source: any = {}) {
-	        return new HostConfig(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.address = source["address"];
-	        this.port = source["port"];
-	        this.community = source["community"];
-	        this.writeCommunity = source["writeCommunity"];
-	        this.version = source["version"];
-	        this.lastUsedAt = source["lastUsedAt"];
-	        this.createdAt = source["createdAt"];
-	        this.contextName = source["contextName"];
-	        this.securityLevel = source["securityLevel"];
-	        this.securityUsername = source["securityUsername"];
-	        this.authProtocol = source["authProtocol"];
-	        this.authPassword = source["authPassword"];
-	        this.privProtocol = source["privProtocol"];
-	        this.privPassword = source["privPassword"];
-	    }
-	}
 	export class ModuleStats {
 	    nodeCount: number;
 	    scalarCount: number;
@@ -212,6 +1419,7 @@ export namespace mib {
 	    typeCount: number;
 	    skippedNodes: number;
 	    missingImports: string[];
+	    identityOid?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new ModuleSummary(source);
@@ -228,6 +1436,7 @@ export namespace mib {
 	        this.typeCount = source["typeCount"];
 	        this.skippedNodes = source["skippedNodes"];
 	        this.missingImports = source["missingImports"];
+	        this.identityOid = source["identityOid"];
 	    }
 	}
 	export class Node {
@@ -242,6 +1451,14 @@ export namespace mib {
 	    description: string;
 	    module: string;
 	    children?: Node[];
+	    rawDescription?: string;
+	    notificationObjects?: string[];
+	    index?: IndexComponent[];
+	    implementedOn?: string[];
+	    pinned?: number;
+	    resolvedBaseType?: string;
+	    typeChain?: string[];
+	    typeChainIncomplete?: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new Node(source);
@@ -260,6 +1477,52 @@ export namespace mib {
 	        this.description = source["description"];
 	        this.module = source["module"];
 	        this.children = this.convertValues(source["children"], Node);
+	        this.rawDescription = source["rawDescription"];
+	        this.notificationObjects = source["notificationObjects"];
+	        this.index = this.convertValues(source["index"], IndexComponent);
+	        this.implementedOn = source["implementedOn"];
+	        this.pinned = source["pinned"];
+	        this.resolvedBaseType = source["resolvedBaseType"];
+	        this.typeChain = source["typeChain"];
+	        this.typeChainIncomplete = source["typeChainIncomplete"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NodeContext {
+	    node?: Node;
+	    parent?: Node;
+	    siblings: Node[];
+	    children: Node[];
+	    ancestorPath: Node[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NodeContext(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.node = this.convertValues(source["node"], Node);
+	        this.parent = this.convertValues(source["parent"], Node);
+	        this.siblings = this.convertValues(source["siblings"], Node);
+	        this.children = this.convertValues(source["children"], Node);
+	        this.ancestorPath = this.convertValues(source["ancestorPath"], Node);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -280,6 +1543,121 @@ export namespace mib {
 		    return a;
 		}
 	}
+	export class OIDSuggestion {
+	    oid: string;
+	    name: string;
+	    type: string;
+	    module: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new OIDSuggestion(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.oid = source["oid"];
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.module = source["module"];
+	    }
+	}
+	export class OIDWatch {
+	    id: number;
+	    host: string;
+	    oid: string;
+	    intervalSeconds: number;
+	    compareMode: string;
+	    lastValue: string;
+	    lastDisplayValue: string;
+	    lastCheckedAt: string;
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new OIDWatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.host = source["host"];
+	        this.oid = source["oid"];
+	        this.intervalSeconds = source["intervalSeconds"];
+	        this.compareMode = source["compareMode"];
+	        this.lastValue = source["lastValue"];
+	        this.lastDisplayValue = source["lastDisplayValue"];
+	        this.lastCheckedAt = source["lastCheckedAt"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class PollGroup {
+	    id: number;
+	    name: string;
+	    oids: string[];
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PollGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.oids = source["oids"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	
+	export class TreeStats {
+	    maxDepth: number;
+	    maxBreadth: number;
+	    totalLeaves: number;
+	    totalInternalNodes: number;
+	    averageChildCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TreeStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.maxDepth = source["maxDepth"];
+	        this.maxBreadth = source["maxBreadth"];
+	        this.totalLeaves = source["totalLeaves"];
+	        this.totalInternalNodes = source["totalInternalNodes"];
+	        this.averageChildCount = source["averageChildCount"];
+	    }
+	}
+	export class WalkProfile {
+	    id: number;
+	    name: string;
+	    rootOid: string;
+	    useBulk: boolean;
+	    maxRepetitions: number;
+	    filter?: string;
+	    maxResults: number;
+	    maxDurationMs: number;
+	    createdAt: string;
+	    updatedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WalkProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.rootOid = source["rootOid"];
+	        this.useBulk = source["useBulk"];
+	        this.maxRepetitions = source["maxRepetitions"];
+	        this.filter = source["filter"];
+	        this.maxResults = source["maxResults"];
+	        this.maxDurationMs = source["maxDurationMs"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
 
 }
 
@@ -306,6 +1684,26 @@ export namespace services {
 
 export namespace snmp {
 	
+	export class CapturedPacket {
+	    timestamp: string;
+	    direction: string;
+	    length: number;
+	    hex: string;
+	    truncated?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CapturedPacket(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.timestamp = source["timestamp"];
+	        this.direction = source["direction"];
+	        this.length = source["length"];
+	        this.hex = source["hex"];
+	        this.truncated = source["truncated"];
+	    }
+	}
 	export class Config {
 	    host: string;
 	    port: number;
@@ -319,6 +1717,12 @@ export namespace snmp {
 	    authPassword?: string;
 	    privProtocol?: string;
 	    privPassword?: string;
+	    allowVersionFallback?: boolean;
+	    sourcePort?: number;
+	    serializeRequests?: boolean;
+	    maxDurationMs?: number;
+	    timeoutMs?: number;
+	    retries?: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new Config(source);
@@ -338,8 +1742,113 @@ export namespace snmp {
 	        this.authPassword = source["authPassword"];
 	        this.privProtocol = source["privProtocol"];
 	        this.privPassword = source["privPassword"];
+	        this.allowVersionFallback = source["allowVersionFallback"];
+	        this.sourcePort = source["sourcePort"];
+	        this.serializeRequests = source["serializeRequests"];
+	        this.maxDurationMs = source["maxDurationMs"];
+	        this.timeoutMs = source["timeoutMs"];
+	        this.retries = source["retries"];
+	    }
+	}
+	export class ErrorDetail {
+	    status: string;
+	    statusCode: number;
+	    index: number;
+	    oid?: string;
+	    cause?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ErrorDetail(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.status = source["status"];
+	        this.statusCode = source["statusCode"];
+	        this.index = source["index"];
+	        this.oid = source["oid"];
+	        this.cause = source["cause"];
 	    }
 	}
+	export class DiagnosticResult {
+	    oid: string;
+	    value: string;
+	    type: string;
+	    status: string;
+	    responseTime: number;
+	    timestamp: string;
+	    resolvedName: string;
+	    rawValue?: string;
+	    displayValue?: string;
+	    syntax?: string;
+	    errorDetail?: ErrorDetail;
+	    host?: string;
+	    version?: string;
+	    typeMismatch?: boolean;
+	    expectedSyntax?: string;
+	    autoCorrectedFrom?: string;
+	    statusWarning?: string;
+	    pduType: string;
+	    engineId?: string;
+	    engineBoots?: number;
+	    engineTime?: number;
+	    requestId: number;
+	    errorStatus: string;
+	    errorIndex: number;
+	    rawPdu: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiagnosticResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.oid = source["oid"];
+	        this.value = source["value"];
+	        this.type = source["type"];
+	        this.status = source["status"];
+	        this.responseTime = source["responseTime"];
+	        this.timestamp = source["timestamp"];
+	        this.resolvedName = source["resolvedName"];
+	        this.rawValue = source["rawValue"];
+	        this.displayValue = source["displayValue"];
+	        this.syntax = source["syntax"];
+	        this.errorDetail = this.convertValues(source["errorDetail"], ErrorDetail);
+	        this.host = source["host"];
+	        this.version = source["version"];
+	        this.typeMismatch = source["typeMismatch"];
+	        this.expectedSyntax = source["expectedSyntax"];
+	        this.autoCorrectedFrom = source["autoCorrectedFrom"];
+	        this.statusWarning = source["statusWarning"];
+	        this.pduType = source["pduType"];
+	        this.engineId = source["engineId"];
+	        this.engineBoots = source["engineBoots"];
+	        this.engineTime = source["engineTime"];
+	        this.requestId = source["requestId"];
+	        this.errorStatus = source["errorStatus"];
+	        this.errorIndex = source["errorIndex"];
+	        this.rawPdu = source["rawPdu"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
 	export class Result {
 	    oid: string;
 	    value: string;
@@ -351,6 +1860,13 @@ export namespace snmp {
 	    rawValue?: string;
 	    displayValue?: string;
 	    syntax?: string;
+	    errorDetail?: ErrorDetail;
+	    host?: string;
+	    version?: string;
+	    typeMismatch?: boolean;
+	    expectedSyntax?: string;
+	    autoCorrectedFrom?: string;
+	    statusWarning?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new Result(source);
@@ -368,6 +1884,71 @@ export namespace snmp {
 	        this.rawValue = source["rawValue"];
 	        this.displayValue = source["displayValue"];
 	        this.syntax = source["syntax"];
+	        this.errorDetail = this.convertValues(source["errorDetail"], ErrorDetail);
+	        this.host = source["host"];
+	        this.version = source["version"];
+	        this.typeMismatch = source["typeMismatch"];
+	        this.expectedSyntax = source["expectedSyntax"];
+	        this.autoCorrectedFrom = source["autoCorrectedFrom"];
+	        this.statusWarning = source["statusWarning"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SetRequest {
+	    oid: string;
+	    type: string;
+	    value: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new SetRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.oid = source["oid"];
+	        this.type = source["type"];
+	        this.value = source["value"];
+	    }
+	}
+	export class TrapResult {
+	    trapOid: string;
+	    inform: boolean;
+	    status: string;
+	    error?: string;
+	    varbinds: number;
+	    responseTime: number;
+	    timestamp: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TrapResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.trapOid = source["trapOid"];
+	        this.inform = source["inform"];
+	        this.status = source["status"];
+	        this.error = source["error"];
+	        this.varbinds = source["varbinds"];
+	        this.responseTime = source["responseTime"];
+	        this.timestamp = source["timestamp"];
 	    }
 	}
 
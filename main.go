@@ -32,8 +32,10 @@ func main() {
 			log.SetContext(ctx)
 			log.StartDemoLogs()
 		},
+		OnBeforeClose: application.BeforeClose,
 		OnShutdown: func(ctx context.Context) {
 			log.StopDemoLogs()
+			application.Shutdown(ctx)
 		},
 	})
 